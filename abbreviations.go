@@ -0,0 +1,49 @@
+package pdfmarkdown
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultAbbreviations are common abbreviations whose trailing period does not
+// end a sentence.
+var defaultAbbreviations = []string{
+	"No.", "Nos.", "Inc.", "Ltd.", "Co.", "Corp.", "Pty.", "Bros.",
+	"e.g.", "i.e.", "cf.", "vs.", "viz.", "approx.", "et al.",
+	"Mr.", "Mrs.", "Ms.", "Dr.", "Prof.", "Sr.", "Jr.", "St.",
+	"Fig.", "Figs.", "Vol.", "Ch.", "Sec.", "Art.", "Para.", "p.", "pp.",
+	"Jan.", "Feb.", "Mar.", "Apr.", "Jun.", "Jul.", "Aug.", "Sep.", "Sept.", "Oct.", "Nov.", "Dec.",
+}
+
+// DefaultAbbreviations returns the built-in abbreviation list. Append to the
+// result to extend it:
+//
+//	config.Abbreviations = append(pdfmarkdown.DefaultAbbreviations(), "Cl.", "Sch.")
+func DefaultAbbreviations() []string {
+	return slices.Clone(defaultAbbreviations)
+}
+
+// isAbbreviation reports whether word, ignoring leading brackets and quotes, is
+// one of the configured abbreviations. Matching is case-sensitive so that
+// "No." is an abbreviation while "NO." is not.
+func (c Config) isAbbreviation(word string) bool {
+	abbreviations := c.Abbreviations
+	if abbreviations == nil {
+		abbreviations = defaultAbbreviations
+	}
+	return slices.Contains(abbreviations, strings.TrimLeft(word, "([\"'‘“"))
+}
+
+// endsWithAbbreviation reports whether the last word of the line is an
+// abbreviation, meaning the text runs on into the next line. Multi-word
+// abbreviations such as "et al." are matched against the last two words.
+func (c Config) endsWithAbbreviation(line Line) bool {
+	n := len(line.Words)
+	if n == 0 {
+		return false
+	}
+	if c.isAbbreviation(line.Words[n-1].Text) {
+		return true
+	}
+	return n >= 2 && c.isAbbreviation(line.Words[n-2].Text+" "+line.Words[n-1].Text)
+}
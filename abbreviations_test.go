@@ -0,0 +1,66 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectLists_Abbreviations(t *testing.T) {
+	para := func(text string) Paragraph {
+		var line Line
+		for _, word := range strings.Fields(text) {
+			line.Words = append(line.Words, EnrichedWord{Text: word})
+		}
+		return Paragraph{Lines: []Line{line}}
+	}
+
+	tests := []struct {
+		name          string
+		abbreviations []string
+		prev          string
+		next          string
+		wantList      bool
+	}{
+		{"after No.", nil, "as set out in Schedule No.", "3. of the agreement", false},
+		{"after e.g.", nil, "some entities, e.g.", "2. and 3. above", false},
+		{"after et al.", nil, "as reported by Smith et al.", "2019 found", false},
+		{"after sentence", nil, "This is the first item.", "2. Second item", true},
+		{"bullet after abbreviation", nil, "Acme Pty. Ltd.", "• Item", true},
+		{"custom abbreviation", []string{"Cl."}, "see Cl.", "4. below", false},
+		{"custom list replaces defaults", []string{"Cl."}, "Schedule No.", "3. Item", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{para(tt.prev), para(tt.next)}
+			detectLists(paragraphs, Config{Abbreviations: tt.abbreviations})
+			if paragraphs[1].IsList != tt.wantList {
+				t.Errorf("IsList = %v, want %v", paragraphs[1].IsList, tt.wantList)
+			}
+		})
+	}
+}
+
+func TestConvertParagraphToMarkdown_AbbreviationLineBreak(t *testing.T) {
+	line := func(text string) Line {
+		var l Line
+		for _, word := range strings.Fields(text) {
+			l.Words = append(l.Words, EnrichedWord{Text: word})
+		}
+		return l
+	}
+	para := Paragraph{Lines: []Line{
+		line("The obligations in Schedule No."),
+		line("2. apply to all parties."),
+		line("3. Each party must comply."),
+	}}
+
+	doc := &Document{Pages: []Page{{Paragraphs: []Paragraph{para}}}}
+	got := doc.ToMarkdown(Config{CollapseWhitespace: true})
+
+	// The number after "No." continues the sentence; the one after a full stop starts a section
+	want := "The obligations in Schedule No.\n2. apply to all parties.\n\n3. Each party must comply.\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
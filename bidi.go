@@ -0,0 +1,179 @@
+package pdfmarkdown
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Reading directions of paragraphs and text blocks.
+const (
+	DirectionLTR = "ltr"
+	DirectionRTL = "rtl"
+)
+
+// isRTLRune reports whether r belongs to a script written right to left:
+// Hebrew, Arabic, Syriac, Thaana or N'Ko.
+func isRTLRune(r rune) bool {
+	return unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko)
+}
+
+// isLTRRune reports whether r is read left to right even within
+// right-to-left text: letters of other scripts and digits of any script.
+func isLTRRune(r rune) bool {
+	return unicode.IsDigit(r) || (unicode.IsLetter(r) && !isRTLRune(r))
+}
+
+// textDirection returns DirectionRTL when text has more right-to-left
+// letters than left-to-right ones, and DirectionLTR otherwise.
+func textDirection(text string) string {
+	var rtl, ltr int
+	for _, r := range text {
+		switch {
+		case isRTLRune(r):
+			rtl++
+		case unicode.IsLetter(r):
+			ltr++
+		}
+	}
+	if rtl > ltr {
+		return DirectionRTL
+	}
+	return DirectionLTR
+}
+
+// hasRTL reports whether text contains any right-to-left letters.
+func hasRTL(text string) bool {
+	for _, r := range text {
+		if isRTLRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// rtlOrder returns the logical order of n items laid out left to right in
+// right-to-left text: the items are read from the right, except that runs of
+// items for which ltr is true keep their left-to-right order, as numbers and
+// Latin words do inside Hebrew or Arabic.
+func rtlOrder(n int, ltr func(i int) bool) []int {
+	order := make([]int, 0, n)
+	for end := n; end > 0; {
+		if !ltr(end - 1) {
+			order = append(order, end-1)
+			end--
+			continue
+		}
+		start := end - 1
+		for start > 0 && ltr(start-1) {
+			start--
+		}
+		for i := start; i < end; i++ {
+			order = append(order, i)
+		}
+		end = start
+	}
+	return order
+}
+
+// logicalChars returns a word's characters in reading order. pdfium usually
+// reports right-to-left text in the order it is drawn, left to right, which
+// spells words backwards; such words are reversed by grapheme cluster,
+// keeping digits and Latin letters in their order. Words already in reading
+// order, which end further left than they start, are returned as they are.
+func logicalChars(chars []EnrichedChar) []EnrichedChar {
+	found := false
+	for _, char := range chars {
+		found = found || isRTLRune(char.Text)
+	}
+	if !found || chars[0].Box.CenterX() >= chars[len(chars)-1].Box.CenterX() {
+		return chars
+	}
+
+	var clusters [][]EnrichedChar
+	for i, start := range charClusterStarts(chars) {
+		if start || len(clusters) == 0 {
+			clusters = append(clusters, nil)
+		}
+		clusters[len(clusters)-1] = append(clusters[len(clusters)-1], chars[i])
+	}
+
+	ordered := make([]EnrichedChar, 0, len(chars))
+	for _, i := range rtlOrder(len(clusters), func(i int) bool { return isLTRRune(clusters[i][0].Text) }) {
+		ordered = append(ordered, clusters[i]...)
+	}
+	return ordered
+}
+
+// unshapeArabic replaces Arabic presentation forms, the positional glyphs
+// some PDFs store instead of letters, with the letters they show, so viewers
+// can shape the text themselves.
+func unshapeArabic(text string) string {
+	for _, r := range text {
+		if (r >= 0xFB50 && r <= 0xFDFF) || (r >= 0xFE70 && r <= 0xFEFC) {
+			return norm.NFKC.String(text)
+		}
+	}
+	return text
+}
+
+// logicalWords returns a line's words, laid out left to right, in reading
+// order. Lines that are mostly right-to-left are read from the right, with
+// runs of left-to-right words kept in order; in other lines, runs of
+// right-to-left words are reversed in place.
+func logicalWords(words []EnrichedWord) []EnrichedWord {
+	rtl := make([]bool, len(words))
+	found := false
+	var text string
+	for i, word := range words {
+		if isRotatedWord(word) {
+			return words
+		}
+		rtl[i] = hasRTL(word.Text)
+		found = found || rtl[i]
+		text += word.Text
+	}
+	if !found {
+		return words
+	}
+
+	ordered := make([]EnrichedWord, 0, len(words))
+	if textDirection(text) == DirectionRTL {
+		ltr := func(i int) bool {
+			for _, r := range words[i].Text {
+				if isLTRRune(r) {
+					return !rtl[i]
+				}
+			}
+			return false
+		}
+		for _, i := range rtlOrder(len(words), ltr) {
+			ordered = append(ordered, words[i])
+		}
+		return ordered
+	}
+
+	for i := 0; i < len(words); {
+		if !rtl[i] {
+			ordered = append(ordered, words[i])
+			i++
+			continue
+		}
+		end := i
+		for end < len(words) && rtl[end] {
+			end++
+		}
+		for j := end - 1; j >= i; j-- {
+			ordered = append(ordered, words[j])
+		}
+		i = end
+	}
+	return ordered
+}
+
+// isRotatedWord reports whether a word is set at an angle, where left to
+// right says nothing about reading order.
+func isRotatedWord(word EnrichedWord) bool {
+	angle := normalizeAngle(word.Rotation)
+	return angle > 10 && angle < 350
+}
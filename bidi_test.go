@@ -0,0 +1,99 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogicalChars(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string // Characters as laid out, left to right
+		want   string
+	}{
+		{"latin", "hello", "hello"},
+		{"hebrew", "םולש", "שלום"},
+		{"hebrew with year", "2024ב", "ב2024"},
+		{"arabic", "ابحرم", "مرحبا"},
+		{"single letter", "ו", "ו"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textOf(logicalChars(charsOf(tt.visual))); got != tt.want {
+				t.Errorf("logicalChars(%q) = %q, want %q", tt.visual, got, tt.want)
+			}
+		})
+	}
+
+	// Characters already in reading order run leftwards and are kept
+	chars := charsOf("שלום")
+	for i := range chars {
+		chars[i].Box.X0, chars[i].Box.X1 = 100-chars[i].Box.X1, 100-chars[i].Box.X0
+	}
+	if got := textOf(logicalChars(chars)); got != "שלום" {
+		t.Errorf("logicalChars(logical order) = %q, want %q", got, "שלום")
+	}
+}
+
+func TestLogicalWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string // Words as laid out, left to right
+		want   string
+	}{
+		{"latin", "plain old text", "plain old text"},
+		{"hebrew", "עולם שלום", "שלום עולם"},
+		{"hebrew with number", "2024 בשנת", "בשנת 2024"},
+		{"hebrew with latin run", "PDF 2 של ממיר", "ממיר של PDF 2"},
+		{"latin with hebrew run", "the words עולם שלום mean hello world", "the words שלום עולם mean hello world"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			para := placedParagraph(tt.visual, 72, 100)
+			if got := joinWords(logicalWords(para.Lines[0].Words)); got != tt.want {
+				t.Errorf("logicalWords(%q) = %q, want %q", tt.visual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnshapeArabic(t *testing.T) {
+	// Lam-alef ligature and the final form of meem, as some fonts encode them
+	if got := unshapeArabic("\uFEFB\uFEE2"); got != "لام" {
+		t.Errorf("unshapeArabic() = %q, want %q", got, "لام")
+	}
+	if got := unshapeArabic("ﬁle"); got != "ﬁle" {
+		t.Errorf("unshapeArabic() changed text without Arabic forms: %q", got)
+	}
+}
+
+func TestTextDirection(t *testing.T) {
+	tests := map[string]string{
+		"Hello world":             DirectionLTR,
+		"שלום עולם":               DirectionRTL,
+		"مرحبا بالعالم 2024":      DirectionRTL,
+		"The word שלום is Hebrew": DirectionLTR,
+		"":                        DirectionLTR,
+	}
+	for text, want := range tests {
+		if got := textDirection(text); got != want {
+			t.Errorf("textDirection(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestRTLParagraphHTML(t *testing.T) {
+	para := placedParagraph("עולם שלום", 72, 100)
+	para.Lines[0].Words = logicalWords(para.Lines[0].Words)
+	para.ReadingDirection = textDirection(para.Text())
+	heading := placedParagraph("פתיחה", 72, 80)
+	heading.IsHeading, heading.HeadingLevel, heading.ReadingDirection = true, 1, DirectionRTL
+
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: []Paragraph{heading, para, placedParagraph("Hello", 72, 120)}}}}
+	got := doc.ToHTML(DefaultConfig())
+	for _, want := range []string{`dir="rtl">פתיחה</h1>`, `<p dir="rtl">שלום עולם</p>`, "<p>Hello</p>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToHTML() missing %q:\n%s", want, got)
+		}
+	}
+}
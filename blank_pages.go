@@ -0,0 +1,53 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// blankPageMaxChars is the most non-space characters, page numbers aside, a
+// page can hold and still count as blank, enough for a stray footer such as
+// "Confidential".
+const blankPageMaxChars = 12
+
+// intentionallyBlankPattern matches notices such as "This page intentionally
+// left blank", which mark a page as blank however long they are.
+var intentionallyBlankPattern = regexp.MustCompile(`(?i)^(this\s+page\s+(has\s+been\s+|is\s+)?)?(intentionally|deliberately)\s+(left\s+)?blank\.?$`)
+
+// isBlankPage reports whether a page has no meaningful content: no tables or
+// headings, and at most blankPageMaxChars characters of text once page
+// numbers and blank-page notices are set aside.
+func isBlankPage(page Page) bool {
+	if len(page.Tables) > 0 {
+		return false
+	}
+
+	chars := 0
+	for _, para := range page.Paragraphs {
+		if para.IsHeading {
+			return false
+		}
+		text := strings.TrimSpace(para.Text())
+		if pageNumberPattern.MatchString(text) || intentionallyBlankPattern.MatchString(text) {
+			continue
+		}
+		for _, r := range text {
+			if !unicode.IsSpace(r) {
+				chars++
+			}
+		}
+	}
+	return chars <= blankPageMaxChars
+}
+
+// withoutBlankPages returns the pages that are not blank.
+func withoutBlankPages(pages []Page) []Page {
+	kept := make([]Page, 0, len(pages))
+	for _, page := range pages {
+		if !isBlankPage(page) {
+			kept = append(kept, page)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,73 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// textPage builds a page with one single-line paragraph per text.
+func textPage(number int, texts ...string) Page {
+	page := Page{Number: number}
+	for _, text := range texts {
+		var words []EnrichedWord
+		for _, word := range strings.Fields(text) {
+			words = append(words, EnrichedWord{Text: word, FontSize: 12})
+		}
+		page.Paragraphs = append(page.Paragraphs, Paragraph{Lines: []Line{{Words: words}}})
+	}
+	return page
+}
+
+func TestIsBlankPage(t *testing.T) {
+	heading := textPage(1, "Appendix")
+	heading.Paragraphs[0].IsHeading = true
+
+	table := textPage(1)
+	table.Tables = []Table{makeTable([]string{"A", "B"}, []string{"1", "2"})}
+
+	tests := []struct {
+		name string
+		page Page
+		want bool
+	}{
+		{"empty", textPage(1), true},
+		{"page number only", textPage(1, "Page 7 of 12"), true},
+		{"short footer", textPage(1, "Confidential", "- 7 -"), true},
+		{"blank notice", textPage(1, "This page intentionally left blank."), true},
+		{"short sentence", textPage(1, "Thank you for your business."), false},
+		{"divider heading", heading, false},
+		{"table", table, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBlankPage(tt.page); got != tt.want {
+				t.Errorf("isBlankPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentToMarkdown_SkipBlankPages(t *testing.T) {
+	doc := &Document{Pages: []Page{
+		textPage(1, "The first page of the report"),
+		textPage(2, "2"),
+		textPage(3, "The third page of the report"),
+	}}
+
+	config := DefaultConfig()
+	config.CollapseWhitespace = true
+	if got := doc.ToMarkdown(config); strings.Count(got, "---") != 2 {
+		t.Errorf("blank page should be kept without SkipBlankPages:\n%s", got)
+	}
+
+	config.SkipBlankPages = true
+	want := "The first page of the report\n\n---\n\nThe third page of the report\n"
+	if got := doc.ToMarkdown(config); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if stats := calculateDocumentStatistics(doc); stats.BlankPages != 1 {
+		t.Errorf("BlankPages = %d, want 1", stats.BlankPages)
+	}
+}
@@ -0,0 +1,24 @@
+package pdfmarkdown
+
+import "strconv"
+
+// ParagraphID returns the stable id of a paragraph, "p:12-3" for the third
+// paragraph on page 12, as written by Config.BlockAnchors and in the JSON
+// output. index is the paragraph's 0-based position among the rendered
+// page's paragraphs, so ids depend on the blocks the config drops and are
+// only stable for the same config.
+func ParagraphID(page, index int) string {
+	return "p:" + strconv.Itoa(page) + "-" + strconv.Itoa(index+1)
+}
+
+// TableID returns the stable id of a table, "t:12-1" for the first table on
+// page 12; see ParagraphID.
+func TableID(page, index int) string {
+	return "t:" + strconv.Itoa(page) + "-" + strconv.Itoa(index+1)
+}
+
+// blockAnchor returns the HTML comment Config.BlockAnchors writes before the
+// block with id.
+func blockAnchor(id string) string {
+	return "<!-- " + id + " -->"
+}
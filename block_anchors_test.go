@@ -0,0 +1,68 @@
+package pdfmarkdown
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestBlockAnchors tests that the anchors in the markdown name the same
+// blocks as the ids in the JSON output
+func TestBlockAnchors(t *testing.T) {
+	first, second := placedParagraph("Sales rose", 72, 90), placedParagraph("Costs fell", 72, 120)
+	doc := &Document{Pages: []Page{{
+		Number:     3,
+		Width:      600,
+		Height:     800,
+		Paragraphs: []Paragraph{first, second},
+		Tables:     []Table{placedTable(ruledRow(200, "Region", "Sales", "Stock"))},
+	}}}
+
+	config := DefaultConfig()
+	config.BlockAnchors = true
+	config.ExcludeBlocks = SelectText(regexp.MustCompile(`^Sales`))
+
+	md := doc.ToMarkdown(config)
+	for _, want := range []string{"<!-- p:3-1 -->", "<!-- t:3-1 -->"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown has no %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "p:3-2") {
+		t.Errorf("markdown anchors an excluded paragraph:\n%s", md)
+	}
+	if anchor, text := strings.Index(md, "<!-- p:3-1 -->"), strings.Index(md, "Costs fell"); anchor < 0 || text < anchor {
+		t.Errorf("anchor does not precede its paragraph:\n%s", md)
+	}
+
+	data, err := doc.ToJSON(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Pages []struct {
+			Blocks []struct{ ID, Text string }
+			Tables []struct{ ID string }
+		}
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, block := range got.Pages[0].Blocks {
+		ids = append(ids, block.ID+" "+block.Text)
+	}
+	for _, table := range got.Pages[0].Tables {
+		ids = append(ids, table.ID)
+	}
+	if want := []string{"p:3-1 Costs fell", "t:3-1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("JSON ids = %q, want %q", ids, want)
+	}
+
+	config.BlockAnchors = false
+	if md := doc.ToMarkdown(config); strings.Contains(md, "<!--") {
+		t.Errorf("markdown has anchors without BlockAnchors:\n%s", md)
+	}
+}
@@ -0,0 +1,177 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ContentBlock is a paragraph or table on a page, as seen by a BlockSelector.
+// Exactly one of Paragraph and Table is set.
+type ContentBlock struct {
+	PageNumber int
+	Paragraph  *Paragraph
+	Table      *Table
+
+	// Section is the text of the nearest heading at or before the block,
+	// across pages, or "" before the first heading
+	Section string
+}
+
+// BlockSelector reports whether a block matches, for choosing which blocks
+// Document.Filter and Config.IncludeBlocks and ExcludeBlocks keep.
+type BlockSelector func(block ContentBlock) bool
+
+// Role names the block's role: "h1"-"h6", "body", "list", "code", "leader",
+// "quote", "equation" or "table".
+func (b ContentBlock) Role() string {
+	if b.Table != nil {
+		return "table"
+	}
+	return paragraphRole(*b.Paragraph)
+}
+
+// Box returns the block's bounding box in page coordinates.
+func (b ContentBlock) Box() Rect {
+	if b.Table != nil {
+		return Rect{X0: b.Table.BBox.X0, Y0: b.Table.BBox.Top, X1: b.Table.BBox.X1, Y1: b.Table.BBox.Bottom}
+	}
+	return b.Paragraph.Box
+}
+
+// Text returns the block's text: a paragraph's lines, or a table's cells
+// separated by tabs and its rows by newlines.
+func (b ContentBlock) Text() string {
+	if b.Paragraph != nil {
+		return b.Paragraph.Text()
+	}
+	rows := make([]string, len(b.Table.Rows))
+	for i, row := range b.Table.Rows {
+		cells := make([]string, len(row.Cells))
+		for j, cell := range row.Cells {
+			cells[j] = cell.Content
+		}
+		rows[i] = strings.Join(cells, "\t")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// SelectRoles matches blocks with any of the given roles; see ContentBlock.Role.
+func SelectRoles(roles ...string) BlockSelector {
+	return func(block ContentBlock) bool {
+		role := block.Role()
+		for _, r := range roles {
+			if r == role {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SelectPages matches blocks on pages first to last (1-based, inclusive).
+func SelectPages(first, last int) BlockSelector {
+	return func(block ContentBlock) bool {
+		return block.PageNumber >= first && block.PageNumber <= last
+	}
+}
+
+// SelectWithin matches blocks lying entirely inside box.
+func SelectWithin(box Rect) BlockSelector {
+	return func(block ContentBlock) bool {
+		b := block.Box()
+		return b.X0 >= box.X0 && b.Y0 >= box.Y0 && b.X1 <= box.X1 && b.Y1 <= box.Y1
+	}
+}
+
+// SelectText matches blocks whose text matches pattern.
+func SelectText(pattern *regexp.Regexp) BlockSelector {
+	return func(block ContentBlock) bool {
+		return pattern.MatchString(block.Text())
+	}
+}
+
+// SelectSection matches blocks under a heading whose text matches pattern,
+// including the heading itself, up to the next heading.
+func SelectSection(pattern *regexp.Regexp) BlockSelector {
+	return func(block ContentBlock) bool {
+		return block.Section != "" && pattern.MatchString(block.Section)
+	}
+}
+
+// AnyOf matches blocks matching any of selectors.
+func AnyOf(selectors ...BlockSelector) BlockSelector {
+	return func(block ContentBlock) bool {
+		for _, selector := range selectors {
+			if selector(block) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Filter returns a copy of the document keeping only the blocks keep returns
+// true for. Pages left empty are kept, so page numbers and breaks are
+// unchanged.
+func (d *Document) Filter(keep BlockSelector) *Document {
+	filtered := *d
+	filtered.Pages = make([]Page, len(d.Pages))
+	sections := &blockSections{}
+	for i, page := range d.Pages {
+		filtered.Pages[i] = sections.filter(page, keep)
+	}
+	return &filtered
+}
+
+// blockSelector combines Config.IncludeBlocks and ExcludeBlocks into one
+// selector for the blocks to keep, or nil when neither is set.
+func (c Config) blockSelector() BlockSelector {
+	include, exclude := c.IncludeBlocks, c.ExcludeBlocks
+	if include == nil && exclude == nil {
+		return nil
+	}
+	return func(block ContentBlock) bool {
+		return (include == nil || include(block)) && (exclude == nil || !exclude(block))
+	}
+}
+
+// blockSections filters pages in document order, tracking the heading each
+// block falls under from one page to the next.
+type blockSections struct {
+	section string
+}
+
+// filter returns a copy of page keeping only the blocks keep returns true
+// for. Tables fall under the last heading above them.
+func (s *blockSections) filter(page Page, keep BlockSelector) Page {
+	before := s.section
+
+	paragraphs := make([]Paragraph, 0, len(page.Paragraphs))
+	for i := range page.Paragraphs {
+		para := &page.Paragraphs[i]
+		if para.IsHeading {
+			s.section = headingText(*para)
+		}
+		if keep(ContentBlock{PageNumber: page.Number, Paragraph: para, Section: s.section}) {
+			paragraphs = append(paragraphs, *para)
+		}
+	}
+
+	var tables []Table
+	for i := range page.Tables {
+		table := &page.Tables[i]
+		section := before
+		for _, para := range page.Paragraphs {
+			if para.IsHeading && para.Box.Y0 <= table.BBox.Top {
+				section = headingText(para)
+			}
+		}
+		if keep(ContentBlock{PageNumber: page.Number, Table: table, Section: section}) {
+			tables = append(tables, *table)
+		}
+	}
+
+	page.Paragraphs = paragraphs
+	page.Tables = tables
+	return page
+}
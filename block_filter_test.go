@@ -0,0 +1,77 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// blockTexts lists the text of each paragraph and table left on each page
+func blockTexts(doc *Document) [][]string {
+	var pages [][]string
+	for _, page := range doc.Pages {
+		var texts []string
+		for i := range page.Paragraphs {
+			texts = append(texts, ContentBlock{Paragraph: &page.Paragraphs[i]}.Text())
+		}
+		for i := range page.Tables {
+			texts = append(texts, ContentBlock{Table: &page.Tables[i]}.Text())
+		}
+		pages = append(pages, texts)
+	}
+	return pages
+}
+
+func TestDocumentFilter(t *testing.T) {
+	appendix := headingPage(2, "Appendix A", 18)
+	appendix.Tables = []Table{{Rows: []TableRow{{Cells: []TableCell{{Content: "Fee"}, {Content: "$10"}}}}}}
+	doc := &Document{Pages: []Page{headingPage(1, "Introduction", 18), appendix, headingPage(3, "Glossary", 18)}}
+
+	tests := []struct {
+		name    string
+		exclude BlockSelector
+		want    [][]string
+	}{
+		{
+			name:    "section",
+			exclude: SelectSection(regexp.MustCompile(`^Appendix`)),
+			want:    [][]string{{"Introduction", "Body"}, nil, {"Glossary", "Body"}},
+		},
+		{
+			name:    "role",
+			exclude: SelectRoles("table", "body"),
+			want:    [][]string{{"Introduction"}, {"Appendix A"}, {"Glossary"}},
+		},
+		{
+			name:    "pages or text",
+			exclude: AnyOf(SelectPages(1, 2), SelectText(regexp.MustCompile(`^Body$`))),
+			want:    [][]string{nil, nil, {"Glossary"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := doc.Filter(func(block ContentBlock) bool { return !tt.exclude(block) })
+			if got := blockTexts(filtered); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The document itself is unchanged
+	if got := len(doc.Pages[1].Paragraphs) + len(doc.Pages[1].Tables); got != 3 {
+		t.Errorf("original page 2 has %d blocks after filtering, want 3", got)
+	}
+}
+
+// TestBlockSelectorConfig tests that IncludeBlocks and ExcludeBlocks apply to markdown output
+func TestBlockSelectorConfig(t *testing.T) {
+	doc := &Document{Pages: []Page{headingPage(1, "Introduction", 18), headingPage(2, "Appendix", 18)}}
+	config := DefaultConfig()
+	config.IncludePageBreaks = false
+	config.IncludeBlocks = SelectRoles("h1", "h2")
+	config.ExcludeBlocks = SelectPages(2, 2)
+
+	if got, want := doc.ToMarkdown(config), "# Introduction\n  "; got != want {
+		t.Errorf("ToMarkdown() = %q, want %q", got, want)
+	}
+}
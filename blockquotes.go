@@ -0,0 +1,127 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strings"
+)
+
+// minQuoteIndentEm is how far, in ems, a block must start right of the body
+// text around it to be taken for an indented quotation.
+const minQuoteIndentEm = 2.0
+
+// maxQuoteRuleGapEm is the furthest, in ems, a vertical rule may stand left
+// of a block it marks as quoted.
+const maxQuoteRuleGapEm = 2.0
+
+// detectBlockquotes marks paragraphs set apart as quotations, the way legal
+// and academic documents set long quotes: indented from the body text of
+// their column on the left, and not pushed right by ragged or centred
+// alignment; marked by a vertical rule down their left edge; or set wholly in
+// italics at full width among upright body text. Only running text of two
+// lines or more is considered, leaving headings, lists, code and short
+// indented lines such as signatures alone.
+func detectBlockquotes(paragraphs []Paragraph, rules []Edge) {
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if !isQuoteCandidate(*para) {
+			continue
+		}
+		em := paragraphEm(*para)
+		if quoteRule(*para, rules, em) {
+			para.IsQuote = true
+			continue
+		}
+
+		left, italic, ok := bodyTextAround(paragraphs, i)
+		if !ok {
+			continue
+		}
+		switch {
+		case para.Alignment != AlignmentCenter && para.Alignment != AlignmentRight && para.Box.X0-left >= em*minQuoteIndentEm:
+			para.IsQuote = true
+		case !italic && math.Abs(para.Box.X0-left) < em*minIndentEm && italicShare(*para) >= 0.9:
+			para.IsQuote = true
+		}
+	}
+}
+
+// isQuoteCandidate reports whether a paragraph is running text that could be
+// a quotation.
+func isQuoteCandidate(para Paragraph) bool {
+	return len(para.Lines) >= 2 && !para.IsHeading && !para.IsList && !para.IsCode &&
+		len(para.Leaders) == 0 && para.OrientedBox == nil
+}
+
+// quoteRule reports whether a vertical rule runs down the left of a
+// paragraph, close to its text and along most of its height.
+func quoteRule(para Paragraph, rules []Edge, em float64) bool {
+	for _, rule := range rules {
+		if rule.Orientation != "v" || rule.IsColumnSeparator {
+			continue
+		}
+		gap := para.Box.X0 - rule.X1
+		covered := math.Min(rule.Bottom, para.Box.Y1) - math.Max(rule.Top, para.Box.Y0)
+		if gap >= 0 && gap <= em*maxQuoteRuleGapEm && covered >= para.Box.Height()*0.8 {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyTextAround returns the left edge of the body text in the column of
+// paragraphs[i], as the median start of the other running text paragraphs
+// beside or beneath it, and whether that text is mostly italic. ok is false
+// when there is none to compare with.
+func bodyTextAround(paragraphs []Paragraph, i int) (left float64, italic bool, ok bool) {
+	para := paragraphs[i]
+	var starts []float64
+	var italics int
+	for j, other := range paragraphs {
+		if j == i || !isQuoteCandidate(other) || other.Alignment == AlignmentCenter || other.Alignment == AlignmentRight {
+			continue
+		}
+		// Only paragraphs sharing the column count
+		if math.Min(other.Box.X1, para.Box.X1)-math.Max(other.Box.X0, para.Box.X0) <= 0 {
+			continue
+		}
+		starts = append(starts, other.Box.X0)
+		if italicShare(other) >= 0.5 {
+			italics++
+		}
+	}
+	if len(starts) == 0 {
+		return 0, false, false
+	}
+	return calculateMedian(starts), italics*2 > len(starts), true
+}
+
+// italicShare returns the fraction of a paragraph's letters set in italics.
+func italicShare(para Paragraph) float64 {
+	var italic, total int
+	for _, line := range para.Lines {
+		for _, word := range line.Words {
+			n := len([]rune(word.Text))
+			total += n
+			if word.IsItalic {
+				italic += n
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(italic) / float64(total)
+}
+
+// quoteMarkdown prefixes each line of rendered markdown with "> ".
+func quoteMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
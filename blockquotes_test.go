@@ -0,0 +1,87 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// blockParagraph lays out a two-line paragraph starting at x0, each line
+// from placedParagraph.
+func blockParagraph(first, second string, x0, y float64) Paragraph {
+	a, b := placedParagraph(first, x0, y), placedParagraph(second, x0, y+12)
+	lines := []Line{a.Lines[0], b.Lines[0]}
+	return Paragraph{Lines: lines, Box: mergeRects(a.Box, b.Box), Alignment: AlignmentLeft, Indent: x0}
+}
+
+func TestDetectBlockquotes(t *testing.T) {
+	body := func(y float64) Paragraph {
+		return blockParagraph("The court then turned to the question of", "whether the contract had been formed.", 72, y)
+	}
+	italic := func(para Paragraph) Paragraph {
+		for i := range para.Lines {
+			for j := range para.Lines[i].Words {
+				para.Lines[i].Words[j].IsItalic = true
+			}
+		}
+		return para
+	}
+
+	tests := []struct {
+		name  string
+		para  Paragraph
+		rules []Edge
+		want  bool
+	}{
+		{"indented", blockParagraph("No person shall be deprived of", "life, liberty, or property.", 108, 40), nil, true},
+		{"slightly indented", blockParagraph("No person shall be deprived of", "life, liberty, or property.", 80, 40), nil, false},
+		{"ruled", blockParagraph("No person shall be deprived of", "life, liberty, or property.", 72, 40),
+			[]Edge{{X0: 66, X1: 66, Top: 38, Bottom: 64, Orientation: "v"}}, true},
+		{"column separator", blockParagraph("No person shall be deprived of", "life, liberty, or property.", 72, 40),
+			[]Edge{{X0: 66, X1: 66, Top: 38, Bottom: 64, Orientation: "v", IsColumnSeparator: true}}, false},
+		{"italic", italic(blockParagraph("No person shall be deprived of", "life, liberty, or property.", 72, 40)), nil, true},
+		{"centered", func() Paragraph {
+			para := blockParagraph("No person shall be deprived of", "life, liberty, or property.", 108, 40)
+			para.Alignment = AlignmentCenter
+			return para
+		}(), nil, false},
+		{"single line", placedParagraph("Signed by the parties", 108, 40), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{body(0), tt.para, body(80)}
+			detectBlockquotes(paragraphs, tt.rules)
+			if paragraphs[1].IsQuote != tt.want {
+				t.Errorf("IsQuote = %v, want %v", paragraphs[1].IsQuote, tt.want)
+			}
+			if paragraphs[0].IsQuote || paragraphs[2].IsQuote {
+				t.Error("body text marked as a quote")
+			}
+		})
+	}
+
+	// Italic text among italic text is not set apart
+	paragraphs := []Paragraph{italic(body(0)), italic(body(40)), italic(body(80))}
+	detectBlockquotes(paragraphs, nil)
+	for _, para := range paragraphs {
+		if para.IsQuote {
+			t.Error("italic body text marked as a quote")
+		}
+	}
+}
+
+func TestBlockquoteMarkdown(t *testing.T) {
+	quote := blockParagraph("No person shall be deprived of", "life, liberty, or property.", 108, 40)
+	quote.IsQuote = true
+	doc := &Document{Pages: []Page{{Paragraphs: []Paragraph{textParagraph("As the clause reads:"), quote}}}}
+
+	config := DefaultConfig()
+	config.CollapseWhitespace = true
+	want := "As the clause reads:\n\n> No person shall be deprived of\n> life, liberty, or property.\n"
+	if got := doc.ToMarkdown(config); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := doc.ToHTML(config); !strings.Contains(got, "<blockquote>\n<p>No person shall be deprived of<br>\nlife, liberty, or property.</p>\n</blockquote>\n") {
+		t.Errorf("HTML blockquote not as expected:\n%s", got)
+	}
+}
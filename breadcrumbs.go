@@ -0,0 +1,51 @@
+package pdfmarkdown
+
+import "strings"
+
+// BuildBreadcrumbs records the heading path, such as ["Report", "Results",
+// "Revenue"], that each paragraph and page falls under, in Paragraph.Breadcrumb
+// and Page.Breadcrumb. Heading levels are normalized with config first, so the
+// paths follow the outline ToMarkdown would render. A heading closes every
+// open heading at its own level or deeper.
+func (d *Document) BuildBreadcrumbs(config Config) {
+	normalizeDocumentHeadings(d, config)
+
+	type openHeading struct {
+		level int
+		text  string
+	}
+	var open []openHeading
+	var path []string // Texts of open, shared by paragraphs until it changes
+
+	setPath := func() {
+		path = make([]string, len(open))
+		for i, h := range open {
+			path[i] = h.text
+		}
+	}
+
+	for pi := range d.Pages {
+		page := &d.Pages[pi]
+		page.Breadcrumb = path
+
+		for i := range page.Paragraphs {
+			para := &page.Paragraphs[i]
+			if !para.IsHeading {
+				para.Breadcrumb = path
+				continue
+			}
+
+			closed := false
+			for len(open) > 0 && open[len(open)-1].level >= para.HeadingLevel {
+				open, closed = open[:len(open)-1], true
+			}
+			if closed {
+				setPath()
+			}
+			para.Breadcrumb = path
+
+			open = append(open, openHeading{level: para.HeadingLevel, text: strings.Join(strings.Fields(para.Text()), " ")})
+			setPath()
+		}
+	}
+}
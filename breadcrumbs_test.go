@@ -0,0 +1,68 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sizedHeading returns a one-line heading paragraph set at size points.
+func sizedHeading(text string, size float64) Paragraph {
+	para := textParagraph(text)
+	for i := range para.Lines[0].Words {
+		para.Lines[0].Words[i].FontSize = size
+	}
+	para.IsHeading = true
+	return para
+}
+
+func TestDocument_BuildBreadcrumbs(t *testing.T) {
+	doc := &Document{Pages: []Page{
+		{Paragraphs: []Paragraph{
+			textParagraph("Preamble"),
+			sizedHeading("Annual  Report", 24),
+			sizedHeading("Results", 18),
+			sizedHeading("Revenue", 14),
+			textParagraph("Revenue grew"),
+		}},
+		{Paragraphs: []Paragraph{
+			textParagraph("Continued"),
+			sizedHeading("Costs", 14),
+			textParagraph("Costs fell"),
+			sizedHeading("Outlook", 18),
+			textParagraph("Steady"),
+		}},
+	}}
+
+	doc.BuildBreadcrumbs(DefaultConfig())
+
+	want := [][][]string{
+		{
+			nil,
+			nil,
+			{"Annual Report"},
+			{"Annual Report", "Results"},
+			{"Annual Report", "Results", "Revenue"},
+		},
+		{
+			{"Annual Report", "Results", "Revenue"},
+			{"Annual Report", "Results"},
+			{"Annual Report", "Results", "Costs"},
+			{"Annual Report"},
+			{"Annual Report", "Outlook"},
+		},
+	}
+	for pi, page := range doc.Pages {
+		for i, para := range page.Paragraphs {
+			if !reflect.DeepEqual(para.Breadcrumb, want[pi][i]) {
+				t.Errorf("page %d paragraph %d (%q) breadcrumb = %q, want %q", pi+1, i, para.Text(), para.Breadcrumb, want[pi][i])
+			}
+		}
+	}
+
+	if got := doc.Pages[1].Breadcrumb; !reflect.DeepEqual(got, []string{"Annual Report", "Results", "Revenue"}) {
+		t.Errorf("page 2 breadcrumb = %q", got)
+	}
+	if got := doc.Pages[0].Breadcrumb; got != nil {
+		t.Errorf("page 1 breadcrumb = %q, want none", got)
+	}
+}
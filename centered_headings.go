@@ -0,0 +1,88 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// centeredHeadingMaxWords is the word count beyond which a centered line no
+// longer reads as a title; lines of half this many words or fewer count as
+// fully short.
+const centeredHeadingMaxWords = 12
+
+// centeredHeadingScore rates, from 0 to 1, how much a single-line paragraph
+// at index i looks like a centered section title: it must be centered and
+// narrower than the text width, and scores on being short and on the
+// whitespace above and below it, weighted equally. Sentences, page numbers
+// and list items score 0.
+func centeredHeadingScore(paragraphs []Paragraph, i int, textWidth float64) float64 {
+	para := paragraphs[i]
+	if para.Alignment != AlignmentCenter || len(para.Lines) != 1 || para.Box.Width() > textWidth*0.6 {
+		return 0
+	}
+	line := para.Lines[0]
+	text := strings.TrimSpace(line.Text())
+	if text == "" || !strings.ContainsFunc(text, unicode.IsLetter) || pageNumberPattern.MatchString(text) {
+		return 0
+	}
+	if strings.HasSuffix(text, ".") || line.Words[0].IsBulletOrNumber() {
+		return 0
+	}
+
+	shortness := headingShortness(line)
+
+	// Whitespace above and below, relative to the font size; the page edge
+	// counts as whitespace
+	fontSize := getLineFontSize(line)
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+	gap := func(j int) float64 {
+		if j < 0 || j >= len(paragraphs) {
+			return 1
+		}
+		other := paragraphs[j].Box
+		g := math.Max(other.Y0-para.Box.Y1, para.Box.Y0-other.Y1)
+		return math.Min(1, math.Max(0, g/fontSize))
+	}
+	spacing := math.Min(gap(i-1), gap(i+1))
+
+	return 0.5*shortness + 0.5*spacing
+}
+
+// headingShortness rates a line from 1, at half centeredHeadingMaxWords words
+// or fewer, down to 0 at centeredHeadingMaxWords.
+func headingShortness(line Line) float64 {
+	words := float64(len(line.Words))
+	return math.Min(1, math.Max(0, (centeredHeadingMaxWords-words)/(centeredHeadingMaxWords/2)))
+}
+
+// detectCenteredHeadings promotes short centered lines whose score reaches
+// Config.CenteredHeadings to headings of the given level.
+func detectCenteredHeadings(paragraphs []Paragraph, level int, config Config) {
+	if config.CenteredHeadings <= 0 {
+		return
+	}
+
+	var textWidth float64
+	for _, para := range paragraphs {
+		for _, line := range para.Lines {
+			textWidth = math.Max(textWidth, line.Box.Width())
+		}
+	}
+
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if para.IsHeading {
+			continue
+		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
+		if centeredHeadingScore(paragraphs, i, textWidth) >= config.CenteredHeadings {
+			para.IsHeading = true
+			para.HeadingLevel = level
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// placedParagraph lays out a one-line paragraph of 10pt words from x0, with
+// its top at y.
+func placedParagraph(text string, x0, y float64) Paragraph {
+	var line Line
+	x := x0
+	for _, word := range strings.Fields(text) {
+		width := float64(len(word)) * 5
+		line.Words = append(line.Words, EnrichedWord{Text: word, FontSize: 10, Box: Rect{X0: x, Y0: y, X1: x + width, Y1: y + 10}})
+		x += width + 3
+	}
+	line.Box = Rect{X0: x0, Y0: y, X1: x - 3, Y1: y + 10}
+	return Paragraph{Lines: []Line{line}, Box: line.Box, Alignment: detectAlignment([]Line{line}, 600)}
+}
+
+// centeredParagraph lays out a one-line paragraph centered on a 600pt page.
+func centeredParagraph(text string, y float64) Paragraph {
+	width := float64(len(text)) * 5
+	return placedParagraph(text, 300-width/2, y)
+}
+
+func TestDetectCenteredHeadings(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog and then keeps on running far away"
+
+	tests := []struct {
+		name      string
+		middle    Paragraph
+		gap       float64
+		threshold float64
+		want      bool
+	}{
+		{name: "centered title with space around it", middle: centeredParagraph("Results and Discussion", 0), gap: 20, threshold: 0.75, want: true},
+		{name: "disabled", middle: centeredParagraph("Results and Discussion", 0), gap: 20, threshold: 0},
+		{name: "left aligned", middle: placedParagraph("Results and Discussion", 50, 0), gap: 20, threshold: 0.75},
+		{name: "tight spacing", middle: centeredParagraph("Results and Discussion", 0), gap: 2, threshold: 0.75},
+		{name: "sentence", middle: centeredParagraph("See the results below.", 0), gap: 20, threshold: 0.75},
+		{name: "page number", middle: centeredParagraph("Page 4", 0), gap: 20, threshold: 0.75},
+		{name: "long centered line", middle: centeredParagraph("one two three four five six seven eight nine ten eleven", 0), gap: 20, threshold: 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			above := placedParagraph(body, 50, 0)
+			middle := tt.middle
+			shift := above.Box.Y1 + tt.gap
+			middle.Box.Y0, middle.Box.Y1 = shift, shift+10
+			below := placedParagraph(body, 50, middle.Box.Y1+tt.gap)
+
+			paragraphs := []Paragraph{above, middle, below}
+			detectCenteredHeadings(paragraphs, 2, Config{CenteredHeadings: tt.threshold})
+			if got := paragraphs[1]; got.IsHeading != tt.want || (tt.want && got.HeadingLevel != 2) {
+				t.Errorf("IsHeading = %v (level %d), want %v", got.IsHeading, got.HeadingLevel, tt.want)
+			}
+			if paragraphs[0].IsHeading || paragraphs[2].IsHeading {
+				t.Error("body text promoted to a heading")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package pdfmarkdown
+
+import "sort"
+
+// PageChanges lists the pages that differ between two conversions of a
+// document, by 1-indexed page number.
+type PageChanges struct {
+	Added   []int // Pages only in the current conversion
+	Removed []int // Pages only in the previous conversion
+	Changed []int // Pages whose content hash differs
+}
+
+// Empty reports whether the conversions have the same pages and content.
+func (c PageChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// PageHashes returns each page's ContentHash keyed by page number, for
+// comparing against a later conversion with ComparePageHashes.
+func (d *Document) PageHashes() map[int]string {
+	hashes := make(map[int]string, len(d.Pages))
+	for _, page := range d.Pages {
+		hashes[page.Number] = page.ContentHash
+	}
+	return hashes
+}
+
+// ComparePageHashes reports the pages added, removed and changed between the
+// page hashes of a previous and the current conversion. Content hashes ignore
+// case and whitespace, so only changes to the words themselves are reported.
+func ComparePageHashes(previous, current map[int]string) PageChanges {
+	var changes PageChanges
+	for number, hash := range current {
+		old, ok := previous[number]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, number)
+		case old != hash:
+			changes.Changed = append(changes.Changed, number)
+		}
+	}
+	for number := range previous {
+		if _, ok := current[number]; !ok {
+			changes.Removed = append(changes.Removed, number)
+		}
+	}
+
+	sort.Ints(changes.Added)
+	sort.Ints(changes.Removed)
+	sort.Ints(changes.Changed)
+	return changes
+}
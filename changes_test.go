@@ -0,0 +1,29 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComparePageHashes(t *testing.T) {
+	previous := &Document{Pages: []Page{
+		*pageWithText(1, []string{"Cover"}),
+		*pageWithText(2, []string{"Terms", "apply"}),
+		*pageWithText(3, []string{"Appendix"}),
+	}}
+	current := &Document{Pages: []Page{
+		*pageWithText(1, []string{"COVER"}),
+		*pageWithText(2, []string{"Terms", "changed"}),
+		*pageWithText(4, []string{"Index"}),
+	}}
+
+	got := ComparePageHashes(previous.PageHashes(), current.PageHashes())
+	want := PageChanges{Added: []int{4}, Removed: []int{3}, Changed: []int{2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComparePageHashes() = %+v, want %+v", got, want)
+	}
+
+	if !ComparePageHashes(previous.PageHashes(), previous.PageHashes()).Empty() {
+		t.Error("Expected no changes between identical conversions")
+	}
+}
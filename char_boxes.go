@@ -0,0 +1,112 @@
+package pdfmarkdown
+
+import "math"
+
+// Proportions of the font size used for rebuilt character boxes: the
+// ascent above the baseline, the descent below it, and the width of a glyph
+// when its advance is unknown.
+const (
+	syntheticAscent    = 0.8
+	syntheticDescent   = 0.2
+	syntheticCharWidth = 0.5
+)
+
+// isDegenerateBox reports whether a character's box has no usable width or
+// height, as Type3 and some broken fonts report.
+func isDegenerateBox(box Rect) bool {
+	const minExtent = 0.01 // points
+	for _, v := range []float64{box.X0, box.Y0, box.X1, box.Y1} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return box.Width() < minExtent || box.Height() < minExtent
+}
+
+// repairCharBoxes rebuilds the boxes of visible horizontal characters that
+// have no width or height, so they don't collapse word boxes or throw off
+// baselines. The height comes from the glyph origin and font size, or from a
+// neighbouring character on the same line, and the width from the advance to
+// the next character's origin, or half the font size. It returns the
+// characters, copied when any changed, and how many boxes were rebuilt.
+func repairCharBoxes(chars []EnrichedChar) ([]EnrichedChar, int) {
+	repaired := 0
+	for i, char := range chars {
+		if char.Text == ' ' || char.Text == '\t' || char.Text == '\n' || char.Text == '\r' ||
+			isRotatedText(char.Angle) || !isDegenerateBox(char.Box) {
+			continue
+		}
+		if repaired == 0 {
+			chars = append([]EnrichedChar(nil), chars...)
+		}
+		chars[i].Box = syntheticCharBox(chars, i)
+		repaired++
+	}
+	return chars, repaired
+}
+
+// syntheticCharBox returns a plausible box for the character at i.
+func syntheticCharBox(chars []EnrichedChar, i int) Rect {
+	char := chars[i]
+	size := char.FontSize
+	if size <= 0 {
+		size = 12
+	}
+
+	box := char.Box
+	for _, v := range []float64{box.X0, box.Y0, box.X1, box.Y1} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			box = Rect{}
+			break
+		}
+	}
+
+	x, baseline := box.X0, box.Y1
+	if char.HasOrigin {
+		x, baseline = char.Origin.X, char.Origin.Y
+	}
+
+	// Vertical extent: the box's own, the origin's, or a neighbour's
+	top, bottom := box.Y0, box.Y1
+	if bottom-top < 0.01 {
+		top, bottom = baseline-size*syntheticAscent, baseline+size*syntheticDescent
+		if !char.HasOrigin {
+			if neighbour, ok := lineNeighbour(chars, i, baseline, size); ok {
+				top, bottom = neighbour.Y0, neighbour.Y1
+			}
+		}
+	}
+
+	// Horizontal extent: the box's own, or the advance to the next character
+	left, right := box.X0, box.X1
+	if right-left < 0.01 {
+		width := size * syntheticCharWidth
+		if char.HasOrigin && i+1 < len(chars) {
+			next := chars[i+1]
+			advance := next.Origin.X - char.Origin.X
+			if next.HasOrigin && math.Abs(next.Origin.Y-char.Origin.Y) < size/2 && advance > 0 && advance < size*2 {
+				width = advance
+			}
+		}
+		left, right = x, x+width
+	}
+
+	return Rect{X0: left, Y0: top, X1: right, Y1: bottom}
+}
+
+// lineNeighbour returns the box of the nearest character within a few of i
+// with a usable box on the same line as baseline.
+func lineNeighbour(chars []EnrichedChar, i int, baseline, size float64) (Rect, bool) {
+	const reach = 8 // characters either side
+	for d := 1; d <= reach; d++ {
+		for _, j := range []int{i - d, i + d} {
+			if j < 0 || j >= len(chars) || isDegenerateBox(chars[j].Box) {
+				continue
+			}
+			if box := chars[j].Box; baseline >= box.Y0-size/2 && baseline <= box.Y1+size/2 {
+				return box, true
+			}
+		}
+	}
+	return Rect{}, false
+}
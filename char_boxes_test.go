@@ -0,0 +1,73 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestRepairCharBoxes(t *testing.T) {
+	// "Hi" set in a Type3 font with empty boxes, then "there" with real ones
+	var chars []EnrichedChar
+	for i, r := range "Hi" {
+		x := 100 + float64(i)*7
+		chars = append(chars, EnrichedChar{
+			Text:      r,
+			Box:       Rect{X0: x, Y0: 200, X1: x, Y1: 200},
+			FontSize:  10,
+			Origin:    Point{X: x, Y: 200},
+			HasOrigin: true,
+		})
+	}
+	chars = append(chars, EnrichedChar{Text: ' ', Box: Rect{X0: 114, Y0: 200, X1: 114, Y1: 200}, FontSize: 10})
+	for i, r := range "there" {
+		x := 118 + float64(i)*6
+		chars = append(chars, EnrichedChar{Text: r, Box: Rect{X0: x, Y0: 192, X1: x + 5, Y1: 202}, FontSize: 10})
+	}
+	original := chars[0].Box
+
+	repaired, n := repairCharBoxes(chars)
+	if n != 2 {
+		t.Fatalf("repaired %d boxes, want 2", n)
+	}
+	if chars[0].Box != original {
+		t.Error("repairCharBoxes changed its input")
+	}
+
+	// The width is the advance to the next origin, the height the font's
+	if got, want := repaired[0].Box, (Rect{X0: 100, Y0: 192, X1: 107, Y1: 202}); got != want {
+		t.Errorf("H box = %+v, want %+v", got, want)
+	}
+	// The last glyph has no next origin and falls back to half the font size
+	if got, want := repaired[1].Box, (Rect{X0: 107, Y0: 192, X1: 112, Y1: 202}); got != want {
+		t.Errorf("i box = %+v, want %+v", got, want)
+	}
+	// Spaces and usable boxes are left alone
+	if repaired[2].Box != chars[2].Box || repaired[3].Box != chars[3].Box {
+		t.Error("repairCharBoxes changed a space or a usable box")
+	}
+
+	if _, n := repairCharBoxes(repaired); n != 0 {
+		t.Errorf("repaired %d boxes a second time, want 0", n)
+	}
+
+	page := structurePage(&rawPage{width: 600, height: 800, chars: chars}, 1, DefaultConfig())
+	if page.Diagnostics.RebuiltCharBoxes != 2 {
+		t.Errorf("Diagnostics.RebuiltCharBoxes = %d, want 2", page.Diagnostics.RebuiltCharBoxes)
+	}
+	if len(page.Paragraphs) != 1 || page.Paragraphs[0].Text() != "Hi there" {
+		var texts []string
+		for _, para := range page.Paragraphs {
+			texts = append(texts, para.Text())
+		}
+		t.Errorf("paragraphs = %q, want [\"Hi there\"]", texts)
+	}
+}
+
+func TestRepairCharBoxes_Neighbour(t *testing.T) {
+	// Without an origin the height is taken from a character on the same line
+	chars := []EnrichedChar{
+		{Text: 'a', Box: Rect{X0: 100, Y0: 190, X1: 105, Y1: 201}, FontSize: 10},
+		{Text: 'b', Box: Rect{X0: 105, Y0: 201, X1: 105, Y1: 201}, FontSize: 10},
+	}
+	repaired, _ := repairCharBoxes(chars)
+	if got, want := repaired[1].Box, (Rect{X0: 105, Y0: 190, X1: 110, Y1: 201}); got != want {
+		t.Errorf("box = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,221 @@
+package pdfmarkdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint records the progress of a resumable conversion.
+type Checkpoint struct {
+	// NextPage is the 0-indexed page to resume extraction from
+	NextPage int
+
+	// PageCount is the total number of pages in the document, used to detect
+	// checkpoints that no longer match the file
+	PageCount int
+
+	// Document holds every page extracted so far
+	Document Document
+}
+
+// CheckpointStore persists conversion checkpoints between attempts.
+// Keys are derived from the PDF content, so a checkpoint is never applied to a
+// different document that happens to share a path.
+type CheckpointStore interface {
+	// Load returns the checkpoint for key, or nil if none exists.
+	Load(key string) (*Checkpoint, error)
+
+	// Save persists the checkpoint for key, replacing any previous one.
+	Save(key string, checkpoint *Checkpoint) error
+
+	// Delete removes the checkpoint for key once conversion completes.
+	Delete(key string) error
+}
+
+// FileCheckpointStore stores checkpoints as JSON files in a directory.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// NewFileCheckpointStore creates a checkpoint store rooted at dir, creating it if needed.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "failed to create checkpoint directory")
+	}
+	return &FileCheckpointStore{Dir: dir}, nil
+}
+
+// Load reads the checkpoint for key, returning nil if none exists.
+func (s *FileCheckpointStore) Load(key string) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read checkpoint")
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, errors.Wrap(err, "failed to decode checkpoint")
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint atomically so a crash mid-write never leaves a
+// truncated file behind.
+func (s *FileCheckpointStore) Save(key string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode checkpoint")
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, key+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkpoint file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), s.path(key)), "failed to commit checkpoint")
+}
+
+// Delete removes the checkpoint for key. Deleting a missing checkpoint is not an error.
+func (s *FileCheckpointStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete checkpoint")
+	}
+	return nil
+}
+
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// checkpointKey derives a stable key from the PDF file content.
+func checkpointKey(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open PDF file")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "failed to hash PDF file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ConvertFileResumable converts a PDF file to markdown, persisting progress to
+// store every Config.CheckpointInterval pages. If an earlier attempt on the same
+// document failed, extraction resumes after the last checkpointed page rather
+// than starting over. The checkpoint is deleted once conversion succeeds.
+func (c *Converter) ConvertFileResumable(filePath string, store CheckpointStore) (string, error) {
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
+	key, err := checkpointKey(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
+		Document: doc.Document,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get page count")
+	}
+
+	document := &Document{
+		Pages: make([]Page, 0, pageCount.PageCount),
+	}
+
+	// Resume from a previous attempt if its checkpoint still matches the document
+	startPage := 0
+	checkpoint, err := store.Load(key)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load checkpoint")
+	}
+	if checkpoint != nil && checkpoint.PageCount == pageCount.PageCount &&
+		len(checkpoint.Document.Pages) <= checkpoint.NextPage && checkpoint.NextPage <= pageCount.PageCount {
+		document.Pages = append(document.Pages, checkpoint.Document.Pages...)
+		document.Duplicates = append(document.Duplicates, checkpoint.Document.Duplicates...)
+		startPage = checkpoint.NextPage
+	}
+
+	interval := c.config.CheckpointInterval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	// Re-register resumed pages so duplicates of them are still detected. A
+	// shared registry may already hold them from the failed attempt, which
+	// Register doesn't count as duplicates.
+	registry := c.pageRegistry()
+	for _, page := range document.Pages {
+		if page.ContentHash == "" {
+			continue
+		}
+		registry.Register(page.ContentHash, PageRef{Source: filePath, PageNumber: page.Number})
+	}
+
+	err = c.extractPages(doc.Document, startPage, pageCount.PageCount-1, func(page *Page, _ time.Duration) error {
+		if !c.recordDuplicate(registry, document, filePath, page) {
+			document.Pages = append(document.Pages, *page)
+		}
+
+		// Skip the final page: a completed conversion deletes the checkpoint anyway
+		completed := page.Number
+		if completed%interval == 0 && completed < pageCount.PageCount {
+			err := store.Save(key, &Checkpoint{
+				NextPage:  completed,
+				PageCount: pageCount.PageCount,
+				Document:  *document,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to save checkpoint after page %d", completed)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	markdown := document.ToMarkdown(c.config)
+
+	if err := store.Delete(key); err != nil {
+		return "", errors.Wrap(err, "failed to delete checkpoint")
+	}
+
+	return markdown, nil
+}
@@ -0,0 +1,107 @@
+package pdfmarkdown_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// failingStore wraps a checkpoint store and fails once a number of saves succeed,
+// simulating a conversion interrupted partway through the document.
+type failingStore struct {
+	pdfmarkdown.CheckpointStore
+	savesBeforeFailure int
+	saves              int
+}
+
+func (s *failingStore) Save(key string, checkpoint *pdfmarkdown.Checkpoint) error {
+	if s.saves >= s.savesBeforeFailure {
+		return errors.New("simulated crash")
+	}
+	s.saves++
+	return s.CheckpointStore.Save(key, checkpoint)
+}
+
+// recordingStore records the checkpoint returned by Load.
+type recordingStore struct {
+	pdfmarkdown.CheckpointStore
+	loaded *pdfmarkdown.Checkpoint
+}
+
+func (s *recordingStore) Load(key string) (*pdfmarkdown.Checkpoint, error) {
+	checkpoint, err := s.CheckpointStore.Load(key)
+	s.loaded = checkpoint
+	return checkpoint, err
+}
+
+func TestConverter_ConvertFileResumable(t *testing.T) {
+	instance := setupPDFium(t)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.CheckpointInterval = 1
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	info, err := converter.GetDocumentInfo(pdfPath)
+	require.NoError(t, err)
+	if info.PageCount < 3 {
+		t.Skip("Need a document with at least 3 pages")
+	}
+
+	expected, err := converter.ConvertFile(pdfPath)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	store, err := pdfmarkdown.NewFileCheckpointStore(dir)
+	require.NoError(t, err)
+
+	// First attempt fails after checkpointing two pages
+	_, err = converter.ConvertFileResumable(pdfPath, &failingStore{CheckpointStore: store, savesBeforeFailure: 2})
+	require.Error(t, err)
+
+	// Second attempt resumes from the checkpoint and completes
+	recorder := &recordingStore{CheckpointStore: store}
+	markdown, err := converter.ConvertFileResumable(pdfPath, recorder)
+	require.NoError(t, err)
+
+	require.NotNil(t, recorder.loaded, "expected resume from checkpoint")
+	assert.Equal(t, 2, recorder.loaded.NextPage)
+	assert.Equal(t, expected, markdown)
+
+	// Checkpoint is removed after a successful conversion
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestConverter_ConvertFileResumable_SharedRegistry(t *testing.T) {
+	instance := setupPDFium(t)
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	expected, err := pdfmarkdown.NewConverter(instance).ConvertFile(pdfPath)
+	require.NoError(t, err)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.CheckpointInterval = 1
+	config.PageRegistry = pdfmarkdown.NewPageRegistry()
+	config.SkipDuplicatePages = true
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	store, err := pdfmarkdown.NewFileCheckpointStore(t.TempDir())
+	require.NoError(t, err)
+
+	// The failed attempt leaves its pages in the shared registry
+	_, err = converter.ConvertFileResumable(pdfPath, &failingStore{CheckpointStore: store, savesBeforeFailure: 2})
+	require.Error(t, err)
+
+	markdown, err := converter.ConvertFileResumable(pdfPath, store)
+	require.NoError(t, err)
+	assert.Equal(t, expected, markdown)
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/klippa-app/go-pdfium"
 	"github.com/klippa-app/go-pdfium/webassembly"
 	"github.com/urfave/cli/v3"
 
@@ -19,10 +22,9 @@ func main() {
 		Usage: "Convert PDF files to markdown",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "input",
-				Aliases:  []string{"i"},
-				Usage:    "Input PDF file path",
-				Required: true,
+				Name:    "input",
+				Aliases: []string{"i"},
+				Usage:   "Input PDF file path",
 			},
 			&cli.StringFlag{
 				Name:    "output",
@@ -45,8 +47,42 @@ func main() {
 				Usage:   "Enable processing time and statistics logging",
 				Value:   false,
 			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Output format: markdown, html, text, or json for the document structure with positions and fonts",
+				Value:   "markdown",
+			},
+			&cli.StringFlag{
+				Name:  "styles",
+				Usage: "Write a JSON catalog of the document's text styles and their assigned roles to this path",
+			},
+			&cli.IntFlag{
+				Name:  "split-level",
+				Usage: "Write one markdown file per heading of this level or above to --out-dir, such as 1 for one per chapter",
+			},
+			&cli.StringFlag{
+				Name:  "out-dir",
+				Usage: "Directory for the files written with --split-level",
+			},
+			&cli.BoolFlag{
+				Name:  "layout",
+				Usage: "With --format text, keep each page's printed layout by padding words with spaces",
+			},
+			&cli.IntFlag{
+				Name:  "wrap",
+				Usage: "With --format text, wrap paragraphs at this many characters",
+			},
+			&cli.BoolFlag{
+				Name:  "front-matter",
+				Usage: "Start the markdown with the PDF's title, author and other metadata as YAML front matter",
+			},
 		},
 		Action: convertPDF,
+		Commands: []*cli.Command{
+			watchCommand(),
+			verifyCommand(),
+		},
 	}
 
 	if err := cmd.Run(context.Background(), os.Args); err != nil {
@@ -60,26 +96,27 @@ func convertPDF(_ context.Context, cmd *cli.Command) error {
 	startPage := cmd.Int("start-page")
 	endPage := cmd.Int("end-page")
 	enableMetrics := cmd.Bool("metrics")
-
-	// Initialise pdfium
-	pool, err := webassembly.Init(webassembly.Config{
-		MinIdle:  1,
-		MaxIdle:  1,
-		MaxTotal: 1,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to initialise pdfium: %w", err)
+	stylesPath := cmd.String("styles")
+	format := cmd.String("format")
+	if inputPath == "" {
+		return fmt.Errorf("--input is required")
+	}
+	if format != "markdown" && format != "html" && format != "text" && format != "json" {
+		return fmt.Errorf("unknown --format %q: use markdown, html, text or json", format)
 	}
-	defer pool.Close()
 
-	instance, err := pool.GetInstance(time.Second * 30)
+	instance, closeInstance, err := openInstance()
 	if err != nil {
-		return fmt.Errorf("failed to get pdfium instance: %w", err)
+		return err
 	}
+	defer closeInstance()
 
 	// Create converter with metrics enabled if requested
 	config := pdfmarkdown.DefaultConfig()
 	config.EnableMetricsLogging = enableMetrics
+	config.IncludeFrontMatter = cmd.Bool("front-matter")
+	config.TextLayout = cmd.Bool("layout")
+	config.TextWrapWidth = cmd.Int("wrap")
 	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
 
 	// Get document info
@@ -90,9 +127,36 @@ func convertPDF(_ context.Context, cmd *cli.Command) error {
 
 	fmt.Fprintf(os.Stderr, "Processing PDF with %d pages...\n", info.PageCount)
 
+	if splitLevel := cmd.Int("split-level"); splitLevel > 0 {
+		outDir := cmd.String("out-dir")
+		if outDir == "" {
+			return fmt.Errorf("--split-level requires --out-dir")
+		}
+		if format != "markdown" || stylesPath != "" || startPage >= 0 || endPage >= 0 || outputPath != "" {
+			return fmt.Errorf("--split-level cannot be combined with --format, --styles, --start-page, --end-page or --output")
+		}
+		fmt.Fprintf(os.Stderr, "Converting all pages...\n")
+		return writeSplit(converter, config, inputPath, outDir, splitLevel)
+	}
+
+	if format != "markdown" {
+		if stylesPath != "" || startPage >= 0 || endPage >= 0 {
+			return fmt.Errorf("--format %s cannot be combined with --styles, --start-page or --end-page", format)
+		}
+		fmt.Fprintf(os.Stderr, "Converting all pages...\n")
+		return writeStructure(converter, config, inputPath, outputPath, format)
+	}
+
 	// Convert PDF
 	var markdown string
-	if startPage >= 0 || endPage >= 0 {
+	var metrics pdfmarkdown.ProcessingMetrics
+	if stylesPath != "" && (startPage >= 0 || endPage >= 0) {
+		return fmt.Errorf("--styles cannot be combined with --start-page or --end-page")
+	}
+	if stylesPath != "" {
+		fmt.Fprintf(os.Stderr, "Converting all pages...\n")
+		markdown, metrics, err = converter.ConvertFileWithMetrics(inputPath)
+	} else if startPage >= 0 || endPage >= 0 {
 		if startPage < 0 {
 			startPage = 0
 		}
@@ -103,13 +167,19 @@ func convertPDF(_ context.Context, cmd *cli.Command) error {
 		markdown, err = converter.ConvertPageRange(inputPath, startPage, endPage)
 	} else {
 		fmt.Fprintf(os.Stderr, "Converting all pages...\n")
-		markdown, err = converter.ConvertFile(inputPath)
+		return streamMarkdown(converter, inputPath, outputPath)
 	}
 
 	if err != nil {
 		return fmt.Errorf("failed to convert PDF: %w", err)
 	}
 
+	if stylesPath != "" {
+		if err := writeStyleCatalog(stylesPath, metrics.Statistics.Styles); err != nil {
+			return err
+		}
+	}
+
 	// Write output
 	if outputPath != "" {
 		err = os.WriteFile(outputPath, []byte(markdown), 0644)
@@ -123,3 +193,135 @@ func convertPDF(_ context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+// openInstance initialises pdfium and returns an instance along with a
+// function that shuts it down.
+func openInstance() (pdfium.Pdfium, func(), error) {
+	pool, err := webassembly.Init(webassembly.Config{
+		MinIdle:  1,
+		MaxIdle:  1,
+		MaxTotal: 1,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialise pdfium: %w", err)
+	}
+
+	instance, err := pool.GetInstance(time.Second * 30)
+	if err != nil {
+		pool.Close()
+		return nil, nil, fmt.Errorf("failed to get pdfium instance: %w", err)
+	}
+	return instance, func() { pool.Close() }, nil
+}
+
+// streamMarkdown converts the whole document, streaming markdown to the output
+// file or stdout as it is rendered.
+func streamMarkdown(converter *pdfmarkdown.Converter, inputPath, outputPath string) error {
+	if outputPath == "" {
+		if err := converter.ConvertFileTo(os.Stdout, inputPath); err != nil {
+			return fmt.Errorf("failed to convert PDF: %w", err)
+		}
+		return nil
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := converter.ConvertFileTo(file, inputPath); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to convert PDF: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Markdown written to %s\n", outputPath)
+	return nil
+}
+
+// writeStructure converts the whole document and writes it to the output
+// file or stdout as HTML or plain text, or its structure as JSON.
+func writeStructure(converter *pdfmarkdown.Converter, config pdfmarkdown.Config, inputPath, outputPath, format string) error {
+	doc, err := converter.ConvertFileToStructured(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to convert PDF: %w", err)
+	}
+
+	write, label := doc.WriteJSON, "JSON"
+	switch format {
+	case "html":
+		write, label = doc.WriteHTML, "HTML"
+	case "text":
+		write, label = doc.WritePlainText, "Text"
+	}
+
+	if outputPath == "" {
+		if err := write(os.Stdout, config); err != nil {
+			return fmt.Errorf("failed to write %s: %w", label, err)
+		}
+		return nil
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := write(file, config); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", label, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "%s written to %s\n", label, outputPath)
+	return nil
+}
+
+// writeSplit converts the whole document and writes one markdown file per
+// part of it split at headings of level or above, named by their position
+// and heading, such as "02-installation.md".
+func writeSplit(converter *pdfmarkdown.Converter, config pdfmarkdown.Config, inputPath, outDir string, level int) error {
+	doc, err := converter.ConvertFileToStructured(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to convert PDF: %w", err)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	parts := doc.SplitByHeading(level, config)
+	for i, part := range parts {
+		slug := part.Slug
+		if slug == "" {
+			slug = "part"
+		}
+		path := filepath.Join(outDir, fmt.Sprintf("%02d-%s.md", i+1, slug))
+
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		if err := part.Document.WriteMarkdown(file, config); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write markdown: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d markdown files written to %s\n", len(parts), outDir)
+	return nil
+}
+
+// writeStyleCatalog writes the style catalog to path as indented JSON.
+func writeStyleCatalog(path string, styles []pdfmarkdown.StyleEntry) error {
+	data, err := json.MarshalIndent(styles, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode style catalog: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write style catalog: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Style catalog written to %s\n", path)
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+func verifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Check that converted markdown retains the text of the PDF it came from, failing when it doesn't",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "input",
+				Aliases:  []string{"i"},
+				Usage:    "Input PDF file path",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "markdown",
+				Aliases:  []string{"m"},
+				Usage:    "Markdown converted from the PDF",
+				Required: true,
+			},
+			&cli.FloatFlag{
+				Name:  "min-retention",
+				Usage: "Share of the PDF's letters and digits the markdown must retain, from 0 to 1",
+				Value: pdfmarkdown.DefaultMinRetention,
+			},
+		},
+		Action: verifyMarkdown,
+	}
+}
+
+func verifyMarkdown(_ context.Context, cmd *cli.Command) error {
+	inputPath := cmd.String("input")
+	minRetention := cmd.Float("min-retention")
+	if minRetention <= 0 || minRetention > 1 {
+		return fmt.Errorf("--min-retention must be between 0 and 1")
+	}
+
+	markdown, err := os.ReadFile(cmd.String("markdown"))
+	if err != nil {
+		return fmt.Errorf("failed to read markdown: %w", err)
+	}
+
+	instance, closeInstance, err := openInstance()
+	if err != nil {
+		return err
+	}
+	defer closeInstance()
+
+	converter := pdfmarkdown.NewConverter(instance)
+	report, err := converter.Verify(inputPath, string(markdown), minRetention)
+	if err != nil {
+		return fmt.Errorf("failed to verify markdown: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Retained %d of %d characters (%.1f%%)\n", report.Retained, report.Characters, report.Retention*100)
+	for _, page := range report.Pages {
+		if page.Retention < minRetention {
+			fmt.Fprintf(os.Stderr, "  Page %d: %.1f%% of %d characters\n", page.Page, page.Retention*100, page.Characters)
+		}
+	}
+	for _, region := range report.MissingRegions {
+		fmt.Fprintf(os.Stderr, "  Page %d is missing %d characters: %s\n", region.Page, region.Characters, region.Text)
+	}
+
+	if !report.Passed {
+		return fmt.Errorf("verification failed: %s", inputPath)
+	}
+	fmt.Fprintf(os.Stderr, "Verification passed\n")
+	return nil
+}
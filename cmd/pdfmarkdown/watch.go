@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// watchStateFile is the name of the file in the output directory recording
+// what each PDF looked like when it was last converted.
+const watchStateFile = ".pdfmarkdown-watch.json"
+
+// watchedFile is the recorded state of one converted PDF.
+type watchedFile struct {
+	ModTime    time.Time      `json:"mod_time"`
+	Size       int64          `json:"size"`
+	Hash       string         `json:"hash"`        // SHA-256 of the file content
+	PageHashes map[int]string `json:"page_hashes"` // Page content hashes from the last conversion
+}
+
+// changeSummary is written next to each output as <name>.changes.json.
+type changeSummary struct {
+	Source    string    `json:"source"`
+	Converted time.Time `json:"converted"`
+	Added     []int     `json:"added,omitempty"`
+	Removed   []int     `json:"removed,omitempty"`
+	Changed   []int     `json:"changed,omitempty"`
+}
+
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "watch",
+		Usage: "Convert PDFs dropped into a directory, reconverting them when they change",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "dir",
+				Aliases:  []string{"d"},
+				Usage:    "Directory to watch for PDF files",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "out-dir",
+				Usage:    "Directory to write markdown and change summaries to",
+				Required: true,
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "How often to scan the directory for new or changed PDFs",
+				Value: 2 * time.Second,
+			},
+			&cli.BoolFlag{
+				Name:  "once",
+				Usage: "Scan the directory once and exit",
+			},
+		},
+		Action: watchPDFs,
+	}
+}
+
+func watchPDFs(ctx context.Context, cmd *cli.Command) error {
+	dir := cmd.String("dir")
+	outDir := cmd.String("out-dir")
+	interval := cmd.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	state, err := loadWatchState(outDir)
+	if err != nil {
+		return err
+	}
+
+	instance, closeInstance, err := openInstance()
+	if err != nil {
+		return err
+	}
+	defer closeInstance()
+
+	config := pdfmarkdown.DefaultConfig()
+	// Kept for as long as the watch runs, so a PDF that changes only
+	// re-extracts the pages that changed
+	config.PageCache = pdfmarkdown.NewMemoryPageCache()
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	if !cmd.Bool("once") {
+		fmt.Fprintf(os.Stderr, "Watching %s every %v...\n", dir, interval)
+	}
+	for {
+		if err := scanDirectory(converter, config, dir, outDir, state); err != nil {
+			return err
+		}
+		if cmd.Bool("once") {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// scanDirectory converts every PDF in dir that is new or has changed since it
+// was recorded in state, reports PDFs that were removed, and saves the state.
+func scanDirectory(converter *pdfmarkdown.Converter, config pdfmarkdown.Config, dir, outDir string, state map[string]*watchedFile) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read watched directory: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	dirty := false
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".pdf") {
+			continue
+		}
+		name := entry.Name()
+		seen[name] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since the directory was read
+		}
+		previous := state[name]
+		if previous != nil && previous.ModTime.Equal(info.ModTime()) && previous.Size == info.Size() {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		hash, err := fileHash(path)
+		if err != nil {
+			// Keep watching; the file is read again on the next scan
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			continue
+		}
+		if previous != nil && previous.Hash == hash {
+			// Touched but unchanged
+			previous.ModTime, previous.Size = info.ModTime(), info.Size()
+			dirty = true
+			continue
+		}
+
+		current, err := convertWatched(converter, config, path, outDir, previous)
+		if err != nil {
+			// Keep watching; a half-written file is retried once it changes again
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			continue
+		}
+		current.ModTime, current.Size, current.Hash = info.ModTime(), info.Size(), hash
+		state[name] = current
+		dirty = true
+	}
+
+	for name := range state {
+		if !seen[name] {
+			fmt.Printf("%s: removed\n", name)
+			delete(state, name)
+			dirty = true
+		}
+	}
+
+	if !dirty {
+		return nil
+	}
+	return saveWatchState(outDir, state)
+}
+
+// convertWatched converts one PDF, writing its markdown and a summary of the
+// pages that changed since the previous conversion to outDir.
+func convertWatched(converter *pdfmarkdown.Converter, config pdfmarkdown.Config, path, outDir string, previous *watchedFile) (*watchedFile, error) {
+	doc, err := converter.ConvertFileToStructured(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PDF: %w", err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	err = writeFile(filepath.Join(outDir, base+".md"), func(w io.Writer) error {
+		return doc.WriteMarkdown(w, config)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pageHashes := doc.PageHashes()
+	var previousHashes map[int]string
+	if previous != nil {
+		previousHashes = previous.PageHashes
+	}
+	changes := pdfmarkdown.ComparePageHashes(previousHashes, pageHashes)
+
+	summary := changeSummary{
+		Source:    filepath.Base(path),
+		Converted: time.Now().UTC(),
+		Added:     changes.Added,
+		Removed:   changes.Removed,
+		Changed:   changes.Changed,
+	}
+	err = writeFile(filepath.Join(outDir, base+".changes.json"), func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("%s: %s\n", summary.Source, describeChanges(previous == nil, changes))
+	return &watchedFile{PageHashes: pageHashes}, nil
+}
+
+// describeChanges summarises page changes in one line.
+func describeChanges(isNew bool, changes pdfmarkdown.PageChanges) string {
+	if isNew {
+		return fmt.Sprintf("converted %d pages", len(changes.Added))
+	}
+	if changes.Empty() {
+		return "reconverted, no page content changed"
+	}
+
+	var parts []string
+	for _, group := range []struct {
+		label string
+		pages []int
+	}{
+		{"changed", changes.Changed},
+		{"added", changes.Added},
+		{"removed", changes.Removed},
+	} {
+		if len(group.pages) == 0 {
+			continue
+		}
+		numbers := make([]string, len(group.pages))
+		for i, page := range group.pages {
+			numbers[i] = fmt.Sprint(page)
+		}
+		parts = append(parts, fmt.Sprintf("pages %s %s", strings.Join(numbers, ", "), group.label))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// fileHash returns the SHA-256 of a file's content.
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open PDF file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash PDF file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeFile writes a file through a temporary file, so readers of the output
+// directory never see a partly written file.
+func writeFile(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// loadWatchState reads the state recorded in outDir, if any.
+func loadWatchState(outDir string) (map[string]*watchedFile, error) {
+	state := make(map[string]*watchedFile)
+	data, err := os.ReadFile(filepath.Join(outDir, watchStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode watch state: %w", err)
+	}
+	return state, nil
+}
+
+// saveWatchState records state in outDir.
+func saveWatchState(outDir string, state map[string]*watchedFile) error {
+	return writeFile(filepath.Join(outDir, watchStateFile), func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(state)
+	})
+}
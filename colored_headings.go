@@ -0,0 +1,117 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Colors closer than minHeadingContrast to the body color, such as near-black
+// text on a black body, are not a heading signal; at fullHeadingContrast and
+// beyond, such as a mid blue against black, contrast scores fully. Both are
+// Euclidean distances in RGB.
+const (
+	minHeadingContrast  = 40.0
+	fullHeadingContrast = 150.0
+)
+
+// coloredHeadingScore rates, from 0 to 1, how much a single-line paragraph
+// looks like a heading set in a color of its own: it must be in one color at
+// least as large as the body text, and scores on its contrast with the body
+// color and on being short, weighted equally. Sentences, page numbers, list
+// items and links score 0.
+func coloredHeadingScore(para Paragraph, bodyColor RGBA, bodyFontSize float64) float64 {
+	if len(para.Lines) != 1 || len(para.Lines[0].Words) == 0 {
+		return 0
+	}
+	line := para.Lines[0]
+	text := strings.TrimSpace(line.Text())
+	if text == "" || !strings.ContainsFunc(text, unicode.IsLetter) || pageNumberPattern.MatchString(text) {
+		return 0
+	}
+	if strings.HasSuffix(text, ".") || line.Words[0].IsBulletOrNumber() || line.Words[0].Link != "" {
+		return 0
+	}
+	if lineMaxFontSize(line) < bodyFontSize*0.95 {
+		return 0 // Captions and notes are often colored and smaller
+	}
+
+	color, ok := lineColor(line)
+	if !ok {
+		return 0
+	}
+	distance := colorDistance(color, bodyColor)
+	if distance < minHeadingContrast {
+		return 0
+	}
+	contrast := math.Min(1, distance/fullHeadingContrast)
+
+	return 0.5*contrast + 0.5*headingShortness(line)
+}
+
+// lineColor returns the fill color of every word in the line, or false when
+// the words differ.
+func lineColor(line Line) (RGBA, bool) {
+	color := line.Words[0].FillColor
+	for _, word := range line.Words[1:] {
+		if word.FillColor != color {
+			return RGBA{}, false
+		}
+	}
+	return color, true
+}
+
+// colorDistance is the Euclidean distance between two colors' RGB values.
+func colorDistance(a, b RGBA) float64 {
+	dr, dg, db := float64(a.R)-float64(b.R), float64(a.G)-float64(b.G), float64(a.B)-float64(b.B)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+// bodyColor returns the color of most of the paragraphs' text, by character.
+func bodyColor(paragraphs []Paragraph) RGBA {
+	counts := make(map[RGBA]int)
+	for _, para := range paragraphs {
+		for _, line := range para.Lines {
+			for _, word := range line.Words {
+				counts[word.FillColor] += utf8.RuneCountInString(word.Text)
+			}
+		}
+	}
+	return dominant(counts)
+}
+
+// detectColoredHeadings promotes short lines whose score reaches
+// Config.ColoredHeadings to headings of the given level, when at least
+// Config.ColoredHeadingMinLines candidate lines share their color.
+func detectColoredHeadings(paragraphs []Paragraph, bodyFontSize float64, level int, config Config) {
+	if config.ColoredHeadings <= 0 {
+		return
+	}
+
+	body := bodyColor(paragraphs)
+	var candidates []int
+	lines := make(map[RGBA]int)
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if para.IsHeading {
+			continue
+		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
+		if coloredHeadingScore(*para, body, bodyFontSize) >= config.ColoredHeadings {
+			candidates = append(candidates, i)
+			color, _ := lineColor(para.Lines[0])
+			lines[color]++
+		}
+	}
+
+	for _, i := range candidates {
+		color, _ := lineColor(paragraphs[i].Lines[0])
+		if lines[color] >= config.ColoredHeadingMinLines {
+			paragraphs[i].IsHeading = true
+			paragraphs[i].HeadingLevel = level
+		}
+	}
+}
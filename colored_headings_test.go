@@ -0,0 +1,53 @@
+package pdfmarkdown
+
+import "testing"
+
+// colored sets every word of a paragraph to color
+func colored(para Paragraph, color RGBA) Paragraph {
+	for i := range para.Lines[0].Words {
+		para.Lines[0].Words[i].FillColor = color
+	}
+	return para
+}
+
+func TestDetectColoredHeadings(t *testing.T) {
+	black := RGBA{A: 255}
+	brand := RGBA{R: 0, G: 82, B: 155, A: 255}
+	nearBlack := RGBA{R: 20, G: 20, B: 20, A: 255}
+	body := "the quick brown fox jumps over the lazy dog and then keeps on running far away"
+
+	tests := []struct {
+		name     string
+		headings []Paragraph
+		minLines int
+		want     bool
+	}{
+		{name: "brand colored title", headings: []Paragraph{colored(placedParagraph("Our Approach", 50, 20), brand)}, want: true},
+		{name: "near body color", headings: []Paragraph{colored(placedParagraph("Our Approach", 50, 20), nearBlack)}},
+		{name: "sentence", headings: []Paragraph{colored(placedParagraph("Read our approach.", 50, 20), brand)}},
+		{name: "long line", headings: []Paragraph{colored(placedParagraph("one two three four five six seven eight nine ten eleven", 50, 20), brand)}},
+		{name: "alone below the gate", headings: []Paragraph{colored(placedParagraph("Our Approach", 50, 20), brand)}, minLines: 2},
+		{name: "repeated color meets the gate", headings: []Paragraph{
+			colored(placedParagraph("Our Approach", 50, 20), brand),
+			colored(placedParagraph("Our Team", 50, 60), brand),
+		}, minLines: 2, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{colored(placedParagraph(body, 50, 0), black)}
+			paragraphs = append(paragraphs, tt.headings...)
+			paragraphs = append(paragraphs, colored(placedParagraph(body, 50, 100), black))
+
+			detectColoredHeadings(paragraphs, 10, 3, Config{ColoredHeadings: 0.75, ColoredHeadingMinLines: tt.minLines})
+			for i, para := range paragraphs[1 : len(paragraphs)-1] {
+				if para.IsHeading != tt.want || (tt.want && para.HeadingLevel != 3) {
+					t.Errorf("heading %d: IsHeading = %v (level %d), want %v", i, para.IsHeading, para.HeadingLevel, tt.want)
+				}
+			}
+			if paragraphs[0].IsHeading || paragraphs[len(paragraphs)-1].IsHeading {
+				t.Error("body text promoted to a heading")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package pdfmarkdown
+
+import "strings"
+
+// minSpanContrast is how far, as a Euclidean distance in RGB, text must be
+// from black and from white for Config.PreserveColors to keep its color.
+const minSpanContrast = 40.0
+
+// isSpanColor reports whether text in color should keep it in the output.
+// Near-black text is ordinary body text, and near-white text sits on a fill
+// that isn't kept, so both are left plain, as is text of unknown color.
+func isSpanColor(color RGBA) bool {
+	if color == (RGBA{}) {
+		return false
+	}
+	return colorDistance(color, RGBA{A: 255}) >= minSpanContrast &&
+		colorDistance(color, RGBA{R: 255, G: 255, B: 255, A: 255}) >= minSpanContrast
+}
+
+// colorSpan wraps rendered text in a span setting its color.
+func colorSpan(color RGBA, text string) string {
+	return `<span style="color:` + colorJSON(color) + `">` + text + "</span>"
+}
+
+// colorRuns renders words with render, wrapping each run of consecutive words
+// in one color that isSpanColor keeps in a span of that color. Words of only
+// punctuation join the run before them, as with inline formatting.
+func colorRuns(words []EnrichedWord, render func([]EnrichedWord) string) string {
+	var b strings.Builder
+	for i := 0; i < len(words); {
+		color := words[i].FillColor
+		end := i + 1
+		for end < len(words) && (words[end].FillColor == color || isPunctuationOnly(words[end].Text)) {
+			end++
+		}
+		if i > 0 {
+			b.WriteString(wordSeparator(words[i-1].Text, words[i].Text))
+		}
+		if text := render(words[i:end]); isSpanColor(color) {
+			b.WriteString(colorSpan(color, text))
+		} else {
+			b.WriteString(text)
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// hasSpanColor reports whether any of the words are in a color isSpanColor
+// keeps.
+func hasSpanColor(words []EnrichedWord) bool {
+	for _, word := range words {
+		if isSpanColor(word.FillColor) {
+			return true
+		}
+	}
+	return false
+}
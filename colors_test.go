@@ -0,0 +1,74 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsSpanColor(t *testing.T) {
+	tests := []struct {
+		color RGBA
+		want  bool
+	}{
+		{RGBA{}, false}, // Unknown
+		{RGBA{A: 255}, false},
+		{RGBA{R: 20, G: 20, B: 20, A: 255}, false},
+		{RGBA{R: 250, G: 250, B: 250, A: 255}, false},
+		{RGBA{R: 200, A: 255}, true},
+		{RGBA{R: 128, G: 128, B: 128, A: 255}, true},
+	}
+	for _, tt := range tests {
+		if got := isSpanColor(tt.color); got != tt.want {
+			t.Errorf("isSpanColor(%+v) = %v, want %v", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_PreserveColors(t *testing.T) {
+	black := RGBA{A: 255}
+	red := RGBA{R: 204, A: 255}
+	para := placedParagraph("Net loss of (1,200) this year.", 72, 100)
+	for i := range para.Lines[0].Words {
+		para.Lines[0].Words[i].FillColor = black
+	}
+	para.Lines[0].Words[2].FillColor = red
+	para.Lines[0].Words[2].IsBold = true
+
+	table := placedTable(
+		ruledRow(200, "Item", "2023", "2024"),
+		ruledRow(220, "Profit", "(300)", "450"),
+	)
+	table.Rows[1].Cells[1].Words[0].FillColor = red
+
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: []Paragraph{para}, Tables: []Table{table}}}}
+	config := DefaultConfig()
+	if got := doc.ToMarkdown(config); strings.Contains(got, "<span") {
+		t.Errorf("ToMarkdown() without PreserveColors =\n%s", got)
+	}
+
+	config.PreserveColors = true
+	markdown := doc.ToMarkdown(config)
+	for _, want := range []string{
+		`Net loss <span style="color:#cc0000">**of**</span> (1,200) this year.`,
+		`| Profit | <span style="color:#cc0000">(300)</span> |`,
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("ToMarkdown() missing %q:\n%s", want, markdown)
+		}
+	}
+
+	html := doc.ToHTML(config)
+	for _, want := range []string{
+		`Net loss <span style="color:#cc0000"><strong>of</strong></span> (1,200) this year.`,
+		`<span style="color:#cc0000">(300)</span></td>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("ToHTML() missing %q:\n%s", want, html)
+		}
+	}
+
+	config.StripInlineFormatting = true
+	if got := doc.ToMarkdown(config); strings.Contains(got, "<span") {
+		t.Errorf("ToMarkdown() with StripInlineFormatting =\n%s", got)
+	}
+}
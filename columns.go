@@ -3,10 +3,18 @@ package pdfmarkdown
 import (
 	"math"
 	"sort"
+	"strings"
 )
 
 // detectColumns detects multi-column layout using vertical projection profile
 func detectColumns(words []EnrichedWord, pageWidth float64) []Column {
+	return detectColumnsWithSeparators(words, pageWidth, nil)
+}
+
+// detectColumnsWithSeparators detects columns using the projection profile plus
+// explicit column separator rules. A drawn rule is strong evidence of a column
+// gutter even when the whitespace valley alone is too narrow to qualify.
+func detectColumnsWithSeparators(words []EnrichedWord, pageWidth float64, separators []float64) []Column {
 	if len(words) == 0 {
 		return nil
 	}
@@ -30,6 +38,7 @@ func detectColumns(words []EnrichedWord, pageWidth float64) []Column {
 
 	// Find valleys (gaps between columns)
 	valleys := findSignificantValleys(bins, pageWidth)
+	valleys = mergeSeparatorValleys(valleys, separators, pageWidth)
 
 	if len(valleys) == 0 {
 		// Single column layout
@@ -150,6 +159,31 @@ func findSignificantValleys(bins []int, pageWidth float64) []float64 {
 	return filteredValleys
 }
 
+// mergeSeparatorValleys adds separator rule positions to the detected valleys,
+// skipping rules that coincide with a valley already found.
+func mergeSeparatorValleys(valleys, separators []float64, pageWidth float64) []float64 {
+	const sameGutterDistance = 20.0 // Matches the minimum valley width
+
+	for _, sep := range separators {
+		if sep <= 0 || sep >= pageWidth {
+			continue
+		}
+		duplicate := false
+		for _, valley := range valleys {
+			if math.Abs(valley-sep) < sameGutterDistance {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			valleys = append(valleys, sep)
+		}
+	}
+
+	sort.Float64s(valleys)
+	return valleys
+}
+
 // filterWordsByXRange returns words whose horizontal center is within the X range
 func filterWordsByXRange(words []EnrichedWord, xStart, xEnd float64) []EnrichedWord {
 	var filtered []EnrichedWord
@@ -203,16 +237,18 @@ func determineReadingOrder(paragraphs []Paragraph, columns []Column) []Paragraph
 		return sortedCols[i].Box.X0 < sortedCols[j].Box.X0
 	})
 
+	// Assign each paragraph to the column containing its center. Paragraphs
+	// outside every column (text past the page edge) go to the nearest one so
+	// they are never dropped.
+	byColumn := make([][]Paragraph, len(sortedCols))
+	for _, para := range paragraphs {
+		ci := nearestColumn(sortedCols, para.Box.CenterX())
+		byColumn[ci] = append(byColumn[ci], para)
+	}
+
 	// Process each column
-	for _, col := range sortedCols {
-		// Find paragraphs in this column
-		var colParas []Paragraph
-		for _, para := range paragraphs {
-			paraCenter := para.Box.CenterX()
-			if paraCenter >= col.Box.X0 && paraCenter < col.Box.X1 {
-				colParas = append(colParas, para)
-			}
-		}
+	for ci := range sortedCols {
+		colParas := byColumn[ci]
 
 		// Sort paragraphs within column by Y position (top to bottom)
 		sort.Slice(colParas, func(i, j int) bool {
@@ -225,7 +261,151 @@ func determineReadingOrder(paragraphs []Paragraph, columns []Column) []Paragraph
 	return ordered
 }
 
+// joinColumnContinuations merges a paragraph ending at the bottom of one
+// column with the one starting the next column when the text runs on: the
+// first ends without closing punctuation and the second starts higher up the
+// page in the same font, without a list marker. paragraphs must be in
+// reading order.
+func joinColumnContinuations(paragraphs []Paragraph, columns []Column) []Paragraph {
+	if len(columns) <= 1 || len(paragraphs) < 2 {
+		return paragraphs
+	}
+	sortedCols := make([]Column, len(columns))
+	copy(sortedCols, columns)
+	sort.Slice(sortedCols, func(i, j int) bool {
+		return sortedCols[i].Box.X0 < sortedCols[j].Box.X0
+	})
+
+	result := paragraphs[:1]
+	for _, para := range paragraphs[1:] {
+		prev := &result[len(result)-1]
+		if !continuesInNextColumn(*prev, para, sortedCols) {
+			result = append(result, para)
+			continue
+		}
+		prev.Lines = append(prev.Lines, para.Lines...)
+		prev.Box = mergeRects(prev.Box, para.Box)
+	}
+	return result
+}
+
+// continuesInNextColumn reports whether next, the paragraph after para in
+// reading order, carries on para's text at the top of the next column.
+func continuesInNextColumn(para, next Paragraph, columns []Column) bool {
+	if len(para.Lines) == 0 || len(next.Lines) == 0 {
+		return false
+	}
+	if nearestColumn(columns, next.Box.CenterX())-nearestColumn(columns, para.Box.CenterX()) != 1 {
+		return false
+	}
+	if next.Box.Y0 >= para.Box.Y0 {
+		return false // Not back at the top of the page
+	}
+
+	return textRunsOn(para.Lines[len(para.Lines)-1], next.Lines[0])
+}
+
+// textRunsOn reports whether first carries on the text of last, the line
+// before it in reading order: last ends without closing punctuation and first
+// is in the same font, without a list marker.
+func textRunsOn(last, first Line) bool {
+	if lineEndsSentence(last) || strings.HasSuffix(last.Text(), ":") || len(first.Words) == 0 || first.Words[0].IsBulletOrNumber() {
+		return false
+	}
+	lastFont, firstFont := summarizeFont([]Line{last}), summarizeFont([]Line{first})
+	return lastFont.Name == firstFont.Name && lastFont.Weight == firstFont.Weight && math.Abs(lastFont.Size-firstFont.Size) < 0.5
+}
+
+// nearestColumn returns the index of the column containing x, or the closest
+// column when x falls outside all of them.
+func nearestColumn(columns []Column, x float64) int {
+	best := 0
+	bestDist := math.MaxFloat64
+	for i, col := range columns {
+		if x >= col.Box.X0 && x < col.Box.X1 {
+			return i
+		}
+		dist := math.Min(math.Abs(x-col.Box.X0), math.Abs(x-col.Box.X1))
+		if dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
 // CenterX returns the horizontal center of a paragraph's bounding box
 func (p Paragraph) CenterX() float64 {
 	return (p.Box.X0 + p.Box.X1) / 2
 }
+
+// minGutterEm is the gap, in multiples of the font size, that a column
+// boundary must fall in before a line is split there. Word spacing in a line
+// crossing the boundary, such as a page-wide title, is far narrower.
+const minGutterEm = 2.0
+
+// splitLinesAtColumns breaks lines that run across a column gutter, as lines
+// in neighbouring columns sharing a baseline are grouped together.
+func splitLinesAtColumns(lines []Line, columns []Column) []Line {
+	if len(columns) <= 1 {
+		return lines
+	}
+
+	var result []Line
+	for _, line := range lines {
+		start := 0
+		for i := 1; i < len(line.Words); i++ {
+			prev, word := line.Words[i-1], line.Words[i]
+			gap := word.Box.X0 - prev.Box.X1
+			if gap < getLineFontSize(line)*minGutterEm || !gutterBetween(columns, prev.Box.X1, word.Box.X0) {
+				continue
+			}
+			result = append(result, lineFromWords(line.Words[start:i], line.Baseline))
+			start = i
+		}
+		if start == 0 {
+			result = append(result, line)
+			continue
+		}
+		result = append(result, lineFromWords(line.Words[start:], line.Baseline))
+	}
+	return result
+}
+
+// gutterBetween reports whether a column boundary lies between x0 and x1.
+func gutterBetween(columns []Column, x0, x1 float64) bool {
+	for _, col := range columns[1:] {
+		if col.Box.X0 >= x0 && col.Box.X0 <= x1 {
+			return true
+		}
+	}
+	return false
+}
+
+// lineFromWords builds a line around the given words.
+func lineFromWords(words []EnrichedWord, baseline float64) Line {
+	box := words[0].Box
+	for _, word := range words[1:] {
+		box = mergeRects(box, word.Box)
+	}
+	return Line{Words: words, Box: box, Baseline: baseline}
+}
+
+// groupLinesByColumn groups lines into paragraphs one column at a time, so a
+// paragraph never takes in lines from the column beside it.
+func groupLinesByColumn(lines []Line, columns []Column, pageWidth float64, figures []Rect) []Paragraph {
+	if len(columns) <= 1 {
+		return groupLinesIntoParagraphsAdaptive(lines, pageWidth, figures)
+	}
+
+	byColumn := make([][]Line, len(columns))
+	for _, line := range lines {
+		ci := nearestColumn(columns, (line.Box.X0+line.Box.X1)/2)
+		byColumn[ci] = append(byColumn[ci], line)
+	}
+
+	var paragraphs []Paragraph
+	for _, colLines := range byColumn {
+		paragraphs = append(paragraphs, groupLinesIntoParagraphsAdaptive(colLines, pageWidth, figures)...)
+	}
+	return paragraphs
+}
@@ -0,0 +1,115 @@
+package pdfmarkdown
+
+import (
+	"sync"
+	"time"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/pkg/errors"
+)
+
+// ErrConcurrentUse is returned when a Converter method is called while
+// another call on the same Converter is still running. A pdfium instance
+// handles one call at a time, and interleaving two documents corrupts its
+// state, so the second call fails instead. Use a ConverterPool to convert
+// from several goroutines.
+var ErrConcurrentUse = errors.New("converter is already in use by another goroutine; use a ConverterPool for concurrent conversions")
+
+// acquire marks the converter busy for the duration of a call, failing with
+// ErrConcurrentUse if it already is.
+func (c *Converter) acquire() error {
+	if !c.busy.CompareAndSwap(false, true) {
+		return ErrConcurrentUse
+	}
+	return nil
+}
+
+// release ends a call started with acquire.
+func (c *Converter) release() {
+	c.busy.Store(false)
+}
+
+// DefaultInstanceTimeout is how long ConverterPool waits for a free pdfium
+// instance when Timeout is not set.
+const DefaultInstanceTimeout = 30 * time.Second
+
+// ConverterPool converts PDFs concurrently. Each call takes its own instance
+// from a pdfium pool and returns it afterwards, so a ConverterPool is safe
+// for use by multiple goroutines, and as many documents convert at once as
+// the pool has workers:
+//
+//	pool, err := webassembly.Init(webassembly.Config{MinIdle: 1, MaxIdle: 4, MaxTotal: 4})
+//	...
+//	converters := pdfmarkdown.NewConverterPool(pool, pdfmarkdown.WithTables())
+//	markdown, err := converters.ConvertFile("report.pdf") // from any goroutine
+type ConverterPool struct {
+	// Timeout is how long a call waits for a free instance before failing.
+	// Zero means DefaultInstanceTimeout
+	Timeout time.Duration
+
+	pool   pdfium.Pool
+	config Config
+
+	probe    sync.Once
+	features FeatureSet
+}
+
+// NewConverterPool creates a concurrency-safe converter drawing instances from
+// pool, with the default configuration adjusted by opts.
+func NewConverterPool(pool pdfium.Pool, opts ...Option) *ConverterPool {
+	return &ConverterPool{
+		pool:   pool,
+		config: NewConfig(opts...),
+	}
+}
+
+// Do calls fn with a Converter backed by an instance taken from the pool for
+// the duration of the call. The Converter must not be kept after fn returns.
+func (p *ConverterPool) Do(fn func(c *Converter) error) error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultInstanceTimeout
+	}
+
+	instance, err := p.pool.GetInstance(timeout)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pdfium instance")
+	}
+	defer instance.Close()
+
+	// Instances from one pool share a pdfium build, so one probe covers all
+	p.probe.Do(func() {
+		p.features = probeFeatures(instance)
+	})
+
+	return fn(&Converter{
+		instance: instance,
+		config:   p.config,
+		features: p.features,
+	})
+}
+
+// ConvertFile converts a PDF file to markdown. See Converter.ConvertFile.
+func (p *ConverterPool) ConvertFile(filePath string) (markdown string, err error) {
+	err = p.Do(func(c *Converter) error {
+		markdown, err = c.ConvertFile(filePath)
+		return err
+	})
+	return markdown, err
+}
+
+// ConvertBytes converts PDF bytes to markdown. See Converter.ConvertBytes.
+func (p *ConverterPool) ConvertBytes(pdfBytes []byte) (markdown string, err error) {
+	err = p.Do(func(c *Converter) error {
+		markdown, err = c.ConvertBytes(pdfBytes)
+		return err
+	})
+	return markdown, err
+}
+
+// Features reports which optional pdfium text APIs the pool's instances
+// support, taking an instance to probe if no call has yet.
+func (p *ConverterPool) Features() (FeatureSet, error) {
+	err := p.Do(func(*Converter) error { return nil })
+	return p.features, err
+}
@@ -0,0 +1,64 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/klippa-app/go-pdfium/webassembly"
+)
+
+func TestConverter_RejectsConcurrentUse(t *testing.T) {
+	// No instance is needed: the guard fails before pdfium is touched
+	c := &Converter{config: DefaultConfig()}
+	if err := c.acquire(); err != nil {
+		t.Fatalf("first acquire failed: %v", err)
+	}
+
+	calls := map[string]func() error{
+		"ConvertFile":             func() error { _, err := c.ConvertFile("x.pdf"); return err },
+		"ConvertFileTo":           func() error { return c.ConvertFileTo(io.Discard, "x.pdf") },
+		"ConvertFileToStructured": func() error { _, err := c.ConvertFileToStructured("x.pdf"); return err },
+		"ConvertBytes":            func() error { _, err := c.ConvertBytes(nil); return err },
+		"ConvertReader":           func() error { _, err := c.ConvertReader(bytes.NewReader(nil)); return err },
+		"ConvertPageRange":        func() error { _, err := c.ConvertPageRange("x.pdf", 0, 1); return err },
+		"ConvertFileWithMetrics": func() error {
+			_, _, err := c.ConvertFileWithMetrics("x.pdf")
+			return err
+		},
+		"ConvertFileResumable": func() error { _, err := c.ConvertFileResumable("x.pdf", nil); return err },
+		"GetDocumentInfo":      func() error { _, err := c.GetDocumentInfo("x.pdf"); return err },
+		"RenderPageImage":      func() error { _, err := c.RenderPageImage("x.pdf", 0, 0); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrConcurrentUse) {
+			t.Errorf("%s while busy returned %v, want ErrConcurrentUse", name, err)
+		}
+	}
+
+	c.release()
+	if err := c.acquire(); err != nil {
+		t.Errorf("acquire after release failed: %v", err)
+	}
+}
+
+func TestConverter_ReleasesAfterCall(t *testing.T) {
+	pool, err := webassembly.Init(webassembly.Config{MinIdle: 1, MaxIdle: 1, MaxTotal: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	instance, err := pool.GetInstance(DefaultInstanceTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConverter(instance)
+	for i := 0; i < 2; i++ {
+		// Failed calls release the converter too
+		if _, err := c.ConvertFile("testdata/missing.pdf"); err == nil || errors.Is(err, ErrConcurrentUse) {
+			t.Fatalf("call %d: got %v, want an open error", i, err)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package pdfmarkdown
 
 import (
+	"fmt"
 	"io"
 	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/klippa-app/go-pdfium"
@@ -21,8 +26,9 @@ type ProcessingMetrics struct {
 
 // PageMetrics contains timing for a single page
 type PageMetrics struct {
-	PageNumber int
-	Duration   time.Duration
+	PageNumber      int
+	Duration        time.Duration
+	EstimatedTokens int // Estimated tokens in the page's markdown; see Config.TokenEstimator
 }
 
 // DocumentStatistics contains document-level statistics
@@ -33,6 +39,16 @@ type DocumentStatistics struct {
 	TotalHeadings   int
 	TotalWords      int
 	TotalCharacters int
+	EstimatedTokens int          // Estimated tokens in the markdown; see Config.TokenEstimator
+	DuplicatePages  int          // Pages whose content matched an earlier page
+	BlankPages      int          // Pages with no meaningful content (omitted with Config.SkipBlankPages)
+	Diagnostics     Diagnostics  // Text corrections summed over all pages
+	Styles          []StyleEntry // Distinct text styles, most used first (ConvertFileWithMetrics only)
+
+	// TableDetectors counts the tables kept per detector, and DuplicateTables
+	// the tables dropped for overlapping one that was kept; see TableProvenance
+	TableDetectors  map[string]int
+	DuplicateTables int
 }
 
 // Config controls markdown conversion behavior.
@@ -44,22 +60,399 @@ type Config struct {
 	// A value of 0 disables size-based heading detection (default: 1.15x body text)
 	MinHeadingFontSize float64
 
-	// DetectTables enables table detection and extraction (default: false)
+	// DetectTables enables table detection and extraction (default: true)
 	DetectTables bool
 
 	// TableSettings configures table detection behavior (default: DefaultTableSettings())
 	TableSettings TableSettings
 
+	// TableDetectors replaces the built-in table detectors. Results from all
+	// detectors are merged and deduplicated (default: nil, uses DefaultTableDetectors(config))
+	TableDetectors []TableDetector
+
 	// UseSegmentBasedTables enables PDF-TREX segment-based table detection
-	// This works better for tables without ruling lines (default: true)
+	// This works better for tables without ruling lines (default: false)
 	UseSegmentBasedTables bool
 
+	// UseHybridTables replaces the line-based and segment-based detectors with
+	// HybridTableDetector, which uses drawn rules where a table has them and text
+	// alignment elsewhere. Best for partially ruled tables (default: false)
+	UseHybridTables bool
+
+	// UprightRotatedPages structures pages whose text mostly runs at 90° or
+	// 270°, such as landscape tables printed on portrait pages, as if the page
+	// were turned upright, then maps the results back to the page's own
+	// coordinates. Without it such text is read in vertical strips (default: false)
+	UprightRotatedPages bool
+
 	// UseAdaptiveThresholds enables document-specific threshold calculation
 	// Based on spacing distribution analysis (default: true)
 	UseAdaptiveThresholds bool
 
 	// EnableMetricsLogging enables processing time and statistics logging (default: false)
 	EnableMetricsLogging bool
+
+	// DeduplicateCJK removes doubled CJK characters that some PDFs render twice
+	// at the same position, e.g. "微微软软" → "微软" (default: true)
+	DeduplicateCJK bool
+
+	// CJKDuplicateWidthRatio is the average character width, as a fraction of
+	// font size, below which repeated CJK characters are treated as duplicates.
+	// Lower it for tightly kerned text with legitimate repeats (default: 0.7)
+	CJKDuplicateWidthRatio float64
+
+	// WordSplitter repairs long alphabetic tokens that lost their spaces, such as
+	// "Billamount". NewEnglishWordSplitter provides a built-in English word list (default: nil, disabled)
+	WordSplitter WordSplitter
+
+	// AggressiveWordSplitting splits text set without any spaces, such as
+	// "numberPORateHandling", into words at wide gaps and at changes of case,
+	// between digits and letters and before currency signs, as is always done
+	// for rotated text. A few pages are sampled first, and documents that have
+	// spaces between their words are left alone (default: false)
+	AggressiveWordSplitting bool
+
+	// BoldTrackedText sets letter-spaced text, such as "S P A C E D", in bold
+	// once its letters are joined back into words, keeping the emphasis the
+	// spacing gave it (default: false)
+	BoldTrackedText bool
+
+	// TextFilters rewrite each word after it is assembled and before
+	// paragraphs, tables and markdown are built from it, to redact personal
+	// data such as emails or ID numbers. Each filter sees the word's bounding
+	// box, for audit logs. Words left without text are dropped. RedactPattern
+	// builds a filter from a regular expression (default: nil)
+	TextFilters []func(word *EnrichedWord)
+
+	// Profile selects a processing preset. ProfileProse skips walking page
+	// objects, column separator rules and all table detection for documents
+	// known to be running text (default: ProfileDefault)
+	Profile Profile
+
+	// FastPreview makes Converter.Preview convert with ProfileProse, skipping
+	// table detection and page objects so a preview of a long document
+	// returns quickly. Full conversions are unaffected (default: false)
+	FastPreview bool
+
+	// CheckpointInterval is the number of pages extracted between checkpoints
+	// in ConvertFileResumable. Values <= 0 checkpoint after every page (default: 10)
+	CheckpointInterval int
+
+	// PrefetchPages reads the next page from pdfium on a separate goroutine while
+	// the current page is structured. pdfium is still only called from one
+	// goroutine at a time, and single-CPU processes always run sequentially (default: true)
+	PrefetchPages bool
+
+	// PageCache, when set, stores each extracted page so converting the
+	// document again takes unchanged pages from the cache rather than
+	// extracting them from pdfium. ConvertRegion always extracts (default: nil)
+	PageCache PageCache
+
+	// PageRegistry, when set, records page content hashes across conversions so
+	// duplicate pages are detected across documents, not just within one (default: nil)
+	PageRegistry *PageRegistry
+
+	// SkipDuplicatePages omits pages whose content matches an earlier page.
+	// Duplicates are still reported in Document.Duplicates (default: false)
+	SkipDuplicatePages bool
+
+	// PageFilter is called with each page's size, rotation and object counts
+	// before its content is read; pages it returns false for are skipped
+	// entirely. It may be called from a goroutine other than the caller's
+	// (default: nil, extracts every page)
+	PageFilter func(info PageInfo) bool
+
+	// IncludeBlocks and ExcludeBlocks choose which paragraphs and tables are
+	// rendered: a block is kept when IncludeBlocks matches it and
+	// ExcludeBlocks does not. They run after heading levels are settled, so
+	// a block's Role and Section reflect the output; see Document.Filter
+	// (default: nil, keeps every block)
+	IncludeBlocks BlockSelector
+	ExcludeBlocks BlockSelector
+
+	// VerifyText compares each page's characters, read one at a time, with
+	// pdfium's bulk text for the page and records text missing from them in
+	// Diagnostics.TextMismatches. It reads each page's text twice (default: false)
+	VerifyText bool
+
+	// RepairMissingText verifies text as VerifyText does and inserts the
+	// missing text after the character before it, in that character's style
+	// (default: false)
+	RepairMissingText bool
+
+	// SkipBlankPages omits pages with no meaningful content, such as those
+	// holding only a page number or short footer, along with their page
+	// breaks. Blank pages are counted in DocumentStatistics either way (default: false)
+	SkipBlankPages bool
+
+	// PlainTextBelow renders pages whose Page.StructureConfidence is below this
+	// value as plain paragraphs in top-to-bottom order, without column, heading,
+	// list or table detection, rather than as scrambled structure. Such pages
+	// are counted in Diagnostics.PlainTextPages (default: 0, disabled)
+	PlainTextBelow float64
+
+	// HeadingLevelOffset shifts every ranked heading level down, e.g. 1 makes the
+	// largest heading H2 to leave H1 for a title added elsewhere (default: 0)
+	HeadingLevelOffset int
+
+	// MaxHeadingLevel caps heading levels; deeper headings use this level.
+	// Values outside 1-6 mean 6 (default: 6)
+	MaxHeadingLevel int
+
+	// CenteredHeadings promotes short centered lines, such as section titles
+	// set at body size, to headings when they score at least this much from 0
+	// to 1. The score weighs how short the line is against the whitespace
+	// around it; 0.75 suits most reports (default: 0, disabled)
+	CenteredHeadings float64
+
+	// ColoredHeadings promotes short lines at body size set in a color other
+	// than the body text's, as brand-colored templates set headings, when they
+	// score at least this much from 0 to 1. The score weighs the contrast with
+	// the body color against how short the line is; 0.75 suits most templates
+	// (default: 0, disabled)
+	ColoredHeadings float64
+
+	// ColoredHeadingMinLines is how many lines on a page must share a color
+	// before ColoredHeadings takes it for a heading color rather than a
+	// highlighted phrase (default: 0, one is enough)
+	ColoredHeadingMinLines int
+
+	// UseOutlineHeadings takes heading levels from the PDF's bookmarks. A
+	// paragraph reading the same as a bookmark's title on its destination page
+	// becomes a heading at the bookmark's depth, shifted by HeadingLevelOffset.
+	// Other headings are still detected by font size, and documents without
+	// bookmarks are unaffected (default: false)
+	UseOutlineHeadings bool
+
+	// NumberedHeadings takes short lines starting with a section number, such
+	// as "3.2 Scope" or "Appendix B", for headings at the number's depth,
+	// ahead of the font size heuristic. For documents that set every heading
+	// at one size (default: false; see NumberedHeadings)
+	NumberedHeadings bool
+
+	// HeadingStrategies replaces the built-in heading detection. Detectors run
+	// in order and the first to mark a paragraph sets its level, e.g.
+	// NumberedHeadings before FontSizeHeadings for documents that set every
+	// heading alike (default: nil, uses DefaultHeadingStrategies(config))
+	HeadingStrategies []HeadingDetector
+
+	// HeadingLevels assigns explicit levels to heading font sizes, matched to
+	// within 0.5pt. Listed sizes skip ranking and the offset (default: nil)
+	HeadingLevels map[float64]int
+
+	// StyleRules classify paragraphs by font name and size before heading
+	// detection runs; the first matching rule wins (default: nil)
+	StyleRules []StyleRule
+
+	// LeaderRows renders rows joined by leader dots ("Coffee ....... $3.00") as a
+	// two-column table or as "Coffee — $3.00" lines (default: LeaderRowsKeep)
+	LeaderRows LeaderRowStyle
+
+	// TOCHandling renders the document's own table of contents pages, lines
+	// running through leader dots to page numbers, as a nested list of links
+	// to the headings they name, or leaves them out (default: TOCKeep)
+	TOCHandling TOCHandling
+
+	// LinkURLs renders URLs and email addresses as markdown links. URLs broken
+	// across lines are rejoined either way (default: false)
+	LinkURLs bool
+
+	// LinkInternalDestinations reads links to other pages of the document,
+	// such as table of contents entries, and links their text to the anchor of
+	// the heading nearest each destination instead of leaving a page reference
+	// that means nothing in markdown. Resolving forward links needs every page,
+	// so ConvertFileTo extracts the whole document before writing (default: false)
+	LinkInternalDestinations bool
+
+	// TextWrapWidth wraps paragraphs in plain text output (Document.ToPlainText)
+	// at this many characters (default: 0, a line per paragraph)
+	TextWrapWidth int
+
+	// TextLayout lays plain text output out as each page is printed, padding
+	// words with spaces to their positions, like pdftotext -layout (default: false)
+	TextLayout bool
+
+	// IncludeFrontMatter starts the markdown with the PDF's title, author,
+	// subject, keywords and creation date as YAML front matter, for static
+	// site generators. Nothing is added when the PDF records none of them
+	// (default: false)
+	IncludeFrontMatter bool
+
+	// TokenEstimator estimates the tokens in markdown for the EstimatedTokens
+	// methods, metrics and JSON output. EstimateTokensByChars and
+	// EstimateTokensByWords are provided (default: nil, for
+	// EstimateTokensByChars)
+	TokenEstimator TokenEstimator
+
+	// FigureImages renders each figure region as a markdown image placed in
+	// the text flow, "![alt](#page-2-figure-1)", with Page.FigureAltText as its
+	// alt text, or "Figure N" where none was found. The image data itself is
+	// not extracted (default: false)
+	FigureImages bool
+
+	// TransposeTables renders tables whose headers run down the first column
+	// with those headers across the header row instead (default: false)
+	TransposeTables bool
+
+	// AlignTableColumns marks right aligned and centered table columns with GFM
+	// alignment markers ("---:" and ":---:") (default: false)
+	AlignTableColumns bool
+
+	// TableMaxRows is the most body rows rendered in one markdown table; larger
+	// tables are split or rendered as CSV according to OversizedTables. The
+	// Table values in the Document always hold every row. Zero means no limit
+	// (default: 0)
+	TableMaxRows int
+
+	// OversizedTables selects how tables over TableMaxRows rows are rendered
+	// (default: OversizedTablesSplit)
+	OversizedTables OversizedTableStyle
+
+	// TwoColumnTableStyle renders tables of label/value pairs, two columns
+	// without a header row, as "**Label:** value" lines or a definition list
+	// instead of a pipe table (default: TwoColumnTablesKeep)
+	TwoColumnTableStyle TwoColumnTableStyle
+
+	// Abbreviations are words whose trailing period does not end a sentence, such
+	// as "No." and "e.g.". A line ending in one runs on into the next, so a number
+	// starting the next line is not read as a list item (default: nil, uses DefaultAbbreviations())
+	Abbreviations []string
+
+	// ListMarkers recognises list items by custom markers such as "(a)" or
+	// "1.1" as well as bullets and "1." / "1)" numbers. Items keep their
+	// marker in the markdown. LegalListMarkers provides common legal
+	// enumerations (default: nil, built-in markers only)
+	ListMarkers []ListMarker
+
+	// DetectEquations renders display equations, paragraphs set mostly in
+	// math fonts or symbols and centred lines of symbols around "=", as
+	// $$...$$ blocks with a best-effort LaTeX transcription. With FigureImages
+	// they become placeholder images instead, with the LaTeX as alt text
+	// (default: false)
+	DetectEquations bool
+
+	// DetectBlockquotes renders quotations set apart from the body text as
+	// blockquotes: blocks indented from the text around them, blocks with a
+	// vertical rule down their left edge, and full-width blocks set wholly in
+	// italics among upright text (default: false)
+	DetectBlockquotes bool
+
+	// SplitParagraphsOver splits paragraphs longer than this many characters
+	// at sentence ends where the indentation changes, separating paragraphs
+	// set without extra spacing between them. 0 disables (default: 0)
+	SplitParagraphsOver int
+
+	// SkipInvisibleText drops transparent, off-page and sub-1pt text, and text in
+	// invisible render mode on pages that also have visible text (default: false)
+	SkipInvisibleText bool
+
+	// NormalizeUnicode applies NFKC normalization and removes zero-width and
+	// other format characters, joining words split at soft hyphens or at
+	// hyphens pdfium reports as line breaks. Fraction glyphs and stacked
+	// fractions become "n/d" (default: false)
+	NormalizeUnicode bool
+
+	// RemovePageFurniture drops page numbers and running headers and footers
+	// repeated in the top or bottom margins of at least half the pages (default: false)
+	RemovePageFurniture bool
+
+	// CollapseWhitespace removes trailing spaces, hard line breaks, table
+	// padding and repeated blank lines from the output (default: false)
+	CollapseWhitespace bool
+
+	// StripInlineFormatting renders bold, italic, inline code and links as
+	// plain text (default: false)
+	StripInlineFormatting bool
+
+	// PreserveColors wraps text in a color other than near black or near
+	// white, such as red negative numbers, in <span style="color:#rrggbb">
+	// in paragraphs and table cells, in markdown and HTML. StripInlineFormatting
+	// takes precedence. JSON output always carries such colors (default: false)
+	PreserveColors bool
+
+	// PageBreakMarker replaces the "---" page separator. "{page}" is replaced
+	// with the number of the page that follows and "{label}" with its logical
+	// label, such as "iii", or its number when it has none (default: "", uses
+	// "---")
+	PageBreakMarker string
+
+	// PageBreakFlow moves the page break out of a paragraph that runs on from
+	// one page to the next, after the paragraph or into it as an HTML comment.
+	// Streaming conversions then extract the whole document first
+	// (default: PageBreaksKeep)
+	PageBreakFlow PageBreakFlow
+
+	// BlockAnchors writes an HTML comment such as "<!-- p:12-3 -->" before
+	// each paragraph and table, naming it by page number and position on the
+	// page so tools can find and patch it. The ids match the "id" fields of
+	// the JSON output written with the same config; see ParagraphID (default: false)
+	BlockAnchors bool
+
+	// IncludeReview adds to every block and table cell of the JSON output a
+	// "review" object with its page, bounding box and confidence scores for
+	// the structural decisions behind it, such as heading detection and cell
+	// assignment; see Review (default: false)
+	IncludeReview bool
+
+	// BlockAttributes wraps right-to-left, centered and right aligned
+	// paragraphs, headings and lists in a div with the dir and text-align
+	// attributes HTML output gives them, so markdown viewers that render
+	// HTML lay them out as on the page. Blank lines inside the div keep the
+	// markdown within it rendered (default: false)
+	BlockAttributes bool
+}
+
+// Profile is a processing preset that trades detection features for speed.
+type Profile string
+
+const (
+	// ProfileDefault runs the full pipeline as configured.
+	ProfileDefault Profile = ""
+
+	// ProfileProse skips walking the page objects (figure regions, text object
+	// colors and path/edge extraction), column separator rules, segment
+	// clustering and table detection entirely. Use it for high-throughput
+	// ingestion of prose documents.
+	ProfileProse Profile = "prose"
+)
+
+// tablesEnabled reports whether table detection should run for this config.
+func (c Config) tablesEnabled() bool {
+	return c.DetectTables && c.Profile != ProfileProse
+}
+
+// cjkDuplicateWidthRatio returns the configured ratio, falling back to the default.
+func (c Config) cjkDuplicateWidthRatio() float64 {
+	if c.CJKDuplicateWidthRatio <= 0 {
+		return 0.7
+	}
+	return c.CJKDuplicateWidthRatio
+}
+
+// clampHeadingLevel limits a heading level to 1 through MaxHeadingLevel.
+func (c Config) clampHeadingLevel(level int) int {
+	maxLevel := c.MaxHeadingLevel
+	if maxLevel < 1 || maxLevel > 6 {
+		maxLevel = 6
+	}
+	return max(1, min(level, maxLevel))
+}
+
+// explicitHeadingLevel returns the level HeadingLevels assigns to the closest
+// listed font size within 0.5pt, preferring the larger size on a tie.
+func (c Config) explicitHeadingLevel(fontSize float64) (int, bool) {
+	const sizeTolerance = 0.5
+
+	level, found := 0, false
+	var bestSize float64
+	bestDiff := sizeTolerance
+	for size, l := range c.HeadingLevels {
+		diff := math.Abs(size - fontSize)
+		if diff < bestDiff || (diff == bestDiff && (!found || size > bestSize)) {
+			level, found, bestDiff, bestSize = l, true, diff, size
+		}
+	}
+	return level, found
 }
 
 // DefaultConfig returns the default converter configuration.
@@ -71,33 +464,57 @@ func DefaultConfig() Config {
 		TableSettings:          DefaultTableSettings(),
 		UseSegmentBasedTables:  false, // Opt-in: good for PDFs without ruling lines
 		UseAdaptiveThresholds:  true,
+		CheckpointInterval:     10,
+		DeduplicateCJK:         true,
+		CJKDuplicateWidthRatio: 0.7,
+		PrefetchPages:          true,
+		MaxHeadingLevel:        6,
 	}
 }
 
 // Converter converts PDFs to markdown using pdfium text extraction.
+//
+// A Converter drives a single pdfium instance and is not safe for concurrent
+// use: a call made while another is running returns ErrConcurrentUse. Use a
+// ConverterPool to convert from several goroutines.
 type Converter struct {
 	instance pdfium.Pdfium
 	config   Config
+	features FeatureSet
+	busy     atomic.Bool // Set while a call is using the instance
 }
 
-// NewConverter creates a new PDF to markdown converter with default configuration.
-func NewConverter(instance pdfium.Pdfium) *Converter {
-	return &Converter{
-		instance: instance,
-		config:   DefaultConfig(),
-	}
+// NewConverter creates a new PDF to markdown converter with the default
+// configuration adjusted by opts:
+//
+//	converter := pdfmarkdown.NewConverter(instance, pdfmarkdown.WithSegmentTables(), pdfmarkdown.WithMetrics())
+func NewConverter(instance pdfium.Pdfium, opts ...Option) *Converter {
+	return NewConverterWithConfig(instance, NewConfig(opts...))
 }
 
 // NewConverterWithConfig creates a new PDF to markdown converter with custom configuration.
+// The instance is probed once for optional text APIs; see Features.
 func NewConverterWithConfig(instance pdfium.Pdfium, config Config) *Converter {
 	return &Converter{
 		instance: instance,
 		config:   config,
+		features: probeFeatures(instance),
 	}
 }
 
+// Features reports which optional pdfium text APIs the converter's instance
+// supports. Missing features are also listed in each page's Diagnostics.
+func (c *Converter) Features() FeatureSet {
+	return c.features
+}
+
 // ConvertFile converts a PDF file to markdown.
 func (c *Converter) ConvertFile(filePath string) (string, error) {
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
 	// Open the PDF document
 	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
 		FilePath: &filePath,
@@ -109,11 +526,130 @@ func (c *Converter) ConvertFile(filePath string) (string, error) {
 		Document: doc.Document,
 	})
 
-	return c.convertDocument(doc.Document)
+	return c.convertDocument(doc.Document, filePath)
+}
+
+// ConvertFileTo converts a PDF file to markdown, writing each page to w as
+// soon as it is extracted instead of building the whole output in memory.
+// Heading levels are ranked among the heading sizes seen so far, so a page's
+// levels can differ from ConvertFile when a larger heading size only appears
+// later; set Config.HeadingLevels to fix them. With RemovePageFurniture the
+// whole document is extracted first, since furniture is found by comparing
+// every page, and likewise with LinkInternalDestinations, since links can
+// point at headings on later pages, with PageBreakFlow, since a page's last
+// paragraph can run on to the next, and with TOCClean, since table of
+// contents entries name headings on later pages.
+func (c *Converter) ConvertFileTo(w io.Writer, filePath string) error {
+	if err := c.acquire(); err != nil {
+		return err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	if c.config.RemovePageFurniture || c.config.LinkInternalDestinations || c.config.PageBreakFlow != PageBreaksKeep || c.config.TOCHandling == TOCClean {
+		document, err := c.extractDocument(doc.Document, filePath)
+		if err != nil {
+			return err
+		}
+		return document.WriteMarkdown(w, c.config)
+	}
+
+	return c.streamDocument(w, doc.Document, filePath)
+}
+
+// streamDocument extracts every page of a PDF document and writes each one as
+// markdown to w once it is structured, keeping no page after it is written.
+// source identifies the document in duplicate page reports.
+func (c *Converter) streamDocument(w io.Writer, docRef references.FPDF_DOCUMENT, source string) error {
+	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
+		Document: docRef,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get page count")
+	}
+
+	if c.config.IncludeFrontMatter {
+		if err := writeFrontMatter(w, readMetadata(c.instance, docRef)); err != nil {
+			return err
+		}
+	}
+
+	pw := newPageWriter(w, c.config)
+	headings := newHeadingRanker(c.config)
+	selector, sections := c.config.blockSelector(), &blockSections{}
+	registry := c.pageRegistry()
+	duplicates := &Document{}
+	err = c.extractPages(docRef, 0, pageCount.PageCount-1, func(page *Page, pageDuration time.Duration) error {
+		if c.recordDuplicate(registry, duplicates, source, page) {
+			return nil
+		}
+		if c.config.SkipBlankPages && isBlankPage(*page) {
+			return nil
+		}
+		if c.config.TOCHandling == TOCSkip && isTOCPage(*page) {
+			return nil
+		}
+
+		if c.config.EnableMetricsLogging {
+			log.Printf("Page %d/%d extracted in %v", page.Number, pageCount.PageCount, pageDuration)
+		}
+
+		headings.apply(page)
+		if selector != nil {
+			*page = sections.filter(*page, selector)
+		}
+		return pw.writePage(*page)
+	})
+	if err != nil {
+		return err
+	}
+	return pw.close()
+}
+
+// ConvertFileToStructured extracts a PDF file's structure without rendering
+// it, for callers that need positions, fonts and block types rather than
+// markdown. Heading levels are normalized and breadcrumbs built with the
+// converter's config; Document.WriteJSON serializes the result.
+func (c *Converter) ConvertFileToStructured(filePath string) (*Document, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	document, err := c.extractDocument(doc.Document, filePath)
+	if err != nil {
+		return nil, err
+	}
+	document.BuildBreadcrumbs(c.config)
+	return document, nil
 }
 
 // ConvertBytes converts PDF bytes to markdown.
 func (c *Converter) ConvertBytes(pdfBytes []byte) (string, error) {
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
 	// Open the PDF document
 	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
 		File: &pdfBytes,
@@ -125,11 +661,16 @@ func (c *Converter) ConvertBytes(pdfBytes []byte) (string, error) {
 		Document: doc.Document,
 	})
 
-	return c.convertDocument(doc.Document)
+	return c.convertDocument(doc.Document, "")
 }
 
 // ConvertReader converts a PDF from an io.ReadSeeker to markdown.
 func (c *Converter) ConvertReader(reader io.ReadSeeker) (string, error) {
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
 	// Open the PDF document
 	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
 		FileReader: reader,
@@ -141,11 +682,16 @@ func (c *Converter) ConvertReader(reader io.ReadSeeker) (string, error) {
 		Document: doc.Document,
 	})
 
-	return c.convertDocument(doc.Document)
+	return c.convertDocument(doc.Document, "")
 }
 
 // ConvertPageRange converts a specific range of pages to markdown.
 func (c *Converter) ConvertPageRange(filePath string, startPage, endPage int) (string, error) {
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
 	// Open the PDF document
 	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
 		FilePath: &filePath,
@@ -177,20 +723,34 @@ func (c *Converter) ConvertPageRange(filePath string, startPage, endPage int) (s
 	}
 
 	// Extract pages
-	document := &Document{}
-	for i := startPage; i <= endPage; i++ {
-		page, err := c.extractPage(doc.Document, i)
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to extract page %d", i+1)
+	document := &Document{Metadata: readMetadata(c.instance, doc.Document)}
+	registry := c.pageRegistry()
+	err = c.extractPages(doc.Document, startPage, endPage, func(page *Page, _ time.Duration) error {
+		if !c.recordDuplicate(registry, document, filePath, page) {
+			document.Pages = append(document.Pages, *page)
 		}
-		document.Pages = append(document.Pages, *page)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return document.ToMarkdown(c.config), nil
 }
 
 // convertDocument converts a complete PDF document to markdown.
-func (c *Converter) convertDocument(docRef references.FPDF_DOCUMENT) (string, error) {
+// source identifies the document in duplicate page reports.
+func (c *Converter) convertDocument(docRef references.FPDF_DOCUMENT, source string) (string, error) {
+	document, err := c.extractDocument(docRef, source)
+	if err != nil {
+		return "", err
+	}
+	return document.ToMarkdown(c.config), nil
+}
+
+// extractDocument extracts every page of a PDF document, logging metrics if
+// enabled. source identifies the document in duplicate page reports.
+func (c *Converter) extractDocument(docRef references.FPDF_DOCUMENT, source string) (*Document, error) {
 	startTime := time.Now()
 
 	// Get page count
@@ -198,33 +758,39 @@ func (c *Converter) convertDocument(docRef references.FPDF_DOCUMENT) (string, er
 		Document: docRef,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get page count")
+		return nil, errors.Wrap(err, "failed to get page count")
 	}
 
 	// Extract all pages with timing
 	document := &Document{
-		Pages: make([]Page, 0, pageCount.PageCount),
+		Pages:    make([]Page, 0, pageCount.PageCount),
+		Metadata: readMetadata(c.instance, docRef),
 	}
 
 	var pageMetrics []PageMetrics
-	for i := 0; i < pageCount.PageCount; i++ {
-		pageStart := time.Now()
-		page, err := c.extractPage(docRef, i)
-		pageDuration := time.Since(pageStart)
+	registry := c.pageRegistry()
+	err = c.extractPages(docRef, 0, pageCount.PageCount-1, func(page *Page, pageDuration time.Duration) error {
+		metrics := PageMetrics{
+			PageNumber: page.Number,
+			Duration:   pageDuration,
+		}
+		if c.config.EnableMetricsLogging {
+			metrics.EstimatedTokens = page.EstimatedTokens(c.config)
+		}
+		pageMetrics = append(pageMetrics, metrics)
 
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to extract page %d", i+1)
+		if c.recordDuplicate(registry, document, source, page) {
+			return nil
 		}
 		document.Pages = append(document.Pages, *page)
 
-		pageMetrics = append(pageMetrics, PageMetrics{
-			PageNumber: i + 1,
-			Duration:   pageDuration,
-		})
-
 		if c.config.EnableMetricsLogging {
-			log.Printf("Page %d/%d extracted in %v", i+1, pageCount.PageCount, pageDuration)
+			log.Printf("Page %d/%d extracted in %v", page.Number, pageCount.PageCount, pageDuration)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Calculate document statistics
@@ -234,6 +800,7 @@ func (c *Converter) convertDocument(docRef references.FPDF_DOCUMENT) (string, er
 
 	// Log metrics if enabled
 	if c.config.EnableMetricsLogging {
+		stats.EstimatedTokens = document.EstimatedTokens(c.config)
 		logProcessingMetrics(ProcessingMetrics{
 			TotalTime:       totalTime,
 			PageExtractions: pageMetrics,
@@ -241,41 +808,45 @@ func (c *Converter) convertDocument(docRef references.FPDF_DOCUMENT) (string, er
 		})
 	}
 
-	return document.ToMarkdown(c.config), nil
+	return document, nil
 }
 
-// extractPage extracts a single page with all its structure.
-func (c *Converter) extractPage(docRef references.FPDF_DOCUMENT, pageIndex int) (*Page, error) {
-	// Load the page
-	pageResp, err := c.instance.FPDF_LoadPage(&requests.FPDF_LoadPage{
-		Document: docRef,
-		Index:    pageIndex,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to load page")
-	}
-	defer c.instance.FPDF_ClosePage(&requests.FPDF_ClosePage{
-		Page: pageResp.Page,
-	})
-
-	// Extract page content
-	page, err := ExtractPage(c.instance, pageResp.Page, pageIndex+1, c.config)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to extract page content")
+// extractPage extracts a single page with all its structure, or takes it from
+// Config.PageCache when cache is set. It returns nil without an error when
+// Config.PageFilter skips the page.
+func (c *Converter) extractPage(docRef references.FPDF_DOCUMENT, pageIndex int, spaceless bool, cache *pageCacheScope) (*Page, error) {
+	raw, err := c.readPage(docRef, pageIndex, cache)
+	if err != nil || raw == nil {
+		return nil, err
 	}
+	raw.spaceless = spaceless
 
-	return page, nil
+	return c.structure(raw, pageIndex)
 }
 
 // calculateDocumentStatistics calculates statistics for the document
 func calculateDocumentStatistics(doc *Document) DocumentStatistics {
 	stats := DocumentStatistics{
-		TotalPages: len(doc.Pages),
+		TotalPages:     len(doc.Pages),
+		DuplicatePages: len(doc.Duplicates),
+	}
+	for _, page := range doc.Pages {
+		stats.Diagnostics.add(page.Diagnostics)
+		if isBlankPage(page) {
+			stats.BlankPages++
+		}
 	}
 
 	for _, page := range doc.Pages {
 		stats.TotalParagraphs += len(page.Paragraphs)
 		stats.TotalTables += len(page.Tables)
+		for _, table := range page.Tables {
+			if stats.TableDetectors == nil {
+				stats.TableDetectors = make(map[string]int)
+			}
+			stats.TableDetectors[table.Provenance.Detector]++
+			stats.DuplicateTables += len(table.Provenance.Duplicates)
+		}
 
 		for _, para := range page.Paragraphs {
 			if para.IsHeading {
@@ -309,6 +880,26 @@ func logProcessingMetrics(metrics ProcessingMetrics) {
 	log.Printf("│   Tables:     %-29d │\n", metrics.Statistics.TotalTables)
 	log.Printf("│   Words:      %-29d │\n", metrics.Statistics.TotalWords)
 	log.Printf("│   Characters: %-29d │\n", metrics.Statistics.TotalCharacters)
+	log.Printf("│   Tokens:     ~%-28d │\n", metrics.Statistics.EstimatedTokens)
+	log.Printf("│   Duplicates: %-29d │\n", metrics.Statistics.DuplicatePages)
+	log.Printf("│   Blank:      %-29d │\n", metrics.Statistics.BlankPages)
+	log.Printf("│   CJK dedup:  %-29d │\n", metrics.Statistics.Diagnostics.CJKCharsRemoved)
+	log.Printf("│   Plain text: %-29d │\n", metrics.Statistics.Diagnostics.PlainTextPages)
+	log.Printf("│   Rejected:   %-29d │\n", len(metrics.Statistics.Diagnostics.RejectedTables))
+	log.Printf("│   Dup tables: %-29d │\n", metrics.Statistics.DuplicateTables)
+	if detectors := metrics.Statistics.TableDetectors; len(detectors) > 0 {
+		names := make([]string, 0, len(detectors))
+		for name, count := range detectors {
+			names = append(names, fmt.Sprintf("%s=%d", name, count))
+		}
+		sort.Strings(names)
+		log.Printf("│   Detectors:  %-29s │\n", strings.Join(names, ","))
+	}
+	log.Printf("│   Text gaps:  %-29d │\n", len(metrics.Statistics.Diagnostics.TextMismatches))
+	log.Printf("│   Rebuilt:    %-29d │\n", metrics.Statistics.Diagnostics.RebuiltCharBoxes)
+	if missing := metrics.Statistics.Diagnostics.MissingFeatures; len(missing) > 0 {
+		log.Printf("│   Missing:    %-29s │\n", strings.Join(missing, ","))
+	}
 	log.Println("├─────────────────────────────────────────────┤")
 	log.Println("│ Per-Page Timing                             │")
 	log.Println("├─────────────────────────────────────────────┤")
@@ -330,6 +921,11 @@ func logProcessingMetrics(metrics ProcessingMetrics) {
 
 // ConvertFileWithMetrics converts a PDF and returns both markdown and metrics
 func (c *Converter) ConvertFileWithMetrics(filePath string) (string, ProcessingMetrics, error) {
+	if err := c.acquire(); err != nil {
+		return "", ProcessingMetrics{}, err
+	}
+	defer c.release()
+
 	startTime := time.Now()
 	openStart := time.Now()
 
@@ -356,28 +952,32 @@ func (c *Converter) ConvertFileWithMetrics(filePath string) (string, ProcessingM
 
 	// Extract all pages with timing
 	document := &Document{
-		Pages: make([]Page, 0, pageCount.PageCount),
+		Pages:    make([]Page, 0, pageCount.PageCount),
+		Metadata: readMetadata(c.instance, doc.Document),
 	}
 
 	var pageMetrics []PageMetrics
-	for i := 0; i < pageCount.PageCount; i++ {
-		pageStart := time.Now()
-		page, err := c.extractPage(doc.Document, i)
-		pageDuration := time.Since(pageStart)
-
-		if err != nil {
-			return "", ProcessingMetrics{}, errors.Wrapf(err, "failed to extract page %d", i+1)
-		}
-		document.Pages = append(document.Pages, *page)
-
+	registry := c.pageRegistry()
+	err = c.extractPages(doc.Document, 0, pageCount.PageCount-1, func(page *Page, pageDuration time.Duration) error {
 		pageMetrics = append(pageMetrics, PageMetrics{
-			PageNumber: i + 1,
-			Duration:   pageDuration,
+			PageNumber:      page.Number,
+			Duration:        pageDuration,
+			EstimatedTokens: page.EstimatedTokens(c.config),
 		})
+
+		if !c.recordDuplicate(registry, document, filePath, page) {
+			document.Pages = append(document.Pages, *page)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", ProcessingMetrics{}, err
 	}
 
 	// Calculate statistics
 	stats := calculateDocumentStatistics(document)
+	stats.Styles = document.StyleCatalog(c.config)
+	stats.EstimatedTokens = document.EstimatedTokens(c.config)
 
 	// Generate markdown
 	markdown := document.ToMarkdown(c.config)
@@ -396,6 +996,11 @@ func (c *Converter) ConvertFileWithMetrics(filePath string) (string, ProcessingM
 
 // GetDocumentInfo returns basic information about a PDF without converting it.
 func (c *Converter) GetDocumentInfo(filePath string) (*DocumentInfo, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
 	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
 		FilePath: &filePath,
 	})
@@ -406,19 +1011,101 @@ func (c *Converter) GetDocumentInfo(filePath string) (*DocumentInfo, error) {
 		Document: doc.Document,
 	})
 
+	return c.documentInfo(doc.Document)
+}
+
+// documentInfo reads an open document's page count, metadata and page labels.
+func (c *Converter) documentInfo(docRef references.FPDF_DOCUMENT) (*DocumentInfo, error) {
 	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
-		Document: doc.Document,
+		Document: docRef,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get page count")
 	}
 
-	return &DocumentInfo{
+	info := &DocumentInfo{
 		PageCount: pageCount.PageCount,
-	}, nil
+		Metadata:  readMetadata(c.instance, docRef),
+	}
+	for i := 0; i < pageCount.PageCount; i++ {
+		if label := readPageLabel(c.instance, docRef, i); label != "" {
+			if info.PageLabels == nil {
+				info.PageLabels = make([]string, pageCount.PageCount)
+			}
+			info.PageLabels[i] = label
+		}
+	}
+	return info, nil
 }
 
 // DocumentInfo contains basic information about a PDF document.
 type DocumentInfo struct {
 	PageCount int
+	Metadata  Metadata
+
+	// PageLabels holds each page's logical label, such as "iii" or "A-7", by
+	// page index, with "" for pages without one. It is nil when no page has
+	// a label
+	PageLabels []string
+}
+
+// DefaultRenderDPI is the resolution used by RenderPageImage when dpi <= 0.
+const DefaultRenderDPI = 150
+
+// RenderPageImage renders a single page (0-indexed) to PNG bytes using the
+// converter's pdfium instance. This lets review UIs show the original page next
+// to its converted markdown without a second pdfium integration.
+func (c *Converter) RenderPageImage(filePath string, pageIndex int, dpi int) ([]byte, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	if dpi <= 0 {
+		dpi = DefaultRenderDPI
+	}
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
+		Document: doc.Document,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get page count")
+	}
+
+	if pageIndex < 0 || pageIndex >= pageCount.PageCount {
+		return nil, errors.Errorf("invalid page index %d: document has %d pages", pageIndex, pageCount.PageCount)
+	}
+
+	rendered, err := c.instance.RenderToFile(&requests.RenderToFile{
+		RenderPageInDPI: &requests.RenderPageInDPI{
+			Page: requests.Page{
+				ByIndex: &requests.PageByIndex{
+					Document: doc.Document,
+					Index:    pageIndex,
+				},
+			},
+			DPI: dpi,
+		},
+		OutputFormat: requests.RenderToFileOutputFormatPNG,
+		OutputTarget: requests.RenderToFileOutputTargetBytes,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render page %d", pageIndex+1)
+	}
+
+	if rendered.ImageBytes == nil {
+		return nil, errors.Errorf("failed to render page %d: no image data returned", pageIndex+1)
+	}
+
+	return *rendered.ImageBytes, nil
 }
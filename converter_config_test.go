@@ -1,12 +1,15 @@
 package pdfmarkdown_test
 
 import (
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/klippa-app/go-pdfium/webassembly"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
@@ -105,3 +108,31 @@ func TestConverter_HeadingDetectionDisabled(t *testing.T) {
 		}
 	}
 }
+
+func TestConverter_TextFilters(t *testing.T) {
+	instance := setupPDFium(t)
+
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+	if _, err := os.Stat(pdfPath); os.IsNotExist(err) {
+		t.Skip("Mock Statement of Advice.pdf not found")
+	}
+
+	var redacted int
+	email := regexp.MustCompile(`[^@\s]+@[^@\s]+\.\w+`)
+	config := pdfmarkdown.DefaultConfig()
+	config.TextFilters = []func(word *pdfmarkdown.EnrichedWord){
+		func(word *pdfmarkdown.EnrichedWord) {
+			if email.MatchString(word.Text) {
+				assert.Greater(t, word.Box.Width(), 0.0, "filters see word boxes")
+				redacted++
+			}
+		},
+		pdfmarkdown.RedactPattern(email, "[REDACTED]"),
+	}
+
+	markdown, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertFile(pdfPath)
+	require.NoError(t, err)
+	assert.NotContains(t, markdown, "@example.com")
+	assert.Contains(t, markdown, "[REDACTED]")
+	assert.Positive(t, redacted)
+}
@@ -1,9 +1,13 @@
 package pdfmarkdown_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"image/png"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,7 +20,7 @@ import (
 )
 
 // setupPDFium initialises a pdfium instance for testing.
-func setupPDFium(t *testing.T) pdfium.Pdfium {
+func setupPDFium(t testing.TB) pdfium.Pdfium {
 	t.Helper()
 
 	pool, err := webassembly.Init(webassembly.Config{
@@ -72,6 +76,48 @@ func TestConverter_ConvertFile(t *testing.T) {
 	assert.NotEmpty(t, markdown)
 }
 
+func TestConverter_ConvertFileTo(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	testPDFPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	// Pin heading levels so streamed pages rank headings as ConvertFile does
+	config := pdfmarkdown.DefaultConfig()
+	doc, err := converter.ConvertFileToStructured(testPDFPath)
+	require.NoError(t, err)
+	config.HeadingLevels = make(map[float64]int)
+	for _, page := range doc.Pages {
+		for _, para := range page.Paragraphs {
+			if !para.IsHeading || len(para.Lines) == 0 {
+				continue
+			}
+			var size float64
+			for _, word := range para.Lines[0].Words {
+				size = max(size, word.FontSize)
+			}
+			config.HeadingLevels[size] = para.HeadingLevel
+		}
+	}
+	converter = pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	want, err := converter.ConvertFile(testPDFPath)
+	require.NoError(t, err)
+
+	var out pageWrites
+	require.NoError(t, converter.ConvertFileTo(&out, testPDFPath))
+	assert.Equal(t, want, strings.Join(out, ""))
+	assert.Greater(t, len(out), 1, "pages should be written as they are extracted")
+}
+
+// pageWrites records each write separately.
+type pageWrites []string
+
+func (w *pageWrites) Write(p []byte) (int, error) {
+	*w = append(*w, string(p))
+	return len(p), nil
+}
+
 func TestConverter_GetDocumentInfo(t *testing.T) {
 	instance := setupPDFium(t)
 	converter := pdfmarkdown.NewConverter(instance)
@@ -87,6 +133,101 @@ func TestConverter_GetDocumentInfo(t *testing.T) {
 	assert.Greater(t, info.PageCount, 0)
 }
 
+func TestConverter_Features(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	// The WebAssembly build includes pdfium's experimental text APIs
+	assert.Empty(t, converter.Features().Missing())
+}
+
+func TestConverterPool_ConcurrentConversions(t *testing.T) {
+	pool, err := webassembly.Init(webassembly.Config{MinIdle: 1, MaxIdle: 2, MaxTotal: 2})
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	converters := pdfmarkdown.NewConverterPool(pool)
+	testPDFPath := filepath.Join("testdata", "issue-842-example.pdf")
+
+	want, err := converters.ConvertFile(testPDFPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, want)
+
+	const workers = 4
+	results := make([]string, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = converters.ConvertFile(testPDFPath)
+		}()
+	}
+	wg.Wait()
+
+	for i := range workers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, want, results[i], "worker %d", i)
+	}
+
+	features, err := converters.Features()
+	require.NoError(t, err)
+	assert.Empty(t, features.Missing())
+}
+
+func TestConverter_ConvertFileToStructured(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	doc, err := converter.ConvertFileToStructured(filepath.Join("testdata", "Mock Statement of Advice.pdf"))
+	require.NoError(t, err)
+	require.NotEmpty(t, doc.Pages)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.WriteJSON(&buf, pdfmarkdown.DefaultConfig()))
+
+	var structure struct {
+		Version int `json:"version"`
+		Pages   []struct {
+			Number int `json:"number"`
+			Blocks []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"blocks"`
+		} `json:"pages"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &structure))
+	assert.Equal(t, pdfmarkdown.JSONSchemaVersion, structure.Version)
+	require.Len(t, structure.Pages, len(doc.Pages))
+	assert.Equal(t, 1, structure.Pages[0].Number)
+	require.NotEmpty(t, structure.Pages[0].Blocks)
+	assert.Equal(t, "heading", structure.Pages[0].Blocks[0].Type)
+	assert.Equal(t, "STATEMENT OF ADVICE", structure.Pages[0].Blocks[0].Text)
+}
+
+func TestConverter_SharedPageRegistry(t *testing.T) {
+	instance := setupPDFium(t)
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	alone, err := pdfmarkdown.NewConverter(instance).ConvertFileToStructured(pdfPath)
+	require.NoError(t, err)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.PageRegistry = pdfmarkdown.NewPageRegistry()
+	config.SkipDuplicatePages = true
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	// Converting the same file again must not flag its pages as duplicates
+	// of themselves
+	for range 2 {
+		doc, err := converter.ConvertFileToStructured(pdfPath)
+		require.NoError(t, err)
+		assert.Len(t, doc.Pages, len(alone.Pages))
+		assert.Equal(t, alone.Duplicates, doc.Duplicates)
+	}
+}
+
 func TestConverter_ConvertPageRange(t *testing.T) {
 	instance := setupPDFium(t)
 	converter := pdfmarkdown.NewConverter(instance)
@@ -106,6 +247,152 @@ func TestConverter_ConvertPageRange(t *testing.T) {
 	assert.Contains(t, markdown, "---")
 }
 
+func TestConverter_Preview(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	testPDFPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	preview, err := converter.Preview(testPDFPath, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, preview.Pages)
+	assert.Greater(t, preview.Info.PageCount, 1)
+
+	// The preview is the first page's conversion
+	markdown, err := converter.ConvertPageRange(testPDFPath, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, preview.Markdown)
+
+	// Asking for more pages than there are previews them all
+	preview, err = converter.Preview(testPDFPath, 10000)
+	require.NoError(t, err)
+	assert.Equal(t, preview.Info.PageCount, preview.Pages)
+
+	_, err = converter.Preview(testPDFPath, 0)
+	require.Error(t, err)
+}
+
+func TestConverter_ExtractOutline(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	testPDFPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	outline, err := converter.ExtractOutline(testPDFPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, outline)
+
+	// Every heading found in the text is a heading of the markdown, at the
+	// same level
+	markdown, err := converter.ConvertFile(testPDFPath)
+	require.NoError(t, err)
+	var check func(headings []pdfmarkdown.OutlineHeading)
+	check = func(headings []pdfmarkdown.OutlineHeading) {
+		for _, heading := range headings {
+			if heading.Anchor != "" {
+				assert.Contains(t, markdown, strings.Repeat("#", heading.Level)+" "+heading.Title)
+			}
+			check(heading.Children)
+		}
+	}
+	check(outline)
+}
+
+func TestConverter_RenderPageImage(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	testPDFPath := filepath.Join("testdata", "issue-905.pdf")
+
+	pngBytes, err := converter.RenderPageImage(testPDFPath, 0, 72)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(pngBytes))
+	require.NoError(t, err)
+	assert.Greater(t, img.Bounds().Dx(), 0)
+	assert.Greater(t, img.Bounds().Dy(), 0)
+
+	// Out of range pages are rejected rather than rendered blank
+	_, err = converter.RenderPageImage(testPDFPath, 999, 72)
+	require.Error(t, err)
+}
+
+func TestConverter_ConvertRegion(t *testing.T) {
+	instance := setupPDFium(t)
+	converter := pdfmarkdown.NewConverter(instance)
+
+	testPDFPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	doc, err := converter.ConvertFileToStructured(testPDFPath)
+	require.NoError(t, err)
+	page := doc.Pages[0]
+	whole, err := converter.ConvertPageRange(testPDFPath, 0, 0)
+	require.NoError(t, err)
+
+	// The top third of the page holds some, but not all, of its text
+	top, err := converter.ConvertRegion(testPDFPath, 0, pdfmarkdown.Rect{X1: page.Width, Y1: page.Height / 3})
+	require.NoError(t, err)
+	assert.NotEmpty(t, strings.TrimSpace(top))
+	assert.Less(t, len(top), len(whole))
+
+	_, err = converter.ConvertRegion(testPDFPath, 999, pdfmarkdown.Rect{X1: 100, Y1: 100})
+	require.Error(t, err)
+	_, err = converter.ConvertRegion(testPDFPath, 0, pdfmarkdown.Rect{X0: 100, X1: 50, Y1: 100})
+	require.Error(t, err)
+}
+
+func TestConverter_LinkInternalDestinations(t *testing.T) {
+	instance := setupPDFium(t)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.LinkInternalDestinations = true
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	markdown, err := converter.ConvertFile(filepath.Join("testdata", "internal-links.pdf"))
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "[Introduction](#introduction)")
+	assert.Contains(t, markdown, "[Results](#results)")
+	assert.NotContains(t, markdown, "[Project website]", "links out of the document are not internal")
+}
+
+// countingCache counts the pages a page cache is asked to save.
+type countingCache struct {
+	*pdfmarkdown.MemoryPageCache
+	saves int
+}
+
+func (c *countingCache) Save(key pdfmarkdown.PageCacheKey, page *pdfmarkdown.Page) error {
+	c.saves++
+	return c.MemoryPageCache.Save(key, page)
+}
+
+func TestConverter_PageCache(t *testing.T) {
+	instance := setupPDFium(t)
+
+	cache := &countingCache{MemoryPageCache: pdfmarkdown.NewMemoryPageCache()}
+	config := pdfmarkdown.DefaultConfig()
+	config.PageCache = cache
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	testPDFPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	first, err := converter.ConvertFile(testPDFPath)
+	require.NoError(t, err)
+	pages := cache.Len()
+	require.Positive(t, pages)
+	assert.Equal(t, pages, cache.saves)
+
+	// Converting again takes every page from the cache
+	second, err := converter.ConvertFile(testPDFPath)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, pages, cache.saves)
+
+	uncached, err := pdfmarkdown.NewConverter(instance).ConvertFile(testPDFPath)
+	require.NoError(t, err)
+	assert.Equal(t, uncached, first)
+}
+
 func TestEnrichedWord_IsBulletOrNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -190,6 +477,109 @@ func TestDocument_ToMarkdown_Headings(t *testing.T) {
 	assert.Contains(t, markdown, "Some text")
 }
 
+func TestDocument_WriteMarkdown(t *testing.T) {
+	page := func(number int, texts ...string) pdfmarkdown.Page {
+		page := pdfmarkdown.Page{Number: number}
+		for _, text := range texts {
+			var words []pdfmarkdown.EnrichedWord
+			for _, word := range strings.Fields(text) {
+				words = append(words, pdfmarkdown.EnrichedWord{Text: word, FontSize: 12})
+			}
+			page.Paragraphs = append(page.Paragraphs, pdfmarkdown.Paragraph{
+				Lines: []pdfmarkdown.Line{{Words: words}},
+			})
+		}
+		return page
+	}
+	doc := &pdfmarkdown.Document{Pages: []pdfmarkdown.Page{
+		page(1, "First page", "More text"),
+		page(2),
+		page(3, "Third page"),
+	}}
+
+	for _, collapse := range []bool{false, true} {
+		config := pdfmarkdown.DefaultConfig()
+		config.CollapseWhitespace = collapse
+
+		var buf bytes.Buffer
+		require.NoError(t, doc.WriteMarkdown(&buf, config))
+		assert.Equal(t, doc.ToMarkdown(config), buf.String(), "CollapseWhitespace: %v", collapse)
+	}
+
+	err := doc.WriteMarkdown(failingWriter{}, pdfmarkdown.DefaultConfig())
+	assert.Error(t, err)
+}
+
+// failingWriter rejects every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
+
+func TestDocument_ToMarkdown_HeadingLevelConfig(t *testing.T) {
+	heading := func(text string, size float64) pdfmarkdown.Paragraph {
+		return pdfmarkdown.Paragraph{
+			Lines:     []pdfmarkdown.Line{{Words: []pdfmarkdown.EnrichedWord{{Text: text, FontSize: size}}}},
+			IsHeading: true,
+		}
+	}
+	newDoc := func() *pdfmarkdown.Document {
+		return &pdfmarkdown.Document{Pages: []pdfmarkdown.Page{{
+			Number: 1,
+			Paragraphs: []pdfmarkdown.Paragraph{
+				heading("Title", 24),
+				heading("Section", 18),
+				heading("Subsection", 14),
+			},
+		}}}
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*pdfmarkdown.Config)
+		want      []string
+	}{
+		{
+			name:      "default ranking",
+			configure: func(c *pdfmarkdown.Config) {},
+			want:      []string{"# Title", "## Section", "### Subsection"},
+		},
+		{
+			name:      "offset reserves H1",
+			configure: func(c *pdfmarkdown.Config) { c.HeadingLevelOffset = 1 },
+			want:      []string{"## Title", "### Section", "#### Subsection"},
+		},
+		{
+			name: "max level caps deeper headings",
+			configure: func(c *pdfmarkdown.Config) {
+				c.HeadingLevelOffset = 1
+				c.MaxHeadingLevel = 3
+			},
+			want: []string{"## Title", "### Section", "### Subsection"},
+		},
+		{
+			name: "explicit size map",
+			configure: func(c *pdfmarkdown.Config) {
+				c.HeadingLevels = map[float64]int{24.2: 2, 14: 5}
+			},
+			want: []string{"## Title", "# Section", "##### Subsection"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := pdfmarkdown.DefaultConfig()
+			tt.configure(&config)
+
+			lines := strings.Split(newDoc().ToMarkdown(config), "\n")
+			for _, want := range tt.want {
+				assert.Contains(t, lines, want)
+			}
+		})
+	}
+}
+
 func TestDocument_ToMarkdown_Lists(t *testing.T) {
 	doc := &pdfmarkdown.Document{
 		Pages: []pdfmarkdown.Page{
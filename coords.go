@@ -0,0 +1,58 @@
+package pdfmarkdown
+
+import "math"
+
+// Coordinates come in two spaces. PDF space, which pdfium reports, puts the
+// origin at the bottom-left of the page with Y growing upwards. Page space,
+// which Rect, Point and everything built from them use, puts the origin at
+// the top-left with Y growing downwards, matching reading order. Values read
+// from pdfium are taken in as PDFRect or PDFPoint and converted once with
+// ToPage, so the two spaces can't be mixed by accident.
+
+// PDFRect is a box in PDF space, with the origin at the bottom-left of the
+// page.
+type PDFRect struct {
+	Left   float64
+	Bottom float64
+	Right  float64
+	Top    float64
+}
+
+// PDFPoint is a point in PDF space, with the origin at the bottom-left of
+// the page.
+type PDFPoint struct {
+	X float64
+	Y float64
+}
+
+// ToPage converts the box to page space on a page of the given height. The
+// corners are put in order, as some PDFs give them the wrong way round.
+func (r PDFRect) ToPage(pageHeight float64) Rect {
+	return Rect{
+		X0: math.Min(r.Left, r.Right),
+		Y0: pageHeight - math.Max(r.Top, r.Bottom),
+		X1: math.Max(r.Left, r.Right),
+		Y1: pageHeight - math.Min(r.Top, r.Bottom),
+	}
+}
+
+// ToPDF converts the box to PDF space on a page of the given height.
+func (r Rect) ToPDF(pageHeight float64) PDFRect {
+	return PDFRect{Left: r.X0, Bottom: pageHeight - r.Y1, Right: r.X1, Top: pageHeight - r.Y0}
+}
+
+// ToPage converts the point to page space on a page of the given height.
+func (p PDFPoint) ToPage(pageHeight float64) Point {
+	return Point{X: p.X, Y: pageHeight - p.Y}
+}
+
+// ToPDF converts the point to PDF space on a page of the given height.
+func (p Point) ToPDF(pageHeight float64) PDFPoint {
+	return PDFPoint{X: p.X, Y: pageHeight - p.Y}
+}
+
+// pdfBounds builds a PDFRect from the float32 bounds pdfium gives for page
+// objects and links.
+func pdfBounds(left, bottom, right, top float32) PDFRect {
+	return PDFRect{Left: float64(left), Bottom: float64(bottom), Right: float64(right), Top: float64(top)}
+}
@@ -0,0 +1,39 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestPDFRectToPage(t *testing.T) {
+	tests := []struct {
+		name string
+		in   PDFRect
+		want Rect
+	}{
+		{"ordered", PDFRect{Left: 72, Bottom: 700, Right: 144, Top: 712}, Rect{X0: 72, Y0: 80, X1: 144, Y1: 92}},
+		{"swapped corners", PDFRect{Left: 144, Bottom: 712, Right: 72, Top: 700}, Rect{X0: 72, Y0: 80, X1: 144, Y1: 92}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.ToPage(792); got != tt.want {
+				t.Errorf("ToPage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinateRoundTrip(t *testing.T) {
+	box := Rect{X0: 10, Y0: 20, X1: 110, Y1: 45}
+	if got := box.ToPDF(842).ToPage(842); got != box {
+		t.Errorf("Rect round trip = %+v, want %+v", got, box)
+	}
+	if pdf := box.ToPDF(842); pdf.Top != 822 || pdf.Bottom != 797 {
+		t.Errorf("ToPDF() = %+v, want top 822 and bottom 797", pdf)
+	}
+
+	point := Point{X: 5, Y: 30}
+	if got := point.ToPDF(600); got != (PDFPoint{X: 5, Y: 570}) {
+		t.Errorf("Point.ToPDF() = %+v", got)
+	}
+	if got := point.ToPDF(600).ToPage(600); got != point {
+		t.Errorf("Point round trip = %+v, want %+v", got, point)
+	}
+}
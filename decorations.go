@@ -0,0 +1,104 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+)
+
+// Where a rule sits relative to a word's baseline, as fractions of the font
+// size, for it to strike the word through or underline it. Strikethrough is
+// drawn near the middle of the lowercase letters, underline just below the
+// baseline.
+const (
+	strikeMin    = 0.15 // Above the baseline
+	strikeMax    = 0.55
+	underlineMin = -0.05 // Below the baseline
+	underlineMax = 0.35
+)
+
+// markTextDecorations sets IsStrikethrough and IsUnderline on words that a
+// horizontal rule passes through or runs directly beneath. A rule only
+// decorates text when it spans a run of words on one line and little more:
+// rules joined to vertical ones at their ends outline boxes and tables, and
+// rules much longer than the words above them, or under words spread out
+// like table cells, separate rows rather than mark text.
+func markTextDecorations(words []EnrichedWord, lines []Edge) {
+	for _, rule := range lines {
+		if rule.Orientation != "h" || joinsVerticalRule(rule, lines) {
+			continue
+		}
+		y := (rule.Top + rule.Bottom) / 2
+
+		var strike, under []int
+		for i, word := range words {
+			size := word.FontSize
+			if size <= 0 || isRotatedWord(word) || rule.Bottom-rule.Top > size*0.25 {
+				continue
+			}
+			overlap := math.Min(rule.X1, word.Box.X1) - math.Max(rule.X0, word.Box.X0)
+			if overlap < word.Box.Width()*0.5 {
+				continue
+			}
+			baseline := word.Baseline
+			if baseline == 0 {
+				baseline = word.Box.Y1
+			}
+			switch offset := (baseline - y) / size; {
+			case offset >= strikeMin && offset <= strikeMax:
+				strike = append(strike, i)
+			case offset >= -underlineMax && offset <= -underlineMin:
+				under = append(under, i)
+			}
+		}
+
+		if decoratesRun(words, strike, rule) {
+			for _, i := range strike {
+				words[i].IsStrikethrough = true
+			}
+		}
+		if decoratesRun(words, under, rule) {
+			for _, i := range under {
+				words[i].IsUnderline = true
+			}
+		}
+	}
+}
+
+// decoratesRun reports whether the words at indexes form one run of text,
+// with no more than word spacing between them, that rule spans to within a
+// font size at either end.
+func decoratesRun(words []EnrichedWord, indexes []int, rule Edge) bool {
+	if len(indexes) == 0 {
+		return false
+	}
+	sort.Slice(indexes, func(a, b int) bool { return words[indexes[a]].Box.X0 < words[indexes[b]].Box.X0 })
+
+	size := words[indexes[0]].FontSize
+	left, right := words[indexes[0]].Box.X0, words[indexes[0]].Box.X1
+	for _, i := range indexes[1:] {
+		if words[i].Box.X0-right > size*1.5 {
+			return false
+		}
+		right = math.Max(right, words[i].Box.X1)
+	}
+	return rule.X0 >= left-size && rule.X1 <= right+size
+}
+
+// joinsVerticalRule reports whether a vertical rule longer than a couple of
+// points touches either end of a horizontal one, as in a box or table. The
+// short sides of a thin filled rectangle drawn as an underline don't count.
+func joinsVerticalRule(rule Edge, lines []Edge) bool {
+	const tolerance = 1.0 // points
+	for _, v := range lines {
+		if v.Orientation != "v" || v.Bottom-v.Top <= 2 {
+			continue
+		}
+		if rule.Top > v.Bottom+tolerance || rule.Bottom < v.Top-tolerance {
+			continue
+		}
+		if math.Abs(v.X0-rule.X0) <= tolerance || math.Abs(v.X0-rule.X1) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package pdfmarkdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMarkTextDecorations(t *testing.T) {
+	// placedParagraph sets words 10pt tall with their baseline at the bottom,
+	// 5pt per character and 3pt apart: "was" runs from 102 to 117 and
+	// "$20 now" from 120 to 152
+	words := func() []EnrichedWord {
+		return placedParagraph("Price was $20 now $15", 72, 100).Lines[0].Words
+	}
+	decorated := func(words []EnrichedWord, strike bool) []string {
+		var texts []string
+		for _, word := range words {
+			if (strike && word.IsStrikethrough) || (!strike && word.IsUnderline) {
+				texts = append(texts, word.Text)
+			}
+		}
+		return texts
+	}
+	rule := func(x0, x1, y float64) Edge {
+		return Edge{X0: x0, X1: x1, Top: y, Bottom: y + 0.5, Width: x1 - x0, Orientation: "h"}
+	}
+
+	tests := []struct {
+		name   string
+		lines  []Edge
+		strike bool
+		want   []string
+	}{
+		{"strikethrough", []Edge{rule(120, 137, 105.5)}, true, []string{"$20"}},
+		{"underline", []Edge{rule(102, 152, 110.5)}, false, []string{"was", "$20", "now"}},
+		{"filled underline", []Edge{
+			rule(102, 117, 110.5), rule(102, 117, 111),
+			{X0: 102, X1: 102, Top: 110.5, Bottom: 111, Height: 0.5, Orientation: "v"},
+			{X0: 117, X1: 117, Top: 110.5, Bottom: 111, Height: 0.5, Orientation: "v"},
+		}, false, []string{"was"}},
+		{"table ruling", []Edge{rule(40, 400, 110.5)}, false, nil},
+		{"box", []Edge{
+			rule(100, 119, 110.5),
+			{X0: 100, X1: 100, Top: 98, Bottom: 111, Height: 13, Orientation: "v"},
+			{X0: 119, X1: 119, Top: 98, Bottom: 111, Height: 13, Orientation: "v"},
+		}, false, nil},
+		{"rule above", []Edge{rule(102, 152, 96)}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words := words()
+			markTextDecorations(words, tt.lines)
+			if got := decorated(words, tt.strike); !slices.Equal(got, tt.want) {
+				t.Errorf("decorated words = %q, want %q", got, tt.want)
+			}
+			if got := decorated(words, !tt.strike); len(got) > 0 {
+				t.Errorf("also decorated %q the other way", got)
+			}
+		})
+	}
+
+	// Words spread out like table cells aren't one run
+	cells := []EnrichedWord{
+		{Text: "North", FontSize: 10, Box: Rect{X0: 72, Y0: 100, X1: 97, Y1: 110}},
+		{Text: "120", FontSize: 10, Box: Rect{X0: 200, Y0: 100, X1: 215, Y1: 110}},
+	}
+	markTextDecorations(cells, []Edge{rule(72, 215, 110.5)})
+	if cells[0].IsUnderline || cells[1].IsUnderline {
+		t.Error("underlined table cells")
+	}
+}
+
+func TestDecoratedInlineRuns(t *testing.T) {
+	words := []EnrichedWord{
+		{Text: "Was"},
+		{Text: "$20", IsStrikethrough: true},
+		{Text: "each,", IsStrikethrough: true, IsUnderline: true},
+		{Text: "now"},
+		{Text: "$15", IsBold: true, IsStrikethrough: true},
+	}
+	if got, want := formatInlineRuns(words), "Was ~~$20 each,~~ now ~~**$15**~~"; got != want {
+		t.Errorf("formatInlineRuns() = %q, want %q", got, want)
+	}
+	if got, want := htmlInlineRuns(words), "Was <s>$20</s> <u><s>each,</s></u> now <s><strong>$15</strong></s>"; got != want {
+		t.Errorf("htmlInlineRuns() = %q, want %q", got, want)
+	}
+}
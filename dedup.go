@@ -0,0 +1,119 @@
+package pdfmarkdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// PageRef identifies a page within a converted document.
+type PageRef struct {
+	Source     string // File path of the document, empty when converted from bytes or a reader
+	PageNumber int    // 1-indexed page number
+}
+
+// DuplicatePage records a page whose content matched an earlier page.
+type DuplicatePage struct {
+	PageNumber  int     // 1-indexed page number in this document
+	ContentHash string  // Hash shared with the first occurrence
+	DuplicateOf PageRef // First page seen with this content
+}
+
+// PageRegistry records page content hashes so duplicate pages can be detected
+// across documents. Share one registry between conversions via Config.PageRegistry.
+// It is safe for concurrent use.
+type PageRegistry struct {
+	mu   sync.Mutex
+	seen map[string]PageRef
+}
+
+// NewPageRegistry creates an empty page registry.
+func NewPageRegistry() *PageRegistry {
+	return &PageRegistry{seen: make(map[string]PageRef)}
+}
+
+// Register records hash for ref. If the hash was already registered it returns
+// the first page seen with that content and true. A page registered again
+// under the same file and page number, as when a file is converted twice or a
+// conversion resumes, is not a duplicate of itself. Pages converted from bytes
+// or a reader have no source to tell them apart, so they always match.
+func (r *PageRegistry) Register(hash string, ref PageRef) (PageRef, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if first, ok := r.seen[hash]; ok {
+		if first.Source != "" && first == ref {
+			return first, false
+		}
+		return first, true
+	}
+	r.seen[hash] = ref
+	return ref, false
+}
+
+// Len returns the number of distinct page hashes registered.
+func (r *PageRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.seen)
+}
+
+// pageContentHash computes a stable hash over the page's normalized text.
+// Text is case-folded and whitespace-collapsed so that pages differing only in
+// layout noise hash identically. Pages without text return an empty hash.
+func pageContentHash(page *Page) string {
+	var b strings.Builder
+	for _, word := range pageWords(page) {
+		b.WriteString(word.Text)
+		b.WriteByte(' ')
+	}
+
+	normalized := normalizeForHash(b.String())
+	if normalized == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeForHash lowercases text and collapses all whitespace runs to single spaces.
+func normalizeForHash(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), unicode.IsSpace)
+	return strings.Join(fields, " ")
+}
+
+// pageRegistry returns the configured registry, or a fresh one scoped to a
+// single conversion so duplicates within the document are still detected.
+func (c *Converter) pageRegistry() *PageRegistry {
+	if c.config.PageRegistry != nil {
+		return c.config.PageRegistry
+	}
+	return NewPageRegistry()
+}
+
+// recordDuplicate registers the page in registry and records it on the document
+// if its content was seen before. It reports whether the page should be skipped.
+func (c *Converter) recordDuplicate(registry *PageRegistry, document *Document, source string, page *Page) bool {
+	if page.ContentHash == "" {
+		return false
+	}
+
+	first, duplicate := registry.Register(page.ContentHash, PageRef{
+		Source:     source,
+		PageNumber: page.Number,
+	})
+	if !duplicate {
+		return false
+	}
+
+	document.Duplicates = append(document.Duplicates, DuplicatePage{
+		PageNumber:  page.Number,
+		ContentHash: page.ContentHash,
+		DuplicateOf: first,
+	})
+
+	return c.config.SkipDuplicatePages
+}
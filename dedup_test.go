@@ -0,0 +1,157 @@
+package pdfmarkdown
+
+import "testing"
+
+// pageWithText builds a page with one paragraph per entry, one word per field.
+func pageWithText(number int, paragraphs ...[]string) *Page {
+	page := &Page{Number: number}
+	for _, texts := range paragraphs {
+		var words []EnrichedWord
+		for _, text := range texts {
+			words = append(words, EnrichedWord{Text: text})
+		}
+		page.Paragraphs = append(page.Paragraphs, Paragraph{
+			Lines: []Line{{Words: words}},
+		})
+	}
+	page.ContentHash = pageContentHash(page)
+	return page
+}
+
+func TestPageContentHash(t *testing.T) {
+	base := pageWithText(1, []string{"Appendix", "A"}, []string{"Terms", "and", "conditions"})
+
+	tests := []struct {
+		name      string
+		page      *Page
+		wantEqual bool
+	}{
+		{
+			name:      "identical text",
+			page:      pageWithText(7, []string{"Appendix", "A"}, []string{"Terms", "and", "conditions"}),
+			wantEqual: true,
+		},
+		{
+			name:      "case and paragraph breaks ignored",
+			page:      pageWithText(2, []string{"APPENDIX", "A", "Terms"}, []string{"and", "CONDITIONS"}),
+			wantEqual: true,
+		},
+		{
+			name:      "different text",
+			page:      pageWithText(3, []string{"Appendix", "B"}, []string{"Terms", "and", "conditions"}),
+			wantEqual: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.page.ContentHash == base.ContentHash
+			if got != tt.wantEqual {
+				t.Errorf("hash equal = %v, want %v", got, tt.wantEqual)
+			}
+		})
+	}
+
+	if hash := pageContentHash(&Page{Number: 1}); hash != "" {
+		t.Errorf("empty page hash = %q, want empty", hash)
+	}
+}
+
+func TestRecordDuplicate(t *testing.T) {
+	tests := []struct {
+		name      string
+		skip      bool
+		wantPages []int
+	}{
+		{name: "report only", skip: false, wantPages: []int{1, 2, 3, 4}},
+		{name: "skip duplicates", skip: true, wantPages: []int{1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.SkipDuplicatePages = tt.skip
+			c := &Converter{config: config}
+
+			pages := []*Page{
+				pageWithText(1, []string{"Introduction"}),
+				pageWithText(2, []string{"Disclaimer", "text"}),
+				pageWithText(3, []string{"disclaimer", "TEXT"}),
+				pageWithText(4),
+			}
+
+			document := &Document{}
+			registry := c.pageRegistry()
+			for _, page := range pages {
+				if c.recordDuplicate(registry, document, "doc.pdf", page) {
+					continue
+				}
+				document.Pages = append(document.Pages, *page)
+			}
+
+			var gotPages []int
+			for _, page := range document.Pages {
+				gotPages = append(gotPages, page.Number)
+			}
+			if len(gotPages) != len(tt.wantPages) {
+				t.Fatalf("pages = %v, want %v", gotPages, tt.wantPages)
+			}
+			for i := range gotPages {
+				if gotPages[i] != tt.wantPages[i] {
+					t.Errorf("pages = %v, want %v", gotPages, tt.wantPages)
+					break
+				}
+			}
+
+			if len(document.Duplicates) != 1 {
+				t.Fatalf("duplicates = %d, want 1", len(document.Duplicates))
+			}
+			dup := document.Duplicates[0]
+			if dup.PageNumber != 3 || dup.DuplicateOf.PageNumber != 2 || dup.DuplicateOf.Source != "doc.pdf" {
+				t.Errorf("duplicate = %+v, want page 3 duplicating doc.pdf page 2", dup)
+			}
+
+			if stats := calculateDocumentStatistics(document); stats.DuplicatePages != 1 {
+				t.Errorf("DuplicatePages = %d, want 1", stats.DuplicatePages)
+			}
+		})
+	}
+}
+
+func TestPageRegistry_AcrossDocuments(t *testing.T) {
+	registry := NewPageRegistry()
+
+	hash := pageWithText(5, []string{"Standard", "terms"}).ContentHash
+	if _, dup := registry.Register(hash, PageRef{Source: "a.pdf", PageNumber: 5}); dup {
+		t.Fatal("first registration reported as duplicate")
+	}
+
+	first, dup := registry.Register(hash, PageRef{Source: "b.pdf", PageNumber: 9})
+	if !dup {
+		t.Fatal("expected duplicate across documents")
+	}
+	if first.Source != "a.pdf" || first.PageNumber != 5 {
+		t.Errorf("first = %+v, want a.pdf page 5", first)
+	}
+	if registry.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", registry.Len())
+	}
+}
+
+func TestPageRegistry_SamePageAgain(t *testing.T) {
+	registry := NewPageRegistry()
+	hash := pageWithText(2, []string{"Standard", "terms"}).ContentHash
+
+	registry.Register(hash, PageRef{Source: "a.pdf", PageNumber: 2})
+	if _, dup := registry.Register(hash, PageRef{Source: "a.pdf", PageNumber: 2}); dup {
+		t.Error("same file and page reported as a duplicate of itself")
+	}
+	if _, dup := registry.Register(hash, PageRef{Source: "a.pdf", PageNumber: 3}); !dup {
+		t.Error("expected another page of the same file to be a duplicate")
+	}
+
+	registry.Register(hash+"x", PageRef{PageNumber: 1})
+	if _, dup := registry.Register(hash+"x", PageRef{PageNumber: 1}); !dup {
+		t.Error("expected pages without a source to match")
+	}
+}
@@ -0,0 +1,46 @@
+package pdfmarkdown
+
+import "slices"
+
+// Diagnostics records corrections the extraction heuristics made to the text,
+// so silent alterations can be audited.
+type Diagnostics struct {
+	CJKCharsRemoved int      // Duplicate CJK characters dropped by deduplication
+	MissingFeatures []string // Optional pdfium APIs unavailable, see FeatureSet
+	PlainTextPages  int      // Pages rendered as plain text, see Config.PlainTextBelow
+
+	// RebuiltCharBoxes counts characters whose font gave them an empty box,
+	// such as Type3 glyphs, and whose box was rebuilt from the font size and
+	// the advance to the next character
+	RebuiltCharBoxes int
+
+	// RejectedTables lists the table candidates segment-based detection
+	// turned down, so missing tables can be traced to the gate that dropped them
+	RejectedTables []RejectedTable
+
+	// TextMismatches lists text pdfium's bulk text API returned but
+	// per-character extraction dropped (Config.VerifyText)
+	TextMismatches []TextMismatch
+}
+
+// RejectedTable is a region segment-based table detection considered and
+// rejected as a table.
+type RejectedTable struct {
+	Page   int    // 1-indexed page number
+	Box    Rect   // Region of the candidate
+	Reason string // The failed gate, such as "unstable column count"
+}
+
+// add accumulates other into d.
+func (d *Diagnostics) add(other Diagnostics) {
+	d.CJKCharsRemoved += other.CJKCharsRemoved
+	d.PlainTextPages += other.PlainTextPages
+	d.RebuiltCharBoxes += other.RebuiltCharBoxes
+	d.RejectedTables = append(d.RejectedTables, other.RejectedTables...)
+	d.TextMismatches = append(d.TextMismatches, other.TextMismatches...)
+	for _, feature := range other.MissingFeatures {
+		if !slices.Contains(d.MissingFeatures, feature) {
+			d.MissingFeatures = append(d.MissingFeatures, feature)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Package pdfmarkdown converts PDF documents to markdown using pdfium text
+// extraction with layout and style analysis.
+//
+// The stable API is Converter and Config, together with the extracted
+// structure they produce: Document, Page, Paragraph and Table. These follow
+// semantic versioning.
+//
+// Page-level extraction and table detection heuristics that are still being
+// tuned live in the experimental subpackage and may change in any release.
+// Their former locations in this package (ExtractPage, DetectTables and
+// DetectTablesSegmentBased) remain as deprecated shims until the next major
+// version.
+package pdfmarkdown
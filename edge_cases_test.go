@@ -422,3 +422,22 @@ func TestEdgeCases_UnicodeIssues(t *testing.T) {
 	require.NotEmpty(t, markdown, "Should extract text despite Unicode issues")
 	t.Logf("Extracted text with Unicode edge cases: %d chars", len(markdown))
 }
+
+// TestEdgeCases_PlainTextFallback checks that pages whose rotated text comes
+// out run together are rendered as plain text when PlainTextBelow is set.
+// Pages 5 to 8 of issue-848 are rotated a quarter turn, and the upright and
+// upside-down pages keep their structure.
+func TestEdgeCases_PlainTextFallback(t *testing.T) {
+	instance := setupPDFium(t)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.PlainTextBelow = 0.5
+	pdfPath := filepath.Join("testdata", "issue-848.pdf")
+	_, metrics, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertFileWithMetrics(pdfPath)
+	require.NoError(t, err)
+	require.Equal(t, 4, metrics.Statistics.Diagnostics.PlainTextPages)
+
+	_, metrics, err = pdfmarkdown.NewConverter(instance).ConvertFileWithMetrics(pdfPath)
+	require.NoError(t, err)
+	require.Zero(t, metrics.Statistics.Diagnostics.PlainTextPages)
+}
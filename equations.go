@@ -0,0 +1,251 @@
+package pdfmarkdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxEquationLines is the most lines a display equation is taken to span.
+const maxEquationLines = 3
+
+// mathFontMarkers are parts of the names of fonts that set mathematics: the
+// TeX math italic, symbol and extension fonts, the AMS symbol fonts, MathTime
+// and Euclid, and OpenType math fonts such as Cambria Math and STIX Two Math.
+var mathFontMarkers = []string{"CMMI", "CMSY", "CMEX", "MSAM", "MSBM", "MTMI", "MTSY", "MTEX", "EUCLID", "ESINT", "MATH", "SYMBOL"}
+
+// equationNumberPattern matches an equation number such as "(3)" or "(2.1)"
+// set at the end of the line.
+var equationNumberPattern = regexp.MustCompile(`^\((\d+(?:\.\d+)*[a-z]?)\)$`)
+
+// relationSymbols relate the two sides of an equation.
+const relationSymbols = "=<>≤≥≠≈≡∼∝"
+
+// binaryOperators join an operand on each side: relations, arithmetic and set
+// operators and arrows.
+const binaryOperators = relationSymbols + "+-−*/^±∓×÷·∙→←⇒⇔∈∉⊂⊆∪∩"
+
+// prefixOperators apply to the operand after them.
+const prefixOperators = "∑∏∫∮√∂∇∀∃"
+
+// latexSymbols transcribes the symbols common in equations to LaTeX.
+var latexSymbols = map[rune]string{
+	'α': `\alpha`, 'β': `\beta`, 'γ': `\gamma`, 'δ': `\delta`, 'ε': `\epsilon`, 'ζ': `\zeta`,
+	'η': `\eta`, 'θ': `\theta`, 'ι': `\iota`, 'κ': `\kappa`, 'λ': `\lambda`, 'μ': `\mu`,
+	'ν': `\nu`, 'ξ': `\xi`, 'π': `\pi`, 'ρ': `\rho`, 'σ': `\sigma`, 'τ': `\tau`,
+	'υ': `\upsilon`, 'φ': `\phi`, 'χ': `\chi`, 'ψ': `\psi`, 'ω': `\omega`,
+	'Γ': `\Gamma`, 'Δ': `\Delta`, 'Θ': `\Theta`, 'Λ': `\Lambda`, 'Ξ': `\Xi`, 'Π': `\Pi`,
+	'Σ': `\Sigma`, 'Φ': `\Phi`, 'Ψ': `\Psi`, 'Ω': `\Omega`,
+	'∑': `\sum`, '∏': `\prod`, '∫': `\int`, '∮': `\oint`, '√': `\sqrt`, '∞': `\infty`,
+	'∂': `\partial`, '∇': `\nabla`, '±': `\pm`, '∓': `\mp`, '×': `\times`, '÷': `\div`,
+	'·': `\cdot`, '∙': `\cdot`, '≤': `\leq`, '≥': `\geq`, '≠': `\neq`, '≈': `\approx`,
+	'≡': `\equiv`, '∼': `\sim`, '∝': `\propto`, '→': `\to`, '←': `\leftarrow`,
+	'⇒': `\Rightarrow`, '⇔': `\Leftrightarrow`, '∈': `\in`, '∉': `\notin`, '⊂': `\subset`,
+	'⊆': `\subseteq`, '∪': `\cup`, '∩': `\cap`, '∀': `\forall`, '∃': `\exists`,
+	'∅': `\emptyset`, '−': `-`, '′': `'`,
+}
+
+// detectEquations marks display equations: paragraphs of a few lines set
+// mostly in math fonts or symbols, or centred single lines whose text is
+// largely symbols around a relation such as "=". Either must apply an
+// operator to letters or digits, so that page numbers such as "- 3 -",
+// markers such as "(a)" or "[1]" and rules such as "* * *" are not taken for
+// equations. Headings, lists and code are left alone. Equations set inline
+// within sentences stay part of their paragraph.
+func detectEquations(paragraphs []Paragraph) {
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if len(para.Lines) == 0 || len(para.Lines) > maxEquationLines || para.IsHeading || para.IsList || para.IsCode || len(para.Leaders) > 0 {
+			continue
+		}
+		share, relation := mathShare(*para)
+		centred := len(para.Lines) == 1 && para.Alignment == AlignmentCenter
+		para.IsEquation = (share >= 0.5 || (centred && relation && share >= 0.25)) && hasOperation(*para)
+	}
+}
+
+// mathShare returns the fraction of a paragraph's characters that are set in
+// a math font or are mathematical symbols, leaving out an equation number,
+// and whether the text contains a relation symbol.
+func mathShare(para Paragraph) (float64, bool) {
+	var symbols, total int
+	var relation bool
+	for _, line := range para.Lines {
+		for _, word := range equationWords(line) {
+			mathFont := isMathFont(word.FontName)
+			// Greek letters in words rather than alone are Greek text
+			greekText := utf8.RuneCountInString(word.Text) > 2
+			for _, r := range word.Text {
+				total++
+				if mathFont || (isMathSymbol(r) && !(greekText && unicode.Is(unicode.Greek, r))) {
+					symbols++
+				}
+				if strings.ContainsRune(relationSymbols, r) {
+					relation = true
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(symbols) / float64(total), relation
+}
+
+// hasOperation reports whether a paragraph applies an operator to operands:
+// a binary operator or relation with an operand on each side, or a prefix
+// operator such as "∑" with one after it.
+func hasOperation(para Paragraph) bool {
+	for _, line := range para.Lines {
+		var text []rune
+		for _, word := range equationWords(line) {
+			text = append(text, []rune(word.Text)...)
+			text = append(text, ' ')
+		}
+		for i, r := range text {
+			switch {
+			case strings.ContainsRune(binaryOperators, r):
+				if operandAt(text, i, -1) && operandAt(text, i, 1) {
+					return true
+				}
+			case strings.ContainsRune(prefixOperators, r):
+				if operandAt(text, i, 1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// operandAt reports whether the nearest character to text[i] in the
+// direction of step, past spaces and brackets, is an operand: a letter, a
+// digit, a constant such as "∞" or a prefix operator. Looking forwards, signs
+// are passed over too, so "x = -1" has an operand after its "=".
+func operandAt(text []rune, i, step int) bool {
+	for i += step; i >= 0 && i < len(text); i += step {
+		r := text[i]
+		if unicode.IsSpace(r) || strings.ContainsRune("()[]{}|", r) || (step > 0 && strings.ContainsRune("+-−±∓", r)) {
+			continue
+		}
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("∞∅′"+prefixOperators, r)
+	}
+	return false
+}
+
+// equationWords returns the words of an equation's line, leaving out an
+// equation number at its end.
+func equationWords(line Line) []EnrichedWord {
+	words := line.Words
+	if n := len(words); n > 1 && equationNumberPattern.MatchString(words[n-1].Text) {
+		words = words[:n-1]
+	}
+	return words
+}
+
+// isMathFont reports whether a font name is that of a math font.
+func isMathFont(name string) bool {
+	_, name = splitSubsetTag(name)
+	name = strings.ToUpper(name)
+	for _, marker := range mathFontMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMathSymbol reports whether r is an operator, relation or Greek letter.
+func isMathSymbol(r rune) bool {
+	if _, ok := latexSymbols[r]; ok {
+		return true
+	}
+	return unicode.Is(unicode.Sm, r) || strings.ContainsRune("+-*/^=()[]{}|", r)
+}
+
+// equationTarget returns the placeholder image link target for the equation
+// at a paragraph index, numbering the page's equations from 1.
+func equationTarget(page Page, para int) string {
+	n := 1
+	for _, p := range page.Paragraphs[:para] {
+		if p.IsEquation {
+			n++
+		}
+	}
+	return fmt.Sprintf("#page-%d-equation-%d", page.Number, n)
+}
+
+// equationLatex transcribes an equation to LaTeX as best it can: symbols
+// become their commands, smaller words raised above or dropped below the
+// baseline become superscripts and subscripts, and an equation number at the
+// end becomes a \tag.
+func equationLatex(para Paragraph) string {
+	var lines []string
+	var tag string
+	for _, line := range para.Lines {
+		words := line.Words
+		if n := len(words); n > 1 && equationNumberPattern.MatchString(words[n-1].Text) {
+			tag = equationNumberPattern.FindStringSubmatch(words[n-1].Text)[1]
+			words = words[:n-1]
+		}
+
+		size, baseline := lineBaseline(words)
+		var b strings.Builder
+		for i, word := range words {
+			text := latexText(word.Text)
+			switch shift := word.Box.Y1 - baseline; {
+			case word.FontSize < size*0.85 && shift < -size*0.15:
+				text = "^{" + text + "}"
+			case word.FontSize < size*0.85 && shift > size*0.1:
+				text = "_{" + text + "}"
+			case i > 0:
+				b.WriteString(" ")
+			}
+			b.WriteString(text)
+		}
+		lines = append(lines, b.String())
+	}
+
+	latex := strings.Join(lines, ` \\`+"\n")
+	if tag != "" {
+		latex += ` \tag{` + tag + `}`
+	}
+	return latex
+}
+
+// lineBaseline returns the largest font size among words and the median
+// bottom of the words set in it.
+func lineBaseline(words []EnrichedWord) (size, baseline float64) {
+	for _, word := range words {
+		size = max(size, word.FontSize)
+	}
+	var bottoms []float64
+	for _, word := range words {
+		if word.FontSize >= size*0.85 {
+			bottoms = append(bottoms, word.Box.Y1)
+		}
+	}
+	return size, calculateMedian(bottoms)
+}
+
+// latexText transcribes a word's symbols to LaTeX commands, separating a
+// command from a letter after it.
+func latexText(text string) string {
+	var b strings.Builder
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		command, ok := latexSymbols[r]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(command)
+		if next, _ := utf8.DecodeRuneInString(text); strings.HasPrefix(command, `\`) && unicode.IsLetter(next) {
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// mathWord places a word at x on a line whose text sits on y, in a math font
+// when font is set.
+func mathWord(text string, x, y, size float64, font string) EnrichedWord {
+	width := float64(len([]rune(text))) * size * 0.5
+	return EnrichedWord{Text: text, FontName: font, FontSize: size, Box: Rect{X0: x, Y0: y - size, X1: x + width, Y1: y}}
+}
+
+func TestDetectEquations(t *testing.T) {
+	line := func(words ...EnrichedWord) Paragraph {
+		l := Line{Words: words, Box: words[0].Box}
+		for _, w := range words[1:] {
+			l.Box = mergeRects(l.Box, w.Box)
+		}
+		return Paragraph{Lines: []Line{l}, Box: l.Box, Alignment: AlignmentCenter}
+	}
+
+	tests := []struct {
+		name string
+		para Paragraph
+		want bool
+	}{
+		{"math font", line(mathWord("E", 250, 100, 10, "CMMI10"), mathWord("=", 260, 100, 10, "CMR10"), mathWord("mc", 270, 100, 10, "CMMI10")), true},
+		{"symbols", line(mathWord("∑", 250, 100, 10, "Times"), mathWord("x", 260, 100, 10, "Times"), mathWord("≤", 270, 100, 10, "Times"), mathWord("∞", 280, 100, 10, "Times")), true},
+		{"centred relation", line(mathWord("y", 250, 100, 10, "Times"), mathWord("=", 260, 100, 10, "Times"), mathWord("ax", 270, 100, 10, "Times"), mathWord("+", 285, 100, 10, "Times"), mathWord("b", 295, 100, 10, "Times")), true},
+		{"centred title", line(mathWord("Annual", 250, 100, 10, "Times"), mathWord("Report", 290, 100, 10, "Times")), false},
+		{"Greek text", line(mathWord("Καλημέρα", 250, 100, 10, "Times"), mathWord("κόσμε", 300, 100, 10, "Times")), false},
+		{"negative right-hand side", line(mathWord("x", 250, 100, 10, "Times"), mathWord("=", 260, 100, 10, "Times"), mathWord("-1", 270, 100, 10, "Times")), true},
+		{"page number", line(mathWord("-", 250, 100, 10, "Times"), mathWord("3", 260, 100, 10, "Times"), mathWord("-", 270, 100, 10, "Times")), false},
+		{"numbered marker", line(mathWord("(1)", 250, 100, 10, "Times")), false},
+		{"lettered marker", line(mathWord("(a)", 250, 100, 10, "Times")), false},
+		{"citation", line(mathWord("[1]", 250, 100, 10, "Times")), false},
+		{"section break", line(mathWord("*", 250, 100, 10, "Times"), mathWord("*", 260, 100, 10, "Times"), mathWord("*", 270, 100, 10, "Times")), false},
+		{"variable in a math font", line(mathWord("x", 250, 100, 10, "CMMI10")), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{tt.para}
+			detectEquations(paragraphs)
+			if paragraphs[0].IsEquation != tt.want {
+				t.Errorf("IsEquation = %v, want %v", paragraphs[0].IsEquation, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquationLatex(t *testing.T) {
+	words := []EnrichedWord{
+		mathWord("E", 250, 100, 10, "CMMI10"),
+		mathWord("=", 260, 100, 10, "CMR10"),
+		mathWord("mc", 270, 100, 10, "CMMI10"),
+		mathWord("2", 280, 96, 7, "CMR7"),
+		mathWord("+", 290, 100, 10, "CMR10"),
+		mathWord("α", 300, 100, 10, "CMMI10"),
+		mathWord("i", 305, 102, 7, "CMMI7"),
+		mathWord("(3)", 500, 100, 10, "CMR10"),
+	}
+	para := Paragraph{Lines: []Line{{Words: words}}, IsEquation: true}
+	want := `E = mc^{2} + \alpha_{i} \tag{3}`
+	if got := equationLatex(para); got != want {
+		t.Errorf("equationLatex() = %q, want %q", got, want)
+	}
+
+	doc := &Document{Pages: []Page{{Number: 2, Paragraphs: []Paragraph{para}}}}
+	config := DefaultConfig()
+	config.CollapseWhitespace = true
+	if got := doc.ToMarkdown(config); got != "$$\n"+want+"\n$$\n" {
+		t.Errorf("markdown = %q", got)
+	}
+	config.FigureImages = true
+	if got := doc.ToMarkdown(config); !strings.Contains(got, "](#page-2-equation-1)") {
+		t.Errorf("markdown placeholder = %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Package experimental exposes the page-level extraction and table detection
+// heuristics behind pdfmarkdown's converter.
+//
+// These functions operate on intermediate types whose fields and behaviour are
+// still being tuned, so they carry no compatibility promise: signatures and
+// results may change in any release. Code that only needs markdown or the
+// extracted document structure should use the stable API in the pdfmarkdown
+// package (Converter, Config, Document, Page and Table) instead.
+package experimental
+
+import (
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/references"
+
+	"github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// AdaptiveThresholds holds the spacing thresholds used by segment-based table detection.
+type AdaptiveThresholds = pdfmarkdown.AdaptiveThresholds
+
+// ExtractPage extracts all enriched text and structure from a loaded PDF page.
+// pageNumber is 1-indexed and only used to label the result.
+func ExtractPage(instance pdfium.Pdfium, page references.FPDF_PAGE, pageNumber int, config pdfmarkdown.Config) (*pdfmarkdown.Page, error) {
+	return pdfmarkdown.ExtractPage(instance, page, pageNumber, config)
+}
+
+// DetectTables finds tables in a page using word alignment or explicit lines,
+// following pdfplumber's TableFinder strategies.
+func DetectTables(page *pdfmarkdown.Page, settings pdfmarkdown.TableSettings) []pdfmarkdown.Table {
+	return pdfmarkdown.DetectTables(page, settings)
+}
+
+// DetectTablesSegmentBased finds tables without ruling lines using PDF-TREX
+// segment analysis.
+func DetectTablesSegmentBased(page *pdfmarkdown.Page, thresholds AdaptiveThresholds) []pdfmarkdown.Table {
+	return pdfmarkdown.DetectTablesSegmentBased(page, thresholds)
+}
@@ -4,13 +4,55 @@ import (
 	"math"
 
 	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
 	"github.com/klippa-app/go-pdfium/references"
 	"github.com/klippa-app/go-pdfium/requests"
 	"github.com/pkg/errors"
 )
 
-// ExtractPage extracts all enriched text from a PDF page.
+// ExtractPage extracts all enriched text from a PDF page. Without the
+// document, links to other pages are not read.
+//
+// Deprecated: Page-level extraction is not part of the stable API. Use
+// experimental.ExtractPage, or Converter to convert whole documents.
 func ExtractPage(instance pdfium.Pdfium, page references.FPDF_PAGE, pageNumber int, config Config) (*Page, error) {
+	raw, err := readPage(instance, "", page, config, allFeatures())
+	if err != nil {
+		return nil, err
+	}
+	// Without the rest of the document, the page is judged on its own text
+	raw.spaceless = config.AggressiveWordSplitting && charsLackSpaces(raw.chars)
+	return structurePage(raw, pageNumber, config), nil
+}
+
+// rawPage holds everything read from pdfium for one page. It contains no
+// pdfium references, so it can be structured after the page is closed and on
+// a different goroutine from the one driving the instance.
+type rawPage struct {
+	width     float64
+	height    float64
+	chars     []EnrichedChar
+	figures   []Rect
+	figureAlt []string       // Tagged alt text for each figure, "" where there is none
+	lines     []Edge         // Explicit line objects; nil for the prose profile
+	links     []InternalLink // Links to other pages; read with Config.LinkInternalDestinations
+	label     string         // Logical page label, read when the document is known
+	spaceless bool           // The text lacks spaces between words (Config.AggressiveWordSplitting)
+
+	textMismatches []TextMismatch // Text missing from per-character extraction (Config.VerifyText)
+
+	missingFeatures []string // Optional pdfium APIs the page was read without
+
+	cached   *Page         // The page found in Config.PageCache, read in place of the rest
+	cacheKey *PageCacheKey // Where to cache the page once structured; nil when it isn't
+}
+
+// readPage performs all pdfium calls needed for a page: dimensions,
+// characters, figure regions and line objects. Optional text APIs missing
+// from features are not called, and links are only read given the page's
+// document. Measurements are quantized so structuring gives the same result
+// on every platform.
+func readPage(instance pdfium.Pdfium, doc references.FPDF_DOCUMENT, page references.FPDF_PAGE, config Config, features FeatureSet) (*rawPage, error) {
 	// Get page dimensions
 	pageSize, err := instance.FPDF_GetPageWidthF(&requests.FPDF_GetPageWidthF{
 		Page: requests.Page{
@@ -30,6 +72,30 @@ func ExtractPage(instance pdfium.Pdfium, page references.FPDF_PAGE, pageNumber i
 		return nil, errors.Wrap(err, "failed to get page size")
 	}
 
+	rotation, err := instance.FPDFPage_GetRotation(&requests.FPDFPage_GetRotation{
+		Page: requests.Page{
+			ByReference: &page,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get page rotation")
+	}
+
+	// pdfium gives the size of the page as displayed, turned by its /Rotate
+	// entry, but places text and objects on the page as stored. The page is
+	// read as stored and turned once everything is on it
+	turns := int(rotation.PageRotation)
+	width, height := float64(pageSize.PageWidth), float64(pageHeight.PageHeight)
+	if turns%2 == 1 {
+		width, height = height, width
+	}
+
+	raw := &rawPage{
+		width:           width,
+		height:          height,
+		missingFeatures: features.Missing(),
+	}
+
 	// Get MediaBox to handle non-zero origins
 	// For now, assume origin at (0,0) - MediaBox support can be added when needed
 	// Most PDFs have MediaBox starting at origin
@@ -57,101 +123,213 @@ func ExtractPage(instance pdfium.Pdfium, page references.FPDF_PAGE, pageNumber i
 		return nil, errors.Wrap(err, "failed to count characters")
 	}
 
+	// The prose profile never walks the page objects: it goes without figure
+	// regions, text object colors and line objects
+	walkObjects := config.Profile != ProfileProse
+
+	// Locate figures so text flow and reading order can route around them
+	if walkObjects {
+		raw.figures, raw.figureAlt, err = extractFigureRegions(instance, page, raw.width, raw.height)
+		if err != nil {
+			// Non-fatal: continue without figures
+			raw.figures, raw.figureAlt = nil, nil
+		}
+	}
+
+	if config.LinkInternalDestinations && features.Links && doc != "" {
+		raw.links = readInternalLinks(instance, doc, page, raw.height)
+	}
+
 	if charCount.Count == 0 {
-		return &Page{
-			Number:     pageNumber,
-			Width:      float64(pageSize.PageWidth),
-			Height:     float64(pageHeight.PageHeight),
-			Paragraphs: []Paragraph{},
-		}, nil
+		raw = displayedPage(raw, turns)
+		quantizeRawPage(raw)
+		return raw, nil
 	}
 
 	// Extract all characters with metadata
-	chars, err := extractEnrichedChars(instance, textPage.TextPage, charCount.Count, float64(pageHeight.PageHeight))
+	raw.chars, err = extractEnrichedChars(instance, textPage.TextPage, charCount.Count, raw.height, config.SkipInvisibleText, features)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to extract characters")
 	}
+	if config.VerifyText || config.RepairMissingText {
+		raw.chars, raw.textMismatches = verifyChars(instance, textPage.TextPage, charCount.Count, raw.chars, config.RepairMissingText)
+	}
+
+	// Without per-character colors, take each character's color from the
+	// text object it sits in
+	if !features.FillColor && walkObjects {
+		colors := textObjectColors(instance, page, raw.height)
+		for i := range raw.chars {
+			if color, ok := colorAt(colors, raw.chars[i].Box); ok {
+				raw.chars[i].FillColor = color
+			}
+		}
+	}
+
+	if config.SkipInvisibleText {
+		raw.chars = removeInvisibleChars(raw.chars, raw.width, raw.height)
+	}
 
 	// Normalize coordinates by MediaBox origin
-	for i := range chars {
-		chars[i].Box.X0 -= originX
-		chars[i].Box.X1 -= originX
-		chars[i].Box.Y0 -= originY
-		chars[i].Box.Y1 -= originY
+	for i := range raw.chars {
+		raw.chars[i].Box.X0 -= originX
+		raw.chars[i].Box.X1 -= originX
+		raw.chars[i].Box.Y0 -= originY
+		raw.chars[i].Box.Y1 -= originY
+		raw.chars[i].Origin.X -= originX
+		raw.chars[i].Origin.Y -= originY
+	}
+
+	// Extract explicit line objects from the PDF
+	if walkObjects {
+		raw.lines, err = extractLinesFromPage(instance, page, raw.width, raw.height)
+		if err != nil {
+			// Non-fatal: continue without lines
+			raw.lines = []Edge{}
+		}
 	}
 
+	raw = displayedPage(raw, turns)
+	quantizeRawPage(raw)
+	return raw, nil
+}
+
+// structurePage builds words, paragraphs, columns and tables from a page read
+// by readPage. It makes no pdfium calls.
+func structurePage(raw *rawPage, pageNumber int, config Config) *Page {
+	if len(raw.chars) == 0 {
+		return &Page{
+			Number:     pageNumber,
+			Label:      raw.label,
+			Width:      raw.width,
+			Height:     raw.height,
+			Paragraphs: []Paragraph{},
+			Figures:    raw.figures,
+
+			FigureAltText: figureAltTexts(raw.figures, raw.figureAlt, nil),
+			Links:         raw.links,
+		}
+	}
+
+	// Structure pages set mostly sideways as if turned upright, so their
+	// lines, rows and columns are found as on any other page
+	if config.UprightRotatedPages {
+		if turn, ok := dominantQuarterTurn(raw.chars, raw.width, raw.height); ok {
+			page := structurePage(turn.uprightRaw(raw), pageNumber, config)
+			turn.restorePage(page, raw)
+			return page
+		}
+	}
+
+	// Rebuild the empty boxes Type3 and broken fonts give characters
+	chars, rebuiltBoxes := repairCharBoxes(raw.chars)
+
 	// Group characters into words
-	words := groupCharsIntoWords(chars)
+	spaces := measureSpaces(chars)
+	if raw.spaceless {
+		spaces.splitGap = wordGapRatio(chars)
+	}
+	words := groupCharsIntoWords(chars, spaces)
 
 	// Expand ligatures
 	words = expandLigatures(words)
 
+	// Join trademark signs and other superscripts to the word before them
+	words = attachSuperscripts(words)
+
+	// Normalize Unicode, strip invisible formatting characters and join
+	// stacked fractions
+	if config.NormalizeUnicode {
+		words = normalizeWords(words)
+		words = joinStackedFractions(words)
+	}
+
 	// Deduplicate CJK characters
-	words = deduplicateCJKChars(words)
+	diagnostics := Diagnostics{MissingFeatures: raw.missingFeatures, TextMismatches: raw.textMismatches, RebuiltCharBoxes: rebuiltBoxes}
+	for i := range diagnostics.TextMismatches {
+		diagnostics.TextMismatches[i].Page = pageNumber
+	}
+	if config.DeduplicateCJK {
+		words, diagnostics.CJKCharsRemoved = deduplicateCJKChars(words, config.cjkDuplicateWidthRatio())
+	}
 
-	// Build document structure
-	// Note: Word merging based on proximity happens in buildParagraphs after line grouping
-	paragraphs := buildParagraphs(words, float64(pageSize.PageWidth), config)
+	// Repair concatenated words if a splitter is configured
+	words = splitMergedWords(words, config.WordSplitter)
 
-	// Extract explicit line objects from the PDF
-	lines, err := extractLinesFromPage(instance, page, float64(pageSize.PageWidth), float64(pageHeight.PageHeight))
-	if err != nil {
-		// Non-fatal: continue without lines
-		lines = []Edge{}
+	// Join letter-spaced text extracted as a word per letter
+	words = mergeTrackedLetters(words, config.BoldTrackedText)
+
+	// Let callers mask or rewrite text before anything is built from it
+	words = applyTextFilters(words, config.TextFilters)
+
+	// Stacked vertical bar glyphs draw rules rather than text
+	words, glyphRules := extractRuleGlyphEdges(words)
+	lines := append(raw.lines, glyphRules...)
+
+	// Rules through or under runs of words strike them out or underline them
+	markTextDecorations(words, lines)
+
+	// Vertical rules between text columns guide column detection, not tables.
+	// The prose profile leaves columns to the text alone.
+	var columnRules []float64
+	if config.Profile != ProfileProse {
+		classifyColumnSeparators(lines, words, raw.height)
+		columnRules = columnSeparatorPositions(lines)
+	}
+
+	// Group words into lines once; paragraphs and table detection share them
+	// Note: Word merging based on proximity happens in buildTextLines after line grouping
+	textLines := buildTextLines(words, spaces)
+
+	// Build document structure, or fall back to plain text when the layout is
+	// too chaotic for structure detection to be trusted
+	confidence := structureConfidence(textLines)
+	plain := confidence < config.PlainTextBelow
+	var paragraphs []Paragraph
+	if plain {
+		paragraphs = plainParagraphs(textLines, raw.width)
+		diagnostics.PlainTextPages = 1
+	} else {
+		paragraphs = buildParagraphs(textLines, words, raw.width, columnRules, raw.figures, config)
+		if config.DetectBlockquotes {
+			detectBlockquotes(paragraphs, lines)
+		}
 	}
 
 	// Detect columns
-	columns := detectColumns(words, float64(pageSize.PageWidth))
+	columns := detectColumnsWithSeparators(words, raw.width, columnRules)
 
 	// Create page with paragraphs
 	resultPage := &Page{
-		Number:     pageNumber,
-		Width:      float64(pageSize.PageWidth),
-		Height:     float64(pageHeight.PageHeight),
-		Paragraphs: paragraphs,
-		Lines:      lines,
-		Columns:    columns,
+		Number:      pageNumber,
+		Label:       raw.label,
+		Width:       raw.width,
+		Height:      raw.height,
+		Paragraphs:  paragraphs,
+		Lines:       lines,
+		Columns:     columns,
+		Figures:     raw.figures,
+		Diagnostics: diagnostics,
+		textLines:   textLines,
+
+		StructureConfidence: confidence,
+		FigureAltText:       figureAltTexts(raw.figures, raw.figureAlt, paragraphs),
+		Links:               raw.links,
 	}
 
 	// Detect tables if enabled
-	if config.DetectTables {
-		var tables []Table
-
-		// Use segment-based detection (better for tables without ruling lines)
-		if config.UseSegmentBasedTables {
-			// Calculate adaptive thresholds if enabled
-			var thresholds AdaptiveThresholds
-			if config.UseAdaptiveThresholds {
-				thresholds = calculateAdaptiveThresholds(words)
-			} else {
-				// Use default thresholds
-				thresholds = AdaptiveThresholds{
-					HorizontalThreshold: 20.0,
-					VerticalThreshold:   5.0,
-				}
-			}
-
-			// Detect tables using segment-based approach
-			segmentTables := DetectTablesSegmentBased(resultPage, thresholds)
-			tables = append(tables, segmentTables...)
-		}
-
-		// Also use line-based detection (good for tables with ruling lines)
-		if len(lines) > 0 {
-			lineTables := DetectTables(resultPage, config.TableSettings)
-			tables = append(tables, lineTables...)
-		}
-
-		// Deduplicate tables (if both methods found the same table)
-		tables = deduplicateTables(tables)
-
-		resultPage.Tables = tables
+	if config.tablesEnabled() && !plain {
+		resultPage.Tables = detectPageTables(resultPage, config)
+		resultPage.Paragraphs = withoutTableText(resultPage.Paragraphs, resultPage.Tables)
 	}
 
-	return resultPage, nil
+	resultPage.ContentHash = pageContentHash(resultPage)
+
+	return resultPage
 }
 
-// deduplicateTables removes duplicate tables based on bounding box overlap
+// deduplicateTables removes duplicate tables based on bounding box overlap,
+// recording each table removed in the provenance of the one kept.
 func deduplicateTables(tables []Table) []Table {
 	if len(tables) <= 1 {
 		return tables
@@ -161,11 +339,19 @@ func deduplicateTables(tables []Table) []Table {
 	for i, t1 := range tables {
 		isDuplicate := false
 		for j := i + 1; j < len(tables); j++ {
-			t2 := tables[j]
+			t2 := &tables[j]
 
 			// Check if tables have significant overlap (> 70%)
-			overlap := calculateTableOverlap(t1, t2)
+			overlap := calculateTableOverlap(t1, *t2)
 			if overlap > 0.7 {
+				// Record the decision on the table kept, along with any
+				// tables the dropped one had replaced
+				t2.Provenance.Duplicates = append(t2.Provenance.Duplicates, TableDuplicate{
+					Detector: t1.Provenance.Detector,
+					BBox:     t1.BBox,
+					Overlap:  overlap,
+				})
+				t2.Provenance.Duplicates = append(t2.Provenance.Duplicates, t1.Provenance.Duplicates...)
 				isDuplicate = true
 				break
 			}
@@ -210,8 +396,12 @@ func calculateTableOverlap(t1, t2 Table) float64 {
 }
 
 // extractEnrichedChars extracts all characters with their metadata.
-func extractEnrichedChars(instance pdfium.Pdfium, textPage references.FPDF_TEXTPAGE, count int, pageHeight float64) ([]EnrichedChar, error) {
+// Render modes are only read when readRenderMode is set, since it costs two
+// extra pdfium calls per character. Optional APIs missing from features are
+// skipped, leaving their defaults.
+func extractEnrichedChars(instance pdfium.Pdfium, textPage references.FPDF_TEXTPAGE, count int, pageHeight float64, readRenderMode bool, features FeatureSet) ([]EnrichedChar, error) {
 	chars := make([]EnrichedChar, 0, count)
+	renderModes := make(map[references.FPDF_PAGEOBJECT]enums.FPDF_TEXT_RENDERMODE)
 
 	for i := range count {
 		// Get Unicode character
@@ -232,79 +422,103 @@ func extractEnrichedChars(instance pdfium.Pdfium, textPage references.FPDF_TEXTP
 			continue
 		}
 
-		// Convert PDF coordinates (origin bottom-left) to standard (origin top-left)
-		box := Rect{
-			X0: charBox.Left,
-			Y0: pageHeight - charBox.Top,
-			X1: charBox.Right,
-			Y1: pageHeight - charBox.Bottom,
-		}
+		box := PDFRect{Left: charBox.Left, Bottom: charBox.Bottom, Right: charBox.Right, Top: charBox.Top}.ToPage(pageHeight)
 
-		// Get font size
-		fontSize, err := instance.FPDFText_GetFontSize(&requests.FPDFText_GetFontSize{
+		// Get glyph origin, which sits on the true baseline
+		origin, err := instance.FPDFText_GetCharOrigin(&requests.FPDFText_GetCharOrigin{
 			TextPage: textPage,
 			Index:    i,
 		})
-		fontSizeVal := 12.0 // Default
+		var originVal Point
+		hasOrigin := false
 		if err == nil {
-			fontSizeVal = fontSize.FontSize
+			originVal = PDFPoint{X: origin.X, Y: origin.Y}.ToPage(pageHeight)
+			hasOrigin = true
 		}
 
-		// Get font weight
-		fontWeight, err := instance.FPDFText_GetFontWeight(&requests.FPDFText_GetFontWeight{
+		// Get font size
+		fontSize, err := instance.FPDFText_GetFontSize(&requests.FPDFText_GetFontSize{
 			TextPage: textPage,
 			Index:    i,
 		})
-		fontWeightVal := 400 // Default normal weight
+		fontSizeVal := 12.0 // Default
 		if err == nil {
-			fontWeightVal = fontWeight.FontWeight
+			fontSizeVal = fontSize.FontSize
 		}
 
 		// Get font info
-		fontInfo, err := instance.FPDFText_GetFontInfo(&requests.FPDFText_GetFontInfo{
-			TextPage: textPage,
-			Index:    i,
-		})
 		fontNameVal := ""
-		fontFlagsVal := 0
-		if err == nil {
-			fontNameVal = fontInfo.FontName
-			fontFlagsVal = fontInfo.Flags
+		var fontFlagsVal FontFlags
+		if features.FontInfo {
+			fontInfo, err := instance.FPDFText_GetFontInfo(&requests.FPDFText_GetFontInfo{
+				TextPage: textPage,
+				Index:    i,
+			})
+			if err == nil {
+				fontNameVal = fontInfo.FontName
+				fontFlagsVal = FontFlags(fontInfo.Flags)
+			}
+		}
+
+		// Get font weight, inferring it from the font name when unsupported
+		fontWeightVal := 400 // Default normal weight
+		if features.FontWeight {
+			fontWeight, err := instance.FPDFText_GetFontWeight(&requests.FPDFText_GetFontWeight{
+				TextPage: textPage,
+				Index:    i,
+			})
+			if err == nil {
+				fontWeightVal = fontWeight.FontWeight
+			}
+		} else if fontNameVal != "" {
+			fontWeightVal = parseFontName(fontNameVal).Weight
 		}
 
 		// Get fill color
-		fillColor, err := instance.FPDFText_GetFillColor(&requests.FPDFText_GetFillColor{
-			TextPage: textPage,
-			Index:    i,
-		})
 		fillColorVal := RGBA{R: 0, G: 0, B: 0, A: 255} // Default black
-		if err == nil {
-			fillColorVal = RGBA{
-				R: fillColor.R,
-				G: fillColor.G,
-				B: fillColor.B,
-				A: fillColor.A,
+		if features.FillColor {
+			fillColor, err := instance.FPDFText_GetFillColor(&requests.FPDFText_GetFillColor{
+				TextPage: textPage,
+				Index:    i,
+			})
+			if err == nil {
+				fillColorVal = RGBA{
+					R: fillColor.R,
+					G: fillColor.G,
+					B: fillColor.B,
+					A: fillColor.A,
+				}
 			}
 		}
 
 		// Get angle
-		angle, err := instance.FPDFText_GetCharAngle(&requests.FPDFText_GetCharAngle{
-			TextPage: textPage,
-			Index:    i,
-		})
 		angleVal := float32(0)
-		if err == nil {
-			angleVal = angle.CharAngle
+		if features.CharAngle {
+			angle, err := instance.FPDFText_GetCharAngle(&requests.FPDFText_GetCharAngle{
+				TextPage: textPage,
+				Index:    i,
+			})
+			if err == nil {
+				angleVal = angle.CharAngle
+			}
+		}
+
+		// Get render mode, which marks invisible text
+		renderModeVal := enums.FPDF_TEXTRENDERMODE_UNKNOWN
+		if readRenderMode && features.TextObject {
+			renderModeVal = charRenderMode(instance, textPage, i, renderModes)
 		}
 
 		// Check if hyphen
-		isHyphen, err := instance.FPDFText_IsHyphen(&requests.FPDFText_IsHyphen{
-			TextPage: textPage,
-			Index:    i,
-		})
 		isHyphenVal := false
-		if err == nil {
-			isHyphenVal = isHyphen.IsHyphen
+		if features.IsHyphen {
+			isHyphen, err := instance.FPDFText_IsHyphen(&requests.FPDFText_IsHyphen{
+				TextPage: textPage,
+				Index:    i,
+			})
+			if err == nil {
+				isHyphenVal = isHyphen.IsHyphen
+			}
 		}
 
 		chars = append(chars, EnrichedChar{
@@ -317,12 +531,41 @@ func extractEnrichedChars(instance pdfium.Pdfium, textPage references.FPDF_TEXTP
 			FillColor:  fillColorVal,
 			Angle:      angleVal,
 			IsHyphen:   isHyphenVal,
+			Invisible:  renderModeVal == enums.FPDF_TEXTRENDERMODE_INVISIBLE,
+			Origin:     originVal,
+			HasOrigin:  hasOrigin,
 		})
 	}
 
 	return chars, nil
 }
 
+// charRenderMode returns the render mode of the text object a character
+// belongs to, caching modes by object since objects hold many characters.
+func charRenderMode(instance pdfium.Pdfium, textPage references.FPDF_TEXTPAGE, index int, cache map[references.FPDF_PAGEOBJECT]enums.FPDF_TEXT_RENDERMODE) enums.FPDF_TEXT_RENDERMODE {
+	textObj, err := instance.FPDFText_GetTextObject(&requests.FPDFText_GetTextObject{
+		TextPage: textPage,
+		Index:    index,
+	})
+	if err != nil {
+		return enums.FPDF_TEXTRENDERMODE_UNKNOWN
+	}
+
+	if mode, ok := cache[textObj.TextObject]; ok {
+		return mode
+	}
+
+	mode := enums.FPDF_TEXTRENDERMODE_UNKNOWN
+	renderMode, err := instance.FPDFTextObj_GetTextRenderMode(&requests.FPDFTextObj_GetTextRenderMode{
+		PageObject: textObj.TextObject,
+	})
+	if err == nil {
+		mode = renderMode.TextRenderMode
+	}
+	cache[textObj.TextObject] = mode
+	return mode
+}
+
 // groupCharsIntoWords groups characters into words based on spacing.
 // isLowerCase returns true if the rune is a lowercase letter
 func isLowerCase(r rune) bool {
@@ -367,20 +610,59 @@ func calculateAverageCharWidth(chars []EnrichedChar) float64 {
 }
 
 // detectWordBoundaries detects word boundaries for normal horizontal text
-// Uses ONLY explicit whitespace to avoid false positives
-// Visual gap analysis is reserved for rotation-aware detection
-func detectWordBoundaries(chars []EnrichedChar) []int {
+// Uses explicit whitespace, plus gaps at least as wide as a measured space
+// when the page has enough whitespace glyphs to measure one
+func detectWordBoundaries(chars []EnrichedChar, spaces spaceMetrics) []int {
 	if len(chars) <= 1 {
 		return nil
 	}
 
 	var boundaries []int
+	starts := charClusterStarts(chars)
+	clusterBox := chars[0].Box // Extent of the cluster ending at chars[i-1]
 
 	for i := 1; i < len(chars); i++ {
 		curr := chars[i]
 
-		// Only explicit whitespace creates word boundaries
+		// Explicit whitespace creates word boundaries
 		if curr.Text == ' ' || curr.Text == '\t' || curr.Text == '\n' || curr.Text == '\r' {
+			boundaries = append(boundaries, i)
+			clusterBox = curr.Box
+			continue
+		}
+
+		// Combining marks and joined characters never start a word, and gaps
+		// are measured from the whole cluster so an accent drawn over its
+		// letter doesn't look like a space before the next one
+		if !starts[i] {
+			if curr.Box.Width() > 0 || curr.Box.Height() > 0 {
+				clusterBox = mergeRects(clusterBox, curr.Box)
+			}
+			continue
+		}
+
+		prev := chars[i-1]
+		prevBox := clusterBox
+		clusterBox = curr.Box
+		gap := curr.Box.X0 - prevBox.X1
+		overlap := math.Min(prevBox.Y1, curr.Box.Y1) - math.Max(prevBox.Y0, curr.Box.Y0)
+		sameLine := overlap > math.Min(prevBox.Height(), curr.Box.Height())*0.5
+
+		// A gap nearly as wide as this document's own space glyph is a missing space
+		if spaceWidth, ok := spaces.spaceWidth(curr.FontSize); ok && sameLine && gap >= spaceWidth*0.8 {
+			boundaries = append(boundaries, i)
+			continue
+		}
+
+		// Latin text set among CJK characters is often spaced by a gap alone
+		if sameLine && isScriptGap(prev.Text, curr.Text, gap, curr.FontSize) {
+			boundaries = append(boundaries, i)
+			continue
+		}
+
+		// Text set without any spaces is split at wide gaps and at changes of
+		// case, digits and currency, as rotated text is
+		if spaces.splitGap > 0 && sameLine && spacelessBoundary(chars, i, gap, spaces.splitGap) {
 			boundaries = append(boundaries, i)
 			continue
 		}
@@ -394,12 +676,12 @@ func detectWordBoundaries(chars []EnrichedChar) []int {
 		// - Hyphens/periods: "SOA-SF0005-2025" has small gaps that aren't word boundaries
 		// - Email addresses: "user.name@example.com" has dots that aren't boundaries
 		//
-		// Any gap-based threshold creates false positives:
+		// Any fixed gap-based threshold creates false positives:
 		// - Too low: Splits normal words "STATEMENT" → "STAT E M E N T"
 		// - Too high: Misses genuine concatenations
 		//
-		// Solution: Use explicit whitespace for normal text, reserve gap analysis
-		// for rotation-aware detection where whitespace is truly absent.
+		// Solution: Use explicit whitespace for normal text, and only fall back to
+		// gaps measured against the document's real space glyphs (above).
 		//
 		// Visual verification: Check PDF rendering to see if spaces are present.
 		// If the PDF visually shows proper spacing, whitespace chars exist.
@@ -444,7 +726,7 @@ func shouldReverseCharOrder(angle float32) bool {
 }
 
 // detectWordBoundariesRotationAware detects boundaries considering rotation
-func detectWordBoundariesRotationAware(chars []EnrichedChar) []int {
+func detectWordBoundariesRotationAware(chars []EnrichedChar, spaces spaceMetrics) []int {
 	if len(chars) <= 1 {
 		return nil
 	}
@@ -498,41 +780,31 @@ func detectWordBoundariesRotationAware(chars []EnrichedChar) []int {
 		}
 	} else {
 		// For normal text, use X-axis gaps (existing logic)
-		boundaries = detectWordBoundaries(chars)
+		boundaries = detectWordBoundaries(chars, spaces)
 	}
 
 	return boundaries
 }
 
-func groupCharsIntoWords(chars []EnrichedChar) []EnrichedWord {
+func groupCharsIntoWords(chars []EnrichedChar, spaces spaceMetrics) []EnrichedWord {
 	if len(chars) == 0 {
 		return nil
 	}
 
-	// Detect word boundaries BEFORE reversing (on original coordinates)
-	boundaries := detectWordBoundariesRotationAware(chars)
+	// Detect word boundaries BEFORE reversing (on original coordinates),
+	// keeping only those between grapheme clusters
+	starts := charClusterStarts(chars)
+	boundarySet := make(map[int]bool)
+	for _, b := range detectWordBoundariesRotationAware(chars, spaces) {
+		if starts[b] {
+			boundarySet[b] = true
+		}
+	}
 
 	// Check if we need to reverse character order (for 270° rotated text)
 	shouldReverse := len(chars) > 0 && shouldReverseCharOrder(chars[0].Angle)
 	if shouldReverse {
-		// Reverse the chars slice
-		reversed := make([]EnrichedChar, len(chars))
-		for i, char := range chars {
-			reversed[len(chars)-1-i] = char
-		}
-		chars = reversed
-
-		// Reverse the boundary indices too
-		reversedBoundaries := make([]int, len(boundaries))
-		for i, b := range boundaries {
-			reversedBoundaries[i] = len(chars) - b
-		}
-		boundaries = reversedBoundaries
-	}
-
-	boundarySet := make(map[int]bool)
-	for _, b := range boundaries {
-		boundarySet[b] = true
+		chars, boundarySet = reverseClusters(chars, starts, boundarySet)
 	}
 
 	var words []EnrichedWord
@@ -576,24 +848,62 @@ func groupCharsIntoWords(chars []EnrichedChar) []EnrichedWord {
 	return words
 }
 
+// reverseClusters reverses the order of the grapheme clusters in chars,
+// keeping the characters within each cluster in order so combining marks stay
+// after their base, and moves the word boundaries between clusters with them.
+func reverseClusters(chars []EnrichedChar, starts []bool, boundaries map[int]bool) ([]EnrichedChar, map[int]bool) {
+	var clusterStart []int
+	for i, start := range starts {
+		if start {
+			clusterStart = append(clusterStart, i)
+		}
+	}
+	clusterStart = append(clusterStart, len(chars))
+
+	reversed := make([]EnrichedChar, 0, len(chars))
+	reversedBoundaries := make(map[int]bool)
+	for k := len(clusterStart) - 2; k >= 0; k-- {
+		// The cluster after this one in the original order now comes before
+		// it, so the boundary that preceded that cluster now precedes this one
+		if boundaries[clusterStart[k+1]] {
+			reversedBoundaries[len(reversed)] = true
+		}
+		reversed = append(reversed, chars[clusterStart[k]:clusterStart[k+1]]...)
+	}
+	return reversed, reversedBoundaries
+}
+
 // aggregateWord creates an EnrichedWord from a slice of characters.
 func aggregateWord(chars []EnrichedChar, box Rect) EnrichedWord {
 	if len(chars) == 0 {
 		return EnrichedWord{}
 	}
 
-	// Build text
+	// Build text in reading order, with Arabic as letters rather than glyphs
+	chars = logicalChars(chars)
 	var text string
 	for _, char := range chars {
 		text += string(char.Text)
 	}
+	text = unshapeArabic(text)
 
-	// Calculate average font size
+	// Calculate average font size, leaving out superscript marks such as "®"
+	// set smaller than the word they follow
 	var totalFontSize float64
+	var sized int
 	for _, char := range chars {
-		totalFontSize += char.FontSize
+		if !isSuperscriptMark(char.Text) {
+			totalFontSize += char.FontSize
+			sized++
+		}
 	}
-	avgFontSize := totalFontSize / float64(len(chars))
+	if sized == 0 {
+		for _, char := range chars {
+			totalFontSize += char.FontSize
+		}
+		sized = len(chars)
+	}
+	avgFontSize := totalFontSize / float64(sized)
 
 	// Find dominant font weight (most common)
 	weightCounts := make(map[int]int)
@@ -609,10 +919,11 @@ func aggregateWord(chars []EnrichedChar, box Rect) EnrichedWord {
 		}
 	}
 
-	// Find dominant font name
+	// Find dominant font name, counting subsets of one font together
 	fontCounts := make(map[string]int)
 	for _, char := range chars {
-		fontCounts[char.FontName]++
+		_, name := splitSubsetTag(char.FontName)
+		fontCounts[name]++
 	}
 	var dominantFont string
 	maxCount = 0
@@ -626,10 +937,11 @@ func aggregateWord(chars []EnrichedChar, box Rect) EnrichedWord {
 	// Get first char's font flags (usually consistent within a word)
 	fontFlags := chars[0].FontFlags
 
-	// Determine style flags
-	isBold := dominantWeight >= 700
-	isItalic := (fontFlags & 0x40) != 0    // Italic flag from PDF spec
-	isMonospace := (fontFlags & 0x01) != 0 // FixedPitch flag
+	// Determine style flags, from the font descriptor or the font's name
+	font := parseFontName(dominantFont)
+	isBold := dominantWeight >= 700 || font.Weight >= 700
+	isItalic := fontFlags.IsItalic() || font.Italic
+	isMonospace := fontFlags.IsFixedPitch()
 
 	// Calculate average rotation angle
 	var totalAngle float64
@@ -643,18 +955,21 @@ func aggregateWord(chars []EnrichedChar, box Rect) EnrichedWord {
 		Box:         box,
 		FontSize:    avgFontSize,
 		FontWeight:  dominantWeight,
-		FontName:    dominantFont,
+		FontName:    font.Name,
+		Font:        font,
 		FontFlags:   fontFlags,
 		FillColor:   chars[0].FillColor,
 		IsBold:      isBold,
 		IsItalic:    isItalic,
 		IsMonospace: isMonospace,
 		Rotation:    float64(avgAngle) * 180 / 3.14159, // Convert radians to degrees
+
+		TrailingHyphen: chars[len(chars)-1].IsHyphen,
 	}
 
 	// Calculate baseline and x-height
-	word.Baseline = calculateBaseline(word)
-	word.XHeight = calculateXHeight(word)
+	word.Baseline = wordBaseline(chars, word)
+	word.XHeight = wordXHeight(chars, word)
 
 	return word
 }
@@ -727,8 +1042,11 @@ func containsCJK(runes []rune) bool {
 }
 
 // deduplicateCJKChars removes duplicate consecutive CJK characters that appear
-// at nearly identical positions (common rendering artifact in some PDFs)
-func deduplicateCJKChars(words []EnrichedWord) []EnrichedWord {
+// at nearly identical positions (common rendering artifact in some PDFs).
+// widthRatio is the average character width, as a fraction of font size, below
+// which repeats are treated as duplicates. It returns the number of characters removed.
+func deduplicateCJKChars(words []EnrichedWord, widthRatio float64) ([]EnrichedWord, int) {
+	removed := 0
 	for i := range words {
 		word := &words[i]
 		runes := []rune(word.Text)
@@ -755,7 +1073,8 @@ func deduplicateCJKChars(words []EnrichedWord) []EnrichedWord {
 
 				// If this looks like a duplicate (same char, CJK, typical spacing suggests overlap)
 				// Skip it. This heuristic catches cases like "微微软软" -> "微软"
-				if avgCharWidth < word.FontSize*0.7 {
+				if avgCharWidth < word.FontSize*widthRatio {
+					removed++
 					continue
 				}
 			}
@@ -765,5 +1084,5 @@ func deduplicateCJKChars(words []EnrichedWord) []EnrichedWord {
 
 		word.Text = string(deduplicated)
 	}
-	return words
+	return words, removed
 }
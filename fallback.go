@@ -0,0 +1,100 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fallbackMinChars is the fewest characters a page needs for its structure
+// confidence to be judged. Sparse pages give too little evidence either way.
+const fallbackMinChars = 100
+
+// runTogetherWordLength is the length, in characters, beyond which a word in
+// a spaced script is taken to be several words run together.
+const runTogetherWordLength = 25
+
+// structureConfidence estimates, from 0 to 1, how well structure detection can
+// be trusted on a page. Each sign of a layout the heuristics misread lowers
+// it: words set at angles other than the page's main one, words broken into
+// single letters, text run together without word breaks, and lines drawn over
+// other lines.
+func structureConfidence(lines []Line) float64 {
+	var words, chars, fragments, runTogether int
+	angles := make(map[float64]int)
+	for _, line := range lines {
+		for _, word := range line.Words {
+			n := utf8.RuneCountInString(word.Text)
+			first, _ := utf8.DecodeRuneInString(word.Text)
+			words++
+			chars += n
+			angles[normalizeAngle(quantizeAngle(normalizeAngle(word.Rotation), 15))]++
+			if isUnspacedScript(first) {
+				continue
+			}
+			if n == 1 && unicode.IsLetter(first) {
+				fragments++
+			}
+			if n > runTogetherWordLength && !strings.ContainsAny(word.Text, "/@") {
+				runTogether += n
+			}
+		}
+	}
+	if chars < fallbackMinChars {
+		return 1
+	}
+
+	mainAngle := 0
+	for _, count := range angles {
+		mainAngle = max(mainAngle, count)
+	}
+
+	overlapping := 0
+	for i := range lines {
+		for j := range lines {
+			if i != j && linesOverlap(lines[i].Box, lines[j].Box) {
+				overlapping++
+				break
+			}
+		}
+	}
+
+	return (float64(mainAngle) / float64(words)) *
+		(1 - float64(fragments)/float64(words)) *
+		(1 - float64(runTogether)/float64(chars)) *
+		(1 - float64(overlapping)/float64(len(lines)))
+}
+
+// linesOverlap reports whether two line boxes cover more than half of the
+// smaller one, as happens when text is overprinted or lines are misgrouped.
+func linesOverlap(a, b Rect) bool {
+	w := math.Min(a.X1, b.X1) - math.Max(a.X0, b.X0)
+	h := math.Min(a.Y1, b.Y1) - math.Max(a.Y0, b.Y0)
+	if w <= 0 || h <= 0 {
+		return false
+	}
+	smaller := math.Min(a.Width()*a.Height(), b.Width()*b.Height())
+	return smaller > 0 && w*h > smaller/2
+}
+
+// plainParagraphs groups lines into paragraphs in simple top-to-bottom,
+// left-to-right order, without column, heading, list or code detection.
+func plainParagraphs(lines []Line, pageWidth float64) []Paragraph {
+	sorted := append([]Line(nil), lines...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if math.Abs(sorted[i].Box.Y0-sorted[j].Box.Y0) > 1 {
+			return sorted[i].Box.Y0 < sorted[j].Box.Y0
+		}
+		return sorted[i].Box.X0 < sorted[j].Box.X0
+	})
+
+	paragraphs := groupLinesIntoParagraphsAdaptive(sorted, pageWidth, nil)
+	for i := range paragraphs {
+		paragraphs[i].Font = summarizeFont(paragraphs[i].Lines)
+		paragraphs[i].ReadingDirection = textDirection(paragraphs[i].Text())
+	}
+	measureIndents(paragraphs)
+	return paragraphs
+}
@@ -0,0 +1,108 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// wordLine lays out words left to right on a line whose top is at y.
+func wordLine(y float64, angle float64, words ...string) Line {
+	line := Line{Box: Rect{Y0: y, Y1: y + 10}}
+	x := 0.0
+	for _, text := range words {
+		width := float64(len(text)) * 5
+		box := Rect{X0: x, Y0: y, X1: x + width, Y1: y + 10}
+		line.Words = append(line.Words, EnrichedWord{Text: text, Box: box, Rotation: angle, FontSize: 10})
+		x += width + 3
+	}
+	line.Box.X1 = x
+	return line
+}
+
+func TestStructureConfidence(t *testing.T) {
+	prose := strings.Fields("the quick brown fox jumps over the lazy dog and keeps running")
+	var clean []Line
+	for i := 0; i < 5; i++ {
+		clean = append(clean, wordLine(float64(i)*14, 0, prose...))
+	}
+
+	tests := []struct {
+		name  string
+		lines []Line
+		low   bool
+	}{
+		{name: "clean prose", lines: clean},
+		{name: "too little text", lines: []Line{wordLine(0, 90, "x")}},
+		{
+			name: "single letters",
+			lines: []Line{
+				wordLine(0, 0, strings.Split("thequickbrownfoxjumpsoverthelazydog", "")...),
+				wordLine(14, 0, strings.Split("thequickbrownfoxjumpsoverthelazydog", "")...),
+				wordLine(28, 0, strings.Split("thequickbrownfoxjumpsoverthelazydog", "")...),
+			},
+			low: true,
+		},
+		{
+			name: "run together",
+			lines: []Line{
+				wordLine(0, 0, "thequickbrownfoxjumpsoverthelazydog"),
+				wordLine(14, 0, "andkeepsrunninguntilthesungoesdown"),
+				wordLine(28, 0, "whereuponitfinallystopstorestabit"),
+			},
+			low: true,
+		},
+		{
+			name: "mixed angles",
+			lines: []Line{
+				wordLine(0, 0, prose...),
+				wordLine(14, 90, prose...),
+				wordLine(28, 45, prose...),
+			},
+			low: true,
+		},
+		{
+			name: "overprinted lines",
+			lines: []Line{
+				wordLine(0, 0, prose...),
+				wordLine(1, 0, prose...),
+				wordLine(30, 0, prose...),
+				wordLine(31, 0, prose...),
+			},
+			low: true,
+		},
+		{name: "uniformly rotated", lines: []Line{wordLine(0, 90, prose...), wordLine(14, 90, prose...)}},
+		{name: "chinese", lines: []Line{wordLine(0, 0, strings.Split(strings.Repeat("年度报告", 30), "")...)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := structureConfidence(tt.lines)
+			if low := got < 0.5; low != tt.low {
+				t.Errorf("structureConfidence() = %.2f, want low = %v", got, tt.low)
+			}
+		})
+	}
+}
+
+func TestPlainParagraphs(t *testing.T) {
+	// Lines out of order, as column-aware reading order might produce
+	lines := []Line{
+		wordLine(40, 0, "third"),
+		wordLine(0, 0, "first"),
+		wordLine(12, 0, "second"),
+	}
+
+	paragraphs := plainParagraphs(lines, 612)
+	var got []string
+	for _, para := range paragraphs {
+		for _, line := range para.Lines {
+			got = append(got, line.Text())
+		}
+		if para.IsHeading || para.IsList {
+			t.Errorf("plain paragraph %q was given structure", para.Text())
+		}
+	}
+	if want := "first second third"; strings.Join(got, " ") != want {
+		t.Errorf("lines in order %q, want %q", strings.Join(got, " "), want)
+	}
+}
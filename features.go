@@ -0,0 +1,233 @@
+package pdfmarkdown
+
+import (
+	"strings"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/structs"
+)
+
+// FeatureSet records which optional pdfium text APIs an instance supports.
+// Builds without go-pdfium's pdfium_experimental tag lack most of them, and
+// extraction then falls back to other sources or defaults for those values.
+type FeatureSet struct {
+	FontWeight bool // FPDFText_GetFontWeight; otherwise inferred from the font name
+	FontInfo   bool // FPDFText_GetFontInfo: font names and flags
+	FillColor  bool // FPDFText_GetFillColor; otherwise read from the page's text objects
+	CharAngle  bool // FPDFText_GetCharAngle; otherwise all text is horizontal
+	TextObject bool // FPDFText_GetTextObject, needed for Config.SkipInvisibleText
+	IsHyphen   bool // FPDFText_IsHyphen, which sets EnrichedWord.TrailingHyphen
+	Links      bool // FPDFLink_Enumerate and the link destination APIs, needed for Config.LinkInternalDestinations
+}
+
+// allFeatures is the feature set assumed when probing is not possible.
+func allFeatures() FeatureSet {
+	return FeatureSet{
+		FontWeight: true,
+		FontInfo:   true,
+		FillColor:  true,
+		CharAngle:  true,
+		TextObject: true,
+		IsHyphen:   true,
+		Links:      true,
+	}
+}
+
+// Missing lists the names of unsupported features.
+func (f FeatureSet) Missing() []string {
+	var missing []string
+	for _, feature := range []struct {
+		name      string
+		supported bool
+	}{
+		{"FontWeight", f.FontWeight},
+		{"FontInfo", f.FontInfo},
+		{"FillColor", f.FillColor},
+		{"CharAngle", f.CharAngle},
+		{"TextObject", f.TextObject},
+		{"IsHyphen", f.IsHyphen},
+		{"Links", f.Links},
+	} {
+		if !feature.supported {
+			missing = append(missing, feature.name)
+		}
+	}
+	return missing
+}
+
+// probeFeatures draws a one-character page in a scratch document and calls
+// each optional text API on it. If the scratch page cannot be built, every
+// feature is assumed supported and extraction behaves as it always has.
+func probeFeatures(instance pdfium.Pdfium) FeatureSet {
+	if instance == nil {
+		return allFeatures()
+	}
+
+	doc, err := instance.FPDF_CreateNewDocument(&requests.FPDF_CreateNewDocument{})
+	if err != nil {
+		return allFeatures()
+	}
+	defer instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{Document: doc.Document})
+
+	page, err := instance.FPDFPage_New(&requests.FPDFPage_New{Document: doc.Document, Width: 100, Height: 100})
+	if err != nil {
+		return allFeatures()
+	}
+	defer instance.FPDF_ClosePage(&requests.FPDF_ClosePage{Page: page.Page})
+
+	if err := drawProbeText(instance, doc.Document, page.Page); err != nil {
+		return allFeatures()
+	}
+
+	textPage, err := instance.FPDFText_LoadPage(&requests.FPDFText_LoadPage{
+		Page: requests.Page{ByReference: &page.Page},
+	})
+	if err != nil {
+		return allFeatures()
+	}
+	defer instance.FPDFText_ClosePage(&requests.FPDFText_ClosePage{TextPage: textPage.TextPage})
+
+	count, err := instance.FPDFText_CountChars(&requests.FPDFText_CountChars{TextPage: textPage.TextPage})
+	if err != nil || count.Count == 0 {
+		return allFeatures()
+	}
+
+	tp := textPage.TextPage
+	var features FeatureSet
+	_, err = instance.FPDFText_GetFontWeight(&requests.FPDFText_GetFontWeight{TextPage: tp})
+	features.FontWeight = err == nil
+	_, err = instance.FPDFText_GetFontInfo(&requests.FPDFText_GetFontInfo{TextPage: tp})
+	features.FontInfo = err == nil
+	_, err = instance.FPDFText_GetFillColor(&requests.FPDFText_GetFillColor{TextPage: tp})
+	features.FillColor = err == nil
+	_, err = instance.FPDFText_GetCharAngle(&requests.FPDFText_GetCharAngle{TextPage: tp})
+	features.CharAngle = err == nil
+	_, err = instance.FPDFText_GetTextObject(&requests.FPDFText_GetTextObject{TextPage: tp})
+	features.TextObject = err == nil
+	_, err = instance.FPDFText_IsHyphen(&requests.FPDFText_IsHyphen{TextPage: tp})
+	features.IsHyphen = err == nil
+	_, err = instance.FPDFLink_Enumerate(&requests.FPDFLink_Enumerate{Page: requests.Page{ByReference: &page.Page}})
+	features.Links = err == nil
+	return features
+}
+
+// drawProbeText puts a single Helvetica character on page.
+func drawProbeText(instance pdfium.Pdfium, doc references.FPDF_DOCUMENT, page references.FPDF_PAGE) error {
+	obj, err := instance.FPDFPageObj_NewTextObj(&requests.FPDFPageObj_NewTextObj{
+		Document: doc,
+		Font:     "Helvetica",
+		FontSize: 12,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := instance.FPDFText_SetText(&requests.FPDFText_SetText{PageObject: obj.PageObject, Text: "A"}); err != nil {
+		return err
+	}
+	if _, err := instance.FPDFPageObj_Transform(&requests.FPDFPageObj_Transform{
+		PageObject: obj.PageObject,
+		Transform:  structs.FPDF_FS_MATRIX{A: 1, D: 1, E: 10, F: 50},
+	}); err != nil {
+		return err
+	}
+	if _, err := instance.FPDFPage_InsertObject(&requests.FPDFPage_InsertObject{
+		Page:       requests.Page{ByReference: &page},
+		PageObject: obj.PageObject,
+	}); err != nil {
+		return err
+	}
+	_, err = instance.FPDFPage_GenerateContent(&requests.FPDFPage_GenerateContent{
+		Page: requests.Page{ByReference: &page},
+	})
+	return err
+}
+
+// fontWeightFromName infers a weight from style words in a font name, for
+// builds that report font names but not weights.
+func fontWeightFromName(name string) int {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "black") || strings.Contains(name, "heavy"):
+		return 900
+	case strings.Contains(name, "extrabold") || strings.Contains(name, "ultrabold"):
+		return 800
+	case strings.Contains(name, "semibold") || strings.Contains(name, "demibold"):
+		return 600
+	case strings.Contains(name, "bold"):
+		return 700
+	case strings.Contains(name, "medium"):
+		return 500
+	case strings.Contains(name, "light") || strings.Contains(name, "thin"):
+		return 300
+	default:
+		return 400
+	}
+}
+
+// objectColor is the fill color of a text object and where it sits.
+type objectColor struct {
+	box   Rect
+	color RGBA
+}
+
+// textObjectColors reads the fill color of each text object on the page
+// through the page object API, which does not need pdfium_experimental.
+func textObjectColors(instance pdfium.Pdfium, page references.FPDF_PAGE, pageHeight float64) []objectColor {
+	count, err := instance.FPDFPage_CountObjects(&requests.FPDFPage_CountObjects{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return nil
+	}
+
+	var colors []objectColor
+	for i := 0; i < count.Count; i++ {
+		obj, err := instance.FPDFPage_GetObject(&requests.FPDFPage_GetObject{
+			Page:  requests.Page{ByReference: &page},
+			Index: i,
+		})
+		if err != nil {
+			continue
+		}
+		objType, err := instance.FPDFPageObj_GetType(&requests.FPDFPageObj_GetType{PageObject: obj.PageObject})
+		if err != nil || objType.Type != enums.FPDF_PAGEOBJ_TEXT {
+			continue
+		}
+		bounds, err := instance.FPDFPageObj_GetBounds(&requests.FPDFPageObj_GetBounds{PageObject: obj.PageObject})
+		if err != nil {
+			continue
+		}
+		fill, err := instance.FPDFPageObj_GetFillColor(&requests.FPDFPageObj_GetFillColor{PageObject: obj.PageObject})
+		if err != nil {
+			continue
+		}
+		colors = append(colors, objectColor{
+			box:   pdfBounds(bounds.Left, bounds.Bottom, bounds.Right, bounds.Top).ToPage(pageHeight),
+			color: RGBA{R: fill.FillColor.R, G: fill.FillColor.G, B: fill.FillColor.B, A: fill.FillColor.A},
+		})
+	}
+	return colors
+}
+
+// colorAt returns the color of the smallest text object containing the center
+// of box, since nested and overlapping objects are common.
+func colorAt(colors []objectColor, box Rect) (RGBA, bool) {
+	x, y := box.CenterX(), box.CenterY()
+	var best *objectColor
+	for i := range colors {
+		c := &colors[i]
+		if x < c.box.X0 || x > c.box.X1 || y < c.box.Y0 || y > c.box.Y1 {
+			continue
+		}
+		if best == nil || c.box.Width()*c.box.Height() < best.box.Width()*best.box.Height() {
+			best = c
+		}
+	}
+	if best == nil {
+		return RGBA{}, false
+	}
+	return best.color, true
+}
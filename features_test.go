@@ -0,0 +1,84 @@
+package pdfmarkdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFeatureSet_Missing(t *testing.T) {
+	if missing := allFeatures().Missing(); len(missing) != 0 {
+		t.Errorf("allFeatures().Missing() = %v, want none", missing)
+	}
+
+	features := allFeatures()
+	features.FillColor = false
+	features.IsHyphen = false
+	if got, want := features.Missing(), []string{"FillColor", "IsHyphen"}; !slices.Equal(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+}
+
+func TestFontWeightFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+	}{
+		{name: "Helvetica", want: 400},
+		{name: "Helvetica-Bold", want: 700},
+		{name: "ABCDEF+OpenSans-SemiBold", want: 600},
+		{name: "Roboto-ExtraBold", want: 800},
+		{name: "Arial Black", want: 900},
+		{name: "Inter-Medium", want: 500},
+		{name: "SourceSansPro-Light", want: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fontWeightFromName(tt.name); got != tt.want {
+				t.Errorf("fontWeightFromName(%q) = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorAt(t *testing.T) {
+	red := RGBA{R: 255, A: 255}
+	blue := RGBA{B: 255, A: 255}
+	colors := []objectColor{
+		{box: Rect{X0: 0, Y0: 0, X1: 500, Y1: 100}, color: red},
+		{box: Rect{X0: 100, Y0: 10, X1: 150, Y1: 22}, color: blue},
+	}
+
+	tests := []struct {
+		name   string
+		box    Rect
+		want   RGBA
+		wantOK bool
+	}{
+		{name: "inside outer object only", box: Rect{X0: 10, Y0: 10, X1: 16, Y1: 22}, want: red, wantOK: true},
+		{name: "smallest object wins", box: Rect{X0: 110, Y0: 10, X1: 116, Y1: 22}, want: blue, wantOK: true},
+		{name: "outside every object", box: Rect{X0: 10, Y0: 200, X1: 16, Y1: 212}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := colorAt(colors, tt.box)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("colorAt() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDiagnostics_AddMissingFeatures(t *testing.T) {
+	var total Diagnostics
+	total.add(Diagnostics{CJKCharsRemoved: 2, MissingFeatures: []string{"FillColor"}})
+	total.add(Diagnostics{CJKCharsRemoved: 1, MissingFeatures: []string{"FillColor", "IsHyphen"}})
+
+	if total.CJKCharsRemoved != 3 {
+		t.Errorf("CJKCharsRemoved = %d, want 3", total.CJKCharsRemoved)
+	}
+	if want := []string{"FillColor", "IsHyphen"}; !slices.Equal(total.MissingFeatures, want) {
+		t.Errorf("MissingFeatures = %v, want %v", total.MissingFeatures, want)
+	}
+}
@@ -0,0 +1,137 @@
+package pdfmarkdown
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// captionPattern matches the label that starts a figure caption, such as
+// "Figure 3:", "Fig. 2" or "Chart".
+var captionPattern = regexp.MustCompile(`(?i)^(figure|fig\.|image|photo|chart|diagram|illustration|plate|exhibit)(\s|\d|$)`)
+
+// captionMaxGap is the furthest, in multiples of the caption's font size, a
+// caption may sit from its figure.
+const captionMaxGap = 3.0
+
+// figureAltTexts chooses alt text for each figure on a page. Alt text tagged
+// in the PDF is used as is; otherwise a caption paragraph directly below the
+// figure, or failing that above it, describes it; otherwise the nearest
+// heading above the figure names the section it illustrates. Figures with
+// none of these get "".
+func figureAltTexts(figures []Rect, tagged []string, paragraphs []Paragraph) []string {
+	if len(figures) == 0 {
+		return nil
+	}
+
+	alts := make([]string, len(figures))
+	for i, fig := range figures {
+		if i < len(tagged) && tagged[i] != "" {
+			alts[i] = tagged[i]
+			continue
+		}
+		if caption, ok := figureCaption(fig, paragraphs); ok {
+			alts[i] = caption
+			continue
+		}
+		alts[i] = headingAbove(fig, paragraphs)
+	}
+	return alts
+}
+
+// figureCaption finds a caption paragraph next to a figure, preferring one
+// below it, and returns its text.
+func figureCaption(fig Rect, paragraphs []Paragraph) (string, bool) {
+	best, bestGap, bestBelow := -1, math.Inf(1), false
+	for i, para := range paragraphs {
+		text := strings.TrimSpace(para.Text())
+		if !captionPattern.MatchString(text) {
+			continue
+		}
+		if para.Box.X1 <= fig.X0 || para.Box.X0 >= fig.X1 {
+			continue
+		}
+
+		below := para.Box.Y0 >= fig.CenterY()
+		gap := para.Box.Y0 - fig.Y1
+		if !below {
+			gap = fig.Y0 - para.Box.Y1
+		}
+		if gap > captionMaxGap*paragraphFontSize(para) {
+			continue
+		}
+		if (below && !bestBelow) || (below == bestBelow && gap < bestGap) {
+			best, bestGap, bestBelow = i, gap, below
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return strings.Join(strings.Fields(paragraphs[best].Text()), " "), true
+}
+
+// headingAbove returns the text of the closest heading ending above a figure.
+func headingAbove(fig Rect, paragraphs []Paragraph) string {
+	text, bestY := "", math.Inf(-1)
+	for _, para := range paragraphs {
+		if para.IsHeading && para.Box.Y1 <= fig.Y0 && para.Box.Y1 > bestY {
+			text, bestY = strings.Join(strings.Fields(para.Text()), " "), para.Box.Y1
+		}
+	}
+	return text
+}
+
+// paragraphFontSize returns a paragraph's font size, falling back to 12pt
+// when none was recorded.
+func paragraphFontSize(para Paragraph) float64 {
+	if para.Font.Size > 0 {
+		return para.Font.Size
+	}
+	return 12
+}
+
+// writeFigureImages writes markdown images for the figures not yet placed
+// that sit above para and overlap it horizontally, so each figure appears
+// before the first text below it. A nil para writes every remaining figure.
+func writeFigureImages(md *markdown.Markdown, page Page, placed []bool, para *Paragraph) {
+	for _, i := range placeFigures(page, placed, para) {
+		alt := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(figureAlt(page, i))
+		md.PlainText(markdown.Image(alt, figureTarget(page, i))).LF()
+	}
+}
+
+// placeFigures marks the figures not yet placed that sit above para and
+// overlap it horizontally as placed, returning their indices. A nil para
+// places every remaining figure.
+func placeFigures(page Page, placed []bool, para *Paragraph) []int {
+	var indices []int
+	for i, fig := range page.Figures {
+		if placed[i] {
+			continue
+		}
+		if para != nil && (fig.CenterY() > para.Box.Y0 || fig.X1 <= para.Box.X0 || fig.X0 >= para.Box.X1) {
+			continue
+		}
+		placed[i] = true
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// figureAlt returns the alt text for a figure placeholder: its entry in
+// Page.FigureAltText, or "Figure N" where none was found.
+func figureAlt(page Page, i int) string {
+	if i < len(page.FigureAltText) && page.FigureAltText[i] != "" {
+		return page.FigureAltText[i]
+	}
+	return "Figure " + strconv.Itoa(i+1)
+}
+
+// figureTarget returns the placeholder link target for a figure.
+func figureTarget(page Page, i int) string {
+	return fmt.Sprintf("#page-%d-figure-%d", page.Number, i+1)
+}
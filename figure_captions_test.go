@@ -0,0 +1,109 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFigureAltTexts(t *testing.T) {
+	figure := Rect{X0: 100, Y0: 200, X1: 400, Y1: 400}
+
+	heading := placedParagraph("Quarterly Results", 100, 150)
+	heading.IsHeading = true
+
+	tests := []struct {
+		name       string
+		tagged     []string
+		paragraphs []Paragraph
+		want       string
+	}{
+		{
+			name:       "tagged alt text wins",
+			tagged:     []string{"Bar chart of revenue"},
+			paragraphs: []Paragraph{heading, placedParagraph("Figure 1: Revenue by quarter", 100, 410)},
+			want:       "Bar chart of revenue",
+		},
+		{
+			name:       "caption below",
+			paragraphs: []Paragraph{heading, placedParagraph("Figure 1: Revenue by quarter", 100, 410)},
+			want:       "Figure 1: Revenue by quarter",
+		},
+		{
+			name:       "caption above",
+			paragraphs: []Paragraph{placedParagraph("Fig. 2 Sales regions", 100, 180)},
+			want:       "Fig. 2 Sales regions",
+		},
+		{
+			name: "caption below preferred over caption above",
+			paragraphs: []Paragraph{
+				placedParagraph("Figure 1 Costs", 100, 180),
+				placedParagraph("Figure 2 Revenue", 100, 410),
+			},
+			want: "Figure 2 Revenue",
+		},
+		{
+			name:       "distant caption ignored in favour of heading",
+			paragraphs: []Paragraph{heading, placedParagraph("Figure 1 Revenue", 100, 500)},
+			want:       "Quarterly Results",
+		},
+		{
+			name:       "caption beside figure ignored",
+			paragraphs: []Paragraph{placedParagraph("Figure 1 Revenue", 450, 410)},
+			want:       "",
+		},
+		{
+			name:       "body text is not a caption",
+			paragraphs: []Paragraph{placedParagraph("Figures for the year were strong", 100, 410)},
+			want:       "",
+		},
+		{
+			name:       "heading below figure ignored",
+			paragraphs: []Paragraph{func() Paragraph { p := placedParagraph("Outlook", 100, 450); p.IsHeading = true; return p }()},
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := figureAltTexts([]Rect{figure}, tt.tagged, tt.paragraphs)
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("figureAltTexts() = %q, want [%q]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentToMarkdown_FigureImages(t *testing.T) {
+	doc := &Document{Pages: []Page{{
+		Number: 3,
+		Width:  600,
+		Height: 800,
+		Paragraphs: []Paragraph{
+			placedParagraph("Intro text", 100, 100),
+			placedParagraph("Closing text", 100, 500),
+		},
+		Figures:       []Rect{{X0: 100, Y0: 200, X1: 400, Y1: 400}, {X0: 100, Y0: 600, X1: 400, Y1: 700}},
+		FigureAltText: []string{"Chart [draft]", ""},
+	}}}
+
+	config := DefaultConfig()
+	if got := doc.ToMarkdown(config); strings.Contains(got, "![") {
+		t.Errorf("figures rendered without FigureImages:\n%s", got)
+	}
+
+	config.FigureImages = true
+	got := doc.ToMarkdown(config)
+
+	order := []string{"Intro text", `![Chart \[draft\]](#page-3-figure-1)`, "Closing text", "![Figure 2](#page-3-figure-2)"}
+	last := -1
+	for _, want := range order {
+		idx := strings.Index(got, want)
+		if idx < 0 {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+		if idx < last {
+			t.Errorf("%q out of order:\n%s", want, got)
+		}
+		last = idx
+	}
+}
@@ -0,0 +1,218 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+)
+
+// extractFigureRegions finds the bounding boxes of image objects on a page,
+// along with the alt text tagged PDFs attach to them, or "" where there is
+// none. Image data is never decoded; only the placement is read. Tiny images
+// (bullets, spacers) and page-sized backgrounds are ignored since neither
+// interrupts the text flow.
+func extractFigureRegions(instance pdfium.Pdfium, page references.FPDF_PAGE, pageWidth, pageHeight float64) ([]Rect, []string, error) {
+	const minFigureSize = 8.0      // Smaller images are decorations, not figures
+	const maxFigureAreaRatio = 0.8 // Larger images are page backgrounds or scans
+
+	countResp, err := instance.FPDFPage_CountObjects(&requests.FPDFPage_CountObjects{
+		Page: requests.Page{
+			ByReference: &page,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tagged := taggedAltTexts(instance, page)
+
+	var figures []Rect
+	var altTexts []string
+	for i := 0; i < countResp.Count; i++ {
+		objResp, err := instance.FPDFPage_GetObject(&requests.FPDFPage_GetObject{
+			Page: requests.Page{
+				ByReference: &page,
+			},
+			Index: i,
+		})
+		if err != nil {
+			continue
+		}
+
+		typeResp, err := instance.FPDFPageObj_GetType(&requests.FPDFPageObj_GetType{
+			PageObject: objResp.PageObject,
+		})
+		if err != nil || typeResp.Type != enums.FPDF_PAGEOBJ_IMAGE {
+			continue
+		}
+
+		boundsResp, err := instance.FPDFPageObj_GetBounds(&requests.FPDFPageObj_GetBounds{
+			PageObject: objResp.PageObject,
+		})
+		if err != nil {
+			continue
+		}
+
+		box := pdfBounds(boundsResp.Left, boundsResp.Bottom, boundsResp.Right, boundsResp.Top).ToPage(pageHeight)
+
+		if box.Width() < minFigureSize || box.Height() < minFigureSize {
+			continue
+		}
+		if box.Width()*box.Height() > pageWidth*pageHeight*maxFigureAreaRatio {
+			continue
+		}
+
+		alt := ""
+		if len(tagged) > 0 {
+			if mcid, err := instance.FPDFPageObj_GetMarkedContentID(&requests.FPDFPageObj_GetMarkedContentID{
+				PageObject: objResp.PageObject,
+			}); err == nil && mcid.MarkedContentID >= 0 {
+				alt = tagged[mcid.MarkedContentID]
+			}
+		}
+
+		figures = append(figures, box)
+		altTexts = append(altTexts, alt)
+	}
+
+	return figures, altTexts, nil
+}
+
+// taggedAltTexts reads the alt text of a tagged page's structure elements,
+// keyed by the marked content IDs the elements cover. Untagged pages, and
+// pdfium builds without structure tree support, give an empty map.
+func taggedAltTexts(instance pdfium.Pdfium, page references.FPDF_PAGE) map[int]string {
+	alts := make(map[int]string)
+
+	tree, err := instance.FPDF_StructTree_GetForPage(&requests.FPDF_StructTree_GetForPage{
+		Page: requests.Page{
+			ByReference: &page,
+		},
+	})
+	if err != nil {
+		return alts
+	}
+	defer instance.FPDF_StructTree_Close(&requests.FPDF_StructTree_Close{StructTree: tree.StructTree})
+
+	var visit func(element references.FPDF_STRUCTELEMENT, alt string)
+	visit = func(element references.FPDF_STRUCTELEMENT, alt string) {
+		// Alt text covers everything beneath the element that carries it
+		if resp, err := instance.FPDF_StructElement_GetAltText(&requests.FPDF_StructElement_GetAltText{
+			StructElement: element,
+		}); err == nil && strings.TrimSpace(resp.AltText) != "" {
+			alt = strings.TrimSpace(resp.AltText)
+		}
+
+		if alt != "" {
+			if count, err := instance.FPDF_StructElement_GetMarkedContentIdCount(&requests.FPDF_StructElement_GetMarkedContentIdCount{
+				StructElement: element,
+			}); err == nil {
+				for i := 0; i < count.Count; i++ {
+					mcid, err := instance.FPDF_StructElement_GetMarkedContentIdAtIndex(&requests.FPDF_StructElement_GetMarkedContentIdAtIndex{
+						StructElement: element,
+						Index:         i,
+					})
+					if err == nil && mcid.MarkedContentID >= 0 {
+						alts[mcid.MarkedContentID] = alt
+					}
+				}
+			}
+		}
+
+		children, err := instance.FPDF_StructElement_CountChildren(&requests.FPDF_StructElement_CountChildren{
+			StructElement: element,
+		})
+		if err != nil {
+			return
+		}
+		for i := 0; i < children.Count; i++ {
+			child, err := instance.FPDF_StructElement_GetChildAtIndex(&requests.FPDF_StructElement_GetChildAtIndex{
+				StructElement: element,
+				Index:         i,
+			})
+			if err == nil {
+				visit(child.StructElement, alt)
+			}
+		}
+	}
+
+	roots, err := instance.FPDF_StructTree_CountChildren(&requests.FPDF_StructTree_CountChildren{
+		StructTree: tree.StructTree,
+	})
+	if err != nil {
+		return alts
+	}
+	for i := 0; i < roots.Count; i++ {
+		root, err := instance.FPDF_StructTree_GetChildAtIndex(&requests.FPDF_StructTree_GetChildAtIndex{
+			StructTree: tree.StructTree,
+			Index:      i,
+		})
+		if err == nil {
+			visit(root.StructElement, "")
+		}
+	}
+
+	return alts
+}
+
+// figureBetween reports whether a figure sits in the vertical gap between two
+// lines and overlaps them horizontally, so the lines must not join one paragraph.
+func figureBetween(above, below Line, figures []Rect) bool {
+	x0 := math.Min(above.Box.X0, below.Box.X0)
+	x1 := math.Max(above.Box.X1, below.Box.X1)
+
+	for _, fig := range figures {
+		if fig.X1 <= x0 || fig.X0 >= x1 {
+			continue
+		}
+		if fig.CenterY() > above.Box.Y1 && fig.CenterY() < below.Box.Y0 {
+			return true
+		}
+	}
+	return false
+}
+
+// determineReadingOrderWithFigures orders paragraphs with figures as obstacles.
+// A figure spanning more than one column divides the page into bands: all
+// columns above it are read before any text below it, instead of reading each
+// column top to bottom straight past the figure.
+func determineReadingOrderWithFigures(paragraphs []Paragraph, columns []Column, figures []Rect) []Paragraph {
+	var cuts []float64
+	for _, fig := range figures {
+		if columnsSpanned(fig, columns) > 1 {
+			cuts = append(cuts, fig.CenterY())
+		}
+	}
+	if len(cuts) == 0 {
+		return determineReadingOrder(paragraphs, columns)
+	}
+	sort.Float64s(cuts)
+
+	bands := make([][]Paragraph, len(cuts)+1)
+	for _, para := range paragraphs {
+		band := sort.SearchFloat64s(cuts, para.Box.CenterY())
+		bands[band] = append(bands[band], para)
+	}
+
+	ordered := make([]Paragraph, 0, len(paragraphs))
+	for _, band := range bands {
+		ordered = append(ordered, determineReadingOrder(band, columns)...)
+	}
+	return ordered
+}
+
+// columnsSpanned counts the columns a region horizontally overlaps.
+func columnsSpanned(region Rect, columns []Column) int {
+	count := 0
+	for _, col := range columns {
+		if region.X0 < col.Box.X1 && region.X1 > col.Box.X0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,79 @@
+package pdfmarkdown
+
+import "testing"
+
+func textLine(text string, x0, y0, x1 float64) Line {
+	word := EnrichedWord{Text: text, Box: Rect{X0: x0, Y0: y0, X1: x1, Y1: y0 + 10}, FontSize: 10}
+	return Line{Words: []EnrichedWord{word}, Box: word.Box}
+}
+
+func TestGroupLinesIntoParagraphs_FigureBreaksParagraph(t *testing.T) {
+	// Tight line spacing that would normally form a single paragraph
+	lines := []Line{
+		textLine("above", 50, 100, 300),
+		textLine("below", 50, 112, 300),
+	}
+
+	if got := groupLinesIntoParagraphsAdaptive(lines, 612, nil); len(got) != 1 {
+		t.Fatalf("without figures got %d paragraphs, want 1", len(got))
+	}
+
+	figure := Rect{X0: 100, Y0: 110, X1: 250, Y1: 112}
+	if got := groupLinesIntoParagraphsAdaptive(lines, 612, []Rect{figure}); len(got) != 2 {
+		t.Errorf("with figure between lines got %d paragraphs, want 2", len(got))
+	}
+
+	// A figure beside the text does not separate the lines
+	aside := Rect{X0: 400, Y0: 105, X1: 550, Y1: 115}
+	if got := groupLinesIntoParagraphsAdaptive(lines, 612, []Rect{aside}); len(got) != 1 {
+		t.Errorf("with figure beside lines got %d paragraphs, want 1", len(got))
+	}
+}
+
+func TestDetermineReadingOrderWithFigures(t *testing.T) {
+	columns := []Column{
+		{Box: Rect{X0: 0, X1: 306}},
+		{Box: Rect{X0: 306, X1: 612}},
+	}
+	paragraphs := []Paragraph{
+		{Lines: []Line{textLine("left top", 50, 100, 280)}, Box: Rect{X0: 50, Y0: 100, X1: 280, Y1: 200}},
+		{Lines: []Line{textLine("left bottom", 50, 500, 280)}, Box: Rect{X0: 50, Y0: 500, X1: 280, Y1: 600}},
+		{Lines: []Line{textLine("right top", 330, 100, 560)}, Box: Rect{X0: 330, Y0: 100, X1: 560, Y1: 200}},
+		{Lines: []Line{textLine("right bottom", 330, 500, 560)}, Box: Rect{X0: 330, Y0: 500, X1: 560, Y1: 600}},
+	}
+
+	tests := []struct {
+		name    string
+		figures []Rect
+		want    []string
+	}{
+		{
+			name: "no figures reads column by column",
+			want: []string{"left top", "left bottom", "right top", "right bottom"},
+		},
+		{
+			name:    "spanning figure reads above before below",
+			figures: []Rect{{X0: 50, Y0: 250, X1: 560, Y1: 450}},
+			want:    []string{"left top", "right top", "left bottom", "right bottom"},
+		},
+		{
+			name:    "figure within one column keeps column order",
+			figures: []Rect{{X0: 50, Y0: 250, X1: 280, Y1: 450}},
+			want:    []string{"left top", "left bottom", "right top", "right bottom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered := determineReadingOrderWithFigures(paragraphs, columns, tt.figures)
+			if len(ordered) != len(tt.want) {
+				t.Fatalf("got %d paragraphs, want %d", len(ordered), len(tt.want))
+			}
+			for i, para := range ordered {
+				if para.Text() != tt.want[i] {
+					t.Errorf("ordered[%d] = %q, want %q", i, para.Text(), tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pdfmarkdown
+
+import "strings"
+
+// FontFlags are the flags of a PDF font descriptor (PDF 32000-1:2008, table
+// 123), describing the style of the font a character is drawn in.
+type FontFlags int
+
+const (
+	FontFixedPitch  FontFlags = 1 << 0  // Every glyph has the same width
+	FontSerif       FontFlags = 1 << 1  // Glyphs have serifs
+	FontSymbolic    FontFlags = 1 << 2  // Uses characters outside the standard Latin set
+	FontScript      FontFlags = 1 << 3  // Glyphs resemble cursive handwriting
+	FontNonsymbolic FontFlags = 1 << 5  // Uses only the standard Latin set
+	FontItalic      FontFlags = 1 << 6  // Glyphs slant
+	FontAllCap      FontFlags = 1 << 16 // No lowercase letters
+	FontSmallCap    FontFlags = 1 << 17 // Lowercase letters are small capitals
+	FontForceBold   FontFlags = 1 << 18 // Glyphs are emboldened at small sizes
+)
+
+// fontFlagNames names each flag in the order Names lists them.
+var fontFlagNames = []struct {
+	flag FontFlags
+	name string
+}{
+	{FontFixedPitch, "fixed_pitch"},
+	{FontSerif, "serif"},
+	{FontSymbolic, "symbolic"},
+	{FontScript, "script"},
+	{FontNonsymbolic, "nonsymbolic"},
+	{FontItalic, "italic"},
+	{FontAllCap, "all_cap"},
+	{FontSmallCap, "small_cap"},
+	{FontForceBold, "force_bold"},
+}
+
+// Has reports whether every flag in flag is set.
+func (f FontFlags) Has(flag FontFlags) bool {
+	return f&flag == flag
+}
+
+// IsFixedPitch reports whether the font is monospaced.
+func (f FontFlags) IsFixedPitch() bool { return f.Has(FontFixedPitch) }
+
+// IsSerif reports whether the font has serifs.
+func (f FontFlags) IsSerif() bool { return f.Has(FontSerif) }
+
+// IsSymbolic reports whether the font uses characters outside the standard
+// Latin set, as symbol and dingbat fonts do.
+func (f FontFlags) IsSymbolic() bool { return f.Has(FontSymbolic) }
+
+// IsScript reports whether the font resembles handwriting.
+func (f FontFlags) IsScript() bool { return f.Has(FontScript) }
+
+// IsItalic reports whether the font is italic or oblique.
+func (f FontFlags) IsItalic() bool { return f.Has(FontItalic) }
+
+// IsAllCap reports whether the font has only capital letters.
+func (f FontFlags) IsAllCap() bool { return f.Has(FontAllCap) }
+
+// IsSmallCap reports whether the font sets lowercase letters as small capitals.
+func (f FontFlags) IsSmallCap() bool { return f.Has(FontSmallCap) }
+
+// IsForceBold reports whether glyphs are emboldened at small sizes.
+func (f FontFlags) IsForceBold() bool { return f.Has(FontForceBold) }
+
+// Names returns the names of the flags set, such as "serif" and "italic",
+// leaving out bits the PDF specification doesn't define.
+func (f FontFlags) Names() []string {
+	var names []string
+	for _, n := range fontFlagNames {
+		if f.Has(n.flag) {
+			names = append(names, n.name)
+		}
+	}
+	return names
+}
+
+// String returns the names of the flags set joined by "|", or "none".
+func (f FontFlags) String() string {
+	names := f.Names()
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
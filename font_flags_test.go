@@ -0,0 +1,25 @@
+package pdfmarkdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFontFlags(t *testing.T) {
+	flags := FontSerif | FontItalic | FontForceBold | 1<<10 // Bit 11 is undefined
+	if !flags.IsSerif() || !flags.IsItalic() || !flags.IsForceBold() {
+		t.Errorf("%v: set flags not reported", flags)
+	}
+	if flags.IsFixedPitch() || flags.IsScript() || flags.Has(FontSerif|FontFixedPitch) {
+		t.Errorf("%v: unset flags reported", flags)
+	}
+	if got, want := flags.Names(), []string{"serif", "italic", "force_bold"}; !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	if got := flags.String(); got != "serif|italic|force_bold" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := FontFlags(0).String(); got != "none" {
+		t.Errorf("String() of no flags = %q", got)
+	}
+}
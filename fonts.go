@@ -0,0 +1,98 @@
+package pdfmarkdown
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// summarizeFont finds the dominant font name, size, weight and color across
+// the lines, weighting each word by its character count.
+func summarizeFont(lines []Line) FontSummary {
+	names := make(map[string]int)
+	sizes := make(map[float64]int)
+	weights := make(map[int]int)
+	colors := make(map[RGBA]int)
+
+	for _, line := range lines {
+		for _, word := range line.Words {
+			n := utf8.RuneCountInString(word.Text)
+			_, name := splitSubsetTag(word.FontName)
+			names[name] += n
+			// Round sizes so averaging noise doesn't split one size into many
+			sizes[math.Round(word.FontSize*10)/10] += n
+			weights[word.FontWeight] += n
+			colors[word.FillColor] += n
+		}
+	}
+
+	summary := FontSummary{
+		Name:   dominant(names),
+		Size:   dominant(sizes),
+		Weight: dominant(weights),
+		Color:  dominant(colors),
+	}
+	summary.Family = fontFamily(summary.Name)
+	return summary
+}
+
+// dominant returns the key with the highest count. Ties are broken by the
+// smallest key in string form so results are deterministic.
+func dominant[K comparable](counts map[K]int) K {
+	var best K
+	bestCount := -1
+	var bestKey string
+	for key, count := range counts {
+		keyStr := fmt.Sprint(key)
+		if count > bestCount || (count == bestCount && keyStr < bestKey) {
+			best, bestCount, bestKey = key, count, keyStr
+		}
+	}
+	return best
+}
+
+// fontFamily strips the subset tag ("ABCDEF+") and style suffix ("-Bold",
+// ",Italic") from a PDF font name, leaving the family.
+func fontFamily(name string) string {
+	_, name = splitSubsetTag(name)
+	if i := strings.IndexAny(name, "-,"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// splitSubsetTag splits the tag of an embedded font subset, six capital
+// letters and a plus sign, from the font's name. The tag is "" for names
+// without one.
+func splitSubsetTag(name string) (tag, rest string) {
+	if len(name) < 7 || name[6] != '+' {
+		return "", name
+	}
+	for i := range 6 {
+		if name[i] < 'A' || name[i] > 'Z' {
+			return "", name
+		}
+	}
+	return name[:6], name[7:]
+}
+
+// parseFontName parses a PDF font name such as "ABCDEF+Helvetica-BoldOblique"
+// or "TimesNewRoman,Italic" into its subset tag, family, weight and style.
+// The weight and style come from the suffix after the family when there is
+// one, so family names like "Blackadder" don't read as weights.
+func parseFontName(name string) FontInfo {
+	tag, rest := splitSubsetTag(name)
+	info := FontInfo{Name: rest, Family: fontFamily(rest), Subset: tag}
+
+	style := rest
+	if i := strings.IndexAny(rest, "-,"); i > 0 {
+		style = rest[i+1:]
+	}
+	info.Weight = fontWeightFromName(style)
+
+	lower := strings.ToLower(style)
+	info.Italic = strings.Contains(lower, "italic") || strings.Contains(lower, "oblique") ||
+		(style != rest && strings.HasSuffix(strings.TrimSuffix(style, "MT"), "It"))
+	return info
+}
@@ -0,0 +1,84 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestSummarizeFont(t *testing.T) {
+	black := RGBA{A: 255}
+	red := RGBA{R: 255, A: 255}
+	lines := []Line{
+		{Words: []EnrichedWord{
+			{Text: "Quarterly", FontName: "ABCDEF+Helvetica-Bold", FontSize: 12.02, FontWeight: 700, FillColor: red},
+			{Text: "revenue", FontName: "ABCDEF+Helvetica", FontSize: 10, FontWeight: 400, FillColor: black},
+		}},
+		{Words: []EnrichedWord{
+			{Text: "increased", FontName: "ABCDEF+Helvetica", FontSize: 10, FontWeight: 400, FillColor: black},
+		}},
+	}
+
+	got := summarizeFont(lines)
+	want := FontSummary{Family: "Helvetica", Name: "Helvetica", Size: 10, Weight: 400, Color: black}
+	if got != want {
+		t.Errorf("summarizeFont() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFontFamily(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ABCDEF+Helvetica-Bold", "Helvetica"},
+		{"TimesNewRoman,Italic", "TimesNewRoman"},
+		{"Arial", "Arial"},
+		{"Foo+Bar", "Foo+Bar"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := fontFamily(tt.name); got != tt.want {
+			t.Errorf("fontFamily(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseFontName(t *testing.T) {
+	tests := []struct {
+		name string
+		want FontInfo
+	}{
+		{"ABCDEF+Helvetica-Bold", FontInfo{Name: "Helvetica-Bold", Family: "Helvetica", Weight: 700, Subset: "ABCDEF"}},
+		{"QRSTUV+TimesNewRomanPS-BoldItalicMT", FontInfo{Name: "TimesNewRomanPS-BoldItalicMT", Family: "TimesNewRomanPS", Weight: 700, Italic: true, Subset: "QRSTUV"}},
+		{"TimesNewRoman,Italic", FontInfo{Name: "TimesNewRoman,Italic", Family: "TimesNewRoman", Weight: 400, Italic: true}},
+		{"MinionPro-SemiboldIt", FontInfo{Name: "MinionPro-SemiboldIt", Family: "MinionPro", Weight: 600, Italic: true}},
+		{"Helvetica-Oblique", FontInfo{Name: "Helvetica-Oblique", Family: "Helvetica", Weight: 400, Italic: true}},
+		{"Blackadder-Regular", FontInfo{Name: "Blackadder-Regular", Family: "Blackadder", Weight: 400}},
+		{"Arial Black", FontInfo{Name: "Arial Black", Family: "Arial Black", Weight: 900}},
+		{"abcdef+Courier", FontInfo{Name: "abcdef+Courier", Family: "abcdef+Courier", Weight: 400}},
+		{"", FontInfo{Weight: 400}},
+	}
+
+	for _, tt := range tests {
+		if got := parseFontName(tt.name); got != tt.want {
+			t.Errorf("parseFontName(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestAggregateWord_SubsetFonts tests that a word set in two subsets of one
+// font takes the font's name without a tag, and its style from the name
+func TestAggregateWord_SubsetFonts(t *testing.T) {
+	chars := charsOf("Total")
+	for i := range chars {
+		chars[i].FontName = "ABCDEF+Helvetica-BoldOblique"
+		chars[i].FontWeight = 400
+	}
+	chars[4].FontName = "GHIJKL+Helvetica-BoldOblique"
+
+	word := aggregateWord(chars, Rect{X0: 0, Y0: 0, X1: 25, Y1: 10})
+	if word.FontName != "Helvetica-BoldOblique" || word.Font.Family != "Helvetica" {
+		t.Errorf("font = %q (family %q), want Helvetica-BoldOblique (Helvetica)", word.FontName, word.Font.Family)
+	}
+	if !word.IsBold || !word.IsItalic {
+		t.Errorf("IsBold = %v, IsItalic = %v, want both from the font name", word.IsBold, word.IsItalic)
+	}
+}
@@ -0,0 +1,119 @@
+package pdfmarkdown
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Characters that join their neighbours into one grapheme cluster without
+// being visible themselves.
+const (
+	zeroWidthNonJoiner = '\u200c'
+	zeroWidthJoiner    = '\u200d'
+)
+
+// viramaCombiningClass is the canonical combining class of the viramas that
+// join Indic consonants into conjuncts.
+const viramaCombiningClass = 9
+
+// clusterStarts reports, for each rune, whether it starts a new grapheme
+// cluster. This is a simplified form of the Unicode extended grapheme cluster
+// rules covering what PDFs produce: base letters with combining accents set as
+// separate characters, Indic vowel signs and conjuncts, emoji with skin tone
+// modifiers, variation selectors and zero-width joiner sequences, and flags
+// made of regional indicator pairs. Hangul syllables and CR LF are not joined.
+func clusterStarts(runes []rune) []bool {
+	starts := make([]bool, len(runes))
+	regionalIndicators := 0 // Run length of regional indicators ending at the previous rune
+	for i, r := range runes {
+		if i == 0 {
+			starts[i] = true
+		} else {
+			prev := runes[i-1]
+			switch {
+			case isRegionalIndicator(r) && isRegionalIndicator(prev):
+				starts[i] = regionalIndicators%2 == 0
+			case prev == zeroWidthJoiner && i > 1 && !unicode.IsSpace(runes[i-2]) && !unicode.IsSpace(r):
+				starts[i] = false
+			case isViramaRune(prev) && unicode.IsLetter(r):
+				starts[i] = false
+			default:
+				starts[i] = !isClusterExtender(r)
+			}
+		}
+
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+	}
+	return starts
+}
+
+// isClusterExtender reports whether r always belongs to the cluster before it:
+// combining marks, variation selectors, emoji modifiers, emoji tag characters
+// and zero-width joiners.
+func isClusterExtender(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true // Includes variation selectors, which are Mn
+	case r == zeroWidthJoiner || r == zeroWidthNonJoiner:
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		return true // Emoji skin tone modifiers
+	case r >= 0xE0020 && r <= 0xE007F:
+		return true // Tags, as in subdivision flags
+	}
+	return false
+}
+
+// isRegionalIndicator reports whether r is one of the letters that pair up
+// into country flags.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isViramaRune reports whether r is a virama, which joins the consonants on
+// either side of it into one conjunct.
+func isViramaRune(r rune) bool {
+	return unicode.Is(unicode.Mn, r) && norm.NFC.PropertiesString(string(r)).CCC() == viramaCombiningClass
+}
+
+// graphemeClusters splits s into grapheme clusters, see clusterStarts.
+func graphemeClusters(s string) []string {
+	runes := []rune(s)
+	starts := clusterStarts(runes)
+
+	var clusters []string
+	begin := 0
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || starts[i] {
+			clusters = append(clusters, string(runes[begin:i]))
+			begin = i
+		}
+	}
+	return clusters
+}
+
+// graphemeCount returns the number of grapheme clusters in s, the number of
+// characters a reader would count.
+func graphemeCount(s string) int {
+	count := 0
+	for _, start := range clusterStarts([]rune(s)) {
+		if start {
+			count++
+		}
+	}
+	return count
+}
+
+// charClusterStarts applies clusterStarts to extracted characters.
+func charClusterStarts(chars []EnrichedChar) []bool {
+	runes := make([]rune, len(chars))
+	for i, char := range chars {
+		runes[i] = char.Text
+	}
+	return clusterStarts(runes)
+}
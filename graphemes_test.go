@@ -0,0 +1,106 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGraphemeClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "plain", text: "abc", want: []string{"a", "b", "c"}},
+		{name: "combining accent", text: "cafe\u0301", want: []string{"c", "a", "f", "e\u0301"}},
+		{name: "stacked accents", text: "a\u0323\u0301b", want: []string{"a\u0323\u0301", "b"}},
+		{name: "devanagari conjunct", text: "\u0928\u092e\u0938\u094d\u0924\u0947", want: []string{"\u0928", "\u092e", "\u0938\u094d\u0924\u0947"}},
+		{name: "emoji zwj sequence", text: "\U0001F469\u200d\U0001F4BB!", want: []string{"\U0001F469\u200d\U0001F4BB", "!"}},
+		{name: "skin tone modifier", text: "\U0001F44D\U0001F3FD\U0001F44D", want: []string{"\U0001F44D\U0001F3FD", "\U0001F44D"}},
+		{name: "variation selector", text: "\u2764\ufe0fx", want: []string{"\u2764\ufe0f", "x"}},
+		{name: "flags pair up", text: "\U0001F1E6\U0001F1FA\U0001F1F3\U0001F1FF", want: []string{"\U0001F1E6\U0001F1FA", "\U0001F1F3\U0001F1FF"}},
+		{name: "joiner before a space does not join", text: "a\u200d b", want: []string{"a\u200d", " ", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphemeClusters(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("graphemeClusters(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if got := graphemeCount(tt.text); got != len(tt.want) {
+				t.Errorf("graphemeCount(%q) = %d, want %d", tt.text, got, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestGroupCharsIntoWords_CombiningMarks(t *testing.T) {
+	t.Run("accent narrower than its letter", func(t *testing.T) {
+		// "cafe" with a combining accent drawn over the middle of the "e", then "au"
+		// 1.5pt after the "e": under the measured 3pt space, but 2.5pt after
+		// the accent's own box
+		chars := charRun("cafe", 0)
+		chars = append(chars, EnrichedChar{Text: '\u0301', Box: Rect{X0: 19, Y0: 98, X1: 23, Y1: 102}, FontSize: 12})
+		chars = append(chars, charRun("au", 25.5)...)
+
+		words := groupCharsIntoWords(chars, measureSpaces(charRun("a b c d", 0)))
+		if len(words) != 1 || words[0].Text != "cafe\u0301au" {
+			t.Errorf("words = %v, want one word", wordTexts(words))
+		}
+	})
+
+	t.Run("rotated text keeps the accent after its letter", func(t *testing.T) {
+		// Text running bottom to top, read in reverse, with the accent
+		// overlapping its letter
+		angle := float32(3 * math.Pi / 2)
+		var chars []EnrichedChar
+		for i, r := range "cafe" {
+			y := 100 + 6*float64(i)
+			chars = append(chars, EnrichedChar{Text: r, Box: Rect{X0: 100, Y0: y, X1: 112, Y1: y + 6}, FontSize: 12, Angle: angle})
+		}
+		chars = append(chars, EnrichedChar{Text: '\u0301', Box: Rect{X0: 100, Y0: 119, X1: 104, Y1: 123}, FontSize: 12, Angle: angle})
+
+		words := groupCharsIntoWords(chars, spaceMetrics{})
+		if len(words) != 1 || words[0].Text != "e\u0301fac" {
+			t.Errorf("words = %q, want one word %q", wordTexts(words), "e\u0301fac")
+		}
+	})
+}
+
+func TestNormalizeWords_KeepsClusterJoiners(t *testing.T) {
+	words := normalizeWords([]EnrichedWord{
+		{Text: "\U0001F469\u200d\U0001F4BB"},
+		{Text: "\u200dlead"},
+		{Text: "me\u200bant"},
+	})
+
+	want := []string{"\U0001F469\u200d\U0001F4BB", "lead", "meant"}
+	if got := wordTexts(words); !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeWords = %q, want %q", got, want)
+	}
+}
+
+func TestSplitWordAtCells_KeepsClusters(t *testing.T) {
+	// Two accented letters make four runes but two clusters, so a rune-count
+	// cut at the cell boundary would split the second accent from its letter
+	word := EnrichedWord{Text: "e\u0301e\u0301xy", Box: Rect{X0: 0, Y0: 0, X1: 40, Y1: 10}}
+	cells := []CellBBox{
+		{X0: 0, Top: 0, X1: 20, Bottom: 10},
+		{X0: 20, Top: 0, X1: 40, Bottom: 10},
+	}
+
+	parts, _ := splitWordAtCells(word, cells, cells[0])
+	if got := wordTexts(parts); !reflect.DeepEqual(got, []string{"e\u0301e\u0301", "xy"}) {
+		t.Errorf("parts = %q", got)
+	}
+}
+
+// wordTexts returns the text of each word.
+func wordTexts(words []EnrichedWord) []string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Text
+	}
+	return texts
+}
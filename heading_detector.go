@@ -0,0 +1,372 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// HeadingDetector marks the headings among a page's paragraphs, in reading
+// order, by setting IsHeading and HeadingLevel. Implement it to recognize the
+// headings of unusual templates while reusing the rest of the pipeline.
+//
+// Levels are provisional: after extraction, headings are ranked by font size
+// across the document (see Config.HeadingLevels), except those whose level a
+// detector fixes by setting HeadingLevelFixed.
+type HeadingDetector interface {
+	DetectHeadings(paragraphs []Paragraph, config Config)
+}
+
+// HeadingDetectorFunc adapts an ordinary function to the HeadingDetector
+// interface.
+type HeadingDetectorFunc func(paragraphs []Paragraph, config Config)
+
+// DetectHeadings calls f(paragraphs, config).
+func (f HeadingDetectorFunc) DetectHeadings(paragraphs []Paragraph, config Config) {
+	f(paragraphs, config)
+}
+
+// CompositeHeadingDetector runs several detectors. Each sees the paragraphs as
+// they were before any of them ran, and where more than one marks the same
+// paragraph, the first in the list sets its level.
+type CompositeHeadingDetector []HeadingDetector
+
+// DetectHeadings runs every detector and merges the headings they mark.
+func (c CompositeHeadingDetector) DetectHeadings(paragraphs []Paragraph, config Config) {
+	original := slices.Clone(paragraphs)
+	found := make([]Paragraph, len(paragraphs))
+	for _, detector := range c {
+		copy(found, original)
+		detector.DetectHeadings(found, config)
+		for i := range paragraphs {
+			if para := &paragraphs[i]; found[i].IsHeading && !para.IsHeading {
+				para.IsHeading = true
+				para.HeadingLevel = found[i].HeadingLevel
+				para.HeadingLevelFixed = found[i].HeadingLevelFixed
+			}
+		}
+	}
+}
+
+// FontSizeHeadings is the built-in heuristic: headings are lines set larger
+// than the body text, ranked by size, along with bold lines slightly larger
+// than it and, when enabled, centered and colored titles
+// (Config.CenteredHeadings, Config.ColoredHeadings). Config.MinHeadingFontSize
+// sets how much larger; 0 disables it.
+type FontSizeHeadings struct{}
+
+// DetectHeadings marks headings by font size.
+func (FontSizeHeadings) DetectHeadings(paragraphs []Paragraph, config Config) {
+	detectHeadings(paragraphs, config)
+}
+
+// BoldCapsHeadings takes short single-line paragraphs set entirely in bold
+// capitals for headings, as templates that keep headings at the body size
+// often do.
+type BoldCapsHeadings struct {
+	// MaxWords is the longest heading in words (default: 0, uses 10)
+	MaxWords int
+
+	// Level fixes the level of these headings. Without it they are ranked by
+	// font size with the rest (default: 0, ranked)
+	Level int
+}
+
+// DetectHeadings marks bold, all-capital lines as headings.
+func (d BoldCapsHeadings) DetectHeadings(paragraphs []Paragraph, config Config) {
+	maxWords := d.MaxWords
+	if maxWords <= 0 {
+		maxWords = 10
+	}
+
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if para.IsHeading || len(para.Lines) != 1 || len(para.Lines[0].Words) > maxWords {
+			continue
+		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
+		line := para.Lines[0]
+		if line.Words[0].IsBulletOrNumber() || !isBoldCapsLine(line) {
+			continue
+		}
+
+		para.IsHeading = true
+		para.HeadingLevel = 6 // Ranked by size after extraction
+		if d.Level > 0 {
+			para.HeadingLevel = config.clampHeadingLevel(d.Level)
+			para.HeadingLevelFixed = true
+		}
+	}
+}
+
+// isBoldCapsLine reports whether every word of a line is bold and the line
+// has at least three letters, none of them lowercase.
+func isBoldCapsLine(line Line) bool {
+	letters := 0
+	for _, word := range line.Words {
+		if !word.IsBold {
+			return false
+		}
+		for _, r := range word.Text {
+			if unicode.IsLower(r) {
+				return false
+			}
+			if unicode.IsLetter(r) {
+				letters++
+			}
+		}
+	}
+	return letters >= 3
+}
+
+// Section numbers a heading can start with: numbers such as "3", "3." and
+// "3.2.1", whose parts of three or more digits would be years and amounts
+// rather than sections; letters with a dot or followed by numbers, such as
+// "A." and "A.1"; and roman numerals with a dot, such as "IV.". A number
+// after a keyword such as "Appendix" may also be a bare letter or numeral.
+var (
+	sectionNumberPattern   = regexp.MustCompile(`^\d{1,2}(\.\d{1,2})*\.?$`)
+	letteredSectionPattern = regexp.MustCompile(`^[A-Z](\.|(\.\d{1,2})+\.?)$`)
+	romanSectionPattern    = regexp.MustCompile(`^[IVX]+\.$`)
+	keywordSectionPattern  = regexp.MustCompile(`^([A-Z]|[IVX]+|\d{1,2}(\.\d{1,2})*)[.:]?$`)
+)
+
+// sectionKeywords are the words that introduce a numbered part of a
+// document, as in "Appendix B" or "Section 4.2".
+var sectionKeywords = map[string]bool{
+	"appendix": true,
+	"annex":    true,
+	"chapter":  true,
+	"part":     true,
+	"schedule": true,
+	"section":  true,
+}
+
+// NumberedHeadings takes short single-line paragraphs that start with a
+// section number for headings at the number's depth: "3", "3." and "A." are
+// level 1, "3.2" and "A.1" level 2 and so on, shifted by
+// Config.HeadingLevelOffset. Numbers introduced by a keyword, as in
+// "Appendix B" or "Section 4.2", count the same way, with letters and
+// numerals at level 1. These levels are kept when headings are ranked by
+// size, as numbered documents often set every heading alike.
+//
+// The title after the number must start with a capital and not end like a
+// sentence, and the line must be bold, larger than the body text or in title
+// case. Top-level numbers and letters on their own must be bold or larger, to
+// tell "1. Introduction" from the first item of a numbered list.
+type NumberedHeadings struct {
+	// MaxWords is the longest title in words, after the number (default: 0,
+	// uses 12)
+	MaxWords int
+}
+
+// DetectHeadings marks numbered section titles as headings.
+func (d NumberedHeadings) DetectHeadings(paragraphs []Paragraph, config Config) {
+	maxWords := d.MaxWords
+	if maxWords <= 0 {
+		maxWords = 12
+	}
+	bodySize := medianWordSize(paragraphs)
+
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if para.IsHeading || len(para.Lines) != 1 {
+			continue
+		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
+		line := para.Lines[0]
+		depth, n, listLike, ok := sectionNumber(line.Words)
+		if !ok {
+			continue
+		}
+		title, ok := sectionTitle(line.Words[n:], n < 2)
+		if !ok || len(title) > maxWords {
+			continue
+		}
+		emphasized := lineIsBold(line) || lineMaxFontSize(line) >= bodySize*1.05
+		if !emphasized && (listLike || !isTitleCase(title)) {
+			continue
+		}
+
+		para.IsHeading = true
+		para.HeadingLevel = config.clampHeadingLevel(depth + config.HeadingLevelOffset)
+		para.HeadingLevelFixed = true
+	}
+}
+
+// sectionNumber parses the section number starting a line's words. It
+// returns the number's depth, how many words it takes, and whether it is a
+// top-level number or letter that could equally mark a list item.
+func sectionNumber(words []EnrichedWord) (depth, n int, listLike, ok bool) {
+	if len(words) == 0 {
+		return 0, 0, false, false
+	}
+	first := words[0].Text
+	switch {
+	case sectionNumberPattern.MatchString(first):
+		depth = numberDepth(first)
+		return depth, 1, depth == 1, true
+	case letteredSectionPattern.MatchString(first):
+		depth = numberDepth(first)
+		return depth, 1, depth == 1, true
+	case romanSectionPattern.MatchString(first):
+		return 1, 1, true, true
+	}
+
+	if len(words) < 2 || !sectionKeywords[strings.ToLower(first)] || !unicode.IsUpper([]rune(first)[0]) {
+		return 0, 0, false, false
+	}
+	number := words[1].Text
+	if !keywordSectionPattern.MatchString(number) {
+		return 0, 0, false, false
+	}
+	depth = 1
+	if isDigit([]rune(number)[0]) {
+		depth = numberDepth(number)
+	}
+	return depth, 2, false, true
+}
+
+// numberDepth counts the parts of a section number such as "3.2.1".
+func numberDepth(number string) int {
+	number = strings.TrimRight(number, ".:")
+	return strings.Count(number, ".") + 1
+}
+
+// sectionTitle returns the words of a section title following its number,
+// without separating dashes or colons. The title must start with a capital
+// and not end like a sentence; it may be empty unless required, as
+// "Appendix B" can stand alone.
+func sectionTitle(words []EnrichedWord, required bool) ([]EnrichedWord, bool) {
+	for len(words) > 0 && !strings.ContainsFunc(words[0].Text, isAlphanumeric) {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return nil, !required
+	}
+
+	title := strings.TrimSpace(joinWords(words))
+	if !unicode.IsUpper([]rune(title)[0]) || strings.HasSuffix(title, ".") || strings.HasSuffix(title, ",") || strings.HasSuffix(title, ";") {
+		return nil, false
+	}
+	return words, true
+}
+
+// isTitleCase reports whether at least two thirds of the words of four or
+// more letters start with a capital, so short function words such as "of"
+// and "the" don't count.
+func isTitleCase(words []EnrichedWord) bool {
+	var long, capitalized int
+	for _, word := range words {
+		letters := []rune(strings.TrimFunc(word.Text, func(r rune) bool { return !unicode.IsLetter(r) }))
+		if len(letters) < 4 {
+			continue
+		}
+		long++
+		if unicode.IsUpper(letters[0]) {
+			capitalized++
+		}
+	}
+	return capitalized*3 >= long*2
+}
+
+// isAlphanumeric reports whether r is a letter or digit.
+func isAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// lineIsBold reports whether every word of a line is bold.
+func lineIsBold(line Line) bool {
+	for _, word := range line.Words {
+		if !word.IsBold {
+			return false
+		}
+	}
+	return len(line.Words) > 0
+}
+
+// OutlineHeadings takes headings from the PDF's bookmarks, as
+// Config.UseOutlineHeadings does. Bookmarks are read once per document, so
+// these headings are matched after the page's other detectors have run and
+// take precedence over them.
+type OutlineHeadings struct{}
+
+// DetectHeadings does nothing on its own; the converter applies the outline
+// once it has read it.
+func (OutlineHeadings) DetectHeadings(paragraphs []Paragraph, config Config) {}
+
+// DefaultHeadingStrategies returns the built-in heading detectors enabled by
+// config. Append to the result to run others alongside them:
+//
+//	config.HeadingStrategies = append(pdfmarkdown.DefaultHeadingStrategies(config), pdfmarkdown.BoldCapsHeadings{})
+func DefaultHeadingStrategies(config Config) []HeadingDetector {
+	var strategies []HeadingDetector
+	if config.NumberedHeadings {
+		strategies = append(strategies, NumberedHeadings{})
+	}
+	strategies = append(strategies, FontSizeHeadings{})
+	if config.UseOutlineHeadings {
+		strategies = append(strategies, OutlineHeadings{})
+	}
+	return strategies
+}
+
+// headingStrategies returns the configured heading detectors, falling back to
+// the defaults.
+func (c Config) headingStrategies() []HeadingDetector {
+	if c.HeadingStrategies != nil {
+		return c.HeadingStrategies
+	}
+	return DefaultHeadingStrategies(c)
+}
+
+// useOutlineHeadings reports whether headings are taken from the bookmarks,
+// by Config.UseOutlineHeadings or an OutlineHeadings strategy.
+func (c Config) useOutlineHeadings() bool {
+	return c.UseOutlineHeadings || hasOutlineStrategy(c.HeadingStrategies)
+}
+
+// hasOutlineStrategy reports whether detectors, or any composite among them,
+// include OutlineHeadings.
+func hasOutlineStrategy(detectors []HeadingDetector) bool {
+	for _, detector := range detectors {
+		switch d := detector.(type) {
+		case OutlineHeadings, *OutlineHeadings:
+			return true
+		case CompositeHeadingDetector:
+			if hasOutlineStrategy(d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markHeadings runs the configured heading detectors on a page's paragraphs.
+func markHeadings(paragraphs []Paragraph, config Config) {
+	CompositeHeadingDetector(config.headingStrategies()).DetectHeadings(paragraphs, config)
+}
+
+// medianWordSize returns the median font size of the paragraphs' words, or 0
+// when they have none.
+func medianWordSize(paragraphs []Paragraph) float64 {
+	var sizes []float64
+	for _, para := range paragraphs {
+		for _, line := range para.Lines {
+			for _, word := range line.Words {
+				sizes = append(sizes, word.FontSize)
+			}
+		}
+	}
+	if len(sizes) == 0 {
+		return 0
+	}
+	sort.Float64s(sizes)
+	return sizes[len(sizes)/2]
+}
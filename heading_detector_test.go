@@ -0,0 +1,172 @@
+package pdfmarkdown
+
+import "testing"
+
+// boldParagraph returns para with every word set in bold.
+func boldParagraph(para Paragraph) Paragraph {
+	for i := range para.Lines[0].Words {
+		para.Lines[0].Words[i].IsBold = true
+	}
+	return para
+}
+
+func TestNumberedHeadings(t *testing.T) {
+	tests := []struct {
+		name      string
+		para      Paragraph
+		wantLevel int // 0 when not a heading
+	}{
+		{name: "subsection", para: placedParagraph("3.2 Results", 72, 0), wantLevel: 2},
+		{name: "third level", para: placedParagraph("3.2.1 Sample Sizes", 72, 0), wantLevel: 3},
+		{name: "bold section", para: boldParagraph(placedParagraph("1. Introduction", 72, 0)), wantLevel: 1},
+		{name: "plain numbered item", para: placedParagraph("1. Introduction", 72, 0)},
+		{name: "sentence", para: placedParagraph("3.2 Results are shown below.", 72, 0)},
+		{name: "lowercase title", para: placedParagraph("3.2 results", 72, 0)},
+		{name: "year", para: boldParagraph(placedParagraph("2024 Annual Report", 72, 0))},
+		{name: "long line", para: placedParagraph("3.2 One Two Three Four Five Six Seven Eight Nine Ten Eleven Twelve Thirteen", 72, 0)},
+		{name: "number alone", para: placedParagraph("3.2", 72, 0)},
+		{name: "sentence case", para: placedParagraph("3.2 Results of the survey", 72, 0)},
+		{name: "bold sentence case", para: boldParagraph(placedParagraph("3.2 Results of the survey", 72, 0)), wantLevel: 2},
+		{name: "lettered", para: placedParagraph("A.1 Data Sources", 72, 0), wantLevel: 2},
+		{name: "plain letter", para: placedParagraph("A. Data Sources", 72, 0)},
+		{name: "article", para: boldParagraph(placedParagraph("A Study of Things", 72, 0))},
+		{name: "bold roman numeral", para: boldParagraph(placedParagraph("IV. Results", 72, 0)), wantLevel: 1},
+		{name: "appendix", para: placedParagraph("Appendix B", 72, 0), wantLevel: 1},
+		{name: "appendix with title", para: placedParagraph("APPENDIX C: Survey Questions", 72, 0), wantLevel: 1},
+		{name: "appendix with dash", para: placedParagraph("Appendix D – Glossary", 72, 0), wantLevel: 1},
+		{name: "numbered section", para: placedParagraph("Section 4.2 Scope of Work", 72, 0), wantLevel: 2},
+		{name: "section in a sentence", para: placedParagraph("Section 3 describes the method", 72, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{tt.para, placedParagraph("the body text of the section goes on for a while", 72, 20)}
+			NumberedHeadings{}.DetectHeadings(paragraphs, DefaultConfig())
+			got := paragraphs[0]
+			if got.IsHeading != (tt.wantLevel > 0) || got.HeadingLevel != tt.wantLevel {
+				t.Errorf("IsHeading = %v, HeadingLevel = %d, want level %d", got.IsHeading, got.HeadingLevel, tt.wantLevel)
+			}
+			if got.IsHeading && !got.HeadingLevelFixed {
+				t.Error("HeadingLevelFixed not set")
+			}
+			if paragraphs[1].IsHeading {
+				t.Error("body text marked as a heading")
+			}
+		})
+	}
+}
+
+func TestBoldCapsHeadings(t *testing.T) {
+	tests := []struct {
+		name string
+		para Paragraph
+		want bool
+	}{
+		{"bold capitals", boldParagraph(placedParagraph("TERMS AND CONDITIONS", 72, 0)), true},
+		{"not bold", placedParagraph("TERMS AND CONDITIONS", 72, 0), false},
+		{"mixed case", boldParagraph(placedParagraph("Terms and Conditions", 72, 0)), false},
+		{"acronym", boldParagraph(placedParagraph("OK", 72, 0)), false},
+		{"bullet", boldParagraph(placedParagraph("• TERMS APPLY", 72, 0)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{tt.para}
+			BoldCapsHeadings{}.DetectHeadings(paragraphs, DefaultConfig())
+			if got := paragraphs[0]; got.IsHeading != tt.want || got.HeadingLevelFixed {
+				t.Errorf("IsHeading = %v, HeadingLevelFixed = %v, want heading %v", got.IsHeading, got.HeadingLevelFixed, tt.want)
+			}
+		})
+	}
+
+	paragraphs := []Paragraph{boldParagraph(placedParagraph("TERMS AND CONDITIONS", 72, 0))}
+	BoldCapsHeadings{Level: 2}.DetectHeadings(paragraphs, DefaultConfig())
+	if got := paragraphs[0]; got.HeadingLevel != 2 || !got.HeadingLevelFixed {
+		t.Errorf("with Level 2: HeadingLevel = %d, HeadingLevelFixed = %v", got.HeadingLevel, got.HeadingLevelFixed)
+	}
+}
+
+func TestCompositeHeadingDetector(t *testing.T) {
+	paragraphs := []Paragraph{
+		boldParagraph(placedParagraph("2 OVERVIEW", 72, 0)),
+		placedParagraph("2.1 Scope", 72, 20),
+		placedParagraph("the body text of the section goes on for a while", 72, 40),
+	}
+	marked := 0
+	counter := HeadingDetectorFunc(func(paragraphs []Paragraph, config Config) {
+		for _, para := range paragraphs {
+			if para.IsHeading {
+				marked++
+			}
+		}
+	})
+
+	CompositeHeadingDetector{NumberedHeadings{}, BoldCapsHeadings{Level: 4}, counter}.DetectHeadings(paragraphs, DefaultConfig())
+	if got := paragraphs[0]; !got.IsHeading || got.HeadingLevel != 1 {
+		t.Errorf("first detector's level not kept: IsHeading = %v, HeadingLevel = %d", got.IsHeading, got.HeadingLevel)
+	}
+	if got := paragraphs[1]; !got.IsHeading || got.HeadingLevel != 2 {
+		t.Errorf("subsection: IsHeading = %v, HeadingLevel = %d", got.IsHeading, got.HeadingLevel)
+	}
+	if paragraphs[2].IsHeading {
+		t.Error("body text marked as a heading")
+	}
+	if marked != 0 {
+		t.Errorf("later detector saw %d headings marked by earlier ones, want 0", marked)
+	}
+}
+
+func TestDefaultHeadingStrategies_Numbered(t *testing.T) {
+	paragraphs := []Paragraph{
+		placedParagraph("1.1 Background", 72, 0),
+		placedParagraph("the body text of the section goes on for a while", 72, 20),
+	}
+	config := DefaultConfig()
+	config.NumberedHeadings = true
+	markHeadings(paragraphs, config)
+	if !paragraphs[0].IsHeading || paragraphs[0].HeadingLevel != 2 || !paragraphs[0].HeadingLevelFixed {
+		t.Errorf("IsHeading = %v, HeadingLevel = %d, want fixed level 2", paragraphs[0].IsHeading, paragraphs[0].HeadingLevel)
+	}
+}
+
+func TestMarkHeadings_Strategies(t *testing.T) {
+	newParagraphs := func() []Paragraph {
+		return []Paragraph{
+			placedParagraph("1.1 Background", 72, 0),
+			placedParagraph("the body text of the section goes on for a while", 72, 20),
+		}
+	}
+
+	// Every line is the same size, so the default finds no headings
+	paragraphs := newParagraphs()
+	markHeadings(paragraphs, DefaultConfig())
+	if paragraphs[0].IsHeading {
+		t.Error("default strategies marked a body-size line as a heading")
+	}
+
+	config := DefaultConfig()
+	config.HeadingStrategies = append(DefaultHeadingStrategies(config), NumberedHeadings{})
+	paragraphs = newParagraphs()
+	markHeadings(paragraphs, config)
+	if !paragraphs[0].IsHeading || paragraphs[0].HeadingLevel != 2 {
+		t.Errorf("IsHeading = %v, HeadingLevel = %d, want level 2", paragraphs[0].IsHeading, paragraphs[0].HeadingLevel)
+	}
+
+	// Ranking by size across the document keeps the level from the number
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: paragraphs}}}
+	normalizeDocumentHeadings(doc, config)
+	if got := doc.Pages[0].Paragraphs[0].HeadingLevel; got != 2 {
+		t.Errorf("HeadingLevel after ranking = %d, want 2", got)
+	}
+}
+
+func TestConfig_UseOutlineHeadings(t *testing.T) {
+	config := DefaultConfig()
+	if config.useOutlineHeadings() {
+		t.Error("outline headings enabled by default")
+	}
+	config.HeadingStrategies = []HeadingDetector{CompositeHeadingDetector{FontSizeHeadings{}, OutlineHeadings{}}}
+	if !config.useOutlineHeadings() {
+		t.Error("OutlineHeadings strategy did not enable outline headings")
+	}
+}
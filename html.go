@@ -0,0 +1,453 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ToHTML converts a document to HTML; see WriteHTML.
+func (d *Document) ToHTML(config Config) string {
+	var buf bytes.Buffer
+	if err := d.WriteHTML(&buf, config); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// WriteHTML writes the document to w as an HTML fragment, rendered from the
+// same structure as WriteMarkdown and with the same config: headings become
+// h1 to h6, paragraphs p, lists ul and ol, code pre, and tables table with
+// their column alignment kept. Headings carry the ids markdown renderers would
+// generate, so internal links resolve in both. Page breaks are hr elements, or
+// Config.PageBreakMarker written as is.
+func (d *Document) WriteHTML(w io.Writer, config Config) error {
+	pages := d.renderedPages(config)
+	anchors := headingAnchors(&Document{Pages: pages})
+
+	var buf bytes.Buffer
+	for pi, page := range pages {
+		buf.Reset()
+		if pi > 0 && config.IncludePageBreaks {
+			if config.PageBreakMarker != "" {
+				buf.WriteString(pageBreakMarker(config.PageBreakMarker, page) + "\n")
+			} else {
+				buf.WriteString("<hr>\n")
+			}
+		}
+		writePageHTML(&buf, page, func(para int) string {
+			return anchors[headingRef{page: pi, para: para}]
+		}, config)
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return errors.Wrap(err, "failed to write HTML")
+		}
+	}
+	return nil
+}
+
+// writePageHTML renders one page's blocks in the order pageWriter renders
+// them. anchor returns the id of the heading at a paragraph index.
+func writePageHTML(b *bytes.Buffer, page Page, anchor func(para int) string, config Config) {
+	var placed []bool
+	if config.FigureImages {
+		placed = make([]bool, len(page.Figures))
+	}
+	figures := func(para *Paragraph) {
+		if config.FigureImages {
+			for _, i := range placeFigures(page, placed, para) {
+				b.WriteString(`<figure><img src="` + html.EscapeString(figureTarget(page, i)) + `" alt="` + html.EscapeString(figureAlt(page, i)) + `"></figure>` + "\n")
+			}
+		}
+	}
+
+	for j := 0; j < len(page.Paragraphs); j++ {
+		para := page.Paragraphs[j]
+		figures(&para)
+
+		if len(para.TOCEntries) > 0 {
+			// Consecutive table of contents paragraphs form one list
+			entries := append([]TOCEntry(nil), para.TOCEntries...)
+			for j+1 < len(page.Paragraphs) && len(page.Paragraphs[j+1].TOCEntries) > 0 {
+				j++
+				entries = append(entries, page.Paragraphs[j].TOCEntries...)
+			}
+			writeTOCHTML(b, entries)
+			continue
+		}
+
+		if len(para.Leaders) > 0 {
+			// Consecutive leader paragraphs form one list or table
+			paragraphs := []Paragraph{para}
+			for j+1 < len(page.Paragraphs) && len(page.Paragraphs[j+1].Leaders) > 0 {
+				j++
+				paragraphs = append(paragraphs, page.Paragraphs[j])
+			}
+			writeLeaderRowsHTML(b, paragraphs, config.LeaderRows)
+			continue
+		}
+
+		if isListItem(para) {
+			// Consecutive items of the same kind form one list, along with
+			// the items nested under them
+			items := []Paragraph{para}
+			for j+1 < len(page.Paragraphs) && continuesList(para, page.Paragraphs[j+1], config) {
+				j++
+				item := page.Paragraphs[j]
+				figures(&item)
+				items = append(items, item)
+			}
+			writeListHTML(b, items, config)
+			continue
+		}
+
+		if para.IsEquation && config.FigureImages {
+			b.WriteString(`<figure><img src="` + html.EscapeString(equationTarget(page, j)) + `" alt="` + html.EscapeString(equationLatex(para)) + `"></figure>` + "\n")
+			continue
+		}
+
+		writeParagraphHTML(b, para, anchor(j), config)
+	}
+	figures(nil)
+
+	if config.tablesEnabled() {
+		for _, table := range page.Tables {
+			writeTableHTML(b, table, config)
+		}
+	}
+}
+
+// writeListHTML renders list items as a list, opening a list inside an item
+// for the items nested under it.
+func writeListHTML(b *bytes.Buffer, items []Paragraph, config Config) {
+	var tags []string // Tag of the list open at each level
+	for i, item := range items {
+		text, ordered := listItemText(item, config)
+		tag := "ul"
+		if ordered {
+			tag = "ol"
+		}
+		depth := min(max(item.ListLevel-items[0].ListLevel, 0), len(tags))
+
+		if i > 0 {
+			for len(tags) > depth+1 {
+				b.WriteString("</li>\n</" + tags[len(tags)-1] + ">\n")
+				tags = tags[:len(tags)-1]
+			}
+			if depth < len(tags) {
+				b.WriteString("</li>\n")
+				if tags[depth] != tag {
+					b.WriteString("</" + tags[depth] + ">\n<" + tag + ">\n")
+					tags[depth] = tag
+				}
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		if depth == len(tags) {
+			b.WriteString("<" + tag + ">\n")
+			tags = append(tags, tag)
+		}
+		b.WriteString("<li" + blockAttributes(item) + ">" + html.EscapeString(text))
+	}
+	for len(tags) > 0 {
+		b.WriteString("</li>\n</" + tags[len(tags)-1] + ">\n")
+		tags = tags[:len(tags)-1]
+	}
+}
+
+// writeParagraphHTML renders a heading, code block or paragraph, in a
+// blockquote when it is a quotation. id is the heading's anchor.
+func writeParagraphHTML(b *bytes.Buffer, para Paragraph, id string, config Config) {
+	if len(para.Lines) == 0 {
+		return
+	}
+
+	if para.IsHeading {
+		level := para.HeadingLevel
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		tag := "h" + strconv.Itoa(level)
+		b.WriteString("<" + tag + ` id="` + html.EscapeString(id) + `"` + blockAttributes(para) + ">" + html.EscapeString(headingText(para)) + "</" + tag + ">\n")
+
+		// Only the first line of a multi-line heading paragraph is the heading
+		if len(para.Lines) > 1 {
+			rest := Paragraph{Lines: para.Lines[1:], Box: para.Box, Alignment: para.Alignment, ReadingDirection: para.ReadingDirection}
+			writeParagraphHTML(b, rest, "", config)
+		}
+		return
+	}
+
+	if para.IsCode {
+		lines := strings.Split(para.Text(), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+		b.WriteString("<pre><code>" + html.EscapeString(strings.Join(lines, "\n")) + "</code></pre>\n")
+		return
+	}
+
+	if para.IsEquation {
+		b.WriteString(`<div class="math">\[` + html.EscapeString(equationLatex(para)) + `\]</div>` + "\n")
+		return
+	}
+
+	if para.IsList {
+		text, ordered := listItemText(para, config)
+		tag := "ul"
+		if ordered {
+			tag = "ol"
+		}
+		b.WriteString("<" + tag + "><li" + blockAttributes(para) + ">" + html.EscapeString(text) + "</li></" + tag + ">\n")
+		return
+	}
+
+	var paragraphs []string
+	for _, section := range numberedSections(para, config) {
+		lines := make([]string, 0, len(section))
+		for _, line := range section {
+			switch {
+			case config.StripInlineFormatting:
+				lines = append(lines, html.EscapeString(joinWords(line.Words)))
+			case config.PreserveColors:
+				lines = append(lines, colorRuns(line.Words, htmlInlineRuns))
+			default:
+				lines = append(lines, htmlInlineRuns(line.Words))
+			}
+		}
+		text := strings.TrimRight(strings.Join(lines, "<br>\n"), " \t")
+		if text != "" {
+			paragraphs = append(paragraphs, "<p"+blockAttributes(para)+">"+text+"</p>\n")
+		}
+	}
+	if para.IsQuote && len(paragraphs) > 0 {
+		b.WriteString("<blockquote>\n" + strings.Join(paragraphs, "") + "</blockquote>\n")
+		return
+	}
+	b.WriteString(strings.Join(paragraphs, ""))
+}
+
+// blockAttributes returns the dir and text-align attributes a paragraph
+// needs to be laid out as on the page: dir="rtl" for right-to-left text, and
+// its alignment when it is centered or, for left-to-right text, right
+// aligned. Justified text reads as well aligned to its starting edge, and a
+// left alignment is also what detectAlignment reports for single lines it
+// can't tell, so neither gets one.
+func blockAttributes(para Paragraph) string {
+	var attrs string
+	start := AlignmentLeft
+	if para.ReadingDirection == DirectionRTL {
+		attrs += ` dir="rtl"`
+		start = AlignmentRight
+	}
+
+	alignment := para.Alignment
+	if alignment == AlignmentCenter && len(para.Lines) > 1 {
+		// Lines that span the column are centered on the page too; only
+		// lines with ragged starts are set centered
+		starts := make([]float64, len(para.Lines))
+		for i, line := range para.Lines {
+			starts[i] = line.Box.X0
+		}
+		if stdDev(starts) < 2 {
+			alignment = AlignmentJustified
+		}
+	}
+	if alignment != start && alignment != AlignmentLeft && alignment != AlignmentJustified {
+		attrs += ` style="text-align: ` + alignment.String() + `"`
+	}
+	return attrs
+}
+
+// htmlInlineRuns renders a line's words as HTML, grouping words the way
+// formatInlineRuns does.
+func htmlInlineRuns(words []EnrichedWord) string {
+	var b strings.Builder
+	var run []EnrichedWord
+	var style inlineStyle
+
+	flush := func() {
+		if len(run) > 0 {
+			b.WriteString(htmlRun(style, joinWords(run)))
+			run = nil
+		}
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word.Link == "" && len(run) > 0 && (htmlWordStyle(word) == style || isPunctuationOnly(word.Text)) {
+			run = append(run, word)
+			continue
+		}
+
+		flush()
+		if i > 0 {
+			b.WriteString(wordSeparator(words[i-1].Text, word.Text))
+		}
+		if word.Link != "" {
+			end := i + 1
+			for end < len(words) && words[end].Link == word.Link {
+				end++
+			}
+			shown, trailing := splitTrailingPunctuation(joinWords(words[i:end]))
+			b.WriteString(`<a href="` + html.EscapeString(word.Link) + `">` + html.EscapeString(shown) + "</a>" + html.EscapeString(trailing))
+			i = end - 1
+			continue
+		}
+		run, style = []EnrichedWord{word}, htmlWordStyle(word)
+	}
+	flush()
+
+	return b.String()
+}
+
+// styleUnderline marks underlined runs, which only HTML can show.
+const styleUnderline inlineStyle = 1 << 5
+
+// htmlWordStyle returns the formatting a word is rendered with in HTML: its
+// markdown style, underlined when it is.
+func htmlWordStyle(word EnrichedWord) inlineStyle {
+	style := wordStyle(word)
+	if word.IsUnderline {
+		style |= styleUnderline
+	}
+	return style
+}
+
+// htmlRun escapes text and wraps it in the elements for style.
+func htmlRun(style inlineStyle, text string) string {
+	if style&styleUnderline != 0 {
+		return "<u>" + htmlRun(style&^styleUnderline, text) + "</u>"
+	}
+	if style&styleStrikethrough != 0 {
+		return "<s>" + htmlRun(style&^styleStrikethrough, text) + "</s>"
+	}
+	text = html.EscapeString(text)
+	switch style {
+	case styleBoldItalic:
+		return "<strong><em>" + text + "</em></strong>"
+	case styleBold:
+		return "<strong>" + text + "</strong>"
+	case styleItalic:
+		return "<em>" + text + "</em>"
+	case styleCode:
+		return "<code>" + text + "</code>"
+	default:
+		return text
+	}
+}
+
+// writeLeaderRowsHTML renders the leader rows of consecutive paragraphs as
+// lines of "Item — $12.00" or as a two-column table without a header.
+func writeLeaderRowsHTML(b *bytes.Buffer, paragraphs []Paragraph, style LeaderRowStyle) {
+	var labels, values []string
+	for _, para := range paragraphs {
+		for i, row := range para.Leaders {
+			label := html.EscapeString(row.Label)
+			if link := leaderRowLink(para, i); link != "" {
+				label = `<a href="` + html.EscapeString(link) + `">` + label + "</a>"
+			}
+			labels = append(labels, label)
+			values = append(values, html.EscapeString(row.Value))
+		}
+	}
+
+	if style == LeaderRowsDash {
+		lines := make([]string, len(labels))
+		for i := range labels {
+			lines[i] = labels[i] + " — " + values[i]
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+		return
+	}
+
+	b.WriteString("<table>\n<tbody>\n")
+	for i := range labels {
+		b.WriteString("<tr><td>" + labels[i] + "</td><td>" + values[i] + "</td></tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+}
+
+// writeTableHTML renders a table with its first row as the header. Right
+// aligned and centered columns keep their alignment, and merged cells span
+// their rows and columns.
+func writeTableHTML(b *bytes.Buffer, table Table, config Config) {
+	if len(table.Rows) == 0 {
+		return
+	}
+	if pairs, ok := labelValuePairs(table); ok && writeLabelValuesHTML(b, pairs, config.TwoColumnTableStyle) {
+		return
+	}
+	if config.TransposeTables && table.Orientation == TableHeaderLeft {
+		table = transposeTable(table)
+	}
+
+	var styles []string
+	for _, alignment := range table.ColumnAlignments() {
+		switch alignment {
+		case AlignmentRight:
+			styles = append(styles, ` style="text-align: right"`)
+		case AlignmentCenter:
+			styles = append(styles, ` style="text-align: center"`)
+		default:
+			styles = append(styles, "")
+		}
+	}
+
+	row := func(cells []TableCell, tag string) {
+		b.WriteString("<tr>")
+		for c := 0; c < table.NumCols; c++ {
+			content, attrs := "", ""
+			if c < len(cells) {
+				if cells[c].Covered {
+					continue
+				}
+				content = strings.ReplaceAll(html.EscapeString(cells[c].Content), "\n", "<br>")
+				if config.PreserveColors && !config.StripInlineFormatting && hasSpanColor(cells[c].Words) {
+					content = colorRuns(cells[c].Words, func(words []EnrichedWord) string {
+						return html.EscapeString(joinWords(words))
+					})
+				}
+				if cells[c].RowSpan > 1 {
+					attrs += ` rowspan="` + strconv.Itoa(cells[c].RowSpan) + `"`
+				}
+				if cells[c].ColSpan > 1 {
+					attrs += ` colspan="` + strconv.Itoa(cells[c].ColSpan) + `"`
+				}
+			}
+			if c < len(styles) {
+				attrs += styles[c]
+			}
+			b.WriteString("<" + tag + attrs + ">" + content + "</" + tag + ">")
+		}
+		b.WriteString("</tr>\n")
+	}
+
+	// Header cells merged with the rows below take those rows into the header
+	header := 1
+	for r := 0; r < header && r < len(table.Rows); r++ {
+		for _, cell := range table.Rows[r].Cells {
+			header = max(header, r+cell.RowSpan)
+		}
+	}
+	header = min(header, len(table.Rows))
+
+	b.WriteString("<table>\n<thead>\n")
+	for _, r := range table.Rows[:header] {
+		row(r.Cells, "th")
+	}
+	b.WriteString("</thead>\n")
+	if len(table.Rows) > header {
+		b.WriteString("<tbody>\n")
+		for _, r := range table.Rows[header:] {
+			row(r.Cells, "td")
+		}
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+}
@@ -0,0 +1,108 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocumentToHTML(t *testing.T) {
+	table := placedTable(
+		ruledRow(0, "Item", "Price", "Stock"),
+		ruledRow(20, "Coffee", "3.50", "Yes"),
+		ruledRow(40, "Tea", "2.75", "No"),
+	)
+
+	doc := &Document{Pages: []Page{
+		{
+			Number: 1,
+			Paragraphs: []Paragraph{
+				{Lines: []Line{{Words: []EnrichedWord{{Text: "Q&A", FontSize: 18}}}}, IsHeading: true},
+				{Lines: []Line{{Words: []EnrichedWord{{Text: "Prices"}, {Text: "rose", IsBold: true}, {Text: "<5%"}}}}},
+				{Lines: []Line{{Words: []EnrichedWord{{Text: "•"}, {Text: "North"}}}}, IsList: true, ListMarker: "•"},
+				{Lines: []Line{{Words: []EnrichedWord{{Text: "•"}, {Text: "South"}}}}, IsList: true, ListMarker: "•"},
+				{Lines: []Line{{Words: []EnrichedWord{{Text: "x"}, {Text: "<"}, {Text: "y"}}}}, IsCode: true},
+			},
+			Tables: []Table{table},
+		},
+		headingPage(2, "Q&A", 18),
+	}}
+
+	got := doc.ToHTML(DefaultConfig())
+
+	for _, want := range []string{
+		`<h1 id="qa">Q&amp;A</h1>`,
+		"<p>Prices <strong>rose</strong> &lt;5%</p>",
+		"<ul>\n<li>North</li>\n<li>South</li>\n</ul>",
+		"<pre><code>x &lt; y</code></pre>",
+		`<th>Item</th><th style="text-align: right">Price</th><th style="text-align: center">Stock</th>`,
+		`<td>Tea</td><td style="text-align: right">2.75</td>`,
+		"<hr>\n",
+		`<h1 id="qa-1">Q&amp;A</h1>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestBlockAttributes(t *testing.T) {
+	rtl := func(para Paragraph) Paragraph {
+		para.ReadingDirection = DirectionRTL
+		return para
+	}
+	fullWidth := Paragraph{
+		Lines: []Line{
+			{Box: Rect{X0: 72, X1: 528}},
+			{Box: Rect{X0: 72, X1: 528}},
+		},
+		Alignment: AlignmentCenter,
+	}
+
+	tests := []struct {
+		name string
+		para Paragraph
+		want string
+	}{
+		{"left", Paragraph{Alignment: AlignmentLeft}, ""},
+		{"justified", Paragraph{Alignment: AlignmentJustified}, ""},
+		{"centered", centeredParagraph("Annual Report", 50), ` style="text-align: center"`},
+		{"right", Paragraph{Alignment: AlignmentRight}, ` style="text-align: right"`},
+		{"full width", fullWidth, ""},
+		{"rtl", rtl(Paragraph{Alignment: AlignmentRight}), ` dir="rtl"`},
+		{"rtl left", rtl(Paragraph{Alignment: AlignmentLeft}), ` dir="rtl"`},
+		{"rtl centered", rtl(centeredParagraph("שלום", 50)), ` dir="rtl" style="text-align: center"`},
+	}
+	for _, tt := range tests {
+		if got := blockAttributes(tt.para); got != tt.want {
+			t.Errorf("%s: blockAttributes() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_BlockAttributes(t *testing.T) {
+	title := centeredParagraph("Annual Report", 50)
+	title.IsHeading, title.HeadingLevel = true, 1
+	hebrew := placedParagraph("שלום עולם", 300, 100)
+	hebrew.ReadingDirection, hebrew.Alignment = DirectionRTL, AlignmentRight
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: []Paragraph{title, hebrew, placedParagraph("Revenue grew", 72, 150)}}}}
+
+	config := DefaultConfig()
+	if got := doc.ToMarkdown(config); strings.Contains(got, "<div") {
+		t.Errorf("ToMarkdown() without BlockAttributes =\n%s", got)
+	}
+
+	config.BlockAttributes = true
+	got := doc.ToMarkdown(config)
+	lines := strings.Split(got, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	want := []string{
+		`<div style="text-align: center">`, "", "# Annual Report", "", "</div>", "",
+		`<div dir="rtl">`, "", "שלום עולם", "", "</div>", "",
+		"Revenue grew", "",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ToMarkdown() =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+}
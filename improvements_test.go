@@ -48,6 +48,57 @@ func TestCalculateBaseline(t *testing.T) {
 	}
 }
 
+// TestWordBaseline tests baseline aggregation from character origins
+func TestWordBaseline(t *testing.T) {
+	word := EnrichedWord{Box: Rect{Y0: 90, Y1: 104}, FontSize: 12}
+	char := func(originY float64, hasOrigin bool, angle float32) EnrichedChar {
+		return EnrichedChar{
+			Box:       Rect{Y0: 90, Y1: 104},
+			FontSize:  12,
+			Origin:    Point{Y: originY},
+			HasOrigin: hasOrigin,
+			Angle:     angle,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		chars    []EnrichedChar
+		expected float64
+	}{
+		{
+			name: "median of char origins",
+			// Deep descender font: the estimate would give 102.2
+			chars:    []EnrichedChar{char(99, true, 0), char(99.2, true, 0), char(99.1, true, 0)},
+			expected: 99.1,
+		},
+		{
+			name:     "falls back without origins",
+			chars:    []EnrichedChar{char(0, false, 0), char(0, false, 0)},
+			expected: 102.2, // Y1 - (fontSize * 0.15)
+		},
+		{
+			name:     "ignores zeroed origins",
+			chars:    []EnrichedChar{char(0, true, 0), char(99, true, 0)},
+			expected: 99,
+		},
+		{
+			name:     "falls back for rotated text",
+			chars:    []EnrichedChar{char(99, true, math.Pi/2)},
+			expected: 102.2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wordBaseline(tt.chars, word)
+			if math.Abs(result-tt.expected) > 0.1 {
+				t.Errorf("wordBaseline() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestCalculateXHeight tests x-height calculation
 func TestCalculateXHeight(t *testing.T) {
 	tests := []struct {
@@ -94,6 +145,48 @@ func TestCalculateXHeight(t *testing.T) {
 	}
 }
 
+// TestWordXHeight tests x-height measurement from glyph boxes
+func TestWordXHeight(t *testing.T) {
+	glyph := func(r rune, height float64) EnrichedChar {
+		return EnrichedChar{Text: r, Box: Rect{Y0: 100 - height, Y1: 100}, FontSize: 12}
+	}
+
+	tests := []struct {
+		name     string
+		chars    []EnrichedChar
+		word     EnrichedWord
+		expected float64
+	}{
+		{
+			name:     "measures x-height glyphs only",
+			chars:    []EnrichedChar{glyph('h', 9), glyph('o', 5.5), glyph('m', 5.4), glyph('e', 5.6)},
+			word:     EnrichedWord{Text: "home", Box: Rect{Y0: 91, Y1: 100}, FontSize: 12},
+			expected: 5.5,
+		},
+		{
+			name:     "ignores descenders",
+			chars:    []EnrichedChar{glyph('g', 8), glyph('o', 5)},
+			word:     EnrichedWord{Text: "go", Box: Rect{Y0: 92, Y1: 103}, FontSize: 12},
+			expected: 5,
+		},
+		{
+			name:     "falls back without x-height glyphs",
+			chars:    []EnrichedChar{glyph('H', 8), glyph('I', 8)},
+			word:     EnrichedWord{Text: "HI", Box: Rect{Y0: 92, Y1: 100}, FontSize: 12},
+			expected: 6.0, // FontSize * 0.5
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wordXHeight(tt.chars, tt.word)
+			if math.Abs(result-tt.expected) > 0.1 {
+				t.Errorf("wordXHeight() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestIsCJK tests CJK character detection
 func TestIsCJK(t *testing.T) {
 	tests := []struct {
@@ -175,7 +268,7 @@ func TestDeduplicateCJKChars(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := deduplicateCJKChars(tt.words)
+			result, _ := deduplicateCJKChars(tt.words, 0.7)
 			if len(result) == 0 {
 				t.Fatal("deduplicateCJKChars returned empty slice")
 			}
@@ -554,3 +647,31 @@ func TestDetectColumns(t *testing.T) {
 		t.Error("Columns not ordered left to right")
 	}
 }
+
+// TestDeduplicateCJKChars_WidthRatio tests the configurable duplicate threshold
+func TestDeduplicateCJKChars_WidthRatio(t *testing.T) {
+	tests := []struct {
+		name        string
+		widthRatio  float64
+		expected    string
+		wantRemoved int
+	}{
+		{name: "default ratio removes duplicates", widthRatio: 0.7, expected: "微软", wantRemoved: 2},
+		{name: "lower ratio keeps tight repeats", widthRatio: 0.4, expected: "微微软软", wantRemoved: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// avgCharWidth is 6, i.e. 0.5x the 12pt font size
+			words := []EnrichedWord{{Text: "微微软软", Box: Rect{X0: 0, X1: 24}, FontSize: 12}}
+
+			result, removed := deduplicateCJKChars(words, tt.widthRatio)
+			if result[0].Text != tt.expected {
+				t.Errorf("deduplicateCJKChars() = %v, want %v", result[0].Text, tt.expected)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("removed = %d, want %d", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package pdfmarkdown
+
+import "math"
+
+// minIndentEm is the smallest difference between line starts, in ems, that
+// counts as an indent rather than ragged alignment.
+const minIndentEm = 0.5
+
+// maxContinuationGapEm is the largest vertical gap, in ems, across which a
+// hanging-indented block still continues the list item above it.
+const maxContinuationGapEm = 1.5
+
+// measureIndents records each paragraph's first-line or hanging indent: how
+// far its first line starts right or left of the line starts below it.
+// Centred and right-aligned paragraphs have ragged starts and get neither, as
+// does rotated text, whose lines don't start at their left edge.
+func measureIndents(paragraphs []Paragraph) {
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		para.FirstLineIndent, para.HangingIndent = 0, 0
+		if len(para.Lines) < 2 || para.OrientedBox != nil || para.Alignment == AlignmentCenter || para.Alignment == AlignmentRight {
+			continue
+		}
+
+		em := paragraphEm(*para)
+		body := continuationStart(*para)
+		switch offset := para.Lines[0].Box.X0 - body; {
+		case offset >= em*minIndentEm:
+			para.FirstLineIndent = offset
+		case -offset >= em*minIndentEm:
+			para.HangingIndent = -offset
+		}
+	}
+}
+
+// joinListContinuations appends to a list item the paragraph after it when
+// that paragraph starts where the item's text does, right of its marker.
+// Such a block is the rest of the item set with a hanging indent, split off by
+// extra leading or a page element in between, not new body text, which starts
+// back at the marker's margin.
+func joinListContinuations(paragraphs []Paragraph) []Paragraph {
+	if len(paragraphs) < 2 {
+		return paragraphs
+	}
+
+	result := paragraphs[:1]
+	for _, para := range paragraphs[1:] {
+		item := &result[len(result)-1]
+		if !continuesListItem(*item, para) {
+			result = append(result, para)
+			continue
+		}
+
+		item.Lines = append(item.Lines, para.Lines...)
+		item.Box = mergeRects(item.Box, para.Box)
+		item.Font = summarizeFont(item.Lines)
+		measureIndents(result[len(result)-1:])
+	}
+	return result
+}
+
+// continuesListItem reports whether para is a hanging-indented continuation of
+// the list item.
+func continuesListItem(item, para Paragraph) bool {
+	if !item.IsList || para.IsList || para.IsHeading || para.IsCode || len(para.Lines) == 0 || len(item.Lines) == 0 {
+		return false
+	}
+
+	em := paragraphEm(item)
+	textStart, ok := listTextStart(item)
+	if !ok || textStart-item.Lines[0].Box.X0 < em*minIndentEm {
+		return false
+	}
+
+	gap := para.Box.Y0 - item.Box.Y1
+	return gap >= -em*minIndentEm && gap <= em*maxContinuationGapEm &&
+		math.Abs(para.Lines[0].Box.X0-textStart) < em*minIndentEm &&
+		math.Abs(paragraphEm(para)-em) < em*0.2
+}
+
+// listTextStart returns where a list item's text starts: at its continuation
+// lines when it has any, otherwise at the word after the marker.
+func listTextStart(item Paragraph) (float64, bool) {
+	if len(item.Lines) > 1 {
+		return continuationStart(item), true
+	}
+	if words := item.Lines[0].Words; len(words) > 1 {
+		return words[1].Box.X0, true
+	}
+	return 0, false
+}
+
+// continuationStart returns the median start of a paragraph's lines after the
+// first, ignoring lines continued in the next column.
+func continuationStart(para Paragraph) float64 {
+	starts := make([]float64, 0, len(para.Lines)-1)
+	for _, line := range para.Lines[1:] {
+		// Lines continued in the next column start right of the first line
+		if line.Box.X0 < para.Lines[0].Box.X1 {
+			starts = append(starts, line.Box.X0)
+		}
+	}
+	if len(starts) == 0 {
+		return para.Lines[0].Box.X0
+	}
+	return calculateMedian(starts)
+}
+
+// paragraphEm returns the paragraph's font size, falling back to the average
+// word size when the font summary is not filled in.
+func paragraphEm(para Paragraph) float64 {
+	if para.Font.Size > 0 {
+		return para.Font.Size
+	}
+	return getAverageFontSize(para.Lines)
+}
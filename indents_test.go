@@ -0,0 +1,146 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"testing"
+)
+
+// linesAt lays out one 10pt line of text per start, 12pt apart from top.
+func linesAt(top float64, text string, starts ...float64) []Line {
+	lines := make([]Line, len(starts))
+	for i, x := range starts {
+		lines[i] = proseLine(text, x, top+12*float64(i))
+		for j := range lines[i].Words {
+			lines[i].Words[j].FontSize = 10
+		}
+	}
+	return lines
+}
+
+// paragraphOfLines builds a left aligned paragraph around lines.
+func paragraphOfLines(lines []Line) Paragraph {
+	para := paragraphFromLines(lines, 600)
+	para.Alignment = AlignmentLeft
+	para.Font = summarizeFont(lines)
+	return para
+}
+
+func TestMeasureIndents(t *testing.T) {
+	tests := []struct {
+		name          string
+		starts        []float64
+		alignment     Alignment
+		wantFirstLine float64
+		wantHanging   float64
+	}{
+		{name: "flush", starts: []float64{72, 72, 72}},
+		{name: "first line indent", starts: []float64{90, 72, 72}, wantFirstLine: 18},
+		{name: "hanging indent", starts: []float64{72, 90, 90, 90}, wantHanging: 18},
+		{name: "ragged start under half an em", starts: []float64{74, 72, 72}},
+		{name: "single line", starts: []float64{90}},
+		{name: "centred lines are ragged", starts: []float64{90, 72, 80}, alignment: AlignmentCenter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			para := paragraphOfLines(linesAt(100, "some words", tt.starts...))
+			para.Alignment = tt.alignment
+			paragraphs := []Paragraph{para}
+
+			measureIndents(paragraphs)
+
+			if got := paragraphs[0].FirstLineIndent; math.Abs(got-tt.wantFirstLine) > 0.01 {
+				t.Errorf("FirstLineIndent = %v, want %v", got, tt.wantFirstLine)
+			}
+			if got := paragraphs[0].HangingIndent; math.Abs(got-tt.wantHanging) > 0.01 {
+				t.Errorf("HangingIndent = %v, want %v", got, tt.wantHanging)
+			}
+		})
+	}
+}
+
+func TestJoinListContinuations(t *testing.T) {
+	// A bullet at 72 with its text at 107, as laid out by proseLine
+	item := func(top float64, continuation ...float64) Paragraph {
+		para := paragraphOfLines(linesAt(top, "• first item", append([]float64{72}, continuation...)...))
+		para.IsList = true
+		para.ListMarker = "•"
+		return para
+	}
+
+	tests := []struct {
+		name      string
+		paragraph []Paragraph
+		wantLines []int // Line count of each paragraph after joining
+	}{
+		{
+			name: "block at the item's text joins it",
+			paragraph: []Paragraph{
+				item(100),
+				paragraphOfLines(linesAt(124, "rest of the item", 107, 107)),
+			},
+			wantLines: []int{3},
+		},
+		{
+			name: "block at the hanging indent of a wrapped item joins it",
+			paragraph: []Paragraph{
+				item(100, 107),
+				paragraphOfLines(linesAt(136, "rest of the item", 107)),
+			},
+			wantLines: []int{3},
+		},
+		{
+			name: "body text back at the margin stays separate",
+			paragraph: []Paragraph{
+				item(100),
+				paragraphOfLines(linesAt(124, "next paragraph", 72, 72)),
+			},
+			wantLines: []int{1, 2},
+		},
+		{
+			name: "distant block stays separate",
+			paragraph: []Paragraph{
+				item(100),
+				paragraphOfLines(linesAt(160, "rest of the item", 107)),
+			},
+			wantLines: []int{1, 1},
+		},
+		{
+			name: "next list item stays separate",
+			paragraph: []Paragraph{
+				item(100),
+				item(124),
+			},
+			wantLines: []int{1, 1},
+		},
+		{
+			name: "heading stays separate",
+			paragraph: []Paragraph{
+				item(100),
+				func() Paragraph {
+					para := paragraphOfLines(linesAt(124, "Heading", 107))
+					para.IsHeading = true
+					return para
+				}(),
+			},
+			wantLines: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinListContinuations(tt.paragraph)
+			if len(got) != len(tt.wantLines) {
+				t.Fatalf("got %d paragraphs, want %d", len(got), len(tt.wantLines))
+			}
+			for i, want := range tt.wantLines {
+				if len(got[i].Lines) != want {
+					t.Errorf("paragraph %d has %d lines, want %d", i, len(got[i].Lines), want)
+				}
+			}
+			if len(got) == 1 && len(tt.paragraph) > 1 && got[0].HangingIndent <= 0 {
+				t.Errorf("joined item HangingIndent = %v, want it set", got[0].HangingIndent)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestFormatInlineRuns(t *testing.T) {
+	plain := func(text string) EnrichedWord { return EnrichedWord{Text: text} }
+	bold := func(text string) EnrichedWord { return EnrichedWord{Text: text, IsBold: true} }
+	italic := func(text string) EnrichedWord { return EnrichedWord{Text: text, IsItalic: true} }
+	code := func(text string) EnrichedWord { return EnrichedWord{Text: text, IsMonospace: true} }
+
+	tests := []struct {
+		name  string
+		words []EnrichedWord
+		want  string
+	}{
+		{
+			name:  "plain words",
+			words: []EnrichedWord{plain("just"), plain("text")},
+			want:  "just text",
+		},
+		{
+			name:  "bold run",
+			words: []EnrichedWord{bold("foo"), bold("bar"), bold("baz")},
+			want:  "**foo bar baz**",
+		},
+		{
+			name:  "runs of different styles",
+			words: []EnrichedWord{plain("This"), plain("is"), bold("very"), bold("bold"), plain("and"), italic("quite"), italic("slanted")},
+			want:  "This is **very bold** and *quite slanted*",
+		},
+		{
+			name:  "bold italic is its own run",
+			words: []EnrichedWord{bold("one"), {Text: "two", IsBold: true, IsItalic: true}, bold("three")},
+			want:  "**one** ***two*** **three**",
+		},
+		{
+			name:  "code run",
+			words: []EnrichedWord{plain("Run"), code("go"), code("test"), plain("now")},
+			want:  "Run `go test` now",
+		},
+		{
+			name:  "punctuation joins the run before it",
+			words: []EnrichedWord{bold("Note"), plain(":"), plain("read"), plain("this")},
+			want:  "**Note :** read this",
+		},
+		{
+			name:  "punctuation at the start stays plain",
+			words: []EnrichedWord{plain("—"), bold("Important")},
+			want:  "— **Important**",
+		},
+		{
+			name:  "links break runs",
+			words: []EnrichedWord{bold("see"), {Text: "docs.", Link: "https://example.com", IsBold: true}, bold("now")},
+			want:  "**see** [docs](https://example.com). **now**",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatInlineRuns(tt.words); got != tt.want {
+				t.Errorf("formatInlineRuns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
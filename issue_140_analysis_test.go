@@ -1,15 +1,15 @@
 package pdfmarkdown_test
 
 import (
-	"encoding/json"
-	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
 	"github.com/klippa-app/go-pdfium/requests"
 	"github.com/klippa-app/go-pdfium/webassembly"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -76,24 +76,26 @@ func TestIssue140_Analysis(t *testing.T) {
 				t.Logf("  [%d,%d]: %q", r, c, content)
 			}
 		}
-
-		// Write table to JSON for inspection
-		tableJSON, _ := json.MarshalIndent(table, "", "  ")
-		outputPath := filepath.Join("testdata", "issue-140-table-analysis.json")
-		_ = os.WriteFile(outputPath, tableJSON, 0644)
-		t.Logf("Table JSON written to: %s", outputPath)
 	}
 
-	// Convert to markdown and inspect
+	// Convert to markdown and check what is known to be right. The page is
+	// stored sideways with a /Rotate entry, so this also covers reading it
+	// as displayed rather than with its text reversed
 	mdDoc := &pdfmarkdown.Document{
 		Pages: []pdfmarkdown.Page{*page},
 	}
 	markdown := mdDoc.ToMarkdown(config)
 
-	outputPath := filepath.Join("testdata", "issue-140-output.md")
-	err = os.WriteFile(outputPath, []byte(markdown), 0644)
-	require.NoError(t, err)
-	t.Logf("Markdown output written to: %s", outputPath)
+	for _, heading := range []string{"# Associated claims", "# Supporting documents", "# Approval history"} {
+		assert.Contains(t, markdown, heading)
+	}
+	assert.Equal(t, 1, strings.Count(markdown, "| Line no | UPC code"), "The line item table should be rendered once, with its header first")
+	for _, item := range []string{"LILYS 40% SLTD ALMND CHOC", "LILYS DRK CHC CRMLZD SLTD", "LILYS ALMND 55% DARK CHOC", "LILYS 55% DARK CHOC BAR"} {
+		assert.Contains(t, markdown, "| "+item+" ", "Each line item should be a table row")
+	}
 
-	t.Logf("\n=== Markdown Output ===\n%s", markdown)
+	// Known gap: the line item table is split in two, with Bill Amount left
+	// out as a paragraph and Accrued Amount onwards in a table of its own,
+	// and the line numbers are lost. Until that is fixed the exact output is
+	// not pinned
 }
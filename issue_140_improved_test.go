@@ -23,15 +23,12 @@ import (
 //	8       | 0085648100380| LILYSKMACENTRAL| SLTD CRMLZD CHC DRK     | 688      | $0.61       | $419.68        | 0.0000        |
 //	...
 //
-// The PDF is rotated 90 degrees (landscape orientation) which causes:
-// - Text coordinates to have negative Y values
-// - Words to be concatenated without spaces (PDF rendering artifact)
-// - Each row appears as a single "word" in extraction
+// The page is stored sideways with a /Rotate entry of 90 degrees, and is read
+// as displayed (landscape orientation).
 //
 // Current issues:
-// 1. Words are merged without spaces: "numberPORateHandling..." instead of "number PO Rate Handling..."
-// 2. Rotation causes negative coordinates
-// 3. Table structure is detected but cell separation is incorrect
+// 1. The table is split in two, with Bill Amount left out as a paragraph
+// 2. The line numbers are lost
 func TestIssue140_ImprovedTableDetection(t *testing.T) {
 	pool, err := webassembly.Init(webassembly.Config{
 		MinIdle:  1,
@@ -90,20 +87,21 @@ func TestIssue140_ImprovedTableDetection(t *testing.T) {
 		t.Logf("\n=== Table in Markdown ===\n%s", markdown)
 
 		// Expected content validation
-		// The table should contain purchase order information
-		// Note: Due to 270° rotation, text is backwards
+		// The table should contain purchase order information, read the way
+		// the page is displayed. Spaces are ignored, as the UPC codes are set
+		// in two runs
 		expectedContent := []string{
-			"5030018465800", // Reversed UPC: 0085648100305 → 5030018465800
-			"0830018465800", // Reversed UPC: 0085648100380 → 0830018465800
-			"3030018465800", // Reversed UPC: 0085648100303 → 3030018465800
-			"0030018465800", // Reversed UPC: 0085648100300 → 0030018465800
-			"LARTNEC",       // CENTRAL backwards (part of LILYSKMACENTRAL)
-			"COHC",          // CHOC backwards
-			"736",           // Amount fragments
-			"886",           // Amount fragments
+			"0085648100305",
+			"0085648100380",
+			"0085648100303",
+			"0085648100300",
+			"CENTRAL",
+			"CHOC",
+			"637",
+			"688",
 		}
 
-		markdownLower := strings.ToLower(markdown)
+		markdownLower := strings.ReplaceAll(strings.ToLower(markdown), " ", "")
 		foundCount := 0
 		for _, content := range expectedContent {
 			if strings.Contains(markdownLower, strings.ToLower(content)) {
@@ -111,7 +109,7 @@ func TestIssue140_ImprovedTableDetection(t *testing.T) {
 			}
 		}
 
-		// Most expected content should be present (accounting for text reversal)
+		// Most expected content should be present
 		require.GreaterOrEqual(t, foundCount, 5,
 			"Most expected content should be present (found %d/%d)", foundCount, len(expectedContent))
 
@@ -221,10 +219,9 @@ func TestIssue140_KnownLimitations(t *testing.T) {
 	t.Log(`
 Known limitations with issue-140-example.pdf:
 
-1. ROTATED TEXT (90 degrees):
-   - Text coordinates have negative Y values
-   - Current rotation detection may not fully handle this case
-   - Rotated text appears as vertical columns instead of horizontal rows
+1. ROTATED PAGE (/Rotate 90):
+   - The page is read as displayed, so its text reads left to right
+   - The wide table is still split in two, and its line numbers are lost
 
 2. WORD BOUNDARY DETECTION:
    - PDF has no whitespace characters between words
@@ -0,0 +1,510 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JSONSchemaVersion is the version of the schema written by WriteJSON. It is
+// raised whenever a field is renamed, removed or changes meaning; new fields
+// may be added without a change.
+const JSONSchemaVersion = 1
+
+// The types below define the JSON schema. They are kept apart from Document
+// and its parts so the internal structures can change without changing it.
+// Boxes are in points from the top-left corner of the page.
+
+type jsonDocument struct {
+	Version         int                 `json:"version"`
+	Metadata        *jsonMetadata       `json:"metadata,omitempty"`
+	EstimatedTokens int                 `json:"estimated_tokens"` // Sum over pages; see Config.TokenEstimator
+	Pages           []jsonPage          `json:"pages"`
+	Duplicates      []jsonDuplicatePage `json:"duplicates,omitempty"`
+}
+
+type jsonMetadata struct {
+	Title        string   `json:"title,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	Subject      string   `json:"subject,omitempty"`
+	Keywords     []string `json:"keywords,omitempty"`
+	CreationDate string   `json:"creation_date,omitempty"` // RFC 3339
+}
+
+// jsonDuplicatePage is a page left out because its content matched an
+// earlier page, possibly in another document (Source).
+type jsonDuplicatePage struct {
+	Page        int    `json:"page"`
+	DuplicateOf int    `json:"duplicate_of"`
+	Source      string `json:"source,omitempty"`
+}
+
+type jsonPage struct {
+	Number              int          `json:"number"`
+	Label               string       `json:"label,omitempty"`
+	Width               float64      `json:"width"`
+	Height              float64      `json:"height"`
+	StructureConfidence float64      `json:"structure_confidence"`
+	EstimatedTokens     int          `json:"estimated_tokens"`
+	Breadcrumb          []string     `json:"breadcrumb,omitempty"`
+	Columns             []jsonBox    `json:"columns,omitempty"`
+	Blocks              []jsonBlock  `json:"blocks"`
+	Tables              []jsonTable  `json:"tables,omitempty"`
+	Figures             []jsonFigure `json:"figures,omitempty"`
+
+	// RejectedTables are the segment-based table candidates turned down
+	RejectedTables []jsonRejectedTable `json:"rejected_tables,omitempty"`
+}
+
+type jsonBox struct {
+	X0 float64 `json:"x0"`
+	Y0 float64 `json:"y0"`
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+}
+
+// jsonBlock is a paragraph. Type is "heading", "list_item", "code",
+// "leaders", "toc", "quote", "equation" or "paragraph".
+type jsonBlock struct {
+	ID              string       `json:"id"` // See ParagraphID
+	Type            string       `json:"type"`
+	Text            string       `json:"text"`
+	Box             jsonBox      `json:"box"`
+	Level           int          `json:"level,omitempty"`             // Headings: 1-6
+	Marker          string       `json:"marker,omitempty"`            // List items: the marker as printed
+	ListLevel       int          `json:"list_level,omitempty"`        // List items: nesting depth
+	Latex           string       `json:"latex,omitempty"`             // Equations: best-effort LaTeX transcription
+	Leaders         []jsonLeader `json:"leaders,omitempty"`           // Leader rows: label/value pairs
+	Entries         []jsonTOC    `json:"entries,omitempty"`           // Table of contents entries (Config.TOCHandling)
+	Breadcrumb      []string     `json:"breadcrumb,omitempty"`        // Enclosing headings, outermost first
+	Alignment       string       `json:"alignment"`                   // CSS text-align value
+	Direction       string       `json:"direction,omitempty"`         // "rtl" for right-to-left text
+	Indent          float64      `json:"indent,omitempty"`            // Left indentation in points
+	FirstLineIndent float64      `json:"first_line_indent,omitempty"` // Points the first line starts right of the rest
+	HangingIndent   float64      `json:"hanging_indent,omitempty"`    // Points the first line starts left of the rest
+	Font            jsonFont     `json:"font"`                        // Dominant font
+	Rotation        float64      `json:"rotation,omitempty"`          // Text angle in degrees for rotated text
+	Lines           []jsonLine   `json:"lines"`
+
+	Review *jsonReview `json:"review,omitempty"` // With Config.IncludeReview
+}
+
+// jsonReview is a Review. Confidence is the lowest of the scores.
+type jsonReview struct {
+	Page       int                `json:"page"`
+	Box        jsonBox            `json:"box"`
+	Confidence float64            `json:"confidence"`
+	Decisions  map[string]float64 `json:"decisions"`
+}
+
+type jsonLeader struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+type jsonTOC struct {
+	Title  string `json:"title"`
+	Page   string `json:"page,omitempty"`
+	Level  int    `json:"level,omitempty"`
+	Anchor string `json:"anchor,omitempty"`
+}
+
+type jsonFont struct {
+	Family string  `json:"family,omitempty"`
+	Name   string  `json:"name,omitempty"`
+	Size   float64 `json:"size"`
+	Weight int     `json:"weight,omitempty"`
+	Color  string  `json:"color,omitempty"` // "#rrggbb"
+}
+
+type jsonLine struct {
+	Text  string     `json:"text"`
+	Box   jsonBox    `json:"box"`
+	Words []jsonWord `json:"words"`
+}
+
+type jsonWord struct {
+	Text      string   `json:"text"`
+	Box       jsonBox  `json:"box"`
+	FontName  string   `json:"font_name,omitempty"`
+	FontSize  float64  `json:"font_size"`
+	Bold      bool     `json:"bold,omitempty"`
+	Italic    bool     `json:"italic,omitempty"`
+	Monospace bool     `json:"monospace,omitempty"`
+	FontFlags []string `json:"font_flags,omitempty"` // See FontFlags.Names
+	Strike    bool     `json:"strikethrough,omitempty"`
+	Underline bool     `json:"underline,omitempty"`
+	Color     string   `json:"color,omitempty"` // "#rrggbb", for text neither near black nor near white
+	Link      string   `json:"link,omitempty"`
+}
+
+type jsonTable struct {
+	ID          string       `json:"id"` // See TableID
+	Box         jsonBox      `json:"box"`
+	Rows        [][]jsonCell `json:"rows"`
+	NumRows     int          `json:"num_rows"`
+	NumCols     int          `json:"num_cols"`
+	Orientation string       `json:"orientation"` // "top" or "left": where the headers are
+
+	// RowBoundaries and ColumnBoundaries are the inferred grid lines, for
+	// correcting with Table.WithBoundaries
+	RowBoundaries    []float64 `json:"row_boundaries"`
+	ColumnBoundaries []float64 `json:"column_boundaries"`
+
+	Provenance jsonTableProvenance `json:"provenance"` // See TableProvenance
+}
+
+type jsonTableProvenance struct {
+	Detector   string               `json:"detector"` // "lines", "segments", "hybrid", "custom" or a custom name
+	Adaptive   bool                 `json:"adaptive,omitempty"`
+	Thresholds *jsonThresholds      `json:"thresholds,omitempty"`
+	Settings   jsonTableSettings    `json:"settings"`
+	Duplicates []jsonTableDuplicate `json:"duplicates,omitempty"` // Tables dropped in favour of this one
+}
+
+type jsonThresholds struct {
+	Horizontal float64 `json:"horizontal"`
+	Vertical   float64 `json:"vertical"`
+}
+
+type jsonTableSettings struct {
+	VerticalStrategy           string  `json:"vertical_strategy"`
+	HorizontalStrategy         string  `json:"horizontal_strategy"`
+	SnapXTolerance             float64 `json:"snap_x_tolerance"`
+	SnapYTolerance             float64 `json:"snap_y_tolerance"`
+	JoinXTolerance             float64 `json:"join_x_tolerance"`
+	JoinYTolerance             float64 `json:"join_y_tolerance"`
+	EdgeMinLength              float64 `json:"edge_min_length"`
+	MinWordsVertical           int     `json:"min_words_vertical"`
+	MinWordsHorizontal         int     `json:"min_words_horizontal"`
+	IntersectionXTolerance     float64 `json:"intersection_x_tolerance"`
+	IntersectionYTolerance     float64 `json:"intersection_y_tolerance"`
+	CellAssignment             string  `json:"cell_assignment"`
+	SplitWordsAtCellBoundaries bool    `json:"split_words_at_cell_boundaries,omitempty"`
+}
+
+type jsonTableDuplicate struct {
+	Detector string  `json:"detector"`
+	Box      jsonBox `json:"box"`
+	Overlap  float64 `json:"overlap"` // Share of the smaller table's area
+}
+
+type jsonCell struct {
+	Text              string  `json:"text"`
+	Box               jsonBox `json:"box"`
+	Alignment         string  `json:"alignment"`
+	VerticalAlignment string  `json:"vertical_alignment"`
+	RowSpan           int     `json:"row_span,omitempty"`
+	ColSpan           int     `json:"col_span,omitempty"`
+	Covered           bool    `json:"covered,omitempty"`
+
+	Review *jsonReview `json:"review,omitempty"` // With Config.IncludeReview
+}
+
+type jsonRejectedTable struct {
+	Box    jsonBox `json:"box"`
+	Reason string  `json:"reason"`
+}
+
+type jsonFigure struct {
+	Box     jsonBox `json:"box"`
+	AltText string  `json:"alt_text,omitempty"`
+}
+
+// ToJSON returns the document's structure as JSON; see WriteJSON.
+func (d *Document) ToJSON(config Config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.WriteJSON(&buf, config); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteJSON writes the document's structure to w as JSON: its metadata, pages
+// with their columns, figures and tables, and paragraphs in reading order with
+// their type, heading level, list marker, heading breadcrumb, font and the
+// lines and words they are made of, each with its bounding box. config is
+// applied as for WriteMarkdown: heading levels are normalized, furniture,
+// blank pages and unselected blocks are dropped when configured, and tables
+// appear when detection is enabled. Paragraphs and tables carry the ids
+// Config.BlockAnchors writes. The schema is versioned by JSONSchemaVersion.
+func (d *Document) WriteJSON(w io.Writer, config Config) error {
+	d.BuildBreadcrumbs(config)
+	pages := d.renderedPages(config)
+
+	doc := jsonDocument{
+		Version: JSONSchemaVersion,
+		Pages:   make([]jsonPage, 0, len(pages)),
+	}
+	if !d.Metadata.IsZero() {
+		doc.Metadata = metadataJSON(d.Metadata)
+	}
+	for _, page := range pages {
+		out := pageJSON(page, config)
+		doc.Pages = append(doc.Pages, out)
+		doc.EstimatedTokens += out.EstimatedTokens
+	}
+	for _, dup := range d.Duplicates {
+		doc.Duplicates = append(doc.Duplicates, jsonDuplicatePage{
+			Page:        dup.PageNumber,
+			DuplicateOf: dup.DuplicateOf.PageNumber,
+			Source:      dup.DuplicateOf.Source,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return errors.Wrap(enc.Encode(doc), "failed to write JSON")
+}
+
+// pageJSON converts a page to its JSON form.
+func pageJSON(page Page, config Config) jsonPage {
+	out := jsonPage{
+		Number:              page.Number,
+		Label:               page.Label,
+		Width:               page.Width,
+		Height:              page.Height,
+		StructureConfidence: page.StructureConfidence,
+		EstimatedTokens:     page.EstimatedTokens(config),
+		Breadcrumb:          page.Breadcrumb,
+		Blocks:              make([]jsonBlock, 0, len(page.Paragraphs)),
+	}
+	if len(page.Columns) > 1 {
+		for _, col := range page.Columns {
+			out.Columns = append(out.Columns, boxJSON(col.Box))
+		}
+	}
+	var bodySize float64
+	if config.IncludeReview {
+		bodySize = pageBodyFontSize(page)
+	}
+	for i, para := range page.Paragraphs {
+		block := blockJSON(para)
+		block.ID = ParagraphID(page.Number, i)
+		if config.IncludeReview {
+			block.Review = reviewJSON(paragraphReview(page, i, bodySize))
+		}
+		out.Blocks = append(out.Blocks, block)
+	}
+	if config.tablesEnabled() {
+		for i, table := range page.Tables {
+			t := tableJSON(table)
+			t.ID = TableID(page.Number, i)
+			if config.IncludeReview {
+				for r, row := range t.Rows {
+					for c := range row {
+						row[c].Review = reviewJSON(page.CellReview(i, r, c))
+					}
+				}
+			}
+			out.Tables = append(out.Tables, t)
+		}
+		for _, reject := range page.Diagnostics.RejectedTables {
+			out.RejectedTables = append(out.RejectedTables, jsonRejectedTable{Box: boxJSON(reject.Box), Reason: reject.Reason})
+		}
+	}
+	for i, fig := range page.Figures {
+		figure := jsonFigure{Box: boxJSON(fig)}
+		if i < len(page.FigureAltText) {
+			figure.AltText = page.FigureAltText[i]
+		}
+		out.Figures = append(out.Figures, figure)
+	}
+	return out
+}
+
+// blockJSON converts a paragraph to its JSON form.
+func blockJSON(para Paragraph) jsonBlock {
+	block := jsonBlock{
+		Type:            "paragraph",
+		Text:            para.Text(),
+		Box:             boxJSON(para.Box),
+		Breadcrumb:      para.Breadcrumb,
+		Alignment:       para.Alignment.String(),
+		Indent:          para.Indent,
+		FirstLineIndent: para.FirstLineIndent,
+		HangingIndent:   para.HangingIndent,
+		Font: jsonFont{
+			Family: para.Font.Family,
+			Name:   para.Font.Name,
+			Size:   para.Font.Size,
+			Weight: para.Font.Weight,
+			Color:  colorJSON(para.Font.Color),
+		},
+		Lines: make([]jsonLine, 0, len(para.Lines)),
+	}
+	if para.OrientedBox != nil {
+		block.Rotation = para.OrientedBox.Angle
+	}
+	if para.ReadingDirection == DirectionRTL {
+		block.Direction = DirectionRTL
+	}
+
+	switch {
+	case para.IsHeading:
+		block.Type = "heading"
+		block.Level = para.HeadingLevel
+	case len(para.TOCEntries) > 0:
+		block.Type = "toc"
+		for _, entry := range para.TOCEntries {
+			block.Entries = append(block.Entries, jsonTOC{Title: entry.Title, Page: entry.Page, Level: entry.Level, Anchor: entry.Anchor})
+		}
+	case len(para.Leaders) > 0:
+		block.Type = "leaders"
+		for _, row := range para.Leaders {
+			block.Leaders = append(block.Leaders, jsonLeader{Label: row.Label, Value: row.Value})
+		}
+	case para.IsList:
+		block.Type = "list_item"
+		block.Marker = para.ListMarker
+		block.ListLevel = para.ListLevel
+	case para.IsCode:
+		block.Type = "code"
+	case para.IsQuote:
+		block.Type = "quote"
+	case para.IsEquation:
+		block.Type = "equation"
+		block.Latex = equationLatex(para)
+	}
+
+	for _, line := range para.Lines {
+		out := jsonLine{
+			Text:  line.Text(),
+			Box:   boxJSON(line.Box),
+			Words: make([]jsonWord, 0, len(line.Words)),
+		}
+		for _, word := range line.Words {
+			out.Words = append(out.Words, jsonWord{
+				Text:      word.Text,
+				Box:       boxJSON(word.Box),
+				FontName:  word.FontName,
+				FontSize:  word.FontSize,
+				Bold:      word.IsBold,
+				Italic:    word.IsItalic,
+				Monospace: word.IsMonospace,
+				FontFlags: word.FontFlags.Names(),
+				Strike:    word.IsStrikethrough,
+				Underline: word.IsUnderline,
+				Color:     wordColorJSON(word.FillColor),
+				Link:      word.Link,
+			})
+		}
+		block.Lines = append(block.Lines, out)
+	}
+	return block
+}
+
+// tableJSON converts a table to its JSON form.
+func tableJSON(table Table) jsonTable {
+	out := jsonTable{
+		Box:         jsonBox{X0: table.BBox.X0, Y0: table.BBox.Top, X1: table.BBox.X1, Y1: table.BBox.Bottom},
+		Rows:        make([][]jsonCell, 0, len(table.Rows)),
+		NumRows:     table.NumRows,
+		NumCols:     table.NumCols,
+		Orientation: "top",
+
+		RowBoundaries:    table.RowBoundaries(),
+		ColumnBoundaries: table.ColumnBoundaries(),
+	}
+	if table.Orientation == TableHeaderLeft {
+		out.Orientation = "left"
+	}
+	out.Provenance = tableProvenanceJSON(table.Provenance)
+	for _, row := range table.Rows {
+		cells := make([]jsonCell, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells = append(cells, jsonCell{
+				Text:              strings.TrimSpace(cell.Content),
+				Box:               jsonBox{X0: cell.BBox.X0, Y0: cell.BBox.Top, X1: cell.BBox.X1, Y1: cell.BBox.Bottom},
+				Alignment:         cell.Alignment.String(),
+				VerticalAlignment: cell.VerticalAlignment.String(),
+				RowSpan:           cell.RowSpan,
+				ColSpan:           cell.ColSpan,
+				Covered:           cell.Covered,
+			})
+		}
+		out.Rows = append(out.Rows, cells)
+	}
+	return out
+}
+
+// reviewJSON converts a block's review to its JSON form.
+func reviewJSON(review Review) *jsonReview {
+	return &jsonReview{Page: review.Page, Box: boxJSON(review.Box), Confidence: review.Score(), Decisions: review.Confidence}
+}
+
+// tableProvenanceJSON converts a table's provenance to its JSON form.
+func tableProvenanceJSON(p TableProvenance) jsonTableProvenance {
+	settings := p.Settings
+	out := jsonTableProvenance{
+		Detector: p.Detector,
+		Adaptive: p.Adaptive,
+		Settings: jsonTableSettings{
+			VerticalStrategy:           settings.VerticalStrategy,
+			HorizontalStrategy:         settings.HorizontalStrategy,
+			SnapXTolerance:             settings.SnapXTolerance,
+			SnapYTolerance:             settings.SnapYTolerance,
+			JoinXTolerance:             settings.JoinXTolerance,
+			JoinYTolerance:             settings.JoinYTolerance,
+			EdgeMinLength:              settings.EdgeMinLength,
+			MinWordsVertical:           settings.MinWordsVertical,
+			MinWordsHorizontal:         settings.MinWordsHorizontal,
+			IntersectionXTolerance:     settings.IntersectionXTolerance,
+			IntersectionYTolerance:     settings.IntersectionYTolerance,
+			CellAssignment:             settings.CellAssignment,
+			SplitWordsAtCellBoundaries: settings.SplitWordsAtCellBoundaries,
+		},
+	}
+	if p.Thresholds != nil {
+		out.Thresholds = &jsonThresholds{Horizontal: p.Thresholds.HorizontalThreshold, Vertical: p.Thresholds.VerticalThreshold}
+	}
+	for _, dup := range p.Duplicates {
+		out.Duplicates = append(out.Duplicates, jsonTableDuplicate{
+			Detector: dup.Detector,
+			Box:      jsonBox{X0: dup.BBox.X0, Y0: dup.BBox.Top, X1: dup.BBox.X1, Y1: dup.BBox.Bottom},
+			Overlap:  dup.Overlap,
+		})
+	}
+	return out
+}
+
+// metadataJSON converts document metadata to its JSON form.
+func metadataJSON(metadata Metadata) *jsonMetadata {
+	out := &jsonMetadata{
+		Title:    metadata.Title,
+		Author:   metadata.Author,
+		Subject:  metadata.Subject,
+		Keywords: metadata.KeywordList(),
+	}
+	if !metadata.CreationDate.IsZero() {
+		out.CreationDate = metadata.CreationDate.Format(time.RFC3339)
+	}
+	return out
+}
+
+// boxJSON converts a rectangle to its JSON form.
+func boxJSON(r Rect) jsonBox {
+	return jsonBox{X0: r.X0, Y0: r.Y0, X1: r.X1, Y1: r.Y1}
+}
+
+// colorJSON writes an opaque color as "#rrggbb", or "" for no color.
+// wordColorJSON returns a word's color as colorJSON does, or "" for colors
+// Config.PreserveColors leaves plain.
+func wordColorJSON(c RGBA) string {
+	if !isSpanColor(c) {
+		return ""
+	}
+	return colorJSON(c)
+}
+
+func colorJSON(c RGBA) string {
+	if c == (RGBA{}) {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c.R&0xff, c.G&0xff, c.B&0xff)
+}
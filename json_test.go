@@ -0,0 +1,143 @@
+package pdfmarkdown
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_WriteJSON(t *testing.T) {
+	heading := placedParagraph("Results", 72, 72)
+	heading.IsHeading = true
+	heading.HeadingLevel = 1
+	heading.Font = FontSummary{Family: "Helvetica", Size: 18, Weight: 700, Color: RGBA{R: 255, A: 255}}
+	for i := range heading.Lines[0].Words {
+		heading.Lines[0].Words[i].FontSize = 18
+		heading.Lines[0].Words[i].IsBold = true
+	}
+
+	item := placedParagraph("• North up", 72, 110)
+	item.IsList = true
+	item.ListMarker = "•"
+
+	leaders := placedParagraph("Coffee ...... $3.00", 72, 130)
+	leaders.Leaders = []LeaderRow{{Label: "Coffee", Value: "$3.00"}}
+
+	doc := &Document{Pages: []Page{{
+		Number:     2,
+		Width:      600,
+		Height:     800,
+		Paragraphs: []Paragraph{heading, placedParagraph("Sales rose", 72, 90), item, leaders},
+		Tables: []Table{{
+			BBox:    CellBBox{X0: 72, Top: 200, X1: 272, Bottom: 240},
+			NumRows: 1,
+			NumCols: 2,
+			Rows: []TableRow{{Cells: []TableCell{
+				{Content: "Region ", BBox: CellBBox{X0: 72, Top: 200, X1: 172, Bottom: 220}},
+				{Content: "120", BBox: CellBBox{X0: 172, Top: 200, X1: 272, Bottom: 220}, Alignment: AlignmentRight},
+			}}},
+		}},
+		Figures:       []Rect{{X0: 72, Y0: 300, X1: 300, Y1: 400}},
+		FigureAltText: []string{"Figure 1: Sales"},
+	}}}
+
+	data, err := doc.ToJSON(DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Version int
+		Pages   []struct {
+			Number int
+			Blocks []struct {
+				Type       string
+				Text       string
+				Level      int
+				Marker     string
+				Breadcrumb []string
+				Alignment  string
+				Font       struct {
+					Size  float64
+					Color string
+				}
+				Leaders []struct{ Label, Value string }
+				Lines   []struct {
+					Words []struct {
+						Text string
+						Box  struct{ X0, Y0, X1, Y1 float64 }
+						Bold bool
+					}
+				}
+			}
+			Tables []struct {
+				Orientation string
+				Rows        [][]struct {
+					Text      string
+					Alignment string
+				}
+			}
+			Figures []struct {
+				AltText string `json:"alt_text"`
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+
+	if got.Version != JSONSchemaVersion || len(got.Pages) != 1 || got.Pages[0].Number != 2 {
+		t.Fatalf("unexpected document: %s", data)
+	}
+	blocks := got.Pages[0].Blocks
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want 4", len(blocks))
+	}
+
+	var types []string
+	for _, block := range blocks {
+		types = append(types, block.Type)
+	}
+	if want := []string{"heading", "paragraph", "list_item", "leaders"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("block types = %q, want %q", types, want)
+	}
+
+	if h := blocks[0]; h.Level != 1 || h.Font.Size != 18 || h.Font.Color != "#ff0000" || !h.Lines[0].Words[0].Bold {
+		t.Errorf("heading block = %+v", h)
+	}
+	if word := blocks[0].Lines[0].Words[0]; word.Text != "Results" || word.Box.X0 != 72 || word.Box.Y0 != 72 {
+		t.Errorf("heading word = %+v", word)
+	}
+	if b := blocks[1]; !reflect.DeepEqual(b.Breadcrumb, []string{"Results"}) || b.Alignment != "left" {
+		t.Errorf("paragraph block = %+v", b)
+	}
+	if b := blocks[2]; b.Marker != "•" || b.Text != "• North up" {
+		t.Errorf("list block = %+v", b)
+	}
+	if b := blocks[3]; len(b.Leaders) != 1 || b.Leaders[0].Value != "$3.00" {
+		t.Errorf("leaders block = %+v", b)
+	}
+
+	tables := got.Pages[0].Tables
+	if len(tables) != 1 || tables[0].Orientation != "top" || tables[0].Rows[0][0].Text != "Region" || tables[0].Rows[0][1].Alignment != "right" {
+		t.Errorf("tables = %+v", tables)
+	}
+	if figures := got.Pages[0].Figures; len(figures) != 1 || figures[0].AltText != "Figure 1: Sales" {
+		t.Errorf("figures = %+v", figures)
+	}
+
+	// Tables are left out when detection is disabled, as in markdown
+	config := DefaultConfig()
+	config.DetectTables = false
+	data, err = doc.ToJSON(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var withoutTables struct{ Pages []map[string]json.RawMessage }
+	if err := json.Unmarshal(data, &withoutTables); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := withoutTables.Pages[0]["tables"]; ok {
+		t.Error("tables written with DetectTables disabled")
+	}
+}
@@ -0,0 +1,91 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// LeaderRowStyle selects how rows joined by leader dots ("Coffee ....... $3.00"),
+// as found in price lists, menus and indexes, are rendered.
+type LeaderRowStyle string
+
+const (
+	// LeaderRowsKeep renders leader rows as ordinary text, dots included.
+	LeaderRowsKeep LeaderRowStyle = ""
+
+	// LeaderRowsTable renders consecutive leader rows as a two-column table.
+	LeaderRowsTable LeaderRowStyle = "table"
+
+	// LeaderRowsDash renders each leader row as "Item — $12.00".
+	LeaderRowsDash LeaderRowStyle = "dash"
+)
+
+// LeaderRow is a label and value that were joined by a run of leader dots.
+type LeaderRow struct {
+	Label string
+	Value string
+}
+
+// leaderRowPattern matches a label, a run of at least four leader characters
+// (optionally spaced, as in ". . . ."), and a value. Four rather than three
+// keeps ellipses in prose from being read as leaders.
+var leaderRowPattern = regexp.MustCompile(`^(.*?[^\s.·…_])\s*(?:[.·…_]\s*){4,}(\S.*)$`)
+
+// parseLeaderRow splits a line of text at its leader dots.
+func parseLeaderRow(text string) (LeaderRow, bool) {
+	m := leaderRowPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return LeaderRow{}, false
+	}
+	return LeaderRow{Label: m[1], Value: strings.TrimSpace(m[2])}, true
+}
+
+// detectLeaderRows marks paragraphs whose every line is a leader row. Headings,
+// lists and code are left alone since their own rendering takes priority.
+func detectLeaderRows(paragraphs []Paragraph) {
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if para.IsHeading || para.IsList || para.IsCode || len(para.Lines) == 0 {
+			continue
+		}
+
+		rows := make([]LeaderRow, 0, len(para.Lines))
+		for _, line := range para.Lines {
+			row, ok := parseLeaderRow(line.Text())
+			if !ok {
+				rows = nil
+				break
+			}
+			rows = append(rows, row)
+		}
+		para.Leaders = rows
+	}
+}
+
+// convertLeaderRowsToMarkdown renders leader rows without their dot filler.
+func convertLeaderRowsToMarkdown(md *markdown.Markdown, rows []LeaderRow, style LeaderRowStyle) {
+	if len(rows) == 0 {
+		return
+	}
+
+	if style == LeaderRowsDash {
+		lines := make([]string, len(rows))
+		for i, row := range rows {
+			lines[i] = row.Label + " — " + row.Value
+		}
+		md.PlainText(strings.Join(lines, "  \n"))
+		return
+	}
+
+	// Leader rows have no header, so the table gets an empty one and every row is data
+	tableRows := make([][]string, len(rows))
+	for i, row := range rows {
+		tableRows[i] = []string{row.Label, row.Value}
+	}
+	md.Table(markdown.TableSet{
+		Header: []string{"", ""},
+		Rows:   tableRows,
+	})
+}
@@ -0,0 +1,99 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLeaderRow(t *testing.T) {
+	tests := []struct {
+		text string
+		want LeaderRow
+		ok   bool
+	}{
+		{"Flat White ........ $4.50", LeaderRow{"Flat White", "$4.50"}, true},
+		{"Espresso.......$3.00", LeaderRow{"Espresso", "$3.00"}, true},
+		{"Introduction . . . . . 12", LeaderRow{"Introduction", "12"}, true},
+		{"Scones ··········· 6.00", LeaderRow{"Scones", "6.00"}, true},
+		{"The end...", LeaderRow{}, false},
+		{"Wait... what happened", LeaderRow{}, false},
+		{"Plain text", LeaderRow{}, false},
+		{"........ $4.50", LeaderRow{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseLeaderRow(tt.text)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseLeaderRow(%q) = %+v, %v, want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDocumentToMarkdown_LeaderRows(t *testing.T) {
+	para := func(lines ...string) Paragraph {
+		var p Paragraph
+		for _, text := range lines {
+			var line Line
+			for _, word := range strings.Fields(text) {
+				line.Words = append(line.Words, EnrichedWord{Text: word})
+			}
+			p.Lines = append(p.Lines, line)
+		}
+		return p
+	}
+
+	page := Page{Paragraphs: []Paragraph{
+		para("Espresso ........ $3.00", "Flat White ........ $4.50"),
+		para("Scones ........ $6.00"),
+		para("All prices include GST."),
+	}}
+
+	tests := []struct {
+		name  string
+		style LeaderRowStyle
+		want  []string
+		avoid []string
+	}{
+		{
+			name:  "keep",
+			style: LeaderRowsKeep,
+			want:  []string{"Espresso ........ $3.00"},
+		},
+		{
+			name:  "table",
+			style: LeaderRowsTable,
+			want:  []string{"| Espresso   | $3.00 |", "| Flat White | $4.50 |", "| Scones     | $6.00 |", "All prices include GST."},
+			avoid: []string{"...."},
+		},
+		{
+			name:  "dash",
+			style: LeaderRowsDash,
+			want:  []string{"Espresso — $3.00", "Flat White — $4.50", "Scones — $6.00"},
+			avoid: []string{"....", "|"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := page
+			p.Paragraphs = append([]Paragraph(nil), page.Paragraphs...)
+			if tt.style != LeaderRowsKeep {
+				detectLeaderRows(p.Paragraphs)
+			}
+
+			doc := &Document{Pages: []Page{p}}
+			got := doc.ToMarkdown(Config{LeaderRows: tt.style})
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("markdown missing %q:\n%s", want, got)
+				}
+			}
+			for _, avoid := range tt.avoid {
+				if strings.Contains(got, avoid) {
+					t.Errorf("markdown unexpectedly contains %q:\n%s", avoid, got)
+				}
+			}
+		})
+	}
+}
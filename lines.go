@@ -1,6 +1,9 @@
 package pdfmarkdown
 
 import (
+	"math"
+	"sort"
+
 	"github.com/klippa-app/go-pdfium"
 	"github.com/klippa-app/go-pdfium/enums"
 	"github.com/klippa-app/go-pdfium/references"
@@ -52,11 +55,8 @@ func extractLinesFromPage(instance pdfium.Pdfium, page references.FPDF_PAGE, pag
 			continue
 		}
 
-		// Convert PDF coordinates (origin bottom-left) to standard (origin top-left)
-		x0 := float64(boundsResp.Left)
-		y0 := pageHeight - float64(boundsResp.Top)
-		x1 := float64(boundsResp.Right)
-		y1 := pageHeight - float64(boundsResp.Bottom)
+		bounds := pdfBounds(boundsResp.Left, boundsResp.Bottom, boundsResp.Right, boundsResp.Top).ToPage(pageHeight)
+		x0, y0, x1, y1 := bounds.X0, bounds.Y0, bounds.X1, bounds.Y1
 
 		// Get path segments to determine if it's a line
 		segCountResp, err := instance.FPDFPath_CountSegments(&requests.FPDFPath_CountSegments{
@@ -198,3 +198,199 @@ func boundsToEdges(x0, y0, x1, y1 float64) []Edge {
 		},
 	}
 }
+
+// ruleGlyphs are characters some PDFs stack vertically to draw column rules.
+var ruleGlyphs = map[rune]bool{
+	'|': true,
+	'¦': true,
+	'│': true,
+	'┃': true,
+	'║': true,
+}
+
+// isRuleGlyphWord reports whether a word consists solely of vertical rule glyphs.
+func isRuleGlyphWord(word EnrichedWord) bool {
+	if word.Text == "" {
+		return false
+	}
+	for _, r := range word.Text {
+		if !ruleGlyphs[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// extractRuleGlyphEdges removes stacks of vertical rule glyphs from the word list
+// and returns them as vertical edges instead. A decorative rule drawn with text
+// glyphs would otherwise surface as stray "|" characters in paragraphs.
+// Glyphs only stack when each touches or nearly touches the one above it, so
+// unrelated pipe characters that happen to share an x position stay text.
+func extractRuleGlyphEdges(words []EnrichedWord) ([]EnrichedWord, []Edge) {
+	const sameXTolerance = 2.0 // Glyphs within 2pt horizontally belong to the same rule
+	const minStackSize = 3     // Fewer stacked glyphs are more likely genuine text
+
+	type stack struct {
+		x       float64
+		indices []int
+	}
+
+	var columns []stack
+	for i, word := range words {
+		if !isRuleGlyphWord(word) {
+			continue
+		}
+		centerX := word.Box.CenterX()
+		found := false
+		for si := range columns {
+			if math.Abs(columns[si].x-centerX) <= sameXTolerance {
+				columns[si].indices = append(columns[si].indices, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			columns = append(columns, stack{x: centerX, indices: []int{i}})
+		}
+	}
+
+	// Split each column of glyphs into vertically contiguous stacks. The gap
+	// to the next glyph may be up to about one line height, which covers the
+	// leading between glyphs drawn one per text line.
+	var stacks []stack
+	for _, column := range columns {
+		sort.Slice(column.indices, func(a, b int) bool {
+			return words[column.indices[a]].Box.Y0 < words[column.indices[b]].Box.Y0
+		})
+		current := stack{x: column.x}
+		bottom := 0.0
+		for _, idx := range column.indices {
+			box := words[idx].Box
+			if len(current.indices) > 0 && box.Y0-bottom > box.Height() {
+				stacks = append(stacks, current)
+				current = stack{x: column.x}
+			}
+			if len(current.indices) == 0 || box.Y1 > bottom {
+				bottom = box.Y1
+			}
+			current.indices = append(current.indices, idx)
+		}
+		stacks = append(stacks, current)
+	}
+
+	removed := make(map[int]bool)
+	var edges []Edge
+	for _, s := range stacks {
+		if len(s.indices) < minStackSize {
+			continue
+		}
+
+		top := math.MaxFloat64
+		bottom := -math.MaxFloat64
+		for _, idx := range s.indices {
+			top = math.Min(top, words[idx].Box.Y0)
+			bottom = math.Max(bottom, words[idx].Box.Y1)
+			removed[idx] = true
+		}
+
+		edges = append(edges, Edge{
+			X0:          s.x,
+			X1:          s.x,
+			Top:         top,
+			Bottom:      bottom,
+			Height:      bottom - top,
+			Orientation: "v",
+		})
+	}
+
+	if len(removed) == 0 {
+		return words, nil
+	}
+
+	remaining := make([]EnrichedWord, 0, len(words)-len(removed))
+	for i, word := range words {
+		if !removed[i] {
+			remaining = append(remaining, word)
+		}
+	}
+
+	return remaining, edges
+}
+
+// classifyColumnSeparators marks long vertical rules that sit in the gutter
+// between text columns. Such rules are layout decoration rather than table
+// borders: they feed column detection and are ignored by line-based tables.
+func classifyColumnSeparators(edges []Edge, words []EnrichedWord, pageHeight float64) {
+	const minSpanRatio = 0.25  // Rule must span at least 25% of the page height
+	const crossTolerance = 3.0 // Horizontal edges ending within 3pt don't count as crossing
+
+	for i := range edges {
+		v := &edges[i]
+		if v.Orientation != "v" || v.Height < pageHeight*minSpanRatio {
+			continue
+		}
+
+		// Table borders are crossed by row rules, or closed off at both ends by
+		// cell outlines meeting them at a corner; column separators are neither
+		crossed := false
+		cappedTop, cappedBottom := false, false
+		for _, h := range edges {
+			if h.Orientation != "h" {
+				continue
+			}
+			if h.X0 < v.X0-crossTolerance && h.X1 > v.X1+crossTolerance &&
+				h.Top > v.Top+crossTolerance && h.Top < v.Bottom-crossTolerance {
+				crossed = true
+				break
+			}
+			if endsAt(h, *v, crossTolerance) {
+				cappedTop = cappedTop || math.Abs(h.Top-v.Top) <= crossTolerance
+				cappedBottom = cappedBottom || math.Abs(h.Top-v.Bottom) <= crossTolerance
+			}
+		}
+		if crossed || (cappedTop && cappedBottom) {
+			continue
+		}
+
+		// Text must sit on both sides of the rule, and no word may straddle it
+		var left, right int
+		straddled := false
+		for _, word := range words {
+			if word.Box.Y1 < v.Top || word.Box.Y0 > v.Bottom {
+				continue
+			}
+			switch {
+			case word.Box.X1 <= v.X0:
+				left++
+			case word.Box.X0 >= v.X1:
+				right++
+			default:
+				straddled = true
+			}
+			if straddled {
+				break
+			}
+		}
+
+		if !straddled && left > 0 && right > 0 {
+			v.IsColumnSeparator = true
+		}
+	}
+}
+
+// endsAt reports whether one end of horizontal edge h sits on vertical edge v.
+func endsAt(h, v Edge, tolerance float64) bool {
+	x := (v.X0 + v.X1) / 2
+	return math.Abs(h.X0-x) <= tolerance || math.Abs(h.X1-x) <= tolerance
+}
+
+// columnSeparatorPositions returns the X positions of edges classified as column separators.
+func columnSeparatorPositions(edges []Edge) []float64 {
+	var positions []float64
+	for _, edge := range edges {
+		if edge.IsColumnSeparator {
+			positions = append(positions, (edge.X0+edge.X1)/2)
+		}
+	}
+	return positions
+}
@@ -0,0 +1,252 @@
+package pdfmarkdown
+
+import (
+	"testing"
+)
+
+// twoColumnWords builds two columns of words either side of a gutter at X=300
+func twoColumnWords() []EnrichedWord {
+	var words []EnrichedWord
+	for i := 0; i < 20; i++ {
+		y := float64(100 + i*15)
+		words = append(words,
+			EnrichedWord{Text: "Left", Box: Rect{X0: 50, Y0: y, X1: 290, Y1: y + 10}},
+			EnrichedWord{Text: "Right", Box: Rect{X0: 310, Y0: y, X1: 560, Y1: y + 10}},
+		)
+	}
+	return words
+}
+
+// TestClassifyColumnSeparators tests that gutter rules are distinguished from table borders
+func TestClassifyColumnSeparators(t *testing.T) {
+	words := twoColumnWords()
+
+	t.Run("rule in gutter is a column separator", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 300, X1: 300, Top: 90, Bottom: 400, Height: 310, Orientation: "v"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if !edges[0].IsColumnSeparator {
+			t.Error("Expected gutter rule to be classified as column separator")
+		}
+	})
+
+	t.Run("rule crossed by horizontal edges is a table border", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 300, X1: 300, Top: 90, Bottom: 400, Height: 310, Orientation: "v"},
+			{X0: 40, X1: 570, Top: 200, Bottom: 200, Width: 530, Orientation: "h"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if edges[0].IsColumnSeparator {
+			t.Error("Crossed rule should not be classified as column separator")
+		}
+	})
+
+	t.Run("rule closed off by cell outlines is a table border", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 300, X1: 300, Top: 90, Bottom: 400, Height: 310, Orientation: "v"},
+			{X0: 300, X1: 560, Top: 90, Bottom: 90, Width: 260, Orientation: "h"},
+			{X0: 40, X1: 300, Top: 400, Bottom: 400, Width: 260, Orientation: "h"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if edges[0].IsColumnSeparator {
+			t.Error("Rule ending at cell corners should not be classified as column separator")
+		}
+	})
+
+	t.Run("rule between page-wide rules is a column separator", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 300, X1: 300, Top: 90, Bottom: 400, Height: 310, Orientation: "v"},
+			{X0: 40, X1: 570, Top: 90, Bottom: 90, Width: 530, Orientation: "h"},
+			{X0: 40, X1: 570, Top: 400, Bottom: 400, Width: 530, Orientation: "h"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if !edges[0].IsColumnSeparator {
+			t.Error("Rule merely touching page-wide rules should still be a column separator")
+		}
+	})
+
+	t.Run("short rule is ignored", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 300, X1: 300, Top: 100, Bottom: 150, Height: 50, Orientation: "v"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if edges[0].IsColumnSeparator {
+			t.Error("Short rule should not be classified as column separator")
+		}
+	})
+
+	t.Run("rule through text is ignored", func(t *testing.T) {
+		edges := []Edge{
+			{X0: 100, X1: 100, Top: 90, Bottom: 400, Height: 310, Orientation: "v"},
+		}
+		classifyColumnSeparators(edges, words, 792)
+		if edges[0].IsColumnSeparator {
+			t.Error("Rule straddled by words should not be classified as column separator")
+		}
+	})
+}
+
+// TestExtractRuleGlyphEdges tests that stacked bar glyphs become edges, not text
+func TestExtractRuleGlyphEdges(t *testing.T) {
+	var words []EnrichedWord
+	for i := 0; i < 5; i++ {
+		y := float64(100 + i*12)
+		words = append(words, EnrichedWord{Text: "│", Box: Rect{X0: 299, Y0: y, X1: 301, Y1: y + 12}})
+	}
+	words = append(words,
+		EnrichedWord{Text: "a|b", Box: Rect{X0: 50, Y0: 100, X1: 80, Y1: 110}},
+		EnrichedWord{Text: "|", Box: Rect{X0: 100, Y0: 100, X1: 102, Y1: 110}},
+	)
+
+	remaining, edges := extractRuleGlyphEdges(words)
+
+	if len(edges) != 1 {
+		t.Fatalf("Expected 1 rule edge, got %d", len(edges))
+	}
+	if edges[0].Orientation != "v" || edges[0].Top != 100 || edges[0].Bottom != 160 {
+		t.Errorf("Unexpected rule edge: %+v", edges[0])
+	}
+
+	// Mixed text and a lone bar glyph are genuine content
+	if len(remaining) != 2 {
+		t.Errorf("Expected 2 remaining words, got %d", len(remaining))
+	}
+}
+
+// TestExtractRuleGlyphEdges_RequiresContiguousStack tests that pipe glyphs
+// sharing an x position but scattered down the page stay text
+func TestExtractRuleGlyphEdges_RequiresContiguousStack(t *testing.T) {
+	var words []EnrichedWord
+	// Three unrelated pipes in the same column, far apart
+	for _, y := range []float64{100, 300, 500} {
+		words = append(words, EnrichedWord{Text: "|", Box: Rect{X0: 299, Y0: y, X1: 301, Y1: y + 10}})
+	}
+	// A rule drawn one glyph per line, with leading between the glyphs
+	for i := 0; i < 4; i++ {
+		y := float64(600 + i*14)
+		words = append(words, EnrichedWord{Text: "|", Box: Rect{X0: 299, Y0: y, X1: 301, Y1: y + 10}})
+	}
+
+	remaining, edges := extractRuleGlyphEdges(words)
+
+	if len(edges) != 1 {
+		t.Fatalf("Expected 1 rule edge, got %d: %+v", len(edges), edges)
+	}
+	if edges[0].Top != 600 || edges[0].Bottom != 652 {
+		t.Errorf("Expected rule from 600 to 652, got %+v", edges[0])
+	}
+	if len(remaining) != 3 {
+		t.Errorf("Expected the 3 scattered pipes to remain as words, got %d", len(remaining))
+	}
+}
+
+// TestDetectColumnsWithSeparators tests that separator rules create column splits
+func TestDetectColumnsWithSeparators(t *testing.T) {
+	// Words packed so tightly that the whitespace gutter is too narrow to detect
+	var words []EnrichedWord
+	for i := 0; i < 10; i++ {
+		y := float64(100 + i*15)
+		words = append(words,
+			EnrichedWord{Text: "Left", Box: Rect{X0: 50, Y0: y, X1: 298, Y1: y + 10}},
+			EnrichedWord{Text: "Right", Box: Rect{X0: 304, Y0: y, X1: 560, Y1: y + 10}},
+		)
+	}
+
+	if columns := detectColumns(words, 612); len(columns) != 1 {
+		t.Fatalf("Expected narrow gutter to produce 1 column without rules, got %d", len(columns))
+	}
+
+	columns := detectColumnsWithSeparators(words, 612, []float64{301})
+	if len(columns) != 2 {
+		t.Fatalf("Expected 2 columns with separator rule, got %d", len(columns))
+	}
+	if columns[0].Box.X1 != 301 {
+		t.Errorf("Expected column split at separator, got %v", columns[0].Box.X1)
+	}
+}
+
+// TestDetermineReadingOrder_KeepsParagraphsOutsideColumns tests that text past
+// the page edge is still ordered rather than dropped
+func TestDetermineReadingOrder_KeepsParagraphsOutsideColumns(t *testing.T) {
+	columns := []Column{
+		{Box: Rect{X0: 0, X1: 300}},
+		{Box: Rect{X0: 300, X1: 400}},
+	}
+	paragraphs := []Paragraph{
+		{Box: Rect{X0: 50, Y0: 100, X1: 250, Y1: 110}},
+		{Box: Rect{X0: 420, Y0: 50, X1: 700, Y1: 60}},
+	}
+
+	ordered := determineReadingOrder(paragraphs, columns)
+	if len(ordered) != 2 {
+		t.Fatalf("Expected 2 paragraphs, got %d", len(ordered))
+	}
+	if ordered[1].Box.X0 != 420 {
+		t.Errorf("Expected off-page paragraph in the last column, got %+v", ordered[1].Box)
+	}
+}
+
+// TestSplitLinesAtColumns tests that lines are split at a gutter but a title
+// running across it stays whole
+func TestSplitLinesAtColumns(t *testing.T) {
+	columns := []Column{
+		{Box: Rect{X0: 0, X1: 300}},
+		{Box: Rect{X0: 300, X1: 612}},
+	}
+	word := func(text string, x0, x1 float64) EnrichedWord {
+		return EnrichedWord{Text: text, FontSize: 10, Box: Rect{X0: x0, Y0: 100, X1: x1, Y1: 110}}
+	}
+	lines := []Line{
+		{Words: []EnrichedWord{word("Left", 50, 250), word("Right", 320, 550)}, Box: Rect{X0: 50, Y0: 100, X1: 550, Y1: 110}},
+		{Words: []EnrichedWord{word("Annual", 250, 295), word("Report", 298, 340)}, Box: Rect{X0: 250, Y0: 100, X1: 340, Y1: 110}},
+	}
+
+	got := splitLinesAtColumns(lines, columns)
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(got))
+	}
+	if got[0].Text() != "Left" || got[1].Text() != "Right" || got[1].Box.X0 != 320 {
+		t.Errorf("Unexpected split: %q %q %+v", got[0].Text(), got[1].Text(), got[1].Box)
+	}
+	if got[2].Text() != "Annual Report" {
+		t.Errorf("Expected title to stay whole, got %q", got[2].Text())
+	}
+}
+
+// TestJoinColumnContinuations tests that a paragraph running from the foot of
+// one column to the top of the next is merged, and finished ones are not
+func TestJoinColumnContinuations(t *testing.T) {
+	columns := []Column{
+		{Box: Rect{X0: 0, X1: 300}},
+		{Box: Rect{X0: 300, X1: 612}},
+	}
+	para := func(x0, y0 float64, text string, size float64) Paragraph {
+		word := EnrichedWord{Text: text, FontName: "Times", FontSize: size, Box: Rect{X0: x0, Y0: y0, X1: x0 + 200, Y1: y0 + 10}}
+		return Paragraph{Lines: []Line{{Words: []EnrichedWord{word}, Box: word.Box}}, Box: word.Box}
+	}
+
+	tests := []struct {
+		name       string
+		paragraphs []Paragraph
+		want       int
+	}{
+		{"runs on", []Paragraph{para(50, 700, "the results were", 10), para(320, 72, "consistent.", 10)}, 1},
+		{"ends a sentence", []Paragraph{para(50, 700, "were consistent.", 10), para(320, 72, "Next", 10)}, 2},
+		{"different font", []Paragraph{para(50, 700, "the results were", 10), para(320, 72, "Methods", 14)}, 2},
+		{"starts a list", []Paragraph{para(50, 700, "the results were", 10), para(320, 72, "•", 10)}, 2},
+		{"same column", []Paragraph{para(50, 600, "the results were", 10), para(50, 700, "consistent.", 10)}, 2},
+		{"below the previous", []Paragraph{para(50, 100, "the results were", 10), para(320, 300, "consistent.", 10)}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinColumnContinuations(tt.paragraphs, columns)
+			if len(got) != tt.want {
+				t.Fatalf("got %d paragraphs, want %d", len(got), tt.want)
+			}
+			if tt.want == 1 && got[0].Text() != "the results were\nconsistent." {
+				t.Errorf("merged text = %q", got[0].Text())
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+)
+
+// InternalLink is a link annotation pointing at another place in the same
+// document, such as a table of contents entry.
+type InternalLink struct {
+	Box        Rect     // Clickable area, in top-left page coordinates
+	PageNumber int      // 1-indexed destination page
+	Dest       PDFPoint // Destination on its page, in PDF space
+	HasY       bool     // Whether the destination gives a height
+}
+
+// readInternalLinks reads the page's links to destinations in the document.
+// Links to URLs and other documents are skipped, as are links whose
+// destination page pdfium can't resolve.
+func readInternalLinks(instance pdfium.Pdfium, doc references.FPDF_DOCUMENT, page references.FPDF_PAGE, pageHeight float64) []InternalLink {
+	var links []InternalLink
+	start := 0
+	for {
+		resp, err := instance.FPDFLink_Enumerate(&requests.FPDFLink_Enumerate{
+			Page:     requests.Page{ByReference: &page},
+			StartPos: start,
+		})
+		if err != nil || resp.Link == nil || resp.NextStartPos == nil {
+			return links
+		}
+		start = *resp.NextStartPos
+
+		if link, ok := readInternalLink(instance, doc, *resp.Link, pageHeight); ok {
+			links = append(links, link)
+		}
+	}
+}
+
+// readInternalLink reads a link annotation, reporting false unless it points
+// at a page of the document.
+func readInternalLink(instance pdfium.Pdfium, doc references.FPDF_DOCUMENT, link references.FPDF_LINK, pageHeight float64) (InternalLink, bool) {
+	dest, ok := linkDest(instance, doc, link)
+	if !ok {
+		return InternalLink{}, false
+	}
+	index, err := instance.FPDFDest_GetDestPageIndex(&requests.FPDFDest_GetDestPageIndex{Document: doc, Dest: dest})
+	if err != nil || index.Index < 0 {
+		return InternalLink{}, false
+	}
+	rect, err := instance.FPDFLink_GetAnnotRect(&requests.FPDFLink_GetAnnotRect{Link: link})
+	if err != nil || rect.Rect == nil {
+		return InternalLink{}, false
+	}
+
+	internal := InternalLink{
+		Box:        pdfBounds(rect.Rect.Left, rect.Rect.Bottom, rect.Rect.Right, rect.Rect.Top).ToPage(pageHeight),
+		PageNumber: index.Index + 1,
+	}
+
+	// The height is converted once the destination page's height is known
+	location, err := instance.FPDFDest_GetLocationInPage(&requests.FPDFDest_GetLocationInPage{Dest: dest})
+	if err == nil && location.Y != nil {
+		internal.Dest.Y, internal.HasY = float64(*location.Y), true
+		if location.X != nil {
+			internal.Dest.X = float64(*location.X)
+		}
+	}
+	return internal, true
+}
+
+// linkDest returns a link's destination, given directly or through a GoTo
+// action. Actions opening URLs or other documents have none.
+func linkDest(instance pdfium.Pdfium, doc references.FPDF_DOCUMENT, link references.FPDF_LINK) (references.FPDF_DEST, bool) {
+	direct, err := instance.FPDFLink_GetDest(&requests.FPDFLink_GetDest{Document: doc, Link: link})
+	if err == nil && direct.Dest != nil {
+		return *direct.Dest, true
+	}
+
+	action, err := instance.FPDFLink_GetAction(&requests.FPDFLink_GetAction{Link: link})
+	if err != nil || action.Action == nil {
+		return "", false
+	}
+	kind, err := instance.FPDFAction_GetType(&requests.FPDFAction_GetType{Action: *action.Action})
+	if err != nil || kind.Type != enums.FPDF_ACTION_ACTION_GOTO {
+		return "", false
+	}
+	viaAction, err := instance.FPDFAction_GetDest(&requests.FPDFAction_GetDest{Document: doc, Action: *action.Action})
+	if err != nil || viaAction.Dest == nil {
+		return "", false
+	}
+	return *viaAction.Dest, true
+}
+
+// headingRef locates a heading paragraph in a document.
+type headingRef struct {
+	page, para int
+}
+
+// resolveInternalLinks points the text under each internal link at the
+// anchor of the heading nearest the link's destination, so table of contents
+// entries link into the markdown rather than to page numbers that no longer
+// exist. Words under the link get the anchor as their Link. Links whose
+// destination has no heading on or before its page are left as plain text.
+func resolveInternalLinks(doc *Document) {
+	anchors := headingAnchors(doc)
+
+	// Pages by number, since filtered pages leave gaps
+	pageIndex := make(map[int]int, len(doc.Pages))
+	for i, page := range doc.Pages {
+		pageIndex[page.Number] = i
+	}
+
+	for pi := range doc.Pages {
+		page := &doc.Pages[pi]
+		for _, link := range page.Links {
+			target, ok := nearestHeading(doc, pageIndex, link)
+			if !ok {
+				continue
+			}
+			linkParagraphs(page.Paragraphs, link.Box, "#"+anchors[target])
+		}
+	}
+}
+
+// nearestHeading returns the heading closest to the link's destination on
+// the destination page. Without headings there, the last heading before the
+// page is taken, since the destination lies in that heading's section.
+func nearestHeading(doc *Document, pageIndex map[int]int, link InternalLink) (headingRef, bool) {
+	pi, ok := pageIndex[link.PageNumber]
+	if !ok {
+		return headingRef{}, false
+	}
+
+	page := doc.Pages[pi]
+	best, bestDist := -1, math.MaxFloat64
+	for i, para := range page.Paragraphs {
+		if !para.IsHeading || len(para.Lines) == 0 {
+			continue
+		}
+		dist := float64(i) // Without a height, take the first heading
+		if link.HasY {
+			dist = math.Abs(para.Box.Y0 - link.Dest.ToPage(page.Height).Y)
+		}
+		if dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	if best >= 0 {
+		return headingRef{page: pi, para: best}, true
+	}
+
+	for pi--; pi >= 0; pi-- {
+		paragraphs := doc.Pages[pi].Paragraphs
+		for i := len(paragraphs) - 1; i >= 0; i-- {
+			if paragraphs[i].IsHeading && len(paragraphs[i].Lines) > 0 {
+				return headingRef{page: pi, para: i}, true
+			}
+		}
+	}
+	return headingRef{}, false
+}
+
+// linkParagraphs links the words whose centres lie in box to target. Lines
+// are copied first, since words are shared with the page's text lines.
+func linkParagraphs(paragraphs []Paragraph, box Rect, target string) {
+	for pi := range paragraphs {
+		para := &paragraphs[pi]
+		if para.IsHeading || para.IsCode || !rectsOverlap(para.Box, box) {
+			continue
+		}
+
+		lines := make([]Line, len(para.Lines))
+		for li, line := range para.Lines {
+			line.Words = append([]EnrichedWord(nil), line.Words...)
+			for wi := range line.Words {
+				word := &line.Words[wi]
+				if word.Link == "" && containsPoint(box, word.Box.CenterX(), word.Box.CenterY()) {
+					word.Link = target
+				}
+			}
+			lines[li] = line
+		}
+		para.Lines = lines
+	}
+}
+
+// linkedLeaderRows returns a paragraph's leader rows with each label linked to
+// its leaderRowLink target. Leader rows are how most tables of contents are
+// read.
+func linkedLeaderRows(para Paragraph) []LeaderRow {
+	rows := append([]LeaderRow(nil), para.Leaders...)
+	for i := range rows {
+		if link := leaderRowLink(para, i); link != "" {
+			rows[i].Label = "[" + rows[i].Label + "](" + link + ")"
+		}
+	}
+	return rows
+}
+
+// leaderRowLink returns the link target of the first word on the line a
+// paragraph's leader row was parsed from, or "" for none.
+func leaderRowLink(para Paragraph, i int) string {
+	if len(para.Leaders) != len(para.Lines) || len(para.Lines[i].Words) == 0 {
+		return ""
+	}
+	return para.Lines[i].Words[0].Link
+}
+
+// containsPoint reports whether the point lies within r.
+func containsPoint(r Rect, x, y float64) bool {
+	return x >= r.X0 && x <= r.X1 && y >= r.Y0 && y <= r.Y1
+}
+
+// headingAnchors gives every heading in the document the anchor markdown
+// renderers generate for it: its text lowercased, with punctuation removed
+// and spaces replaced by hyphens. Repeated anchors are numbered "-1", "-2" and
+// so on, in document order.
+func headingAnchors(doc *Document) map[headingRef]string {
+	anchors := make(map[headingRef]string)
+	seen := make(map[string]int)
+	for pi, page := range doc.Pages {
+		for i, para := range page.Paragraphs {
+			if !para.IsHeading || len(para.Lines) == 0 {
+				continue
+			}
+			anchor := headingAnchor(headingText(para))
+			if n := seen[anchor]; n > 0 {
+				seen[anchor]++
+				anchor += "-" + strconv.Itoa(n)
+			} else {
+				seen[anchor] = 1
+			}
+			anchors[headingRef{page: pi, para: i}] = anchor
+		}
+	}
+	return anchors
+}
+
+// headingText returns the text a heading paragraph is rendered with. Only
+// the first line of a multi-line heading paragraph is the heading.
+func headingText(para Paragraph) string {
+	if len(para.Lines) > 1 {
+		return strings.TrimRight(para.Lines[0].Text(), " \t")
+	}
+	return strings.TrimRight(para.Text(), " \t")
+}
+
+// headingAnchor converts heading text to an anchor the way GitHub does.
+func headingAnchor(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/webassembly"
+)
+
+// TestReadInternalLinks reads the links of a contents page pointing at later
+// pages directly and through a GoTo action, alongside a link to a website.
+func TestReadInternalLinks(t *testing.T) {
+	pool, err := webassembly.Init(webassembly.Config{MinIdle: 1, MaxIdle: 1, MaxTotal: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	instance, err := pool.GetInstance(DefaultInstanceTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := "testdata/internal-links.pdf"
+	doc, err := instance.OpenDocument(&requests.OpenDocument{FilePath: &path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{Document: doc.Document})
+	page, err := instance.FPDF_LoadPage(&requests.FPDF_LoadPage{Document: doc.Document, Index: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.FPDF_ClosePage(&requests.FPDF_ClosePage{Page: page.Page})
+
+	links := readInternalLinks(instance, doc.Document, page.Page, 792)
+	want := []InternalLink{
+		{Box: Rect{X0: 70, Y0: 130, X1: 150, Y1: 147}, PageNumber: 2, Dest: PDFPoint{X: 72, Y: 720}, HasY: true},
+		{Box: Rect{X0: 70, Y0: 160, X1: 120, Y1: 177}, PageNumber: 3, Dest: PDFPoint{X: 72, Y: 520}, HasY: true},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("read %d links, want %d (the website link is skipped): %+v", len(links), len(want), links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, links[i], want[i])
+		}
+	}
+}
+
+// TestResolveInternalLinks tests that links to pages point at the heading
+// nearest their destination
+func TestResolveInternalLinks(t *testing.T) {
+	word := func(text string, x0, y0 float64) EnrichedWord {
+		return EnrichedWord{Text: text, FontSize: 10, Box: Rect{X0: x0, Y0: y0, X1: x0 + 40, Y1: y0 + 10}}
+	}
+	heading := func(text string, y0 float64) Paragraph {
+		w := word(text, 50, y0)
+		w.FontSize = 18
+		return Paragraph{Lines: []Line{{Words: []EnrichedWord{w}, Box: w.Box}}, Box: w.Box, IsHeading: true}
+	}
+	body := func(y0 float64, words ...EnrichedWord) Paragraph {
+		box := Rect{X0: 50, Y0: y0, X1: 500, Y1: y0 + 10}
+		return Paragraph{Lines: []Line{{Words: words, Box: box}}, Box: box}
+	}
+
+	doc := &Document{Pages: []Page{
+		{
+			Number: 1, Height: 800,
+			Paragraphs: []Paragraph{
+				heading("Contents", 50),
+				body(100, word("See", 50, 100), word("Results", 100, 100), word("below.", 150, 100)),
+				body(120, word("Back", 50, 120), word("to", 100, 120), word("contents", 150, 120)),
+			},
+			Links: []InternalLink{
+				// Destination height of 595 is 205 from the top of page 2
+				{Box: Rect{X0: 95, Y0: 95, X1: 200, Y1: 115}, PageNumber: 2, Dest: PDFPoint{Y: 595}, HasY: true},
+				{Box: Rect{X0: 45, Y0: 118, X1: 195, Y1: 132}, PageNumber: 1},
+			},
+		},
+		{
+			Number: 2, Height: 800,
+			Paragraphs: []Paragraph{
+				heading("Method", 50),
+				heading("Results", 200),
+			},
+		},
+	}}
+
+	config := DefaultConfig()
+	config.LinkInternalDestinations = true
+	got := doc.ToMarkdown(config)
+
+	if !strings.Contains(got, "See [Results below](#results).") {
+		t.Errorf("Expected link to the nearest heading, got:\n%s", got)
+	}
+	if !strings.Contains(got, "[Back to contents](#contents)") {
+		t.Errorf("Expected link without a height to the first heading, got:\n%s", got)
+	}
+}
+
+// TestHeadingAnchors tests that anchors follow GitHub's rules, numbering repeats
+func TestHeadingAnchors(t *testing.T) {
+	var paragraphs []Paragraph
+	for _, text := range []string{"1. Getting Started!", "Notes", "Notes", "Q&A: Café"} {
+		paragraphs = append(paragraphs, Paragraph{
+			Lines:     []Line{{Words: []EnrichedWord{{Text: text}}}},
+			IsHeading: true,
+		})
+	}
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: paragraphs}}}
+
+	anchors := headingAnchors(doc)
+	want := []string{"1-getting-started", "notes", "notes-1", "qa-café"}
+	for i, anchor := range want {
+		if got := anchors[headingRef{page: 0, para: i}]; got != anchor {
+			t.Errorf("heading %d anchor = %q, want %q", i, got, anchor)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// listLevel is one level of a list being nested: where its items start and
+// the kind of marker they use.
+type listLevel struct {
+	indent float64
+	kind   string
+}
+
+// inferListLevels sets the nesting depth of list items from their layout. An
+// item indented right of the one above starts a deeper level, and one
+// outdented returns to the level it lines up with. Items starting level with
+// each other but marked differently, such as "a." under "1." or "◦" under
+// "•", nest as well, returning to the outer level at the next marker of its
+// kind. A list runs through consecutive items on the same column; any other
+// paragraph ends it. Lists using Config.ListMarkers keep the levels their
+// markers give.
+func inferListLevels(paragraphs []Paragraph, config Config) {
+	for start := 0; start < len(paragraphs); {
+		if !paragraphs[start].IsList {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(paragraphs) && paragraphs[end].IsList && sameListColumn(paragraphs[end-1], paragraphs[end]) {
+			end++
+		}
+		nestListItems(paragraphs[start:end], config)
+		start = end
+	}
+}
+
+// sameListColumn reports whether item follows prev down the same column,
+// rather than starting at the top of the next.
+func sameListColumn(prev, item Paragraph) bool {
+	return item.Box.Y0 >= prev.Box.Y0-paragraphEm(prev)*minIndentEm
+}
+
+// nestListItems sets the levels of a run of consecutive list items.
+func nestListItems(items []Paragraph, config Config) {
+	for _, item := range items {
+		if item.OrientedBox != nil || len(item.Lines) == 0 {
+			return
+		}
+		if _, _, ok := matchListMarker(item.Lines[0].Text(), config.ListMarkers); ok {
+			return
+		}
+	}
+
+	var stack []listLevel
+	for i := range items {
+		item := &items[i]
+		level := listLevel{indent: item.Indent, kind: listMarkerKind(item.ListMarker)}
+		tolerance := paragraphEm(*item) * minIndentEm
+
+		for len(stack) > 1 && level.indent < stack[len(stack)-1].indent-tolerance {
+			stack = stack[:len(stack)-1]
+		}
+		switch top := len(stack) - 1; {
+		case top < 0 || level.indent > stack[top].indent+tolerance:
+			stack = append(stack, level)
+		case level.kind != stack[top].kind:
+			outer := -1
+			for k := top - 1; k >= 0; k-- {
+				if stack[k].kind == level.kind && math.Abs(stack[k].indent-level.indent) <= tolerance {
+					outer = k
+					break
+				}
+			}
+			if outer >= 0 {
+				stack = stack[:outer+1]
+			} else {
+				stack = append(stack, level)
+			}
+		}
+		item.ListLevel = len(stack) - 1
+	}
+}
+
+// listMarkerKind names the style of a list marker, so that items marked alike
+// are recognised as siblings: the bullet glyph, or the numbering scheme with
+// its punctuation, such as "1." and "1)", roman "i.", letter "a." or the
+// two-part "1.1".
+func listMarkerKind(marker string) string {
+	if marker == "" {
+		return ""
+	}
+	if first, _ := utf8.DecodeRuneInString(marker); !isDigit(first) && !isOrdinalMarker(marker) {
+		return string(first)
+	}
+
+	counter := strings.TrimRight(marker, ".)")
+	if counter == "" {
+		return marker
+	}
+	suffix := marker[len(counter):]
+	switch {
+	case isDigit(rune(counter[0])):
+		return "1" + strings.Repeat(".1", strings.Count(counter, ".")) + suffix
+	case romanValue(counter) > 0 && (len(counter) > 1 || strings.ContainsAny(counter, "ivxIVX")):
+		if counter == strings.ToUpper(counter) {
+			return "I" + suffix
+		}
+		return "i" + suffix
+	case counter == strings.ToUpper(counter):
+		return "A" + suffix
+	default:
+		return "a" + suffix
+	}
+}
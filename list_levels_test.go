@@ -0,0 +1,118 @@
+package pdfmarkdown
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInferListLevels(t *testing.T) {
+	type line struct {
+		text string
+		x    float64
+	}
+	tests := []struct {
+		name  string
+		lines []line
+		want  []int
+	}{
+		{
+			name:  "indented bullets",
+			lines: []line{{"• Fruit", 72}, {"◦ Apples", 90}, {"◦ Pears", 90}, {"▪ Conference", 108}, {"• Vegetables", 72}},
+			want:  []int{0, 1, 1, 2, 0},
+		},
+		{
+			name:  "letters under numbers at the same indent",
+			lines: []line{{"1. Scope", 72}, {"a. Included work", 72}, {"b. Excluded work", 72}, {"2. Terms", 72}},
+			want:  []int{0, 1, 1, 0},
+		},
+		{
+			name:  "two-part numbers",
+			lines: []line{{"1. Scope", 72}, {"1.1. Included work", 72}, {"1.2. Excluded work", 72}, {"2. Terms", 72}},
+			want:  []int{0, 1, 1, 0},
+		},
+		{
+			name:  "outdent past the first item",
+			lines: []line{{"• Second level", 90}, {"• First level", 72}},
+			want:  []int{0, 0},
+		},
+		{
+			name:  "ragged starts",
+			lines: []line{{"• One", 72}, {"• Two", 74}, {"• Three", 71}},
+			want:  []int{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := make([]Paragraph, len(tt.lines))
+			for i, l := range tt.lines {
+				paragraphs[i] = placedParagraph(l.text, l.x, float64(i)*14)
+				paragraphs[i].Indent = l.x
+			}
+			config := DefaultConfig()
+			detectLists(paragraphs, config)
+			inferListLevels(paragraphs, config)
+
+			got := make([]int, len(paragraphs))
+			for i, para := range paragraphs {
+				if !para.IsList {
+					t.Fatalf("%q not detected as a list item", para.Text())
+				}
+				got[i] = para.ListLevel
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("levels = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInferListLevels_CustomMarkers(t *testing.T) {
+	paragraphs := []Paragraph{placedParagraph("1.1 Scope", 72, 0), placedParagraph("(a) Included work", 90, 14)}
+	for i := range paragraphs {
+		paragraphs[i].Indent = paragraphs[i].Lines[0].Box.X0
+	}
+	config := DefaultConfig()
+	config.ListMarkers = LegalListMarkers()
+	detectLists(paragraphs, config)
+	inferListLevels(paragraphs, config)
+	if paragraphs[0].ListLevel != 1 || paragraphs[1].ListLevel != 1 {
+		t.Errorf("levels = %d, %d, want the markers' 1, 1", paragraphs[0].ListLevel, paragraphs[1].ListLevel)
+	}
+}
+
+func TestMarkdownList(t *testing.T) {
+	item := func(text string, level int) Paragraph {
+		var para Paragraph
+		for _, line := range strings.Split(text, "\n") {
+			para.Lines = append(para.Lines, textParagraph(line).Lines...)
+		}
+		para.IsList, para.ListMarker, para.ListLevel = true, para.Lines[0].Words[0].Text, level
+		return para
+	}
+
+	items := []Paragraph{
+		item("1. Scope of the work\nand its limits", 0),
+		item("• Included", 1),
+		item("• Excluded", 1),
+		item("a. Travel", 2),
+		item("2. Terms", 0),
+		item("10. Payment", 1),
+	}
+	want := "1. Scope of the work\n" +
+		"   and its limits\n" +
+		"   - Included\n" +
+		"   - Excluded\n" +
+		"     1. Travel\n" +
+		"2. Terms\n" +
+		"   1. Payment"
+	if got := markdownList(items, DefaultConfig()); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	doc := &Document{Pages: []Page{{Paragraphs: items[:3]}}}
+	if got := doc.ToHTML(DefaultConfig()); !strings.Contains(got, "<ol>\n<li>Scope of the work\nand its limits\n<ul>\n<li>Included</li>\n<li>Excluded</li>\n</ul>\n</li>\n</ol>\n") {
+		t.Errorf("nested HTML list not as expected:\n%s", got)
+	}
+}
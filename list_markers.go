@@ -0,0 +1,69 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ListMarker is a custom list item marker, such as the "(a)", "1.1" and "§2"
+// enumerations of legal and academic documents, recognised alongside the
+// built-in bullets and "1." / "1)" numbers.
+type ListMarker struct {
+	// Pattern matches the marker at the start of a paragraph's text. A match
+	// must start at the beginning of the text and end at a word break
+	Pattern *regexp.Regexp
+
+	// Level is the nesting depth of items with this marker, 0 for top-level
+	// items. It is recorded in Paragraph.ListLevel
+	Level int
+}
+
+// LegalListMarkers returns markers for the enumerations common in contracts
+// and legislation: "§2", "(1)", "1.1", "1.1.1", "(a)" and "(iv)". Roman
+// numerals are tried before letters, so "(i)" is read as a numeral.
+func LegalListMarkers() []ListMarker {
+	return []ListMarker{
+		{Pattern: regexp.MustCompile(`^§\s*\d+(\.\d+)*`)},
+		{Pattern: regexp.MustCompile(`^\(\d+\)`)},
+		{Pattern: regexp.MustCompile(`^\d+\.\d+\.?`), Level: 1},
+		{Pattern: regexp.MustCompile(`^\d+\.\d+\.\d+\.?`), Level: 2},
+		{Pattern: regexp.MustCompile(`^\((i|ii|iii|iv|v|vi|vii|viii|ix|x|xi|xii)\)`), Level: 2},
+		{Pattern: regexp.MustCompile(`^\([a-z]\)`), Level: 1},
+	}
+}
+
+// matchListMarker returns the first custom marker matching the start of text,
+// along with the marker as printed.
+func matchListMarker(text string, markers []ListMarker) (ListMarker, string, bool) {
+	for _, marker := range markers {
+		if marker.Pattern == nil {
+			continue
+		}
+		loc := marker.Pattern.FindStringIndex(text)
+		if loc == nil || loc[0] != 0 || loc[1] == 0 {
+			continue
+		}
+		if next, _ := utf8.DecodeRuneInString(text[loc[1]:]); loc[1] < len(text) && !unicode.IsSpace(next) {
+			continue
+		}
+		return marker, strings.TrimSpace(text[:loc[1]]), true
+	}
+	return ListMarker{}, "", false
+}
+
+// listMarker identifies the list marker starting a line. Custom markers take
+// precedence over the built-in bullets and numbers.
+func (c Config) listMarker(line Line) (marker string, level int, ok bool) {
+	if len(line.Words) == 0 {
+		return "", 0, false
+	}
+	if custom, text, ok := matchListMarker(line.Text(), c.ListMarkers); ok {
+		return text, custom.Level, true
+	}
+	if first := line.Words[0]; first.IsBulletOrNumber() {
+		return first.Text, 0, true
+	}
+	return "", 0, false
+}
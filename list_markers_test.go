@@ -0,0 +1,74 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// textParagraph builds a one-line paragraph from space-separated words.
+func textParagraph(text string) Paragraph {
+	var line Line
+	for _, word := range strings.Fields(text) {
+		line.Words = append(line.Words, EnrichedWord{Text: word})
+	}
+	return Paragraph{Lines: []Line{line}}
+}
+
+func TestDetectLists_CustomMarkers(t *testing.T) {
+	tests := []struct {
+		name       string
+		markers    []ListMarker
+		text       string
+		wantList   bool
+		wantMarker string
+		wantLevel  int
+	}{
+		{name: "built-in bullet", text: "• Item", wantList: true, wantMarker: "•"},
+		{name: "built-in number", text: "3. Item", wantList: true, wantMarker: "3."},
+		{name: "letter without markers", text: "(a) the Buyer", wantList: false},
+		{name: "letter", markers: LegalListMarkers(), text: "(a) the Buyer", wantList: true, wantMarker: "(a)", wantLevel: 1},
+		{name: "roman numeral", markers: LegalListMarkers(), text: "(iv) any Taxes", wantList: true, wantMarker: "(iv)", wantLevel: 2},
+		{name: "numbered clause", markers: LegalListMarkers(), text: "1.1 Definitions", wantList: true, wantMarker: "1.1", wantLevel: 1},
+		{name: "numbered subclause", markers: LegalListMarkers(), text: "4.2.1 Notices", wantList: true, wantMarker: "4.2.1", wantLevel: 2},
+		{name: "section sign", markers: LegalListMarkers(), text: "§ 2 Scope", wantList: true, wantMarker: "§ 2"},
+		{name: "decimal in prose", markers: LegalListMarkers(), text: "1.5x faster than before", wantList: false},
+		{name: "parenthesised word", markers: LegalListMarkers(), text: "(about) forty", wantList: false},
+		{
+			name:       "registered pattern",
+			markers:    []ListMarker{{Pattern: regexp.MustCompile(`^Art\.\s*\d+`)}},
+			text:       "Art. 7 Liability",
+			wantList:   true,
+			wantMarker: "Art. 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{textParagraph(tt.text)}
+			detectLists(paragraphs, Config{ListMarkers: tt.markers})
+			got := paragraphs[0]
+			if got.IsList != tt.wantList || got.ListMarker != tt.wantMarker || got.ListLevel != tt.wantLevel {
+				t.Errorf("IsList, ListMarker, ListLevel = %v, %q, %d, want %v, %q, %d",
+					got.IsList, got.ListMarker, got.ListLevel, tt.wantList, tt.wantMarker, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestConvertParagraphToMarkdown_CustomListMarkers(t *testing.T) {
+	config := Config{ListMarkers: LegalListMarkers()}
+	paragraphs := []Paragraph{
+		textParagraph("(a) the Buyer pays the Price;"),
+		textParagraph("(b) the Seller delivers the Goods."),
+	}
+	detectLists(paragraphs, config)
+
+	doc := &Document{Pages: []Page{{Paragraphs: paragraphs}}}
+	got := doc.ToMarkdown(config)
+	for _, want := range []string{"- (a) the Buyer pays the Price;", "- (b) the Seller delivers the Goods."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -2,85 +2,297 @@ package pdfmarkdown
 
 import (
 	"bytes"
+	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ivanvanderbyl/markdown"
+	"github.com/pkg/errors"
 )
 
 // ToMarkdown converts a document to markdown format.
 func (d *Document) ToMarkdown(config Config) string {
-	// Normalize heading levels across the entire document
-	normalizeDocumentHeadings(d)
-
 	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	if err := d.WriteMarkdown(&buf, config); err != nil {
+		// If there's an error building the markdown, fall back to empty string
+		return ""
+	}
+	return buf.String()
+}
+
+// WriteMarkdown writes the document as markdown to w one page at a time, so
+// large documents can be streamed to a file or socket without holding the
+// whole output in memory.
+func (d *Document) WriteMarkdown(w io.Writer, config Config) error {
+	pages := flowPageBreaks(d.renderedPages(config), config)
+
+	if config.IncludeFrontMatter {
+		if err := writeFrontMatter(w, d.Metadata); err != nil {
+			return err
+		}
+	}
 
-	for i, page := range d.Pages {
-		if i > 0 && config.IncludePageBreaks {
+	pw := newPageWriter(w, config)
+	for _, page := range pages {
+		if err := pw.writePage(page); err != nil {
+			return err
+		}
+	}
+	return pw.close()
+}
+
+// renderedPages prepares the document's pages for rendering with config:
+// heading levels are normalized across the entire document, furniture,
+// blank pages, table of contents pages and unselected blocks are dropped when
+// configured, and internal links and table of contents entries are resolved
+// against the headings that remain.
+func (d *Document) renderedPages(config Config) []Page {
+	normalizeDocumentHeadings(d, config)
+
+	pages := d.Pages
+	if selector := config.blockSelector(); selector != nil {
+		pages = d.Filter(selector).Pages
+	}
+	if config.RemovePageFurniture {
+		pages = withoutPageFurniture(pages)
+	}
+	if config.SkipBlankPages {
+		pages = withoutBlankPages(pages)
+	}
+	if config.TOCHandling == TOCSkip {
+		pages = withoutTOCPages(pages)
+	}
+
+	if config.LinkInternalDestinations {
+		resolveInternalLinks(&Document{Pages: pages})
+	}
+	if config.TOCHandling == TOCClean {
+		pages = cleanTOCPages(pages)
+	}
+	return pages
+}
+
+// pageWriter renders pages as markdown one at a time, for WriteMarkdown and
+// for converters streaming pages as they are extracted.
+type pageWriter struct {
+	out       io.Writer
+	collapser *whitespaceCollapser
+	config    Config
+	pageBuf   bytes.Buffer
+	pages     int  // Pages passed to writePage so far
+	written   bool // Whether any page produced output
+}
+
+// newPageWriter returns a pageWriter writing to w.
+func newPageWriter(w io.Writer, config Config) *pageWriter {
+	pw := &pageWriter{out: w, config: config}
+	if config.CollapseWhitespace {
+		pw.collapser = newWhitespaceCollapser(w)
+		pw.out = pw.collapser
+	}
+	return pw
+}
+
+// writePage renders one page and writes it, preceded by a page break after
+// the first page.
+func (pw *pageWriter) writePage(page Page) error {
+	config := pw.config
+	pw.pageBuf.Reset()
+	md := markdown.NewMarkdown(&pw.pageBuf)
+
+	if pw.pages > 0 && config.IncludePageBreaks && !page.inlineBreak {
+		if config.PageBreakMarker != "" {
+			md.PlainText(pageBreakMarker(config.PageBreakMarker, page)).LF()
+		} else {
 			md.HorizontalRule().LF()
 		}
+	}
+	pw.pages++
 
-		for _, para := range page.Paragraphs {
-			convertParagraphToMarkdown(md, para)
+	var placed []bool
+	if config.FigureImages {
+		placed = make([]bool, len(page.Figures))
+	}
+
+	for j := 0; j < len(page.Paragraphs); j++ {
+		para := page.Paragraphs[j]
+		if config.FigureImages {
+			writeFigureImages(md, page, placed, &para)
+		}
+		if config.BlockAnchors {
+			// Lists and leader rows are anchored by their first paragraph, as
+			// a comment between items would end the list
+			md.PlainText(blockAnchor(ParagraphID(page.Number, j))).LF()
+		}
+		if len(para.TOCEntries) > 0 {
+			// Consecutive table of contents paragraphs form one list
+			entries := append([]TOCEntry(nil), para.TOCEntries...)
+			for j+1 < len(page.Paragraphs) && len(page.Paragraphs[j+1].TOCEntries) > 0 {
+				j++
+				entries = append(entries, page.Paragraphs[j].TOCEntries...)
+			}
+			md.PlainText(tocMarkdown(entries)).LF()
+			continue
+		}
+		if len(para.Leaders) > 0 {
+			// Consecutive leader paragraphs form one list or table
+			rows := linkedLeaderRows(para)
+			for j+1 < len(page.Paragraphs) && len(page.Paragraphs[j+1].Leaders) > 0 {
+				j++
+				rows = append(rows, linkedLeaderRows(page.Paragraphs[j])...)
+			}
+			convertLeaderRowsToMarkdown(md, rows, config.LeaderRows)
 			md.LF()
+			continue
+		}
+
+		// Lists take the direction and alignment of their first item
+		attrs := ""
+		if config.BlockAttributes {
+			attrs = blockAttributes(para)
+		}
+		if attrs != "" {
+			md.PlainText("<div" + attrs + ">").LF()
+		}
+
+		if para.IsEquation && config.FigureImages {
+			// Equations are placeholders like figures, described by their LaTeX
+			alt := strings.NewReplacer("[", `\[`, "]", `\]`, "\n", " ").Replace(equationLatex(para))
+			md.PlainText(markdown.Image(alt, equationTarget(page, j)))
+		} else if isListItem(para) {
+			// Consecutive items of the same kind form one list, along with
+			// the items nested under them
+			items := []Paragraph{para}
+			for j+1 < len(page.Paragraphs) && continuesList(para, page.Paragraphs[j+1], config) {
+				j++
+				if config.FigureImages {
+					item := page.Paragraphs[j]
+					writeFigureImages(md, page, placed, &item)
+				}
+				items = append(items, page.Paragraphs[j])
+			}
+			md.PlainText(markdownList(items, config))
+		} else {
+			convertParagraphToMarkdown(md, para, config)
+		}
+		md.LF()
+		if attrs != "" {
+			md.PlainText("</div>").LF()
 		}
+	}
+
+	if config.FigureImages {
+		writeFigureImages(md, page, placed, nil)
+	}
 
-		// Add tables at the end of the page content
-		if config.DetectTables && len(page.Tables) > 0 {
-			for _, table := range page.Tables {
-				convertTableToMarkdown(md, table)
-				md.LF()
+	// Add tables at the end of the page content
+	if config.tablesEnabled() && len(page.Tables) > 0 {
+		for i, table := range page.Tables {
+			if config.BlockAnchors {
+				md.PlainText(blockAnchor(TableID(page.Number, i))).LF()
 			}
+			convertTableToMarkdown(md, table, config)
+			md.LF()
 		}
 	}
 
 	if err := md.Build(); err != nil {
-		// If there's an error building the markdown, fall back to empty string
-		return ""
+		return errors.Wrapf(err, "failed to build markdown for page %d", page.Number)
+	}
+	if pw.pageBuf.Len() == 0 {
+		return nil
 	}
 
-	return buf.String()
+	// Pages are separated like any other pair of blocks
+	if pw.written {
+		if _, err := io.WriteString(pw.out, "\n"); err != nil {
+			return errors.Wrap(err, "failed to write markdown")
+		}
+	}
+	if _, err := pw.out.Write(pw.pageBuf.Bytes()); err != nil {
+		return errors.Wrap(err, "failed to write markdown")
+	}
+	pw.written = true
+	return nil
 }
 
-// normalizeDocumentHeadings adjusts heading levels across all pages to be consistent
-// This ensures H1 is the largest heading across the entire document, not just within a page
-func normalizeDocumentHeadings(doc *Document) {
-	// Collect all heading font sizes across all pages
-	type HeadingInfo struct {
-		fontSize float64
-		pageIdx  int
-		paraIdx  int
+// close flushes any output held back by whitespace collapsing.
+func (pw *pageWriter) close() error {
+	if pw.collapser != nil {
+		return pw.collapser.Flush()
 	}
+	return nil
+}
 
-	var headings []HeadingInfo
+// normalizeDocumentHeadings adjusts heading levels across all pages to be consistent
+// This ensures H1 is the largest heading across the entire document, not just within a page.
+// Config.HeadingLevels, HeadingLevelOffset and MaxHeadingLevel then adjust the result.
+// Headings pinned by Config.StyleRules keep their levels.
+func normalizeDocumentHeadings(doc *Document, config Config) {
 	fontSizeSet := make(map[float64]bool)
+	for _, page := range doc.Pages {
+		addHeadingSizes(fontSizeSet, page, config)
+	}
+	if len(fontSizeSet) == 0 {
+		return
+	}
 
-	for pi, page := range doc.Pages {
-		for pri, para := range page.Paragraphs {
-			if para.IsHeading && len(para.Lines) > 0 && len(para.Lines[0].Words) > 0 {
-				// Get max font size of the heading
-				var maxSize float64
-				for _, word := range para.Lines[0].Words {
-					if word.FontSize > maxSize {
-						maxSize = word.FontSize
-					}
-				}
-
-				headings = append(headings, HeadingInfo{
-					fontSize: maxSize,
-					pageIdx:  pi,
-					paraIdx:  pri,
-				})
-				fontSizeSet[maxSize] = true
-			}
-		}
+	sizeToLevel := rankHeadingSizes(fontSizeSet, config)
+	for i := range doc.Pages {
+		applyHeadingLevels(&doc.Pages[i], sizeToLevel, config)
 	}
+}
 
-	if len(fontSizeSet) == 0 {
+// headingRanker normalizes heading levels for pages streamed one at a time.
+// Later pages are not known yet, so each page's headings are ranked among the
+// heading sizes seen so far.
+type headingRanker struct {
+	config Config
+	sizes  map[float64]bool
+}
+
+// newHeadingRanker returns a headingRanker that has seen no headings.
+func newHeadingRanker(config Config) *headingRanker {
+	return &headingRanker{config: config, sizes: make(map[float64]bool)}
+}
+
+// apply records the page's heading sizes and sets its heading levels.
+func (r *headingRanker) apply(page *Page) {
+	addHeadingSizes(r.sizes, *page, r.config)
+	if len(r.sizes) == 0 {
 		return
 	}
+	applyHeadingLevels(page, rankHeadingSizes(r.sizes, r.config), r.config)
+}
+
+// headingFontSize returns the size a heading is ranked by: the largest font
+// size on its first line. Headings pinned by style rules or the document
+// outline, or given a fixed level by a HeadingDetector, are not ranked.
+func headingFontSize(para Paragraph, config Config) (float64, bool) {
+	if _, pinned := config.styleRule(para.Font); pinned || para.FromOutline || para.HeadingLevelFixed {
+		// Style rules, the outline and text-based detectors fix their own levels
+		return 0, false
+	}
+	if !para.IsHeading || len(para.Lines) == 0 || len(para.Lines[0].Words) == 0 {
+		return 0, false
+	}
+	return lineMaxFontSize(para.Lines[0]), true
+}
+
+// addHeadingSizes adds the font sizes of the page's ranked headings to sizes.
+func addHeadingSizes(sizes map[float64]bool, page Page, config Config) {
+	for _, para := range page.Paragraphs {
+		if size, ok := headingFontSize(para, config); ok {
+			sizes[size] = true
+		}
+	}
+}
 
+// rankHeadingSizes maps heading font sizes to levels, largest first.
+func rankHeadingSizes(fontSizeSet map[float64]bool, config Config) map[float64]int {
 	// Create sorted list of unique font sizes (descending)
 	var uniqueSizes []float64
 	for size := range fontSizeSet {
@@ -93,26 +305,36 @@ func normalizeDocumentHeadings(doc *Document) {
 		uniqueSizes[i], uniqueSizes[j] = uniqueSizes[j], uniqueSizes[i]
 	}
 
-	// Map font sizes to heading levels (largest = H1, etc.)
+	// Map font sizes to heading levels (largest = H1, etc.), shifted by the
+	// configured offset. Sizes given an explicit level are left out of the ranking.
 	sizeToLevel := make(map[float64]int)
-	for i, size := range uniqueSizes {
-		if i < 6 {
-			sizeToLevel[size] = i + 1
-		} else {
-			sizeToLevel[size] = 6 // Max H6
+	rank := 0
+	for _, size := range uniqueSizes {
+		if level, ok := config.explicitHeadingLevel(size); ok {
+			sizeToLevel[size] = config.clampHeadingLevel(level)
+			continue
 		}
+		rank++
+		sizeToLevel[size] = config.clampHeadingLevel(rank + config.HeadingLevelOffset)
 	}
+	return sizeToLevel
+}
 
-	// Apply normalized levels to all headings
-	for _, h := range headings {
-		if level, ok := sizeToLevel[h.fontSize]; ok {
-			doc.Pages[h.pageIdx].Paragraphs[h.paraIdx].HeadingLevel = level
+// applyHeadingLevels sets the level of each ranked heading on the page.
+func applyHeadingLevels(page *Page, sizeToLevel map[float64]int, config Config) {
+	for i, para := range page.Paragraphs {
+		size, ok := headingFontSize(para, config)
+		if !ok {
+			continue
+		}
+		if level, ok := sizeToLevel[size]; ok {
+			page.Paragraphs[i].HeadingLevel = level
 		}
 	}
 }
 
 // convertParagraphToMarkdown converts a single paragraph to markdown using the builder.
-func convertParagraphToMarkdown(md *markdown.Markdown, para Paragraph) {
+func convertParagraphToMarkdown(md *markdown.Markdown, para Paragraph, config Config) {
 	if len(para.Lines) == 0 {
 		return
 	}
@@ -157,7 +379,7 @@ func convertParagraphToMarkdown(md *markdown.Markdown, para Paragraph) {
 				IsHeading: false,
 			}
 			md.LF()
-			convertParagraphToMarkdown(md, restPara)
+			convertParagraphToMarkdown(md, restPara, config)
 		} else {
 			// Single-line heading - render normally
 			text := strings.TrimRight(para.Text(), " \t")
@@ -196,120 +418,307 @@ func convertParagraphToMarkdown(md *markdown.Markdown, para Paragraph) {
 
 	// Handle lists
 	if para.IsList {
-		text := strings.TrimRight(para.Text(), " \t")
-		// Check if it's a numbered list
-		if len(text) > 0 && (text[0] >= '0' && text[0] <= '9') {
-			// Extract the list item text (after the number and period)
-			parts := strings.SplitN(text, ".", 2)
-			if len(parts) == 2 {
-				md.OrderedList(strings.TrimSpace(parts[1]))
-			} else {
-				md.OrderedList(text)
-			}
-		} else {
-			// Bullet list - remove any existing bullet prefix
-			text = strings.TrimPrefix(text, "* ")
-			text = strings.TrimPrefix(text, "- ")
-			text = strings.TrimPrefix(text, "+ ")
-			md.BulletList(text)
-		}
+		md.PlainText(markdownList([]Paragraph{para}, config))
+		return
+	}
+
+	// Handle display equations
+	if para.IsEquation {
+		md.PlainText("$$\n" + equationLatex(para) + "\n$$")
 		return
 	}
 
-	// Handle regular paragraphs with inline formatting
-	// Special handling: split on numbered items for better readability
-	var currentSection strings.Builder
-	sections := []string{}
+	// Handle regular paragraphs with inline formatting, with each numbered
+	// item set apart for readability. Quotations are set the same way inside
+	// a blockquote
+	var quoted []string
+	for si, section := range numberedSections(para, config) {
+		var b strings.Builder
+		for li, line := range section {
+			if li > 0 {
+				b.WriteString("  \n")
+			}
+			switch {
+			case config.StripInlineFormatting:
+				b.WriteString(joinWords(line.Words))
+			case config.PreserveColors:
+				b.WriteString(colorRuns(line.Words, formatInlineRuns))
+			default:
+				b.WriteString(formatInlineRuns(line.Words))
+			}
+		}
+		text := strings.TrimRight(b.String(), " \t")
+		if text == "" {
+			continue
+		}
+		if para.IsQuote {
+			quoted = append(quoted, text)
+			continue
+		}
+		if si > 0 {
+			md.LF() // Blank line before each numbered item
+		}
+		md.PlainText(text)
+	}
+	if len(quoted) > 0 {
+		md.PlainText(quoteMarkdown(strings.Join(quoted, "\n\n")))
+	}
+}
 
-	for _, line := range para.Lines {
-		// Check if this line starts with a numbered item (2., 3., 4., etc.)
+// numberedSections splits a paragraph's lines before each line starting with
+// a numbered item (2., 3., 4., etc.), unless the previous line ended in an
+// abbreviation such as "No.".
+func numberedSections(para Paragraph, config Config) [][]Line {
+	var sections [][]Line
+	var current []Line
+	for li, line := range para.Lines {
 		startsWithNumber := false
-		if len(line.Words) > 0 {
+		if len(line.Words) > 0 && (li == 0 || !config.endsWithAbbreviation(para.Lines[li-1])) {
 			firstWord := line.Words[0].Text
 			if len(firstWord) >= 2 && firstWord[0] >= '2' && firstWord[0] <= '9' && firstWord[1] == '.' {
 				startsWithNumber = true
 			}
 		}
 
-		// If we hit a new numbered section (and we have content), save current section
-		if startsWithNumber && currentSection.Len() > 0 {
-			sections = append(sections, strings.TrimRight(currentSection.String(), " \t"))
-			currentSection.Reset()
+		if startsWithNumber && len(current) > 0 {
+			sections = append(sections, current)
+			current = nil
 		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
 
-		// Add line break before this line (unless it's the first line or start of new section)
-		if currentSection.Len() > 0 {
-			currentSection.WriteString("  \n")
-		}
+// isListItem reports whether a paragraph is rendered as a list item.
+func isListItem(para Paragraph) bool {
+	return para.IsList && !para.IsHeading && !para.IsCode
+}
 
-		// Build the line content
-		for j, word := range line.Words {
-			if j > 0 {
-				currentSection.WriteString(" ")
-			}
-			formattedWord := applyInlineFormatting(word)
-			currentSection.WriteString(formattedWord)
-		}
+// continuesList reports whether next belongs to the list that first starts:
+// items nested under first's level can be of any kind, while those at its
+// level must be ordered or not alike.
+func continuesList(first, next Paragraph, config Config) bool {
+	if !isListItem(next) {
+		return false
 	}
+	if next.ListLevel > first.ListLevel {
+		return true
+	}
+	_, ordered := listItemText(first, config)
+	_, nextOrdered := listItemText(next, config)
+	return ordered == nextOrdered
+}
 
-	// Add final section
-	if currentSection.Len() > 0 {
-		sections = append(sections, strings.TrimRight(currentSection.String(), " \t"))
+// markdownList writes list items as a markdown list. Each level is indented
+// to the text of the item it nests under, and numbered lists count from 1.
+// Lines after the first of an item are indented to its text too, keeping
+// them in the item.
+func markdownList(items []Paragraph, config Config) string {
+	type level struct {
+		ordered bool
+		counter int
+		text    string // Indent of the text of the level's latest item
 	}
 
-	// Output sections with visual separation
-	if len(sections) == 1 {
-		// Single section - output normally
-		md.PlainText(sections[0])
-	} else if len(sections) > 1 {
-		// Multiple sections - add blank lines between numbered items
-		for si, section := range sections {
-			if section != "" {
-				// Output the section
-				md.PlainText(section)
+	var levels []level
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		text, ordered := listItemText(item, config)
+		depth := min(max(item.ListLevel-items[0].ListLevel, 0), len(levels))
+		indent := ""
+		if depth > 0 {
+			indent = levels[depth-1].text
+		}
 
-				// Add visual separator after each section except the last
-				if si < len(sections)-1 {
-					md.LF() // End current section, creating blank line before next section
-				}
+		levels = levels[:min(depth+1, len(levels))]
+		if depth == len(levels) {
+			levels = append(levels, level{ordered: ordered})
+		}
+		current := &levels[depth]
+		if current.ordered != ordered {
+			*current = level{ordered: ordered}
+		}
+		current.counter++
+
+		marker := "-"
+		if ordered {
+			marker = strconv.Itoa(current.counter) + "."
+		}
+		current.text = indent + strings.Repeat(" ", utf8.RuneCountInString(marker)+1)
+
+		for i, line := range strings.Split(text, "\n") {
+			if i == 0 {
+				lines = append(lines, indent+marker+" "+line)
+			} else {
+				lines = append(lines, current.text+line)
 			}
 		}
 	}
+	return strings.Join(lines, "\n")
 }
 
-// applyInlineFormatting applies markdown formatting to a word based on its style.
-func applyInlineFormatting(word EnrichedWord) string {
-	text := word.Text
+// listItemText returns a list paragraph's item text with its marker removed,
+// and whether the item belongs in a numbered list.
+func listItemText(para Paragraph, config Config) (string, bool) {
+	text := strings.TrimRight(para.Text(), " \t")
+	// Custom markers such as "(a)" have no markdown equivalent and are kept
+	if _, _, ok := matchListMarker(text, config.ListMarkers); ok {
+		return text, false
+	}
+	// Roman numerals and letters become numbers; ListMarker keeps the original
+	if isOrdinalMarker(para.ListMarker) {
+		return strings.TrimSpace(strings.TrimPrefix(text, para.ListMarker)), true
+	}
+	// Check if it's a numbered list
+	if len(text) > 0 && (text[0] >= '0' && text[0] <= '9') {
+		// Extract the list item text (after the number and period)
+		parts := strings.SplitN(text, ".", 2)
+		if len(parts) == 2 {
+			return strings.TrimSpace(parts[1]), true
+		}
+		return text, true
+	}
+	// Bullet list - remove the bullet glyph or any markdown bullet prefix
+	if utf8.RuneCountInString(para.ListMarker) == 1 {
+		text = strings.TrimSpace(strings.TrimPrefix(text, para.ListMarker))
+	}
+	text = strings.TrimPrefix(text, "* ")
+	text = strings.TrimPrefix(text, "- ")
+	text = strings.TrimPrefix(text, "+ ")
+	return text, false
+}
 
-	// Apply bold and italic
-	if word.IsBold && word.IsItalic {
-		return markdown.BoldItalic(text)
+// inlineStyle is the markdown formatting applied to a run of words.
+type inlineStyle int
+
+const (
+	stylePlain inlineStyle = iota
+	styleBold
+	styleItalic
+	styleBoldItalic
+	styleCode
+
+	// styleStrikethrough is combined with any of the others
+	styleStrikethrough inlineStyle = 1 << 4
+)
+
+// wordStyle returns the formatting a word is rendered with. Bold and italic
+// take precedence over monospace.
+func wordStyle(word EnrichedWord) inlineStyle {
+	var style inlineStyle
+	switch {
+	case word.IsBold && word.IsItalic:
+		style = styleBoldItalic
+	case word.IsBold:
+		style = styleBold
+	case word.IsItalic:
+		style = styleItalic
+	case word.IsMonospace:
+		style = styleCode
+	}
+	if word.IsStrikethrough {
+		style |= styleStrikethrough
 	}
+	return style
+}
 
-	// Apply bold
-	if word.IsBold {
+// formatRun wraps text in the markers for style.
+func formatRun(style inlineStyle, text string) string {
+	if style&styleStrikethrough != 0 {
+		return "~~" + formatRun(style&^styleStrikethrough, text) + "~~"
+	}
+	switch style {
+	case styleBoldItalic:
+		return markdown.BoldItalic(text)
+	case styleBold:
 		return markdown.Bold(text)
+	case styleItalic:
+		return markdown.Italic(text)
+	case styleCode:
+		return markdown.Code(text)
+	default:
+		return text
 	}
+}
 
-	// Apply italic
-	if word.IsItalic {
-		return markdown.Italic(text)
+// applyInlineFormatting applies markdown formatting to a word based on its style.
+func applyInlineFormatting(word EnrichedWord) string {
+	// Apply link, leaving sentence punctuation after it
+	if word.Link != "" {
+		shown, trailing := splitTrailingPunctuation(word.Text)
+		return "[" + shown + "](" + word.Link + ")" + trailing
 	}
+	return formatRun(wordStyle(word), word.Text)
+}
 
-	// Apply code (monospace)
-	if word.IsMonospace {
-		return markdown.Code(text)
+// formatInlineRuns renders a line's words, wrapping each run of consecutive
+// words in the same style in one set of markers: "**foo bar**" rather than
+// "**foo** **bar**". Words of only punctuation join the run before them, so
+// a stray unstyled full stop stays inside the markers. Consecutive words with
+// the same link target become one link, without other formatting.
+func formatInlineRuns(words []EnrichedWord) string {
+	var b strings.Builder
+	var run []EnrichedWord
+	var style inlineStyle
+
+	flush := func() {
+		if len(run) > 0 {
+			b.WriteString(formatRun(style, joinWords(run)))
+			run = nil
+		}
 	}
 
-	return text
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word.Link == "" && len(run) > 0 && (wordStyle(word) == style || isPunctuationOnly(word.Text)) {
+			run = append(run, word)
+			continue
+		}
+
+		flush()
+		if i > 0 {
+			b.WriteString(wordSeparator(words[i-1].Text, word.Text))
+		}
+		if word.Link != "" {
+			end := i + 1
+			for end < len(words) && words[end].Link == word.Link {
+				end++
+			}
+			linked := word
+			linked.Text = joinWords(words[i:end])
+			b.WriteString(applyInlineFormatting(linked))
+			i = end - 1
+			continue
+		}
+		run, style = []EnrichedWord{word}, wordStyle(word)
+	}
+	flush()
+
+	return b.String()
+}
+
+// isPunctuationOnly reports whether text is made up entirely of punctuation.
+func isPunctuationOnly(text string) bool {
+	return text != "" && !strings.ContainsFunc(text, func(r rune) bool { return !unicode.IsPunct(r) })
 }
 
 // convertTableToMarkdown converts a table to markdown format using the builder.
-func convertTableToMarkdown(md *markdown.Markdown, table Table) {
+func convertTableToMarkdown(md *markdown.Markdown, table Table, config Config) {
 	if len(table.Rows) == 0 {
 		return
 	}
 
+	if pairs, ok := labelValuePairs(table); ok && convertLabelValuesToMarkdown(md, pairs, config.TwoColumnTableStyle) {
+		return
+	}
+
+	if config.TransposeTables && table.Orientation == TableHeaderLeft {
+		table = transposeTable(table)
+	}
+
 	// Convert table rows to string slices for the markdown builder
 	var header []string
 	var rows [][]string
@@ -320,6 +729,9 @@ func convertTableToMarkdown(md *markdown.Markdown, table Table) {
 			if colIdx < len(row.Cells) {
 				// Replace newlines with spaces in cell content
 				cells[colIdx] = strings.ReplaceAll(row.Cells[colIdx].Content, "\n", " ")
+				if config.PreserveColors && !config.StripInlineFormatting && hasSpanColor(row.Cells[colIdx].Words) {
+					cells[colIdx] = colorRuns(row.Cells[colIdx].Words, joinWords)
+				}
 			} else {
 				cells[colIdx] = ""
 			}
@@ -338,10 +750,25 @@ func convertTableToMarkdown(md *markdown.Markdown, table Table) {
 		rows = [][]string{make([]string, len(header))}
 	}
 
-	md.Table(markdown.TableSet{
-		Header: header,
-		Rows:   rows,
-	})
+	if config.TableMaxRows > 0 && len(rows) > config.TableMaxRows && config.OversizedTables == OversizedTablesCSV {
+		md.CodeBlocks(markdown.SyntaxHighlight("csv"), tableCSV(header, rows))
+		return
+	}
+
+	var alignment []markdown.TableAlignment
+	if config.AlignTableColumns {
+		alignment = markdownAlignments(table.ColumnAlignments())
+	}
+	for i, chunk := range chunkTableRows(rows, config.TableMaxRows) {
+		if i > 0 {
+			md.LF()
+		}
+		md.Table(markdown.TableSet{
+			Header:    header,
+			Rows:      chunk,
+			Alignment: alignment,
+		})
+	}
 }
 
 // PageToMarkdown converts a single page to markdown.
@@ -350,14 +777,14 @@ func (p *Page) ToMarkdown() string {
 	md := markdown.NewMarkdown(&buf)
 
 	for _, para := range p.Paragraphs {
-		convertParagraphToMarkdown(md, para)
+		convertParagraphToMarkdown(md, para, DefaultConfig())
 		md.LF()
 	}
 
 	// Add tables at the end of the page content
 	if len(p.Tables) > 0 {
 		for _, table := range p.Tables {
-			convertTableToMarkdown(md, table)
+			convertTableToMarkdown(md, table, DefaultConfig())
 			md.LF()
 		}
 	}
@@ -0,0 +1,73 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"testing"
+)
+
+// headingPage builds a page with one heading of the given size followed by body text.
+func headingPage(number int, heading string, size float64) Page {
+	return Page{
+		Number: number,
+		Paragraphs: []Paragraph{
+			{
+				Lines:     []Line{{Words: []EnrichedWord{{Text: heading, FontSize: size}}}},
+				IsHeading: true,
+			},
+			{
+				Lines: []Line{{Words: []EnrichedWord{{Text: "Body", FontSize: 10}}}},
+			},
+		},
+	}
+}
+
+// TestPageWriter_MatchesWriteMarkdown verifies pages written one at a time
+// produce the same output as the whole document
+func TestPageWriter_MatchesWriteMarkdown(t *testing.T) {
+	pages := []Page{
+		headingPage(1, "Intro", 18),
+		headingPage(2, "Details", 14),
+	}
+
+	config := DefaultConfig()
+	doc := &Document{Pages: append([]Page(nil), pages...)}
+	want := doc.ToMarkdown(config)
+
+	var buf bytes.Buffer
+	pw := newPageWriter(&buf, config)
+	headings := newHeadingRanker(config)
+	for _, page := range pages {
+		headings.apply(&page)
+		if err := pw.writePage(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pw.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != want {
+		t.Errorf("streamed markdown = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestHeadingRanker ranks each page's headings among the sizes seen so far
+func TestHeadingRanker(t *testing.T) {
+	headings := newHeadingRanker(DefaultConfig())
+
+	tests := []struct {
+		page Page
+		want int
+	}{
+		{headingPage(1, "Section", 14), 1},
+		{headingPage(2, "Title", 18), 1},
+		{headingPage(3, "Section", 14), 2},
+	}
+
+	for _, tt := range tests {
+		headings.apply(&tt.page)
+		if got := tt.page.Paragraphs[0].HeadingLevel; got != tt.want {
+			t.Errorf("page %d heading level = %d, want %d", tt.page.Number, got, tt.want)
+		}
+	}
+}
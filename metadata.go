@@ -0,0 +1,150 @@
+package pdfmarkdown
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// Metadata is the document information a PDF records about itself. Fields
+// the PDF leaves out are empty.
+type Metadata struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string // As written, usually separated by commas or semicolons
+
+	// CreationDate is zero when the PDF has none or it can't be read
+	CreationDate time.Time
+}
+
+// IsZero reports whether the PDF recorded no metadata.
+func (m Metadata) IsZero() bool {
+	return m == Metadata{}
+}
+
+// KeywordList splits Keywords at commas and semicolons.
+func (m Metadata) KeywordList() []string {
+	var keywords []string
+	for _, keyword := range strings.FieldsFunc(m.Keywords, func(r rune) bool { return r == ',' || r == ';' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// readMetadata reads the document information dictionary. Tags pdfium can't
+// read are left empty.
+func readMetadata(instance pdfium.Pdfium, docRef references.FPDF_DOCUMENT) Metadata {
+	text := func(tag string) string {
+		resp, err := instance.FPDF_GetMetaText(&requests.FPDF_GetMetaText{Document: docRef, Tag: tag})
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(resp.Value)
+	}
+
+	metadata := Metadata{
+		Title:    text("Title"),
+		Author:   text("Author"),
+		Subject:  text("Subject"),
+		Keywords: text("Keywords"),
+	}
+	metadata.CreationDate, _ = parsePDFDate(text("CreationDate"))
+	return metadata
+}
+
+// parsePDFDate parses a PDF date string, "D:YYYYMMDDHHmmSSOHH'mm'", where
+// everything after the year is optional. Dates without a time zone are taken
+// as UTC.
+func parsePDFDate(s string) (time.Time, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	digits := len(s) - len(strings.TrimLeft(s, "0123456789"))
+	if digits < 4 || digits > 14 || digits%2 != 0 {
+		return time.Time{}, false
+	}
+
+	// Missing month and day default to 1, missing time fields to 0
+	fields := []int{0, 1, 1, 0, 0, 0}
+	fields[0], _ = strconv.Atoi(s[:4])
+	for i := 1; 4+2*i <= digits; i++ {
+		fields[i], _ = strconv.Atoi(s[2+2*i : 4+2*i])
+	}
+
+	loc := time.UTC
+	zone := strings.ReplaceAll(s[digits:], "'", "")
+	if zone != "" && zone != "Z" {
+		sign := 1
+		switch zone[0] {
+		case '+':
+		case '-':
+			sign = -1
+		default:
+			return time.Time{}, false
+		}
+		zone = zone[1:]
+		if len(zone) != 2 && len(zone) != 4 {
+			return time.Time{}, false
+		}
+		hours, err := strconv.Atoi(zone[:2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		minutes := 0
+		if len(zone) == 4 {
+			if minutes, err = strconv.Atoi(zone[2:]); err != nil {
+				return time.Time{}, false
+			}
+		}
+		loc = time.FixedZone("", sign*(hours*3600+minutes*60))
+	}
+
+	t := time.Date(fields[0], time.Month(fields[1]), fields[2], fields[3], fields[4], fields[5], 0, loc)
+	if t.Month() != time.Month(fields[1]) || t.Day() != fields[2] {
+		return time.Time{}, false // Out of range, such as a 13th month
+	}
+	return t, true
+}
+
+// writeFrontMatter writes metadata as a YAML front matter block followed by a
+// blank line, with the keys static site generators read: the subject becomes
+// the description and the creation date the date. Empty fields are left out,
+// and nothing is written for empty metadata.
+func writeFrontMatter(w io.Writer, metadata Metadata) error {
+	if metadata.IsZero() {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, field := range []struct{ key, value string }{
+		{"title", metadata.Title},
+		{"author", metadata.Author},
+		{"description", metadata.Subject},
+	} {
+		if field.value != "" {
+			b.WriteString(field.key + ": " + strconv.Quote(field.value) + "\n")
+		}
+	}
+	if keywords := metadata.KeywordList(); len(keywords) > 0 {
+		quoted := make([]string, len(keywords))
+		for i, keyword := range keywords {
+			quoted[i] = strconv.Quote(keyword)
+		}
+		b.WriteString("keywords: [" + strings.Join(quoted, ", ") + "]\n")
+	}
+	if !metadata.CreationDate.IsZero() {
+		b.WriteString("date: " + metadata.CreationDate.Format(time.RFC3339) + "\n")
+	}
+	b.WriteString("---\n\n")
+
+	_, err := io.WriteString(w, b.String())
+	return errors.Wrap(err, "failed to write front matter")
+}
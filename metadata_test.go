@@ -0,0 +1,67 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"D:20240315093000+10'00'", time.Date(2024, 3, 14, 23, 30, 0, 0, time.UTC), true},
+		{"D:20240315093000-05'30", time.Date(2024, 3, 15, 15, 0, 0, 0, time.UTC), true},
+		{"D:20240315093000Z", time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC), true},
+		{"D:2024", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"20240315", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), true},
+		{"D:20241315", time.Time{}, false},
+		{"D:202403151", time.Time{}, false},
+		{"March 2024", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parsePDFDate(tt.in)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parsePDFDate(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWriteMarkdown_IncludeFrontMatter(t *testing.T) {
+	doc := &Document{
+		Pages: []Page{headingPage(1, "Intro", 18)},
+		Metadata: Metadata{
+			Title:        `The "Annual" Report`,
+			Subject:      "Results for 2024",
+			Keywords:     "finance; annual report, ",
+			CreationDate: time.Date(2024, 3, 15, 9, 30, 0, 0, time.FixedZone("", 10*3600)),
+		},
+	}
+
+	config := DefaultConfig()
+	config.IncludeFrontMatter = true
+	got := doc.ToMarkdown(config)
+
+	want := `---
+title: "The \"Annual\" Report"
+description: "Results for 2024"
+keywords: ["finance", "annual report"]
+date: 2024-03-15T09:30:00+10:00
+---
+
+# Intro
+`
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("markdown = %q, want prefix %q", got, want)
+	}
+
+	// Without metadata there is no front matter
+	doc.Metadata = Metadata{}
+	if got := doc.ToMarkdown(config); strings.HasPrefix(got, "---") {
+		t.Errorf("front matter written without metadata: %q", got)
+	}
+}
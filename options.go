@@ -0,0 +1,84 @@
+package pdfmarkdown
+
+// Option adjusts the configuration a Converter is built with. Options start
+// from DefaultConfig, so every default is set in one place, and later options
+// override earlier ones.
+type Option func(*Config)
+
+// WithConfig replaces the configuration built so far. Options after it adjust
+// the given config.
+func WithConfig(config Config) Option {
+	return func(c *Config) {
+		*c = config
+	}
+}
+
+// WithTables enables table detection.
+func WithTables() Option {
+	return func(c *Config) {
+		c.DetectTables = true
+	}
+}
+
+// WithoutTables disables table detection.
+func WithoutTables() Option {
+	return func(c *Config) {
+		c.DetectTables = false
+	}
+}
+
+// WithTableSettings enables table detection with the given settings.
+func WithTableSettings(settings TableSettings) Option {
+	return func(c *Config) {
+		c.DetectTables = true
+		c.TableSettings = settings
+	}
+}
+
+// WithSegmentTables enables table detection with the segment-based detector,
+// which finds tables without ruling lines.
+func WithSegmentTables() Option {
+	return func(c *Config) {
+		c.DetectTables = true
+		c.UseSegmentBasedTables = true
+	}
+}
+
+// WithHybridTables enables table detection with HybridTableDetector, for
+// partially ruled tables.
+func WithHybridTables() Option {
+	return func(c *Config) {
+		c.DetectTables = true
+		c.UseHybridTables = true
+	}
+}
+
+// WithMetrics enables processing time and statistics logging.
+func WithMetrics() Option {
+	return func(c *Config) {
+		c.EnableMetricsLogging = true
+	}
+}
+
+// WithProfile selects a processing profile.
+func WithProfile(profile Profile) Option {
+	return func(c *Config) {
+		c.Profile = profile
+	}
+}
+
+// ForLLMOutput applies Config.ForLLM to the configuration built so far.
+func ForLLMOutput() Option {
+	return func(c *Config) {
+		*c = c.ForLLM()
+	}
+}
+
+// NewConfig returns DefaultConfig with the given options applied.
+func NewConfig(opts ...Option) Config {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
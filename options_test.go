@@ -0,0 +1,88 @@
+package pdfmarkdown
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestNewConfig(t *testing.T) {
+	if got := NewConfig(); !reflect.DeepEqual(got, DefaultConfig()) {
+		t.Error("NewConfig() without options differs from DefaultConfig()")
+	}
+
+	config := NewConfig(WithoutTables(), WithSegmentTables(), WithMetrics(), WithProfile(ProfileProse))
+	if !config.DetectTables || !config.UseSegmentBasedTables || !config.EnableMetricsLogging || config.Profile != ProfileProse {
+		t.Errorf("options not applied in order: %+v", config)
+	}
+
+	base := Config{MaxHeadingLevel: 3}
+	config = NewConfig(WithMetrics(), WithConfig(base), WithTables())
+	if config.EnableMetricsLogging || config.MaxHeadingLevel != 3 || !config.DetectTables {
+		t.Errorf("WithConfig should replace earlier options and keep later ones: %+v", config)
+	}
+
+	if got, want := NewConfig(ForLLMOutput()), DefaultConfig().ForLLM(); !reflect.DeepEqual(got, want) {
+		t.Error("ForLLMOutput() differs from Config.ForLLM()")
+	}
+}
+
+// TestDefaultConfig_MatchesDocumentation checks each "(default: X)" in the
+// Config field comments against DefaultConfig, for literal booleans, numbers
+// and empty strings.
+func TestDefaultConfig_MatchesDocumentation(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "converter.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaultPattern := regexp.MustCompile(`\(default: (true|false|""|-?[0-9.]+)`)
+	defaults := reflect.ValueOf(DefaultConfig())
+	checked := 0
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "Config" {
+			return true
+		}
+		for _, field := range spec.Type.(*ast.StructType).Fields.List {
+			m := defaultPattern.FindStringSubmatch(field.Doc.Text() + field.Comment.Text())
+			if m == nil {
+				continue
+			}
+			for _, name := range field.Names {
+				value := defaults.FieldByName(name.Name)
+				var documented any
+				switch value.Kind() {
+				case reflect.Bool:
+					documented, err = strconv.ParseBool(m[1])
+				case reflect.Int:
+					documented, err = strconv.Atoi(m[1])
+				case reflect.Float64:
+					documented, err = strconv.ParseFloat(m[1], 64)
+				case reflect.String:
+					documented, err = strconv.Unquote(m[1])
+				default:
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s documents default %s, which is not a %s", name.Name, m[1], value.Kind())
+					continue
+				}
+				if got := value.Convert(reflect.TypeOf(documented)).Interface(); got != documented {
+					t.Errorf("%s documents default %v, DefaultConfig sets %v", name.Name, documented, got)
+				}
+				checked++
+			}
+		}
+		return false
+	})
+
+	if checked == 0 {
+		t.Fatal("no documented defaults found")
+	}
+}
@@ -0,0 +1,158 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ordinalMarkerPattern matches roman numeral and letter list markers such as
+// "iv." and "b)".
+var ordinalMarkerPattern = regexp.MustCompile(`^([ivxlcdm]+|[IVXLCDM]+|[a-z]|[A-Z])[.)]$`)
+
+// ordinalStyle is a way of counting list items other than with digits.
+type ordinalStyle int
+
+const (
+	ordinalLowerRoman ordinalStyle = iota
+	ordinalUpperRoman
+	ordinalLowerAlpha
+	ordinalUpperAlpha
+)
+
+// ordinalValue returns the position a marker counts in style, or 0 if the
+// marker is not written in that style. "i." is both the first roman numeral
+// and the ninth letter, so a marker can have a value in several styles.
+func ordinalValue(marker string, style ordinalStyle) int {
+	m := ordinalMarkerPattern.FindStringSubmatch(marker)
+	if m == nil {
+		return 0
+	}
+	counter := m[1]
+	switch style {
+	case ordinalLowerRoman:
+		if counter == strings.ToLower(counter) {
+			return romanValue(counter)
+		}
+	case ordinalUpperRoman:
+		if counter == strings.ToUpper(counter) {
+			return romanValue(counter)
+		}
+	case ordinalLowerAlpha:
+		if len(counter) == 1 && counter[0] >= 'a' && counter[0] <= 'z' {
+			return int(counter[0]-'a') + 1
+		}
+	case ordinalUpperAlpha:
+		if len(counter) == 1 && counter[0] >= 'A' && counter[0] <= 'Z' {
+			return int(counter[0]-'A') + 1
+		}
+	}
+	return 0
+}
+
+// romanValue converts a roman numeral in canonical form to its value, or
+// returns 0 for anything else, such as "iiii" or "vx".
+func romanValue(numeral string) int {
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100, 'd': 500, 'm': 1000}
+	numeral = strings.ToLower(numeral)
+	total := 0
+	for i := 0; i < len(numeral); i++ {
+		v := values[numeral[i]]
+		if i+1 < len(numeral) && v < values[numeral[i+1]] {
+			total -= v
+		} else {
+			total += v
+		}
+	}
+	if total <= 0 || toRoman(total) != numeral {
+		return 0
+	}
+	return total
+}
+
+// toRoman writes n as a lower case roman numeral.
+func toRoman(n int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"}, {100, "c"}, {90, "xc"},
+		{50, "l"}, {40, "xl"}, {10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+	}
+	var b strings.Builder
+	for _, numeral := range numerals {
+		for n >= numeral.value {
+			b.WriteString(numeral.symbol)
+			n -= numeral.value
+		}
+	}
+	return b.String()
+}
+
+// isOrdinalMarker reports whether a list marker counts with roman numerals or
+// letters.
+func isOrdinalMarker(marker string) bool {
+	return ordinalMarkerPattern.MatchString(marker)
+}
+
+// detectOrdinalLists marks paragraphs starting with roman numeral or letter
+// markers as list items. Since a lone "I." or "A." usually starts a sentence
+// or a name, markers only count in a sequence of at least two that starts at
+// the first numeral or letter and counts up by one with the same punctuation.
+// A heading ends a sequence.
+func detectOrdinalLists(paragraphs []Paragraph) {
+	type candidate struct {
+		index  int
+		marker string
+	}
+
+	var candidates [][]candidate // Runs of candidates not broken by headings
+	var run []candidate
+	for i, para := range paragraphs {
+		if para.IsHeading {
+			candidates, run = append(candidates, run), nil
+			continue
+		}
+		if para.IsList || len(para.Lines) == 0 || len(para.Lines[0].Words) < 2 {
+			continue
+		}
+		if marker := para.Lines[0].Words[0].Text; isOrdinalMarker(marker) {
+			run = append(run, candidate{index: i, marker: marker})
+		}
+	}
+	candidates = append(candidates, run)
+
+	styles := []ordinalStyle{ordinalLowerRoman, ordinalUpperRoman, ordinalLowerAlpha, ordinalUpperAlpha}
+	for _, run := range candidates {
+		for start := 0; start < len(run); {
+			// Follow the longest sequence counting up from this candidate
+			var best []candidate
+			for _, style := range styles {
+				if ordinalValue(run[start].marker, style) != 1 {
+					continue
+				}
+				seq := run[start : start+1]
+				suffix := run[start].marker[len(run[start].marker)-1]
+				for next := start + 1; next < len(run); next++ {
+					marker := run[next].marker
+					if marker[len(marker)-1] != suffix || ordinalValue(marker, style) != len(seq)+1 {
+						break
+					}
+					seq = run[start : next+1]
+				}
+				if len(seq) > len(best) {
+					best = seq
+				}
+			}
+
+			if len(best) < 2 {
+				start++
+				continue
+			}
+			for _, c := range best {
+				paragraphs[c.index].IsList = true
+				paragraphs[c.index].ListMarker = c.marker
+			}
+			start += len(best)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRomanValue(t *testing.T) {
+	tests := []struct {
+		numeral string
+		want    int
+	}{
+		{"i", 1}, {"iv", 4}, {"ix", 9}, {"xiv", 14}, {"XL", 40}, {"mcmxc", 1990},
+		{"iiii", 0}, {"vx", 0}, {"il", 0}, {"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := romanValue(tt.numeral); got != tt.want {
+			t.Errorf("romanValue(%q) = %d, want %d", tt.numeral, got, tt.want)
+		}
+	}
+}
+
+func TestDetectOrdinalLists(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []bool
+	}{
+		{
+			name:  "roman numerals",
+			texts: []string{"i. first", "ii. second", "iii. third", "iv. fourth"},
+			want:  []bool{true, true, true, true},
+		},
+		{
+			name:  "letters with parentheses",
+			texts: []string{"a) apples", "b) bananas", "c) cherries"},
+			want:  []bool{true, true, true},
+		},
+		{
+			name:  "upper case roman sections",
+			texts: []string{"I. Introduction", "Some prose here.", "II. Method"},
+			want:  []bool{true, false, true},
+		},
+		{
+			name:  "letters through i",
+			texts: []string{"a. one", "b. two", "c. three", "d. four", "e. five", "f. six", "g. seven", "h. eight", "i. nine"},
+			want:  []bool{true, true, true, true, true, true, true, true, true},
+		},
+		{
+			name:  "initials are not a list",
+			texts: []string{"J. Smith wrote to", "K. Jones about it"},
+			want:  []bool{false, false},
+		},
+		{
+			name:  "lone marker",
+			texts: []string{"A. Smith attended."},
+			want:  []bool{false},
+		},
+		{
+			name:  "out of order",
+			texts: []string{"a) first", "c) third"},
+			want:  []bool{false, false},
+		},
+		{
+			name:  "mixed punctuation",
+			texts: []string{"a) first", "b. second"},
+			want:  []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paragraphs []Paragraph
+			for _, text := range tt.texts {
+				paragraphs = append(paragraphs, textParagraph(text))
+			}
+			detectLists(paragraphs, Config{})
+			for i, para := range paragraphs {
+				if para.IsList != tt.want[i] {
+					t.Errorf("paragraph %q IsList = %v, want %v", tt.texts[i], para.IsList, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDetectOrdinalLists_HeadingEndsSequence(t *testing.T) {
+	heading := textParagraph("Schedule")
+	heading.IsHeading = true
+	paragraphs := []Paragraph{textParagraph("a) first"), heading, textParagraph("b) second")}
+
+	detectLists(paragraphs, Config{})
+	if paragraphs[0].IsList || paragraphs[2].IsList {
+		t.Error("sequence continued across a heading")
+	}
+}
+
+func TestConvertParagraphToMarkdown_OrdinalLists(t *testing.T) {
+	paragraphs := []Paragraph{textParagraph("i. Scope"), textParagraph("ii. Term")}
+	detectLists(paragraphs, Config{})
+	if paragraphs[1].ListMarker != "ii." {
+		t.Errorf("ListMarker = %q, want %q", paragraphs[1].ListMarker, "ii.")
+	}
+
+	doc := &Document{Pages: []Page{{Paragraphs: paragraphs}}}
+	got := doc.ToMarkdown(Config{})
+	if !strings.Contains(got, "1. Scope") || !strings.Contains(got, "2. Term") || strings.Contains(got, "ii.") {
+		t.Errorf("unexpected markdown:\n%s", got)
+	}
+}
@@ -0,0 +1,269 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/responses"
+	"github.com/pkg/errors"
+)
+
+// OutlineHeading is a heading in a document's outline, with the headings
+// nested under it.
+type OutlineHeading struct {
+	Level int    // 1-6, as in the markdown
+	Title string // The heading's text, or the bookmark's title for bookmarks not found in the text
+	Page  int    // 1-indexed page number
+
+	// Box is the heading's first line on the page, and zero for bookmarks
+	// not found in the text
+	Box Rect
+
+	// Anchor is the anchor markdown renderers generate for the heading, as
+	// Config.LinkInternalDestinations links to, and "" for bookmarks not
+	// found in the text
+	Anchor string
+
+	// Bookmarked is set when the PDF has a bookmark for the heading
+	Bookmarked bool
+
+	Children []OutlineHeading
+}
+
+// ExtractOutline returns the heading tree of a PDF file: the headings
+// detected in its text, with the levels and anchors its markdown would have,
+// merged with its bookmarks. Bookmarks whose title matches a heading on their
+// destination page mark that heading Bookmarked; the rest are added where
+// they point, at their outline depth shifted by Config.HeadingLevelOffset.
+// Pages are extracted as for conversion, but no markdown is rendered.
+func (c *Converter) ExtractOutline(filePath string) ([]OutlineHeading, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	document, err := c.extractDocument(doc.Document, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return buildOutline(document.renderedPages(c.config), c.readOutline(doc.Document), c.config), nil
+}
+
+// buildOutline merges the headings of rendered pages with bookmarks and
+// nests them by level. Within a page, bookmarks not matched to a heading go
+// before the first heading below their destination, or before every heading
+// when they give no height.
+func buildOutline(pages []Page, bookmarks []outlineEntry, config Config) []OutlineHeading {
+	anchors := headingAnchors(&Document{Pages: pages})
+	byPage := make(map[int][]OutlineHeading)
+	heights := make(map[int]float64)
+	for pi, page := range pages {
+		heights[page.Number] = page.Height
+		for i, para := range page.Paragraphs {
+			if !para.IsHeading || len(para.Lines) == 0 {
+				continue
+			}
+			byPage[page.Number] = append(byPage[page.Number], OutlineHeading{
+				Level:  para.HeadingLevel,
+				Title:  strings.Join(strings.Fields(headingText(para)), " "),
+				Page:   page.Number,
+				Box:    para.Lines[0].Box,
+				Anchor: anchors[headingRef{page: pi, para: i}],
+			})
+		}
+	}
+
+	for k, bookmark := range bookmarks {
+		title := normalizeOutlineTitle(bookmark.Title)
+		if title == "" {
+			continue
+		}
+		// Bookmarks without a destination, such as those grouping others
+		// under a part title, go where the next one with a page does
+		pageIndex := bookmark.PageIndex
+		for j := k + 1; pageIndex < 0 && j < len(bookmarks); j++ {
+			pageIndex = bookmarks[j].PageIndex
+		}
+		if pageIndex < 0 {
+			continue
+		}
+		number := pageIndex + 1
+
+		headings := byPage[number]
+		matched := false
+		for i := range headings {
+			if !headings[i].Bookmarked && headings[i].Anchor != "" && normalizeOutlineTitle(headings[i].Title) == title {
+				headings[i].Bookmarked, matched = true, true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		var y float64
+		if bookmark.HasY && bookmark.PageIndex == pageIndex {
+			y = bookmark.Dest.ToPage(heights[number]).Y
+		}
+		at := len(headings)
+		for i, heading := range headings {
+			if heading.Anchor != "" && heading.Box.Y0 > y {
+				at = i
+				break
+			}
+		}
+		byPage[number] = slices.Insert(headings, at, OutlineHeading{
+			Level:      config.clampHeadingLevel(bookmark.Level + config.HeadingLevelOffset),
+			Title:      bookmark.Title,
+			Page:       number,
+			Bookmarked: true,
+		})
+	}
+
+	numbers := make([]int, 0, len(byPage))
+	for number := range byPage {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	var flat []OutlineHeading
+	for _, number := range numbers {
+		flat = append(flat, byPage[number]...)
+	}
+
+	i := 0
+	return nestOutline(flat, &i, 0)
+}
+
+// nestOutline takes the headings from flat[*i] on that are deeper than level,
+// nesting each heading's deeper successors under it.
+func nestOutline(flat []OutlineHeading, i *int, level int) []OutlineHeading {
+	var nested []OutlineHeading
+	for *i < len(flat) && flat[*i].Level > level {
+		heading := flat[*i]
+		*i++
+		heading.Children = nestOutline(flat, i, heading.Level)
+		nested = append(nested, heading)
+	}
+	return nested
+}
+
+// outlineEntry is a bookmark from the document outline.
+type outlineEntry struct {
+	Title     string
+	Level     int      // Depth in the outline, 1 for top-level bookmarks
+	PageIndex int      // 0-indexed destination page, -1 without a page destination
+	Dest      PDFPoint // Destination on its page, in PDF space
+	HasY      bool     // Whether the destination gives a height
+}
+
+// readOutline reads the document outline depth first, so entries are in
+// reading order. A missing or unreadable outline yields no entries, leaving
+// heading detection to the font size heuristic.
+func (c *Converter) readOutline(docRef references.FPDF_DOCUMENT) []outlineEntry {
+	resp, err := c.instance.GetBookmarks(&requests.GetBookmarks{Document: docRef})
+	if err != nil {
+		return nil
+	}
+
+	var entries []outlineEntry
+	var walk func(bookmarks []responses.GetBookmarksBookmark, level int)
+	walk = func(bookmarks []responses.GetBookmarksBookmark, level int) {
+		for _, bookmark := range bookmarks {
+			entry := outlineEntry{Title: strings.TrimSpace(bookmark.Title), Level: level, PageIndex: -1}
+
+			// Bookmarks point at their destination directly or through a GoTo action
+			dest := bookmark.DestInfo
+			if dest == nil && bookmark.ActionInfo != nil {
+				dest = bookmark.ActionInfo.DestInfo
+			}
+			if dest != nil {
+				entry.PageIndex = dest.PageIndex
+				location, err := c.instance.FPDFDest_GetLocationInPage(&requests.FPDFDest_GetLocationInPage{Dest: dest.Reference})
+				if err == nil && location.Y != nil {
+					entry.Dest.Y, entry.HasY = float64(*location.Y), true
+					if location.X != nil {
+						entry.Dest.X = float64(*location.X)
+					}
+				}
+			}
+
+			entries = append(entries, entry)
+			walk(bookmark.Children, level+1)
+		}
+	}
+	walk(resp.Bookmarks, 1)
+	return entries
+}
+
+// applyOutlineHeadings makes the paragraph each outline entry for the page
+// points at a heading at the entry's depth. The paragraph must read the same
+// as the entry's title, ignoring case, punctuation and spacing; where several
+// do, the one nearest the destination height is taken.
+func applyOutlineHeadings(page *Page, entries []outlineEntry, config Config) {
+	used := make(map[int]bool)
+	for _, entry := range entries {
+		if entry.PageIndex != page.Number-1 {
+			continue
+		}
+		title := normalizeOutlineTitle(entry.Title)
+		if title == "" {
+			continue
+		}
+
+		best, bestDist := -1, math.MaxFloat64
+		for i, para := range page.Paragraphs {
+			if used[i] || len(para.Lines) == 0 || !matchesOutlineTitle(para, title) {
+				continue
+			}
+			dist := float64(i) // Without a height, take the first match
+			if entry.HasY {
+				dist = math.Abs(para.Box.Y0 - entry.Dest.ToPage(page.Height).Y)
+			}
+			if dist < bestDist {
+				best, bestDist = i, dist
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		used[best] = true
+		para := &page.Paragraphs[best]
+		para.IsHeading = true
+		para.HeadingLevel = config.clampHeadingLevel(entry.Level + config.HeadingLevelOffset)
+		para.FromOutline = true
+		para.IsList = false
+		para.IsCode = false
+	}
+}
+
+// matchesOutlineTitle reports whether the paragraph, or its first line when a
+// heading ran into the text below it, reads as the normalized title.
+func matchesOutlineTitle(para Paragraph, title string) bool {
+	return normalizeOutlineTitle(para.Text()) == title ||
+		normalizeOutlineTitle(para.Lines[0].Text()) == title
+}
+
+// normalizeOutlineTitle lowercases text and keeps only its letters and digits,
+// separated by single spaces.
+func normalizeOutlineTitle(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
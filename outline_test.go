@@ -0,0 +1,99 @@
+package pdfmarkdown
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestApplyOutlineHeadings(t *testing.T) {
+	// paragraph builds a one-line 10pt paragraph at y
+	paragraph := func(text string, y float64) Paragraph {
+		para := textParagraph(text)
+		para.Box = Rect{X0: 72, Y0: y, X1: 300, Y1: y + 10}
+		return para
+	}
+	page := &Page{
+		Number: 2,
+		Height: 800,
+		Paragraphs: []Paragraph{
+			paragraph("1. Introduction", 100),
+			paragraph("Scope", 200),
+			paragraph("Our scope is narrow.", 220),
+			paragraph("Scope", 600),
+			paragraph("Glossary", 700),
+		},
+	}
+	entries := []outlineEntry{
+		{Title: "1 Introduction", Level: 1, PageIndex: 1},
+		{Title: "SCOPE", Level: 2, PageIndex: 1, Dest: PDFPoint{Y: 200}, HasY: true}, // Top-left y 600
+		{Title: "Glossary", Level: 1, PageIndex: 4},
+		{Title: "Missing", Level: 1, PageIndex: 1},
+	}
+
+	config := DefaultConfig()
+	config.HeadingLevelOffset = 1
+	applyOutlineHeadings(page, entries, config)
+
+	want := []int{2, 0, 0, 3, 0}
+	for i, para := range page.Paragraphs {
+		if para.HeadingLevel != want[i] || para.IsHeading != (want[i] > 0) || para.FromOutline != (want[i] > 0) {
+			t.Errorf("paragraph %d %q: heading %v level %d, want level %d", i, para.Text(), para.IsHeading, para.HeadingLevel, want[i])
+		}
+	}
+
+	// Outline headings keep their level when the document's headings are ranked
+	doc := &Document{Pages: []Page{*page}}
+	normalizeDocumentHeadings(doc, config)
+	if got := doc.Pages[0].Paragraphs[3].HeadingLevel; got != 3 {
+		t.Errorf("outline heading level after ranking = %d, want 3", got)
+	}
+}
+
+func TestBuildOutline(t *testing.T) {
+	heading := func(text string, level int, y float64) Paragraph {
+		para := placedParagraph(text, 72, y)
+		para.IsHeading, para.HeadingLevel = true, level
+		return para
+	}
+	pages := []Page{
+		{Number: 1, Height: 800, Paragraphs: []Paragraph{
+			heading("Introduction", 1, 50),
+			placedParagraph("Some opening text.", 72, 80),
+			heading("Scope", 2, 200),
+		}},
+		{Number: 2, Height: 800, Paragraphs: []Paragraph{
+			heading("Methods", 2, 100),
+			placedParagraph("How it was done.", 72, 130),
+			heading("Results", 1, 400),
+		}},
+	}
+	bookmarks := []outlineEntry{
+		{Title: "INTRODUCTION", Level: 1, PageIndex: 0},
+		{Title: "Part Two", Level: 1, PageIndex: -1},
+		{Title: "Methods", Level: 2, PageIndex: 1},
+		{Title: "Appendix", Level: 1, PageIndex: 1, Dest: PDFPoint{Y: 200}, HasY: true}, // Top-left y 600
+	}
+
+	var got []string
+	var walk func(headings []OutlineHeading, depth int)
+	walk = func(headings []OutlineHeading, depth int) {
+		for _, h := range headings {
+			got = append(got, fmt.Sprintf("%s%d %s p%d #%s bookmarked=%v", strings.Repeat("  ", depth), h.Level, h.Title, h.Page, h.Anchor, h.Bookmarked))
+			walk(h.Children, depth+1)
+		}
+	}
+	walk(buildOutline(pages, bookmarks, DefaultConfig()), 0)
+
+	want := []string{
+		"1 Introduction p1 #introduction bookmarked=true",
+		"  2 Scope p1 #scope bookmarked=false",
+		"1 Part Two p2 # bookmarked=true",
+		"  2 Methods p2 #methods bookmarked=true",
+		"1 Results p2 #results bookmarked=false",
+		"1 Appendix p2 # bookmarked=true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("buildOutline() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,86 @@
+package pdfmarkdown
+
+import "strings"
+
+// PageBreakFlow selects where the markdown page break goes when a paragraph
+// runs on from the bottom of one page to the top of the next.
+type PageBreakFlow string
+
+const (
+	// PageBreaksKeep breaks between the pages, splitting the paragraph.
+	PageBreaksKeep PageBreakFlow = ""
+
+	// PageBreaksAfterParagraph joins the paragraph back together and moves
+	// the break after it.
+	PageBreaksAfterParagraph PageBreakFlow = "after_paragraph"
+
+	// PageBreaksInline joins the paragraph back together with an HTML
+	// comment such as "<!-- page 12 -->" where the page turned, which
+	// renderers don't show. The page's break marker is then left out.
+	PageBreaksInline PageBreakFlow = "inline"
+)
+
+// defaultInlinePageBreak marks a page turning inside a paragraph, with
+// PageBreaksInline, when Config.PageBreakMarker isn't an HTML comment.
+const defaultInlinePageBreak = "<!-- page {page} -->"
+
+// flowPageBreaks joins each paragraph that ends a page without finishing its
+// sentence to the first paragraph of the next page, when that carries on the
+// text, as Config.PageBreakFlow asks. The continuation leaves the next page,
+// so the page break falls after the joined paragraph; with PageBreaksInline
+// it starts with a comment marking the page instead, and the next page's own
+// break is dropped. Pages ending in tables, which are written after the
+// paragraphs, are left as they are, and so is every page with
+// Config.BlockAnchors, whose ids count each page's paragraphs. Pages are
+// copied before they change.
+func flowPageBreaks(pages []Page, config Config) []Page {
+	if config.PageBreakFlow == PageBreaksKeep || config.BlockAnchors || len(pages) < 2 {
+		return pages
+	}
+
+	flowed := make([]Page, len(pages))
+	copy(flowed, pages)
+	for i := 0; i+1 < len(flowed); i++ {
+		page, next := &flowed[i], &flowed[i+1]
+		if len(page.Paragraphs) == 0 || len(next.Paragraphs) == 0 {
+			continue
+		}
+		if config.tablesEnabled() && len(page.Tables) > 0 {
+			continue
+		}
+		last := page.Paragraphs[len(page.Paragraphs)-1]
+		if !continuesOnNextPage(last, next.Paragraphs[0]) {
+			continue
+		}
+
+		lines := next.Paragraphs[0].Lines
+		if config.PageBreakFlow == PageBreaksInline {
+			marker := config.PageBreakMarker
+			if !strings.HasPrefix(marker, "<!--") {
+				marker = defaultInlinePageBreak
+			}
+			lines = append([]Line(nil), lines...)
+			first := lines[0]
+			first.Words = append([]EnrichedWord{{Text: pageBreakMarker(marker, *next)}}, first.Words...)
+			lines[0] = first
+			next.inlineBreak = true
+		}
+
+		last.Lines = append(append([]Line(nil), last.Lines...), lines...)
+		page.Paragraphs = append(append([]Paragraph(nil), page.Paragraphs[:len(page.Paragraphs)-1]...), last)
+		next.Paragraphs = next.Paragraphs[1:]
+	}
+	return flowed
+}
+
+// continuesOnNextPage reports whether next, the first paragraph of a page,
+// carries on para, the last of the page before. Both must be running text
+// rather than headings, list items, code or leader rows.
+func continuesOnNextPage(para, next Paragraph) bool {
+	for _, p := range []Paragraph{para, next} {
+		if len(p.Lines) == 0 || p.IsHeading || p.IsList || p.IsCode || len(p.Leaders) > 0 || len(p.TOCEntries) > 0 {
+			return false
+		}
+	}
+	return textRunsOn(para.Lines[len(para.Lines)-1], next.Lines[0])
+}
@@ -0,0 +1,77 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlowPageBreaks(t *testing.T) {
+	para := func(text string) Paragraph {
+		p := Paragraph{Lines: []Line{styledLine(text, "Times-Roman", 10, 400)}}
+		p.Font = summarizeFont(p.Lines)
+		return p
+	}
+	pages := func(last, first string) []Page {
+		return []Page{
+			{Number: 1, Paragraphs: []Paragraph{para("Opening paragraph."), para(last)}},
+			{Number: 2, Paragraphs: []Paragraph{para(first), para("Closing paragraph.")}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		flow        PageBreakFlow
+		last, first string
+		want        string
+	}{
+		{
+			name: "keep",
+			last: "The paragraph runs on to the", first: "next page and ends here.",
+			want: "Opening paragraph.\n\nThe paragraph runs on to the\n\n---\n\nnext page and ends here.\n\nClosing paragraph.\n",
+		},
+		{
+			name: "after paragraph", flow: PageBreaksAfterParagraph,
+			last: "The paragraph runs on to the", first: "next page and ends here.",
+			want: "Opening paragraph.\n\nThe paragraph runs on to the\nnext page and ends here.\n\n---\n\nClosing paragraph.\n",
+		},
+		{
+			name: "inline", flow: PageBreaksInline,
+			last: "The paragraph runs on to the", first: "next page and ends here.",
+			want: "Opening paragraph.\n\nThe paragraph runs on to the\n<!-- page 2 --> next page and ends here.\n\nClosing paragraph.\n",
+		},
+		{
+			name: "sentence ends at the page", flow: PageBreaksAfterParagraph,
+			last: "The paragraph ends on this page.", first: "A new one starts on the next.",
+			want: "Opening paragraph.\n\nThe paragraph ends on this page.\n\n---\n\nA new one starts on the next.\n\nClosing paragraph.\n",
+		},
+		{
+			name: "list item on the next page", flow: PageBreaksAfterParagraph,
+			last: "The items that follow are", first: "• the first item",
+			want: "Opening paragraph.\n\nThe items that follow are\n\n---\n\n• the first item\n\nClosing paragraph.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CollapseWhitespace = true
+			config.PageBreakFlow = tt.flow
+			doc := &Document{Pages: pages(tt.last, tt.first)}
+			if got := doc.ToMarkdown(config); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Headings never take in the next page
+	heading := pages("Results for the", "next page and ends here.")
+	heading[0].Paragraphs[1].IsHeading, heading[0].Paragraphs[1].HeadingLevel = true, 2
+	config := DefaultConfig()
+	config.PageBreakFlow = PageBreaksAfterParagraph
+	if flowed := flowPageBreaks(heading, config); len(flowed[1].Paragraphs) != 2 {
+		t.Errorf("heading joined to the next page's paragraph")
+	}
+	if !strings.HasPrefix(heading[1].Paragraphs[0].Text(), "next page") {
+		t.Errorf("input pages changed")
+	}
+}
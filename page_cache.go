@@ -0,0 +1,210 @@
+package pdfmarkdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// PageCacheKey identifies an extracted page in a PageCache.
+type PageCacheKey struct {
+	// Document hashes the PDF's permanent file identifier, which stays the
+	// same as a document is edited and saved again. "" when the PDF has none
+	Document string
+
+	// Page is the 0-indexed page number
+	Page int
+
+	// Config hashes the extraction settings, so pages extracted with
+	// different settings are cached apart
+	Config string
+
+	// Content hashes the page's size, object counts and text, so an edited
+	// page misses the cache while the unchanged pages around it hit
+	Content string
+}
+
+// String returns the key as a single hex string, for stores that index pages
+// by name, such as files or a key-value database.
+func (k PageCacheKey) String() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s\x00%s", k.Document, k.Page, k.Config, k.Content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// PageCache stores extracted pages between conversions, so converting a
+// document again only extracts the pages that changed. Set it with
+// Config.PageCache; MemoryPageCache keeps pages in memory, and other stores
+// can keep them on disk or in a shared database, where a Page round-trips
+// through encoding/json.
+type PageCache interface {
+	// Load returns the page cached under key, or nil if none is. The page is
+	// the caller's to change
+	Load(key PageCacheKey) (*Page, error)
+
+	// Save caches page under key, replacing any page cached before. The
+	// caller may change page afterwards, so it must be copied rather than kept
+	Save(key PageCacheKey, page *Page) error
+}
+
+// MemoryPageCache is a PageCache held in memory. It is safe for concurrent
+// use, so converters in a ConverterPool can share one.
+type MemoryPageCache struct {
+	mu    sync.Mutex
+	pages map[PageCacheKey][]byte
+}
+
+// NewMemoryPageCache creates an empty in-memory page cache.
+func NewMemoryPageCache() *MemoryPageCache {
+	return &MemoryPageCache{pages: make(map[PageCacheKey][]byte)}
+}
+
+// Load returns a copy of the page cached under key, or nil if none is.
+func (c *MemoryPageCache) Load(key PageCacheKey) (*Page, error) {
+	c.mu.Lock()
+	data, ok := c.pages[key]
+	c.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	var page Page
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, errors.Wrap(err, "failed to decode cached page")
+	}
+	return &page, nil
+}
+
+// Save caches a copy of page under key.
+func (c *MemoryPageCache) Save(key PageCacheKey, page *Page) error {
+	// Pages are kept encoded so changes made to them after rendering never
+	// reach the cache
+	data, err := json.Marshal(page)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode page")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pages[key] = data
+	return nil
+}
+
+// Len returns the number of cached pages.
+func (c *MemoryPageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pages)
+}
+
+// pageCacheScope holds the parts of a page cache key shared by every page of
+// a conversion.
+type pageCacheScope struct {
+	document string
+	config   string
+}
+
+// pageCacheScope returns the cache key parts shared by the pages of a
+// document, or nil when Config.PageCache is unset.
+func (c *Converter) pageCacheScope(docRef references.FPDF_DOCUMENT, spaceless bool) *pageCacheScope {
+	if c.config.PageCache == nil {
+		return nil
+	}
+	return &pageCacheScope{
+		document: documentIdentifierHash(c.instance, docRef),
+		config:   extractionConfigHash(c.config, c.features, spaceless),
+	}
+}
+
+// key returns the cache key of a loaded page. ok is false when the page's
+// text can't be read to tell whether it changed, and it is not cached.
+func (s *pageCacheScope) key(instance pdfium.Pdfium, page references.FPDF_PAGE, info PageInfo) (PageCacheKey, bool) {
+	content, ok := pageFingerprint(instance, page, info)
+	return PageCacheKey{
+		Document: s.document,
+		Page:     info.Number - 1,
+		Config:   s.config,
+		Content:  content,
+	}, ok
+}
+
+// documentIdentifierHash hashes a document's permanent file identifier,
+// returning "" when it has none.
+func documentIdentifierHash(instance pdfium.Pdfium, docRef references.FPDF_DOCUMENT) string {
+	resp, err := instance.FPDF_GetFileIdentifier(&requests.FPDF_GetFileIdentifier{
+		Document:   docRef,
+		FileIdType: enums.FPDF_FILEIDTYPE_PERMANENT,
+	})
+	if err != nil || len(resp.Identifier) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(resp.Identifier)
+	return hex.EncodeToString(sum[:])
+}
+
+// extractionConfigHash hashes the settings a page is extracted with: config,
+// the pdfium features available and whether the document lacks spaces
+// between words. Settings holding functions or pointers, such as a
+// PageFilter or a HeadingDetector, are hashed by address, so pages extracted
+// with them are only found again within the same process.
+func extractionConfigHash(config Config, features FeatureSet, spaceless bool) string {
+	// Neither decides what is extracted, and their addresses change per run
+	config.PageCache, config.PageRegistry = nil, nil
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v\x00%#v\x00%t", config, features, spaceless)))
+	return hex.EncodeToString(sum[:])
+}
+
+// pageFingerprint hashes a loaded page's size, rotation, object counts and
+// text from pdfium's bulk text API, which is much cheaper to read than the
+// per-character detail extraction needs. ok is false when the text can't be
+// read.
+func pageFingerprint(instance pdfium.Pdfium, page references.FPDF_PAGE, info PageInfo) (string, bool) {
+	textPage, err := instance.FPDFText_LoadPage(&requests.FPDFText_LoadPage{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return "", false
+	}
+	defer instance.FPDFText_ClosePage(&requests.FPDFText_ClosePage{TextPage: textPage.TextPage})
+
+	count, err := instance.FPDFText_CountChars(&requests.FPDFText_CountChars{TextPage: textPage.TextPage})
+	if err != nil {
+		return "", false
+	}
+	var text string
+	if count.Count > 0 {
+		resp, err := instance.FPDFText_GetText(&requests.FPDFText_GetText{TextPage: textPage.TextPage, StartIndex: 0, Count: count.Count})
+		if err != nil {
+			return "", false
+		}
+		text = resp.Text
+	}
+
+	// The label is read from the document, not the page
+	info.Label = ""
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v\x00%s", info, text)))
+	return hex.EncodeToString(sum[:]), true
+}
+
+// structure builds a page from what readPage read: the cached page on a
+// cache hit, or else the structured page, saving it to Config.PageCache.
+func (c *Converter) structure(raw *rawPage, pageIndex int) (*Page, error) {
+	if raw.cached != nil {
+		return raw.cached, nil
+	}
+	page := structurePage(raw, pageIndex+1, c.config)
+	if raw.cacheKey != nil {
+		if err := c.config.PageCache.Save(*raw.cacheKey, page); err != nil {
+			return nil, errors.Wrap(err, "failed to save page to cache")
+		}
+	}
+	return page, nil
+}
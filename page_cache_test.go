@@ -0,0 +1,79 @@
+package pdfmarkdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryPageCache(t *testing.T) {
+	cache := NewMemoryPageCache()
+	key := PageCacheKey{Document: "doc", Page: 2, Config: "config", Content: "content"}
+
+	page, err := cache.Load(key)
+	require.NoError(t, err)
+	assert.Nil(t, page, "nothing is cached yet")
+
+	saved := &Page{Number: 3, Width: 612, Height: 792, Paragraphs: []Paragraph{placedParagraph("Cached text", 72, 100)}}
+	require.NoError(t, cache.Save(key, saved))
+	assert.Equal(t, 1, cache.Len())
+
+	// Changes to the saved page after saving don't reach the cache
+	saved.Paragraphs[0].IsHeading = true
+
+	page, err = cache.Load(key)
+	require.NoError(t, err)
+	require.NotNil(t, page)
+	assert.Equal(t, 3, page.Number)
+	assert.Equal(t, "Cached text", page.Paragraphs[0].Text())
+	assert.False(t, page.Paragraphs[0].IsHeading)
+
+	// Nor do changes to a loaded page
+	page.Paragraphs[0].IsHeading = true
+	again, err := cache.Load(key)
+	require.NoError(t, err)
+	assert.False(t, again.Paragraphs[0].IsHeading)
+
+	other := key
+	other.Content = "edited"
+	page, err = cache.Load(other)
+	require.NoError(t, err)
+	assert.Nil(t, page, "an edited page misses the cache")
+}
+
+func TestPageCacheKey_String(t *testing.T) {
+	key := PageCacheKey{Document: "doc", Page: 1, Config: "config", Content: "content"}
+	assert.Len(t, key.String(), 64)
+	assert.Equal(t, key.String(), key.String())
+
+	for _, other := range []PageCacheKey{
+		{Document: "other", Page: 1, Config: "config", Content: "content"},
+		{Document: "doc", Page: 2, Config: "config", Content: "content"},
+		{Document: "doc", Page: 1, Config: "other", Content: "content"},
+		{Document: "doc", Page: 1, Config: "config", Content: "other"},
+	} {
+		assert.NotEqual(t, key.String(), other.String(), "%+v", other)
+	}
+}
+
+func TestExtractionConfigHash(t *testing.T) {
+	config := DefaultConfig()
+	features := allFeatures()
+	base := extractionConfigHash(config, features, false)
+
+	// Stores are not settings
+	cached := config
+	cached.PageCache = NewMemoryPageCache()
+	cached.PageRegistry = NewPageRegistry()
+	assert.Equal(t, base, extractionConfigHash(cached, features, false))
+
+	changed := config
+	changed.DetectTables = false
+	assert.NotEqual(t, base, extractionConfigHash(changed, features, false))
+
+	assert.NotEqual(t, base, extractionConfigHash(config, features, true), "spacing changes how words are split")
+
+	features.CharAngle = false
+	assert.NotEqual(t, base, extractionConfigHash(config, features, false))
+}
@@ -0,0 +1,86 @@
+package pdfmarkdown
+
+import (
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// PageInfo describes a page before its content is extracted. It is passed to
+// Config.PageFilter and only needs the page loaded, not its text read.
+type PageInfo struct {
+	Number   int     // 1-based page number
+	Label    string  // Logical page label, such as "iii"; "" when the PDF gives none
+	Width    float64 // Page width in points
+	Height   float64 // Page height in points
+	Rotation int     // Clockwise page rotation in degrees: 0, 90, 180 or 270
+
+	// Objects counts the page's top-level objects; a form XObject counts as one
+	Objects      int
+	TextObjects  int
+	PathObjects  int
+	ImageObjects int
+}
+
+// readPageInfo reads a loaded page's size, rotation and object counts.
+func readPageInfo(instance pdfium.Pdfium, page references.FPDF_PAGE, pageIndex int) (PageInfo, error) {
+	info := PageInfo{Number: pageIndex + 1}
+
+	width, err := instance.FPDF_GetPageWidthF(&requests.FPDF_GetPageWidthF{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return info, errors.Wrap(err, "failed to get page width")
+	}
+	height, err := instance.FPDF_GetPageHeightF(&requests.FPDF_GetPageHeightF{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return info, errors.Wrap(err, "failed to get page height")
+	}
+	info.Width, info.Height = float64(width.PageWidth), float64(height.PageHeight)
+
+	rotation, err := instance.FPDFPage_GetRotation(&requests.FPDFPage_GetRotation{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return info, errors.Wrap(err, "failed to get page rotation")
+	}
+	info.Rotation = int(rotation.PageRotation) * 90
+
+	countResp, err := instance.FPDFPage_CountObjects(&requests.FPDFPage_CountObjects{
+		Page: requests.Page{ByReference: &page},
+	})
+	if err != nil {
+		return info, errors.Wrap(err, "failed to count page objects")
+	}
+	info.Objects = countResp.Count
+
+	for i := 0; i < countResp.Count; i++ {
+		objResp, err := instance.FPDFPage_GetObject(&requests.FPDFPage_GetObject{
+			Page:  requests.Page{ByReference: &page},
+			Index: i,
+		})
+		if err != nil {
+			continue
+		}
+		typeResp, err := instance.FPDFPageObj_GetType(&requests.FPDFPageObj_GetType{
+			PageObject: objResp.PageObject,
+		})
+		if err != nil {
+			continue
+		}
+		switch typeResp.Type {
+		case enums.FPDF_PAGEOBJ_TEXT:
+			info.TextObjects++
+		case enums.FPDF_PAGEOBJ_PATH:
+			info.PathObjects++
+		case enums.FPDF_PAGEOBJ_IMAGE:
+			info.ImageObjects++
+		}
+	}
+
+	return info, nil
+}
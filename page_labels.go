@@ -0,0 +1,40 @@
+package pdfmarkdown
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+)
+
+// readPageLabel returns the logical label the document gives a page (0-indexed),
+// such as "iii" or "A-7", or "" when it has none.
+func readPageLabel(instance pdfium.Pdfium, docRef references.FPDF_DOCUMENT, pageIndex int) string {
+	resp, err := instance.FPDF_GetPageLabel(&requests.FPDF_GetPageLabel{
+		Document: docRef,
+		Page:     pageIndex,
+	})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(resp.Label)
+}
+
+// DisplayLabel returns the page's label, or its number when it has none.
+func (p *Page) DisplayLabel() string {
+	if p.Label != "" {
+		return p.Label
+	}
+	return strconv.Itoa(p.Number)
+}
+
+// pageBreakMarker expands Config.PageBreakMarker for the page that follows
+// it: "{page}" is its number and "{label}" its DisplayLabel.
+func pageBreakMarker(marker string, page Page) string {
+	return strings.NewReplacer(
+		"{page}", strconv.Itoa(page.Number),
+		"{label}", page.DisplayLabel(),
+	).Replace(marker)
+}
@@ -0,0 +1,22 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestPageBreakMarker(t *testing.T) {
+	tests := []struct {
+		marker string
+		page   Page
+		want   string
+	}{
+		{"<!-- page {page} -->", Page{Number: 3, Label: "iii"}, "<!-- page 3 -->"},
+		{"<!-- page {label} -->", Page{Number: 3, Label: "iii"}, "<!-- page iii -->"},
+		{"<!-- {label} ({page}) -->", Page{Number: 12, Label: "A-7"}, "<!-- A-7 (12) -->"},
+		// Pages without a label fall back to their number
+		{"<!-- page {label} -->", Page{Number: 4}, "<!-- page 4 -->"},
+	}
+	for _, tt := range tests {
+		if got := pageBreakMarker(tt.marker, tt.page); got != tt.want {
+			t.Errorf("pageBreakMarker(%q, %+v) = %q, want %q", tt.marker, tt.page, got, tt.want)
+		}
+	}
+}
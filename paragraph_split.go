@@ -0,0 +1,120 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// splitLongParagraphs breaks paragraphs longer than config.SplitParagraphsOver
+// characters where tight leading ran several paragraphs together. A paragraph
+// is split before a line when the line above ends a sentence and the
+// indentation changes: the line is indented like a first line, or sits left of
+// the continuation lines above it as in a hanging indent.
+func splitLongParagraphs(paragraphs []Paragraph, pageWidth float64, config Config) []Paragraph {
+	if config.SplitParagraphsOver <= 0 {
+		return paragraphs
+	}
+
+	var result []Paragraph
+	for _, para := range paragraphs {
+		if len(para.Lines) < 2 || utf8.RuneCountInString(para.Text()) <= config.SplitParagraphsOver {
+			result = append(result, para)
+			continue
+		}
+
+		var fontSizes []float64
+		for _, line := range para.Lines {
+			fontSizes = append(fontSizes, getLineFontSize(line))
+		}
+		em := calculateMedian(fontSizes)
+
+		start := 0
+		for i := 1; i < len(para.Lines); i++ {
+			prev, line := para.Lines[i-1], para.Lines[i]
+			if !lineEndsSentence(prev) || config.endsWithAbbreviation(prev) || !lineStartsSentence(line) {
+				continue
+			}
+			indented := line.Box.X0-prev.Box.X0 >= em
+			outdented := prev.Box.X0-line.Box.X0 >= em && i-1 > start
+			if indented || outdented {
+				result = append(result, paragraphFromLines(para.Lines[start:i], pageWidth))
+				start = i
+			}
+		}
+		if start == 0 {
+			result = append(result, para)
+			continue
+		}
+		result = append(result, paragraphFromLines(para.Lines[start:], pageWidth))
+	}
+	return result
+}
+
+// splitListItems breaks a list paragraph before each line that starts with its
+// own marker, so items set in tight leading become separate list items. A
+// number after a line ending in an abbreviation continues that line.
+func splitListItems(paragraphs []Paragraph, pageWidth float64, config Config) []Paragraph {
+	var result []Paragraph
+	for _, para := range paragraphs {
+		if len(para.Lines) < 2 {
+			result = append(result, para)
+			continue
+		}
+		if _, _, ok := config.listMarker(para.Lines[0]); !ok {
+			result = append(result, para)
+			continue
+		}
+
+		start := 0
+		for i := 1; i < len(para.Lines); i++ {
+			marker, _, ok := config.listMarker(para.Lines[i])
+			if !ok || isDigit([]rune(marker)[0]) && config.endsWithAbbreviation(para.Lines[i-1]) {
+				continue
+			}
+			result = append(result, paragraphFromLines(para.Lines[start:i], pageWidth))
+			start = i
+		}
+		if start == 0 {
+			result = append(result, para)
+			continue
+		}
+		result = append(result, paragraphFromLines(para.Lines[start:], pageWidth))
+	}
+	return result
+}
+
+// paragraphFromLines builds a paragraph around the given lines.
+func paragraphFromLines(lines []Line, pageWidth float64) Paragraph {
+	box := lines[0].Box
+	for _, line := range lines[1:] {
+		box = mergeRects(box, line.Box)
+	}
+	return Paragraph{
+		Lines:     lines,
+		Box:       box,
+		Alignment: detectAlignment(lines, pageWidth),
+		Indent:    lines[0].Box.X0,
+	}
+}
+
+// lineEndsSentence reports whether a line's last word ends with sentence
+// punctuation, allowing closing quotes and brackets after it.
+func lineEndsSentence(line Line) bool {
+	if len(line.Words) == 0 {
+		return false
+	}
+	text := strings.TrimRight(line.Words[len(line.Words)-1].Text, `"'”’)]`)
+	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?")
+}
+
+// lineStartsSentence reports whether a line opens with a capital letter or
+// digit, allowing opening quotes and brackets before it.
+func lineStartsSentence(line Line) bool {
+	if len(line.Words) == 0 {
+		return false
+	}
+	text := strings.TrimLeft(line.Words[0].Text, `"'“‘([`)
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsUpper(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,179 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// indentedLine is a line of text starting at x.
+type indentedLine struct {
+	x    float64
+	text string
+}
+
+// indentedLines builds 10pt lines spaced 12pt apart.
+func indentedLines(lines ...indentedLine) []Line {
+	var result []Line
+	for i, l := range lines {
+		y := float64(i * 12)
+		line := Line{Box: Rect{X0: l.x, Y0: y, X1: 500, Y1: y + 10}}
+		for _, word := range strings.Fields(l.text) {
+			line.Words = append(line.Words, EnrichedWord{Text: word, FontSize: 10})
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+func TestSplitLongParagraphs(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []Line
+		limit int
+		want  []string // First word of each resulting paragraph
+	}{
+		{
+			name: "first-line indents",
+			lines: indentedLines(
+				indentedLine{100, "Revenue grew in every region this year."},
+				indentedLine{80, "Growth was strongest in the north, where new"},
+				indentedLine{80, "stores opened."},
+				indentedLine{100, "Costs rose more slowly than revenue did."},
+				indentedLine{80, "Margins improved as a result."},
+			),
+			limit: 50,
+			want:  []string{"Revenue", "Costs"},
+		},
+		{
+			name: "hanging indents",
+			lines: indentedLines(
+				indentedLine{80, "Smith, J. (2020). A long study of things that"},
+				indentedLine{100, "wraps onto a second line."},
+				indentedLine{80, "Jones, K. (2021). Another study of things that"},
+				indentedLine{100, "also wraps."},
+			),
+			limit: 50,
+			want:  []string{"Smith,", "Jones,"},
+		},
+		{
+			name: "short paragraph left alone",
+			lines: indentedLines(
+				indentedLine{100, "Revenue grew."},
+				indentedLine{80, "Costs fell."},
+				indentedLine{100, "Margins rose."},
+			),
+			limit: 200,
+			want:  []string{"Revenue"},
+		},
+		{
+			name: "no indent change",
+			lines: indentedLines(
+				indentedLine{80, "Revenue grew in every region this year."},
+				indentedLine{80, "Costs rose more slowly than revenue did."},
+			),
+			limit: 10,
+			want:  []string{"Revenue"},
+		},
+		{
+			name: "abbreviation is not a sentence end",
+			lines: indentedLines(
+				indentedLine{80, "The contract was signed with Widget Co."},
+				indentedLine{100, "Limited on the first of the month."},
+			),
+			limit: 10,
+			want:  []string{"The"},
+		},
+		{
+			name: "lowercase continuation",
+			lines: indentedLines(
+				indentedLine{80, "The result was up by 4.5."},
+				indentedLine{100, "times on the prior year."},
+			),
+			limit: 10,
+			want:  []string{"The"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{paragraphFromLines(tt.lines, 600)}
+			got := splitLongParagraphs(paragraphs, 600, Config{SplitParagraphsOver: tt.limit})
+
+			var firstWords []string
+			for _, para := range got {
+				firstWords = append(firstWords, para.Lines[0].Words[0].Text)
+			}
+			if strings.Join(firstWords, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("paragraphs start with %q, want %q", firstWords, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLongParagraphs_Disabled(t *testing.T) {
+	lines := indentedLines(
+		indentedLine{80, "Revenue grew."},
+		indentedLine{100, "Costs fell."},
+	)
+
+	paragraphs := []Paragraph{paragraphFromLines(lines, 600)}
+	if got := splitLongParagraphs(paragraphs, 600, DefaultConfig()); len(got) != 1 {
+		t.Errorf("got %d paragraphs with splitting disabled, want 1", len(got))
+	}
+}
+
+func TestSplitListItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []Line
+		want  []string // First word of each resulting paragraph
+	}{
+		{
+			name: "bulleted lines",
+			lines: indentedLines(
+				indentedLine{80, "• Sales rose sharply"},
+				indentedLine{80, "• Costs fell slightly"},
+				indentedLine{80, "• Margins widened"},
+			),
+			want: []string{"•", "•", "•"},
+		},
+		{
+			name: "wrapped item",
+			lines: indentedLines(
+				indentedLine{80, "1. Unpack the box and check"},
+				indentedLine{92, "the contents"},
+				indentedLine{80, "2. Plug in the cable"},
+			),
+			want: []string{"1.", "2."},
+		},
+		{
+			name: "number after abbreviation",
+			lines: indentedLines(
+				indentedLine{80, "1. See Schedule No."},
+				indentedLine{80, "3. for the details"},
+			),
+			want: []string{"1."},
+		},
+		{
+			name: "prose",
+			lines: indentedLines(
+				indentedLine{80, "Revenue grew in every region"},
+				indentedLine{80, "- with one exception"},
+			),
+			want: []string{"Revenue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{paragraphFromLines(tt.lines, 600)}
+			var firstWords []string
+			for _, para := range splitListItems(paragraphs, 600, DefaultConfig()) {
+				firstWords = append(firstWords, para.Lines[0].Words[0].Text)
+			}
+			if strings.Join(firstWords, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("paragraphs start with %q, want %q", firstWords, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package pdfmarkdowntest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// FileConverter converts a PDF file to markdown. *pdfmarkdown.Converter
+// satisfies it, configured as the corpus expects.
+type FileConverter interface {
+	ConvertFile(filePath string) (string, error)
+}
+
+// RunGolden converts every PDF in dir, in name order, and compares each with
+// the golden markdown file beside it, "invoice.pdf" with "invoice.md", as a
+// subtest named after the PDF. Outputs are compared block by block, so a
+// failure lists the paragraphs, headings, lists and tables that changed
+// rather than a byte offset. With update set the golden files are written
+// from the current output instead; pass a flag of the calling test package,
+// so that it owns the flag's name:
+//
+//	var update = flag.Bool("update", false, "rewrite golden files")
+//
+//	pdfmarkdowntest.RunGolden(t, converter, "testdata/corpus", *update)
+func RunGolden(t *testing.T, converter FileConverter, dir string, update bool) {
+	t.Helper()
+	pdfs, err := filepath.Glob(filepath.Join(dir, "*.pdf"))
+	if err != nil {
+		t.Fatalf("listing %s: %v", dir, err)
+	}
+	if len(pdfs) == 0 {
+		t.Fatalf("no PDFs in %s", dir)
+	}
+
+	for _, pdf := range pdfs {
+		name := strings.TrimSuffix(filepath.Base(pdf), filepath.Ext(pdf))
+		t.Run(name, func(t *testing.T) {
+			got, err := converter.ConvertFile(pdf)
+			if err != nil {
+				t.Fatalf("converting %s: %v", pdf, err)
+			}
+			CompareGolden(t, strings.TrimSuffix(pdf, filepath.Ext(pdf))+".md", got, update)
+		})
+	}
+}
+
+// CompareGolden compares markdown with the golden file at path block by
+// block, reporting each block that differs, or writes the file when update
+// is set.
+func CompareGolden(t testing.TB, path, got string, update bool) {
+	t.Helper()
+	if update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s is missing; run the tests with update set to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if diffs := DiffMarkdown(string(want), got); len(diffs) > 0 {
+		t.Errorf("output differs from %s in %d blocks:\n%s", path, len(diffs), FormatDiff(diffs))
+	}
+}
+
+// DiffOp is how a block of markdown differs from the golden output.
+type DiffOp string
+
+const (
+	DiffAdded   DiffOp = "added"   // Only in the output
+	DiffRemoved DiffOp = "removed" // Only in the golden file
+	DiffChanged DiffOp = "changed" // Rewritten in place
+)
+
+// BlockDiff is a block that differs between golden and actual markdown.
+// Block numbers count from 1 in each document, and are 0 on the side the
+// block is missing from.
+type BlockDiff struct {
+	Op        DiffOp
+	WantBlock int
+	GotBlock  int
+	Want      string
+	Got       string
+}
+
+// Blocks splits markdown into its blocks, the runs of lines between blank
+// lines, keeping fenced code blocks whole. Line endings are normalized and
+// trailing spaces trimmed, hard line breaks included, so padding alone never
+// fails a comparison.
+func Blocks(markdown string) []string {
+	var blocks, current []string
+	fenced := false
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+		}
+		if line == "" && !fenced {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return blocks
+}
+
+// DiffMarkdown compares markdown with the golden markdown block by block,
+// returning the blocks that were added, removed or changed in order. A
+// removed block followed by an added one is reported as changed.
+func DiffMarkdown(want, got string) []BlockDiff {
+	a, b := Blocks(want), Blocks(got)
+
+	// lcs[i][j] is the longest common run of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diffs []BlockDiff
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diffs = append(diffs, BlockDiff{Op: DiffRemoved, WantBlock: i + 1, Want: a[i]})
+			i++
+		default:
+			if n := len(diffs); n > 0 && diffs[n-1].Op == DiffRemoved && diffs[n-1].WantBlock == i {
+				diffs[n-1].Op, diffs[n-1].GotBlock, diffs[n-1].Got = DiffChanged, j+1, b[j]
+			} else {
+				diffs = append(diffs, BlockDiff{Op: DiffAdded, GotBlock: j + 1, Got: b[j]})
+			}
+			j++
+		}
+	}
+	return diffs
+}
+
+// FormatDiff renders block differences for a test failure, each block's
+// lines prefixed with "-" for the golden text and "+" for the output.
+func FormatDiff(diffs []BlockDiff) string {
+	var b strings.Builder
+	for _, d := range diffs {
+		switch d.Op {
+		case DiffAdded:
+			fmt.Fprintf(&b, "added block %d:\n", d.GotBlock)
+		case DiffRemoved:
+			fmt.Fprintf(&b, "removed block %d:\n", d.WantBlock)
+		default:
+			fmt.Fprintf(&b, "changed block %d (was %d):\n", d.GotBlock, d.WantBlock)
+		}
+		writePrefixed(&b, "- ", d.Want)
+		writePrefixed(&b, "+ ", d.Got)
+	}
+	return b.String()
+}
+
+// writePrefixed writes each line of text with prefix, writing nothing for
+// empty text.
+func writePrefixed(b *strings.Builder, prefix, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
@@ -0,0 +1,75 @@
+package pdfmarkdowntest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivanvanderbyl/pdfmarkdown/pdfmarkdowntest"
+)
+
+// update is defined here, as a consumer's tests define it, to check that the
+// package leaves the flag's name free
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestBlocks(t *testing.T) {
+	markdown := "# Title  \r\n\r\nFirst line  \nsecond line\n\n\n```\ncode\n\nmore code\n```\n\n- item\n"
+
+	assert.Equal(t, []string{
+		"# Title",
+		"First line\nsecond line",
+		"```\ncode\n\nmore code\n```",
+		"- item",
+	}, pdfmarkdowntest.Blocks(markdown))
+}
+
+func TestDiffMarkdown(t *testing.T) {
+	want := "# Report\n\nRevenue grew.\n\nCosts fell.\n\n| a | b |\n"
+	got := "# Report\n\nRevenue grew strongly.\n\nCosts fell.\n\n| a | b |\n\nNew closing note.\n"
+
+	diffs := pdfmarkdowntest.DiffMarkdown(want, got)
+	assert.Equal(t, []pdfmarkdowntest.BlockDiff{
+		{Op: pdfmarkdowntest.DiffChanged, WantBlock: 2, GotBlock: 2, Want: "Revenue grew.", Got: "Revenue grew strongly."},
+		{Op: pdfmarkdowntest.DiffAdded, GotBlock: 5, Got: "New closing note."},
+	}, diffs)
+
+	assert.Equal(t, "changed block 2 (was 2):\n- Revenue grew.\n+ Revenue grew strongly.\nadded block 5:\n+ New closing note.\n",
+		pdfmarkdowntest.FormatDiff(diffs))
+
+	// Only whitespace differs
+	assert.Empty(t, pdfmarkdowntest.DiffMarkdown(want, strings.ReplaceAll(want, "\n", "  \r\n")))
+
+	removed := pdfmarkdowntest.DiffMarkdown(want, "# Report\n\nCosts fell.\n\n| a | b |\n")
+	assert.Equal(t, []pdfmarkdowntest.BlockDiff{{Op: pdfmarkdowntest.DiffRemoved, WantBlock: 2, Want: "Revenue grew."}}, removed)
+}
+
+// fakeConverter returns the markdown stored for each file's base name.
+type fakeConverter map[string]string
+
+func (f fakeConverter) ConvertFile(filePath string) (string, error) {
+	return f[filepath.Base(filePath)], nil
+}
+
+func TestRunGolden(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.pdf", "b.pdf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	converter := fakeConverter{"a.pdf": "# A\n\nText.\n", "b.pdf": "# B\n"}
+
+	// update writes the golden files
+	pdfmarkdowntest.RunGolden(t, converter, dir, true)
+
+	golden, err := os.ReadFile(filepath.Join(dir, "a.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# A\n\nText.\n", string(golden))
+
+	// Without it the output is compared, ignoring padding
+	converter["a.pdf"] = "# A  \n\nText.\n"
+	pdfmarkdowntest.RunGolden(t, converter, dir, *update)
+}
@@ -0,0 +1,297 @@
+// Package pdfmarkdowntest builds synthetic pdfmarkdown structures for tests.
+//
+// Code that renders or post-processes a pdfmarkdown.Document can be unit
+// tested against pages built here, laid out with plausible boxes, fonts and
+// sizes, without a PDF file or a pdfium runtime:
+//
+//	page := pdfmarkdowntest.NewPage(1).
+//		Heading(1, "Quarterly Report").
+//		Paragraph("Revenue grew in every region.").
+//		ListItem("•", "North up 4%").
+//		Table([]string{"Region", "Sales"}, []string{"North", "120"}).
+//		Page()
+//	doc := pdfmarkdowntest.Document(page)
+//
+// Text is measured with a fixed average glyph width, so positions are
+// realistic enough for layout-aware code but not exact.
+//
+// RunGolden checks a corpus of PDFs against golden markdown files, comparing
+// block by block and rewriting the files when the test's own -update flag is
+// set:
+//
+//	var update = flag.Bool("update", false, "rewrite golden files")
+//
+//	func TestCorpus(t *testing.T) {
+//		pdfmarkdowntest.RunGolden(t, pdfmarkdown.NewConverter(instance), "testdata/corpus", *update)
+//	}
+package pdfmarkdowntest
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// Page dimensions and layout used by NewPage: US Letter with one-inch margins.
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+	Margin     = 72.0
+)
+
+// BodySize is the font size of body text, list items and table cells.
+const BodySize = 11.0
+
+// headingSizes are the font sizes of heading levels 1-6.
+var headingSizes = [...]float64{24, 18, 15, 13, 12, 11}
+
+const (
+	glyphWidth  = 0.5  // Average glyph width as a fraction of font size
+	spaceWidth  = 0.25 // Word space as a fraction of font size
+	leading     = 1.2  // Line height as a multiple of font size
+	cellWidth   = 100.0
+	cellHeight  = 20.0
+	cellPadding = 4.0
+)
+
+var black = pdfmarkdown.RGBA{A: 255}
+
+// Word returns a word in regular Helvetica with its top left corner at x, y.
+func Word(text string, x, y, size float64) pdfmarkdown.EnrichedWord {
+	width := float64(utf8.RuneCountInString(text)) * size * glyphWidth
+	return pdfmarkdown.EnrichedWord{
+		Text:       text,
+		Box:        pdfmarkdown.Rect{X0: x, Y0: y, X1: x + width, Y1: y + size},
+		FontSize:   size,
+		FontWeight: 400,
+		FontName:   "Helvetica",
+		FillColor:  black,
+		Baseline:   y + size*0.8,
+		XHeight:    size * 0.5,
+	}
+}
+
+// Bold returns word set in bold.
+func Bold(word pdfmarkdown.EnrichedWord) pdfmarkdown.EnrichedWord {
+	word.IsBold = true
+	word.FontWeight = 700
+	word.FontName = "Helvetica-Bold"
+	return word
+}
+
+// Italic returns word set in italics.
+func Italic(word pdfmarkdown.EnrichedWord) pdfmarkdown.EnrichedWord {
+	word.IsItalic = true
+	word.FontName = "Helvetica-Oblique"
+	return word
+}
+
+// Monospace returns word set in Courier.
+func Monospace(word pdfmarkdown.EnrichedWord) pdfmarkdown.EnrichedWord {
+	word.IsMonospace = true
+	word.FontName = "Courier"
+	return word
+}
+
+// Line lays out text as one line of words starting at x, y.
+func Line(text string, x, y, size float64) pdfmarkdown.Line {
+	var words []pdfmarkdown.EnrichedWord
+	for _, field := range strings.Fields(text) {
+		word := Word(field, x, y, size)
+		words = append(words, word)
+		x = word.Box.X1 + size*spaceWidth
+	}
+	return LineOf(words...)
+}
+
+// LineOf joins words, already placed, into a line.
+func LineOf(words ...pdfmarkdown.EnrichedWord) pdfmarkdown.Line {
+	line := pdfmarkdown.Line{Words: words}
+	for i, word := range words {
+		if i == 0 {
+			line.Box, line.Baseline = word.Box, word.Baseline
+			continue
+		}
+		line.Box = union(line.Box, word.Box)
+	}
+	return line
+}
+
+// ParagraphOf builds a left aligned paragraph from lines, with its box and
+// font summarized from their words.
+func ParagraphOf(lines ...pdfmarkdown.Line) pdfmarkdown.Paragraph {
+	para := pdfmarkdown.Paragraph{Lines: lines}
+	for i, line := range lines {
+		if i == 0 {
+			para.Box = line.Box
+			continue
+		}
+		para.Box = union(para.Box, line.Box)
+	}
+	for _, line := range lines {
+		if len(line.Words) > 0 {
+			word := line.Words[0]
+			para.Font = pdfmarkdown.FontSummary{
+				Family: strings.SplitN(word.FontName, "-", 2)[0],
+				Name:   word.FontName,
+				Size:   word.FontSize,
+				Weight: word.FontWeight,
+				Color:  word.FillColor,
+			}
+			break
+		}
+	}
+	return para
+}
+
+// Table lays out rows of cell text as a table with its top left corner at
+// x, y, in 100pt by 20pt cells. The first row is the header.
+func Table(x, y float64, rows ...[]string) pdfmarkdown.Table {
+	var table pdfmarkdown.Table
+	table.NumRows = len(rows)
+	for r, cells := range rows {
+		table.NumCols = max(table.NumCols, len(cells))
+
+		top := y + float64(r)*cellHeight
+		row := pdfmarkdown.TableRow{BBox: pdfmarkdown.CellBBox{X0: x, Top: top, X1: x + float64(len(cells))*cellWidth, Bottom: top + cellHeight}}
+		for c, text := range cells {
+			left := x + float64(c)*cellWidth
+			box := pdfmarkdown.CellBBox{X0: left, Top: top, X1: left + cellWidth, Bottom: top + cellHeight}
+			cell := pdfmarkdown.TableCell{
+				BBox:    box,
+				Content: text,
+				Words:   Line(text, left+cellPadding, top+cellPadding, BodySize).Words,
+			}
+			row.Cells = append(row.Cells, cell)
+			table.Cells = append(table.Cells, box)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.BBox = pdfmarkdown.CellBBox{
+		X0:     x,
+		Top:    y,
+		X1:     x + float64(table.NumCols)*cellWidth,
+		Bottom: y + float64(len(rows))*cellHeight,
+	}
+	return table
+}
+
+// Document collects pages into a document.
+func Document(pages ...pdfmarkdown.Page) *pdfmarkdown.Document {
+	return &pdfmarkdown.Document{Pages: pages}
+}
+
+// PageBuilder lays out content top to bottom down a page, the way a simple
+// single-column document would be set.
+type PageBuilder struct {
+	page pdfmarkdown.Page
+	y    float64 // Top of the next block
+}
+
+// NewPage starts a page with the given 1-indexed number.
+func NewPage(number int) *PageBuilder {
+	return &PageBuilder{
+		page: pdfmarkdown.Page{
+			Number:     number,
+			Width:      PageWidth,
+			Height:     PageHeight,
+			Paragraphs: []pdfmarkdown.Paragraph{},
+		},
+		y: Margin,
+	}
+}
+
+// Heading adds a bold heading of the given level, 1-6, sized so that the
+// converter's size ranking would give it that level.
+func (b *PageBuilder) Heading(level int, text string) *PageBuilder {
+	level = min(max(level, 1), len(headingSizes))
+	size := headingSizes[level-1]
+
+	line := Line(text, Margin, b.y, size)
+	for i := range line.Words {
+		line.Words[i] = Bold(line.Words[i])
+	}
+	para := ParagraphOf(line)
+	para.IsHeading = true
+	para.HeadingLevel = level
+	return b.add(para, size)
+}
+
+// Paragraph adds a body text paragraph with one line of text per argument.
+func (b *PageBuilder) Paragraph(lines ...string) *PageBuilder {
+	return b.add(ParagraphOf(b.lines(lines, Margin, nil)...), BodySize)
+}
+
+// ListItem adds a list item whose text follows marker, such as "•" or "2.".
+func (b *PageBuilder) ListItem(marker, text string) *PageBuilder {
+	para := ParagraphOf(Line(marker+" "+text, Margin, b.y, BodySize))
+	para.IsList = true
+	para.ListMarker = marker
+	return b.add(para, BodySize)
+}
+
+// Code adds a code block in Courier with one line of code per argument.
+// Leading spaces are kept as indentation.
+func (b *PageBuilder) Code(lines ...string) *PageBuilder {
+	para := ParagraphOf(b.lines(lines, Margin, Monospace)...)
+	para.IsCode = true
+	return b.add(para, BodySize)
+}
+
+// Table adds a table; the first row is the header.
+func (b *PageBuilder) Table(rows ...[]string) *PageBuilder {
+	table := Table(Margin, b.y, rows...)
+	b.page.Tables = append(b.page.Tables, table)
+	b.y = table.BBox.Bottom + BodySize
+	return b
+}
+
+// Figure adds an image region of the given height spanning the text width.
+func (b *PageBuilder) Figure(height float64) *PageBuilder {
+	b.page.Figures = append(b.page.Figures, pdfmarkdown.Rect{X0: Margin, Y0: b.y, X1: PageWidth - Margin, Y1: b.y + height})
+	b.y += height + BodySize
+	return b
+}
+
+// Page returns the page built so far.
+func (b *PageBuilder) Page() pdfmarkdown.Page {
+	return b.page
+}
+
+// lines lays out one line per text, indenting each by its leading spaces and
+// applying style, if given, to every word.
+func (b *PageBuilder) lines(texts []string, x float64, style func(pdfmarkdown.EnrichedWord) pdfmarkdown.EnrichedWord) []pdfmarkdown.Line {
+	lines := make([]pdfmarkdown.Line, 0, len(texts))
+	y := b.y
+	for _, text := range texts {
+		indent := float64(len(text)-len(strings.TrimLeft(text, " "))) * BodySize * glyphWidth
+		line := Line(text, x+indent, y, BodySize)
+		if style != nil {
+			for i := range line.Words {
+				line.Words[i] = style(line.Words[i])
+			}
+		}
+		lines = append(lines, line)
+		y += BodySize * leading
+	}
+	return lines
+}
+
+// add appends para and moves below it, leaving a blank line of size points.
+func (b *PageBuilder) add(para pdfmarkdown.Paragraph, size float64) *PageBuilder {
+	b.page.Paragraphs = append(b.page.Paragraphs, para)
+	b.y = math.Max(b.y, para.Box.Y1) + size*leading
+	return b
+}
+
+// union returns the smallest rectangle covering a and b.
+func union(a, b pdfmarkdown.Rect) pdfmarkdown.Rect {
+	return pdfmarkdown.Rect{
+		X0: math.Min(a.X0, b.X0),
+		Y0: math.Min(a.Y0, b.Y0),
+		X1: math.Max(a.X1, b.X1),
+		Y1: math.Max(a.Y1, b.Y1),
+	}
+}
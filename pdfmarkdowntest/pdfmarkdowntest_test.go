@@ -0,0 +1,89 @@
+package pdfmarkdowntest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivanvanderbyl/pdfmarkdown"
+	"github.com/ivanvanderbyl/pdfmarkdown/pdfmarkdowntest"
+)
+
+func TestPageBuilder_RendersMarkdown(t *testing.T) {
+	page := pdfmarkdowntest.NewPage(1).
+		Heading(1, "Quarterly Report").
+		Heading(2, "Overview").
+		Paragraph("Revenue grew in every region,", "led by new products.").
+		ListItem("-", "North up 4%").
+		ListItem("-", "South up 2%").
+		Code("func main() {", "    run()", "}").
+		Table([]string{"Region", "Sales"}, []string{"North", "120"}).
+		Page()
+
+	got := pdfmarkdowntest.Document(page).ToMarkdown(pdfmarkdown.DefaultConfig())
+
+	for _, want := range []string{
+		"# Quarterly Report",
+		"## Overview",
+		"Revenue grew in every region,",
+		"- North up 4%",
+		"- South up 2%",
+		"```\nfunc main() {\nrun()\n}\n```",
+		"| Region | Sales |",
+		"| North  | 120   |",
+	} {
+		assert.Contains(t, got, want)
+	}
+	assert.Less(t, strings.Index(got, "Overview"), strings.Index(got, "North up"), "blocks keep their order")
+}
+
+func TestPageBuilder_Layout(t *testing.T) {
+	page := pdfmarkdowntest.NewPage(3).
+		Heading(1, "Title").
+		Paragraph("first line", "second line").
+		Figure(100).
+		Paragraph("after the figure").
+		Page()
+
+	assert.Equal(t, 3, page.Number)
+	assert.Equal(t, pdfmarkdowntest.PageWidth, page.Width)
+	require.Len(t, page.Paragraphs, 3)
+	require.Len(t, page.Figures, 1)
+
+	// Blocks run down the page without overlapping
+	title, body, after := page.Paragraphs[0], page.Paragraphs[1], page.Paragraphs[2]
+	assert.Less(t, title.Box.Y1, body.Box.Y0)
+	assert.Less(t, body.Box.Y1, page.Figures[0].Y0)
+	assert.Less(t, page.Figures[0].Y1, after.Box.Y0)
+
+	// Words, lines and paragraphs carry consistent boxes and fonts
+	require.Len(t, body.Lines, 2)
+	assert.Less(t, body.Lines[0].Box.Y1, body.Lines[1].Box.Y0)
+	assert.Equal(t, "first line", body.Lines[0].Text())
+	first, second := body.Lines[0].Words[0], body.Lines[0].Words[1]
+	assert.Less(t, first.Box.X1, second.Box.X0)
+	assert.Equal(t, pdfmarkdowntest.BodySize, body.Font.Size)
+	assert.True(t, title.IsHeading)
+	assert.True(t, title.Lines[0].Words[0].IsBold)
+	assert.Greater(t, title.Font.Size, body.Font.Size)
+}
+
+func TestTable(t *testing.T) {
+	table := pdfmarkdowntest.Table(72, 100,
+		[]string{"Item", "Price"},
+		[]string{"Coffee", "$3.00"},
+		[]string{"Tea", "$2.50"},
+	)
+
+	assert.Equal(t, 3, table.NumRows)
+	assert.Equal(t, 2, table.NumCols)
+	assert.Len(t, table.Cells, 6)
+	assert.Equal(t, "$2.50", table.Rows[2].Cells[1].Content)
+
+	cell := table.Rows[1].Cells[0]
+	word := cell.Words[0]
+	assert.Equal(t, "Coffee", word.Text)
+	assert.True(t, word.Box.X0 >= cell.BBox.X0 && word.Box.Y1 <= cell.BBox.Bottom, "words sit inside their cell")
+}
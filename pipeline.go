@@ -0,0 +1,173 @@
+package pdfmarkdown
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// pageHandler receives each extracted page in order along with the time spent
+// extracting it. Returning an error stops extraction.
+type pageHandler func(page *Page, duration time.Duration) error
+
+// readResult carries one page from the reading stage to the structuring stage.
+type readResult struct {
+	index    int
+	raw      *rawPage
+	duration time.Duration
+	err      error
+}
+
+// extractPages extracts pages startPage through endPage (0-indexed, inclusive)
+// in order, passing each to handle.
+//
+// With Config.PrefetchPages, extraction runs as a two-stage pipeline: a reader
+// goroutine pulls the next page's characters and objects out of pdfium while
+// the current page is structured. Only the reader goroutine calls into pdfium,
+// so the instance is never used concurrently, and extractPages waits for the
+// reader to stop before returning so the caller can safely close the document.
+// With a single CPU the stages cannot overlap, so pages are extracted in turn.
+// With Config.UseOutlineHeadings, or an OutlineHeadings strategy, the document outline is read up front and
+// applied to each page before it is handled, and with
+// Config.AggressiveWordSplitting a sample of pages is read up front to decide
+// whether the document's text lacks spaces.
+func (c *Converter) extractPages(docRef references.FPDF_DOCUMENT, startPage, endPage int, handle pageHandler) error {
+	spaceless := c.config.AggressiveWordSplitting && c.documentLacksSpaces(docRef, startPage, endPage)
+	cache := c.pageCacheScope(docRef, spaceless)
+
+	if c.config.useOutlineHeadings() {
+		if outline := c.readOutline(docRef); len(outline) > 0 {
+			next := handle
+			handle = func(page *Page, duration time.Duration) error {
+				applyOutlineHeadings(page, outline, c.config)
+				return next(page, duration)
+			}
+		}
+	}
+
+	if !c.config.PrefetchPages || runtime.GOMAXPROCS(0) < 2 {
+		for i := startPage; i <= endPage; i++ {
+			pageStart := time.Now()
+			page, err := c.extractPage(docRef, i, spaceless, cache)
+			if err != nil {
+				return errors.Wrapf(err, "failed to extract page %d", i+1)
+			}
+			if page == nil {
+				// Skipped by Config.PageFilter
+				continue
+			}
+			if err := handle(page, time.Since(pageStart)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// A buffer of one lets the reader finish the next page while this one is structured
+	results := make(chan readResult, 1)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(results)
+
+		for i := startPage; i <= endPage; i++ {
+			readStart := time.Now()
+			raw, err := c.readPage(docRef, i, cache)
+			select {
+			case results <- readResult{index: i, raw: raw, duration: time.Since(readStart), err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	for result := range results {
+		if result.err != nil {
+			return errors.Wrapf(result.err, "failed to extract page %d", result.index+1)
+		}
+		if result.raw == nil {
+			// Skipped by Config.PageFilter
+			continue
+		}
+
+		structureStart := time.Now()
+		result.raw.spaceless = spaceless
+		page, err := c.structure(result.raw, result.index)
+		if err != nil {
+			return errors.Wrapf(err, "failed to extract page %d", result.index+1)
+		}
+		if err := handle(page, result.duration+time.Since(structureStart)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readPage loads a page and reads its content from pdfium, closing the page
+// before returning. It returns nil without an error when Config.PageFilter
+// skips the page. With a cache scope, a page found in Config.PageCache is
+// returned without reading its content.
+func (c *Converter) readPage(docRef references.FPDF_DOCUMENT, pageIndex int, cache *pageCacheScope) (*rawPage, error) {
+	pageResp, err := c.instance.FPDF_LoadPage(&requests.FPDF_LoadPage{
+		Document: docRef,
+		Index:    pageIndex,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load page")
+	}
+	defer c.instance.FPDF_ClosePage(&requests.FPDF_ClosePage{
+		Page: pageResp.Page,
+	})
+
+	label := readPageLabel(c.instance, docRef, pageIndex)
+	var info PageInfo
+	if c.config.PageFilter != nil || cache != nil {
+		info, err = readPageInfo(c.instance, pageResp.Page, pageIndex)
+		if err != nil {
+			return nil, err
+		}
+		info.Label = label
+	}
+	if c.config.PageFilter != nil && !c.config.PageFilter(info) {
+		return nil, nil
+	}
+
+	var key *PageCacheKey
+	if cache != nil {
+		if k, ok := cache.key(c.instance, pageResp.Page, info); ok {
+			page, err := c.config.PageCache.Load(k)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load cached page")
+			}
+			if page != nil {
+				page.Label = label
+				return &rawPage{cached: page}, nil
+			}
+			key = &k
+		}
+	}
+
+	raw, err := readPage(c.instance, docRef, pageResp.Page, c.config, c.features)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract page content")
+	}
+	raw.label = label
+	raw.cacheKey = key
+
+	return raw, nil
+}
@@ -0,0 +1,98 @@
+package pdfmarkdown_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// TestPrefetchPages_MatchesSequential verifies the two-stage pipeline produces
+// exactly the same markdown as extracting pages one at a time
+func TestPrefetchPages_MatchesSequential(t *testing.T) {
+	instance := setupPDFium(t)
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	config := pdfmarkdown.DefaultConfig()
+	config.PrefetchPages = false
+	sequential, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertFile(pdfPath)
+	require.NoError(t, err)
+
+	config.PrefetchPages = true
+	prefetched, metrics, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertFileWithMetrics(pdfPath)
+	require.NoError(t, err)
+
+	require.Equal(t, sequential, prefetched)
+	require.Greater(t, len(metrics.PageExtractions), 1, "test PDF should have multiple pages")
+	for i, page := range metrics.PageExtractions {
+		require.Equal(t, i+1, page.PageNumber, "pages should be reported in order")
+	}
+
+	// The instance must be free for further use once conversion returns
+	_, err = pdfmarkdown.NewConverterWithConfig(instance, config).ConvertPageRange(pdfPath, 1, 2)
+	require.NoError(t, err)
+}
+
+// TestPageFilter verifies skipped pages are left out of the document in both
+// the sequential and prefetching pipelines
+func TestPageFilter(t *testing.T) {
+	instance := setupPDFium(t)
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	for _, prefetch := range []bool{false, true} {
+		var seen []pdfmarkdown.PageInfo
+		config := pdfmarkdown.DefaultConfig()
+		config.PrefetchPages = prefetch
+		config.PageFilter = func(info pdfmarkdown.PageInfo) bool {
+			seen = append(seen, info)
+			return info.Number != 2
+		}
+
+		_, metrics, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertFileWithMetrics(pdfPath)
+		require.NoError(t, err)
+
+		require.Greater(t, len(seen), 2, "test PDF should have more than two pages")
+		for i, info := range seen {
+			require.Equal(t, i+1, info.Number)
+			require.Greater(t, info.Width, 0.0)
+			require.Greater(t, info.Height, 0.0)
+			require.Greater(t, info.TextObjects, 0)
+			require.LessOrEqual(t, info.TextObjects+info.PathObjects+info.ImageObjects, info.Objects)
+		}
+
+		var extracted []int
+		for _, page := range metrics.PageExtractions {
+			extracted = append(extracted, page.PageNumber)
+		}
+		require.NotContains(t, extracted, 2, "prefetch: %v", prefetch)
+		require.Len(t, extracted, len(seen)-1, "prefetch: %v", prefetch)
+	}
+}
+
+func benchmarkConvertPrefetch(b *testing.B, prefetch bool) {
+	instance := setupPDFium(b)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.UseSegmentBasedTables = true
+	config.PrefetchPages = prefetch
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	for b.Loop() {
+		_, err := converter.ConvertFile(pdfPath)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkConvert_Prefetch measures conversion with the two-stage page pipeline
+func BenchmarkConvert_Prefetch(b *testing.B) {
+	benchmarkConvertPrefetch(b, true)
+}
+
+// BenchmarkConvert_Sequential measures conversion extracting one page at a time
+func BenchmarkConvert_Sequential(b *testing.B) {
+	benchmarkConvertPrefetch(b, false)
+}
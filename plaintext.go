@@ -0,0 +1,260 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ToPlainText converts a document to plain text; see WritePlainText.
+func (d *Document) ToPlainText(config Config) string {
+	var buf bytes.Buffer
+	if err := d.WritePlainText(&buf, config); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// WritePlainText writes the document to w as plain text in reading order,
+// without markdown syntax: a paragraph per block separated by blank lines,
+// wrapped at Config.TextWrapWidth, and tables as space-padded columns. With
+// Config.TextLayout each page is instead laid out as it is printed, like
+// pdftotext -layout. Page breaks are form feeds, or Config.PageBreakMarker.
+func (d *Document) WritePlainText(w io.Writer, config Config) error {
+	var buf bytes.Buffer
+	for i, page := range d.renderedPages(config) {
+		buf.Reset()
+		if i > 0 {
+			switch {
+			case !config.IncludePageBreaks:
+				if config.TextLayout {
+					buf.WriteString("\n") // Blocks otherwise end in a blank line already
+				}
+			case config.PageBreakMarker != "":
+				buf.WriteString("\n" + pageBreakMarker(config.PageBreakMarker, page) + "\n\n")
+			default:
+				buf.WriteString("\f")
+			}
+		}
+		if config.TextLayout {
+			writePageLayout(&buf, page)
+		} else {
+			writePageText(&buf, page, config)
+		}
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return errors.Wrap(err, "failed to write plain text")
+		}
+	}
+	return nil
+}
+
+// writePageText writes a page's paragraphs and then its tables, each followed
+// by a blank line.
+func writePageText(b *bytes.Buffer, page Page, config Config) {
+	var blocks []string
+	for _, para := range page.Paragraphs {
+		if len(para.Lines) == 0 {
+			continue
+		}
+		switch {
+		case len(para.TOCEntries) > 0:
+			lines := make([]string, len(para.TOCEntries))
+			for i, entry := range para.TOCEntries {
+				lines[i] = strings.Repeat("  ", entry.Level) + entry.Title
+			}
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		case para.IsCode || len(para.Leaders) > 0:
+			// Line breaks and spacing carry meaning; keep them
+			lines := make([]string, len(para.Lines))
+			for i, line := range para.Lines {
+				lines[i] = strings.TrimRight(line.Text(), " \t")
+			}
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		default:
+			blocks = append(blocks, wrapText(unwrapLines(para.Lines), config.TextWrapWidth))
+		}
+	}
+	if config.tablesEnabled() {
+		for _, table := range page.Tables {
+			if text := tableText(table); text != "" {
+				blocks = append(blocks, text)
+			}
+		}
+	}
+
+	for _, block := range blocks {
+		b.WriteString(block + "\n\n")
+	}
+}
+
+// unwrapLines joins a paragraph's lines into one, rejoining words broken
+// across lines at a hyphen.
+func unwrapLines(lines []Line) string {
+	var b strings.Builder
+	for i, line := range lines {
+		text := line.Text()
+		if i < len(lines)-1 && line.TrailingHyphen() {
+			b.WriteString(strings.TrimSuffix(text, "-"))
+			continue
+		}
+		b.WriteString(text)
+		if i < len(lines)-1 {
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
+
+// wrapText breaks text at spaces into lines of at most width characters.
+// Words longer than width get a line of their own; width 0 leaves text on
+// one line.
+func wrapText(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	var lines []string
+	var line strings.Builder
+	lineLen := 0
+	for _, word := range strings.Fields(text) {
+		n := utf8.RuneCountInString(word)
+		if lineLen > 0 && lineLen+1+n > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineLen = 0
+		}
+		if lineLen > 0 {
+			line.WriteString(" ")
+			lineLen++
+		}
+		line.WriteString(word)
+		lineLen += n
+	}
+	if lineLen > 0 {
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableText renders a table's rows as columns padded with spaces to the
+// widest cell, two spaces apart.
+func tableText(table Table) string {
+	var rows [][]string
+	var widths []int
+	for _, row := range table.Rows {
+		cells := make([]string, len(row.Cells))
+		for c, cell := range row.Cells {
+			cells[c] = strings.Join(strings.Fields(cell.Content), " ")
+			if c >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[c] = max(widths[c], utf8.RuneCountInString(cells[c]))
+		}
+		rows = append(rows, cells)
+	}
+
+	lines := make([]string, len(rows))
+	for r, cells := range rows {
+		var b strings.Builder
+		for c, cell := range cells {
+			if c > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(cell)
+			if c < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell)))
+			}
+		}
+		lines[r] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// writePageLayout writes a page's words at their printed positions: words
+// sharing a baseline, even across columns, go on one row, padded with spaces
+// to their horizontal position at the page's typical character width, and
+// larger vertical gaps become blank lines.
+func writePageLayout(b *bytes.Buffer, page Page) {
+	var words []EnrichedWord
+	for _, para := range page.Paragraphs {
+		for _, line := range para.Lines {
+			words = append(words, line.Words...)
+		}
+	}
+	for _, table := range page.Tables {
+		for _, row := range table.Rows {
+			for _, cell := range row.Cells {
+				words = append(words, cell.Words...)
+			}
+		}
+	}
+	if len(words) == 0 {
+		return
+	}
+
+	charWidth := layoutCharWidth(words)
+	left := words[0].Box.X0
+	for _, word := range words {
+		left = math.Min(left, word.Box.X0)
+	}
+
+	// Group words into rows by vertical overlap with the row's first word
+	sort.SliceStable(words, func(i, j int) bool {
+		return words[i].Box.Y0+words[i].Box.Y1 < words[j].Box.Y0+words[j].Box.Y1
+	})
+	var rows [][]EnrichedWord
+	for _, word := range words {
+		center := (word.Box.Y0 + word.Box.Y1) / 2
+		if n := len(rows); n > 0 {
+			first := rows[n-1][0].Box
+			if center >= first.Y0 && center <= first.Y1 {
+				rows[n-1] = append(rows[n-1], word)
+				continue
+			}
+		}
+		rows = append(rows, []EnrichedWord{word})
+	}
+
+	for r, row := range rows {
+		if r > 0 {
+			prev := rows[r-1][0].Box
+			if gap := row[0].Box.Y0 - prev.Y1; gap > prev.Y1-prev.Y0 {
+				b.WriteString("\n")
+			}
+		}
+
+		sort.SliceStable(row, func(i, j int) bool { return row[i].Box.X0 < row[j].Box.X0 })
+		col := 0
+		for i, word := range row {
+			target := int(math.Round((word.Box.X0 - left) / charWidth))
+			if i > 0 && target <= col {
+				target = col + len(wordSeparator(row[i-1].Text, word.Text))
+			}
+			b.WriteString(strings.Repeat(" ", max(target-col, 0)))
+			b.WriteString(word.Text)
+			col = max(target, col) + utf8.RuneCountInString(word.Text)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// layoutCharWidth returns the median width per character of words, the
+// column width writePageLayout lays text out on.
+func layoutCharWidth(words []EnrichedWord) float64 {
+	widths := make([]float64, 0, len(words))
+	for _, word := range words {
+		if n := utf8.RuneCountInString(word.Text); n > 0 && word.Box.X1 > word.Box.X0 {
+			widths = append(widths, (word.Box.X1-word.Box.X0)/float64(n))
+		}
+	}
+	if len(widths) == 0 {
+		return 6 // Roughly a 12pt font's average character
+	}
+	sort.Float64s(widths)
+	return widths[len(widths)/2]
+}
@@ -0,0 +1,85 @@
+package pdfmarkdown
+
+import "testing"
+
+// placedLine builds a line of words at the given x positions, 5pt per character
+func placedLine(top float64, words ...placedWord) Line {
+	var line Line
+	for _, w := range words {
+		line.Words = append(line.Words, EnrichedWord{
+			Text: w.text,
+			Box:  Rect{X0: w.x, Y0: top, X1: w.x + 5*float64(len(w.text)), Y1: top + 10},
+		})
+	}
+	return line
+}
+
+type placedWord struct {
+	text string
+	x    float64
+}
+
+func TestToPlainText(t *testing.T) {
+	broken := placedLine(20, placedWord{"are", 0}, placedWord{"para-", 20})
+	broken.Words[1].TrailingHyphen = true
+
+	doc := &Document{Pages: []Page{
+		{Number: 1, Paragraphs: []Paragraph{
+			{Lines: []Line{placedLine(0, placedWord{"Overview", 0})}, IsHeading: true, HeadingLevel: 1},
+			{Lines: []Line{
+				placedLine(10, placedWord{"Lines", 0}, placedWord{"of", 30}, placedWord{"text", 45}),
+				broken,
+				placedLine(30, placedWord{"graphs.", 0}),
+			}},
+		}},
+		{Number: 2, Paragraphs: []Paragraph{
+			{Lines: []Line{placedLine(0, placedWord{"Next", 0})}},
+		}},
+	}}
+
+	config := DefaultConfig()
+	want := "Overview\n\nLines of text are paragraphs.\n\n\fNext\n\n"
+	if got := doc.ToPlainText(config); got != want {
+		t.Errorf("ToPlainText() = %q, want %q", got, want)
+	}
+
+	config.TextWrapWidth = 13
+	config.IncludePageBreaks = false
+	want = "Overview\n\nLines of text\nare\nparagraphs.\n\nNext\n\n"
+	if got := doc.ToPlainText(config); got != want {
+		t.Errorf("ToPlainText() wrapped = %q, want %q", got, want)
+	}
+}
+
+// TestToPlainTextLayout tests that words keep their columns and rows
+func TestToPlainTextLayout(t *testing.T) {
+	// Two columns whose lines share baselines, and a gap before the footer
+	doc := &Document{Pages: []Page{{Number: 1, Paragraphs: []Paragraph{
+		{Lines: []Line{
+			placedLine(0, placedWord{"Left", 50}),
+			placedLine(10, placedWord{"side", 50}),
+		}},
+		{Lines: []Line{
+			placedLine(0, placedWord{"Right", 100}),
+			placedLine(10, placedWord{"side", 100}),
+		}},
+		{Lines: []Line{placedLine(40, placedWord{"Footer", 75})}},
+	}}}}
+
+	config := DefaultConfig()
+	config.TextLayout = true
+	want := "Left      Right\nside      side\n\n     Footer\n"
+	if got := doc.ToPlainText(config); got != want {
+		t.Errorf("ToPlainText() = %q, want %q", got, want)
+	}
+}
+
+func TestTableText(t *testing.T) {
+	table := Table{Rows: []TableRow{
+		{Cells: []TableCell{{Content: "Item"}, {Content: "Price"}}},
+		{Cells: []TableCell{{Content: "Coffee\nbeans"}, {Content: "12.00"}}},
+	}}
+	if got, want := tableText(table), "Item          Price\nCoffee beans  12.00"; got != want {
+		t.Errorf("tableText() = %q, want %q", got, want)
+	}
+}
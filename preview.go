@@ -0,0 +1,78 @@
+package pdfmarkdown
+
+import (
+	"time"
+
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// DocumentPreview is a quick look at a document: the markdown of its first
+// pages and its page count, metadata and page labels.
+type DocumentPreview struct {
+	Markdown string
+	Info     DocumentInfo
+
+	// Pages is how many pages the markdown covers, fewer than
+	// Info.PageCount when the document was cut short
+	Pages int
+}
+
+// Preview converts the first nPages pages of a PDF file to markdown and reads
+// its DocumentInfo, for showing a document before committing to converting
+// all of it. Only the previewed pages are read, so a preview of a 2000-page
+// document costs no more than one of a short one. Heading levels are ranked
+// among the previewed pages alone; with Config.FastPreview, tables are left
+// as text.
+func (c *Converter) Preview(filePath string, nPages int) (*DocumentPreview, error) {
+	if nPages < 1 {
+		return nil, errors.New("invalid preview length: nPages must be at least 1")
+	}
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	info, err := c.documentInfo(doc.Document)
+	if err != nil {
+		return nil, err
+	}
+	preview := &DocumentPreview{Info: *info, Pages: min(nPages, info.PageCount)}
+	if preview.Pages == 0 {
+		return preview, nil
+	}
+
+	// A converter sharing the instance, which this call holds, with the
+	// preview's config
+	converter := c
+	if c.config.FastPreview {
+		config := c.config
+		config.Profile = ProfileProse
+		converter = &Converter{instance: c.instance, config: config, features: c.features}
+	}
+
+	document := &Document{Metadata: info.Metadata}
+	registry := converter.pageRegistry()
+	err = converter.extractPages(doc.Document, 0, preview.Pages-1, func(page *Page, _ time.Duration) error {
+		if !converter.recordDuplicate(registry, document, filePath, page) {
+			document.Pages = append(document.Pages, *page)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	preview.Markdown = document.ToMarkdown(converter.config)
+	return preview, nil
+}
@@ -0,0 +1,76 @@
+package pdfmarkdown_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// TestProfileProse_SkipsTables verifies the prose profile never emits tables
+func TestProfileProse_SkipsTables(t *testing.T) {
+	instance := setupPDFium(t)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.UseSegmentBasedTables = true
+	config.Profile = pdfmarkdown.ProfileProse
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+	markdown, metrics, err := converter.ConvertFileWithMetrics(pdfPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, markdown)
+
+	require.Zero(t, metrics.Statistics.TotalTables, "prose profile should not detect tables")
+	require.NotContains(t, markdown, "|---", "prose profile should not render tables")
+	require.Greater(t, metrics.Statistics.TotalParagraphs, 0)
+}
+
+// TestProfileProse_SkipsPageObjects verifies the prose profile reads no
+// figures or line objects and classifies no column separators
+func TestProfileProse_SkipsPageObjects(t *testing.T) {
+	instance := setupPDFium(t)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.Profile = pdfmarkdown.ProfileProse
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	doc, err := converter.ConvertFileToStructured(filepath.Join("testdata", "Mock Statement of Advice.pdf"))
+	require.NoError(t, err)
+	require.NotEmpty(t, doc.Pages)
+
+	for _, page := range doc.Pages {
+		require.Empty(t, page.Figures, "page %d", page.Number)
+		for _, line := range page.Lines {
+			require.False(t, line.IsColumnSeparator, "page %d", page.Number)
+		}
+	}
+}
+
+func benchmarkConvertProfile(b *testing.B, profile pdfmarkdown.Profile) {
+	instance := setupPDFium(b)
+
+	config := pdfmarkdown.DefaultConfig()
+	config.UseSegmentBasedTables = true
+	config.Profile = profile
+	converter := pdfmarkdown.NewConverterWithConfig(instance, config)
+
+	pdfPath := filepath.Join("testdata", "Mock Statement of Advice.pdf")
+
+	for b.Loop() {
+		_, err := converter.ConvertFile(pdfPath)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkConvert_DefaultProfile measures the full pipeline including table detection
+func BenchmarkConvert_DefaultProfile(b *testing.B) {
+	benchmarkConvertProfile(b, pdfmarkdown.ProfileDefault)
+}
+
+// BenchmarkConvert_ProseProfile measures the table-free fast path
+func BenchmarkConvert_ProseProfile(b *testing.B) {
+	benchmarkConvertProfile(b, pdfmarkdown.ProfileProse)
+}
@@ -0,0 +1,46 @@
+package pdfmarkdown
+
+import "math"
+
+// coordinateScale sets the precision positions and sizes are rounded to as
+// they are read from pdfium: 1/coordinateScale of a point. pdfium's results
+// differ in their last bits between builds, such as WebAssembly and native
+// ones, and thresholds compared against unrounded values flip on those
+// differences, changing how lines, columns and tables are grouped. A
+// hundredth of a point is far below anything visible.
+const coordinateScale = 100
+
+// quantize rounds v to the nearest 1/coordinateScale. Dividing by the exact
+// scale gives the closest float to the rounded value, so it prints cleanly.
+func quantize(v float64) float64 {
+	return math.Round(v*coordinateScale) / coordinateScale
+}
+
+// quantizeRect rounds a box's edges with quantize.
+func quantizeRect(r Rect) Rect {
+	return Rect{X0: quantize(r.X0), Y0: quantize(r.Y0), X1: quantize(r.X1), Y1: quantize(r.Y1)}
+}
+
+// quantizeRawPage rounds everything readPage measured with quantize, so
+// structuring starts from the same numbers on every platform.
+func quantizeRawPage(raw *rawPage) {
+	raw.width, raw.height = quantize(raw.width), quantize(raw.height)
+	for i := range raw.chars {
+		char := &raw.chars[i]
+		char.Box = quantizeRect(char.Box)
+		char.Origin = Point{X: quantize(char.Origin.X), Y: quantize(char.Origin.Y)}
+		char.FontSize = quantize(char.FontSize)
+	}
+	for i := range raw.figures {
+		raw.figures[i] = quantizeRect(raw.figures[i])
+	}
+	for i := range raw.lines {
+		e := &raw.lines[i]
+		e.X0, e.X1, e.Top, e.Bottom = quantize(e.X0), quantize(e.X1), quantize(e.Top), quantize(e.Bottom)
+		e.Width, e.Height = quantize(e.Width), quantize(e.Height)
+	}
+	for i := range raw.links {
+		raw.links[i].Box = quantizeRect(raw.links[i].Box)
+		raw.links[i].Dest = PDFPoint{X: quantize(raw.links[i].Dest.X), Y: quantize(raw.links[i].Dest.Y)}
+	}
+}
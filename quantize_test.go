@@ -0,0 +1,64 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuantize(t *testing.T) {
+	for _, tt := range []struct{ in, want float64 }{
+		{12.344999, 12.34},
+		{12.3450001, 12.35},
+		{-3.0000000001, -3},
+		{0.1 + 0.2, 0.3},
+	} {
+		if got := quantize(tt.in); got != tt.want {
+			t.Errorf("quantize(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+		if got := quantize(quantize(tt.in)); got != tt.want {
+			t.Errorf("quantize is not idempotent for %v: %v", tt.in, got)
+		}
+	}
+}
+
+// TestQuantizeRawPage_StableStructure tests that pages differing only in the
+// last bits of their measurements, as pdfium builds return, are structured
+// identically
+func TestQuantizeRawPage_StableStructure(t *testing.T) {
+	page := func(jitter float64) *Page {
+		raw := &rawPage{width: 612 + jitter, height: 792 - jitter}
+		y := 100.0
+		for _, line := range []string{"Heading", "Body text on the first line", "and the second line"} {
+			size := 10.0
+			if line == "Heading" {
+				size = 18
+			}
+			x := 72.0
+			for _, r := range line {
+				raw.chars = append(raw.chars, EnrichedChar{
+					Text:      r,
+					Box:       Rect{X0: x + jitter, Y0: y - size*0.8 - jitter, X1: x + size*0.5 + jitter, Y1: y + size*0.2 + jitter},
+					FontSize:  size + jitter,
+					Origin:    Point{X: x - jitter, Y: y + jitter},
+					HasOrigin: true,
+				})
+				x += size * 0.5
+			}
+			y += size * 1.6
+		}
+		raw.lines = []Edge{{X0: 72 - jitter, X1: 300 + jitter, Top: 300, Bottom: 300 + jitter, Width: 228, Orientation: "h"}}
+		quantizeRawPage(raw)
+		return structurePage(raw, 1, DefaultConfig())
+	}
+
+	want := page(0)
+	for _, jitter := range []float64{1e-9, -1e-9, 3e-7} {
+		got := page(jitter)
+		if !reflect.DeepEqual(got.Paragraphs, want.Paragraphs) || !reflect.DeepEqual(got.Lines, want.Lines) {
+			t.Errorf("jitter %g changed the page structure", jitter)
+		}
+		if a, b := (&Document{Pages: []Page{*got}}).ToMarkdown(DefaultConfig()), (&Document{Pages: []Page{*want}}).ToMarkdown(DefaultConfig()); a != b {
+			t.Errorf("jitter %g changed the markdown:\n%s\nwant:\n%s", jitter, a, b)
+		}
+	}
+}
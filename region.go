@@ -0,0 +1,110 @@
+package pdfmarkdown
+
+import (
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+)
+
+// ConvertRegion converts the content inside region, in top-left page
+// coordinates, on one page (0-indexed) to markdown, such as the address
+// block or line items of an invoice. Characters count as inside when their
+// centre is, figures likewise, and ruling lines are cut at the region's
+// edges, so a table's borders reaching beyond it still frame its cells.
+// Paragraphs, lists and tables are then found within the region alone.
+func (c *Converter) ConvertRegion(filePath string, pageIndex int, region Rect) (string, error) {
+	if region.Width() <= 0 || region.Height() <= 0 {
+		return "", errors.Errorf("invalid region %+v: it must have a positive width and height", region)
+	}
+
+	if err := c.acquire(); err != nil {
+		return "", err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
+		Document: doc.Document,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get page count")
+	}
+	if pageIndex < 0 || pageIndex >= pageCount.PageCount {
+		return "", errors.Errorf("invalid page index %d: document has %d pages", pageIndex, pageCount.PageCount)
+	}
+
+	// Cached pages are whole, so the region is always read afresh
+	raw, err := c.readPage(doc.Document, pageIndex, nil)
+	if err != nil || raw == nil {
+		return "", err
+	}
+	// Judge spacing on the whole page, which has more text to go on
+	raw.spaceless = c.config.AggressiveWordSplitting && charsLackSpaces(raw.chars)
+
+	page := structurePage(cropRawPage(raw, region), pageIndex+1, c.config)
+	return (&Document{Pages: []Page{*page}}).ToMarkdown(c.config), nil
+}
+
+// cropRawPage returns a copy of raw holding only what lies inside region:
+// characters and figures whose centre is inside, links overlapping it, and
+// ruling lines cut at its edges. The page keeps its size and coordinates.
+func cropRawPage(raw *rawPage, region Rect) *rawPage {
+	cropped := *raw
+
+	cropped.chars = nil
+	for _, char := range raw.chars {
+		if containsPoint(region, char.Box.CenterX(), char.Box.CenterY()) {
+			cropped.chars = append(cropped.chars, char)
+		}
+	}
+
+	cropped.figures, cropped.figureAlt = nil, nil
+	for i, fig := range raw.figures {
+		if !containsPoint(region, fig.CenterX(), fig.CenterY()) {
+			continue
+		}
+		cropped.figures = append(cropped.figures, fig)
+		if i < len(raw.figureAlt) {
+			cropped.figureAlt = append(cropped.figureAlt, raw.figureAlt[i])
+		}
+	}
+
+	if raw.lines != nil {
+		cropped.lines = []Edge{}
+		for _, e := range raw.lines {
+			if clipped, ok := clipEdge(e, region); ok {
+				cropped.lines = append(cropped.lines, clipped)
+			}
+		}
+	}
+
+	cropped.links = nil
+	for _, link := range raw.links {
+		if rectsOverlap(link.Box, region) {
+			cropped.links = append(cropped.links, link)
+		}
+	}
+
+	// Mismatches aren't located on the page, so none can be placed in it
+	cropped.textMismatches = nil
+	return &cropped
+}
+
+// clipEdge cuts a ruling line to r, reporting false when none of it is inside.
+func clipEdge(e Edge, r Rect) (Edge, bool) {
+	e.X0, e.X1 = max(e.X0, r.X0), min(e.X1, r.X1)
+	e.Top, e.Bottom = max(e.Top, r.Y0), min(e.Bottom, r.Y1)
+	if e.X0 > e.X1 || e.Top > e.Bottom {
+		return Edge{}, false
+	}
+	e.Width, e.Height = e.X1-e.X0, e.Bottom-e.Top
+	return e, true
+}
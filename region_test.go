@@ -0,0 +1,56 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestCropRawPage(t *testing.T) {
+	char := func(r rune, x, y float64) EnrichedChar {
+		return EnrichedChar{Text: r, Box: Rect{X0: x, Y0: y, X1: x + 5, Y1: y + 10}}
+	}
+	raw := &rawPage{
+		width:  600,
+		height: 800,
+		chars:  []EnrichedChar{char('a', 50, 50), char('b', 300, 50), char('c', 50, 500), char('d', 198, 50)},
+		figures: []Rect{
+			{X0: 20, Y0: 100, X1: 120, Y1: 180},
+			{X0: 150, Y0: 100, X1: 400, Y1: 180},
+		},
+		figureAlt: []string{"Logo", "Chart"},
+		lines: []Edge{
+			{X0: 0, X1: 600, Top: 90, Bottom: 90, Width: 600, Orientation: "h"},
+			{X0: 100, X1: 100, Top: 0, Bottom: 800, Height: 800, Orientation: "v"},
+			{X0: 0, X1: 600, Top: 700, Bottom: 700, Width: 600, Orientation: "h"},
+		},
+	}
+	region := Rect{X0: 0, Y0: 0, X1: 200, Y1: 200}
+
+	cropped := cropRawPage(raw, region)
+
+	var text string
+	for _, c := range cropped.chars {
+		text += string(c.Text)
+	}
+	if text != "a" {
+		t.Errorf("chars = %q, want %q (d's centre is outside)", text, "a")
+	}
+	if len(cropped.figures) != 1 || cropped.figureAlt[0] != "Logo" {
+		t.Errorf("figures = %v %v, want the logo only", cropped.figures, cropped.figureAlt)
+	}
+	wantLines := []Edge{
+		{X0: 0, X1: 200, Top: 90, Bottom: 90, Width: 200, Orientation: "h"},
+		{X0: 100, X1: 100, Top: 0, Bottom: 200, Height: 200, Orientation: "v"},
+	}
+	if len(cropped.lines) != len(wantLines) {
+		t.Fatalf("lines = %+v, want %+v", cropped.lines, wantLines)
+	}
+	for i := range wantLines {
+		if cropped.lines[i] != wantLines[i] {
+			t.Errorf("line %d = %+v, want %+v", i, cropped.lines[i], wantLines[i])
+		}
+	}
+	if len(raw.chars) != 4 || len(raw.lines) != 3 {
+		t.Error("the raw page was changed")
+	}
+	if cropped.width != 600 || cropped.height != 800 {
+		t.Error("the page size changed")
+	}
+}
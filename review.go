@@ -0,0 +1,187 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Structural decisions scored in Review.Confidence.
+const (
+	ConfidenceStructure      = "structure"       // The page's StructureConfidence, for every block
+	ConfidenceHeading        = "heading"         // A paragraph was taken for a heading
+	ConfidenceRotation       = "rotation"        // Rotated text was read along one angle
+	ConfidenceCellAssignment = "cell_assignment" // Words were assigned to a table cell
+)
+
+// Review records where a paragraph or table cell came from and how sure
+// structure detection was of the decisions that shaped it, for review tools
+// that highlight doubtful regions. Unlike a table's TableProvenance, which
+// records how the table was found, it scores the result.
+type Review struct {
+	Page int  // 1-indexed page number
+	Box  Rect // Bounding box in top-left page coordinates
+
+	// Confidence scores each decision made about the block from 0 to 1,
+	// keyed by the Confidence constants. Decisions not made, such as
+	// heading detection for body text, are absent
+	Confidence map[string]float64
+}
+
+// Score returns the lowest of the block's confidence scores, or 1 when no
+// decision was scored.
+func (r Review) Score() float64 {
+	score := 1.0
+	for _, c := range r.Confidence {
+		score = math.Min(score, c)
+	}
+	return score
+}
+
+// ParagraphReview returns the review of the paragraph at index.
+func (p Page) ParagraphReview(index int) Review {
+	return paragraphReview(p, index, pageBodyFontSize(p))
+}
+
+// CellReview returns the review of a cell of the table at index,
+// by row and column in Table.Rows.
+func (p Page) CellReview(table, row, col int) Review {
+	cell := p.Tables[table].Rows[row].Cells[col]
+	review := Review{
+		Page:       p.Number,
+		Box:        Rect{X0: cell.BBox.X0, Y0: cell.BBox.Top, X1: cell.BBox.X1, Y1: cell.BBox.Bottom},
+		Confidence: map[string]float64{ConfidenceStructure: p.StructureConfidence},
+	}
+	if score, ok := cellAssignmentConfidence(cell); ok {
+		review.Confidence[ConfidenceCellAssignment] = score
+	}
+	return review
+}
+
+// paragraphReview returns the review of the paragraph at index on a
+// page whose body text is set at bodySize.
+func paragraphReview(page Page, index int, bodySize float64) Review {
+	para := page.Paragraphs[index]
+	review := Review{
+		Page:       page.Number,
+		Box:        para.Box,
+		Confidence: map[string]float64{ConfidenceStructure: page.StructureConfidence},
+	}
+	if para.IsHeading {
+		review.Confidence[ConfidenceHeading] = headingConfidence(para, bodySize)
+	}
+	if score, ok := rotationConfidence(para); ok {
+		review.Confidence[ConfidenceRotation] = score
+	}
+	return review
+}
+
+// pageBodyFontSize returns the median font size of a page's words.
+func pageBodyFontSize(page Page) float64 {
+	var sizes []float64
+	for _, para := range page.Paragraphs {
+		for _, line := range para.Lines {
+			for _, word := range line.Words {
+				sizes = append(sizes, word.FontSize)
+			}
+		}
+	}
+	if len(sizes) == 0 {
+		return 0
+	}
+	sort.Float64s(sizes)
+	return sizes[len(sizes)/2]
+}
+
+// headingConfidence scores how clearly a heading stands apart from body text
+// set at bodySize. Headings named by the document outline or numbered by a
+// HeadingDetector are trusted; others score by how much larger than the body
+// text they are, full marks from half as large again, with credit for bold
+// type. Headings over two lines or ending in a full stop read more like body
+// text and score lower.
+func headingConfidence(para Paragraph, bodySize float64) float64 {
+	if para.FromOutline {
+		return 1
+	}
+	if para.HeadingLevelFixed {
+		return 0.9
+	}
+
+	size := 0.0
+	if bodySize > 0 {
+		size = math.Max(0, math.Min(1, (paragraphEm(para)/bodySize-1)/0.5))
+	}
+	score := 0.3 + 0.5*size
+	if para.Font.Weight >= 600 {
+		score += 0.2
+	}
+	if len(para.Lines) > 2 {
+		score *= 0.7
+	}
+	if strings.HasSuffix(strings.TrimSpace(para.Text()), ".") {
+		score *= 0.8
+	}
+	return math.Min(score, 1)
+}
+
+// rotationConfidence scores how consistently a rotated paragraph's text runs
+// at its main angle: the share of its characters within 5° of it. ok is false
+// for horizontal paragraphs, where no rotation was corrected.
+func rotationConfidence(para Paragraph) (float64, bool) {
+	const tolerance = 5.0 // degrees
+
+	counts := make(map[float64]int)
+	var total int
+	rotated := para.OrientedBox != nil
+	for _, line := range para.Lines {
+		for _, word := range line.Words {
+			angle := normalizeAngle(word.Rotation)
+			n := max(len([]rune(word.Text)), 1)
+			counts[angle] += n
+			total += n
+			if math.Min(angle, 360-angle) > tolerance {
+				rotated = true
+			}
+		}
+	}
+	if !rotated || total == 0 {
+		return 0, false
+	}
+
+	best := 0
+	for angle := range counts {
+		near := 0
+		for other, n := range counts {
+			if d := math.Abs(angle - other); math.Min(d, 360-d) <= tolerance {
+				near += n
+			}
+		}
+		best = max(best, near)
+	}
+	return float64(best) / float64(total), true
+}
+
+// cellAssignmentConfidence scores how cleanly a cell's words fall within it:
+// the share of their area inside the cell's box. Words straddling a cell
+// boundary, as when columns are inferred from text that runs across them,
+// lower it. ok is false for cells without words.
+func cellAssignmentConfidence(cell TableCell) (float64, bool) {
+	box := Rect{X0: cell.BBox.X0, Y0: cell.BBox.Top, X1: cell.BBox.X1, Y1: cell.BBox.Bottom}
+	var inside, total float64
+	for _, word := range cell.Words {
+		area := word.Box.Width() * word.Box.Height()
+		if area <= 0 {
+			continue
+		}
+		w := math.Min(word.Box.X1, box.X1) - math.Max(word.Box.X0, box.X0)
+		h := math.Min(word.Box.Y1, box.Y1) - math.Max(word.Box.Y0, box.Y0)
+		if w > 0 && h > 0 {
+			inside += w * h
+		}
+		total += area
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return inside / total, true
+}
@@ -0,0 +1,132 @@
+package pdfmarkdown
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestHeadingConfidence(t *testing.T) {
+	heading := func(text string, size float64, weight int) Paragraph {
+		para := placedParagraph(text, 72, 72)
+		para.IsHeading = true
+		para.Font = FontSummary{Size: size, Weight: weight}
+		return para
+	}
+	outline := heading("Results", 10, 400)
+	outline.FromOutline = true
+
+	tests := []struct {
+		name string
+		para Paragraph
+		want float64
+	}{
+		{"large and bold", heading("Results", 18, 700), 1},
+		{"large", heading("Results", 15, 400), 0.8},
+		{"bold at body size", heading("Results", 10, 700), 0.5},
+		{"sentence at body size", heading("Results were mixed.", 10, 400), 0.24},
+		{"from the outline", outline, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := headingConfidence(tt.para, 10); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("headingConfidence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationConfidence(t *testing.T) {
+	para := placedParagraph("rotated side label text", 72, 72)
+	if _, ok := rotationConfidence(para); ok {
+		t.Error("horizontal text was scored")
+	}
+
+	for i := range para.Lines[0].Words {
+		para.Lines[0].Words[i].Rotation = 90
+	}
+	if got, ok := rotationConfidence(para); !ok || got != 1 {
+		t.Errorf("consistent rotation = %v, %v; want 1", got, ok)
+	}
+
+	// "text" runs at another angle: 4 of 20 characters
+	para.Lines[0].Words[3].Rotation = 45
+	if got, _ := rotationConfidence(para); math.Abs(got-16.0/20) > 1e-9 {
+		t.Errorf("mixed rotation = %v, want 0.8", got)
+	}
+}
+
+func TestCellAssignmentConfidence(t *testing.T) {
+	cell := TableCell{BBox: CellBBox{X0: 0, Top: 0, X1: 100, Bottom: 20}}
+	if _, ok := cellAssignmentConfidence(cell); ok {
+		t.Error("empty cell was scored")
+	}
+
+	cell.Words = []EnrichedWord{{Text: "inside", Box: Rect{X0: 10, Y0: 5, X1: 40, Y1: 15}}}
+	if got, _ := cellAssignmentConfidence(cell); got != 1 {
+		t.Errorf("word inside = %v, want 1", got)
+	}
+
+	// Half of the second word's area lies in the next cell
+	cell.Words = append(cell.Words, EnrichedWord{Text: "straddling", Box: Rect{X0: 70, Y0: 5, X1: 130, Y1: 15}})
+	if got, _ := cellAssignmentConfidence(cell); math.Abs(got-600.0/900) > 1e-9 {
+		t.Errorf("straddling word = %v, want %v", got, 600.0/900)
+	}
+}
+
+func TestWriteJSON_Review(t *testing.T) {
+	heading := placedParagraph("Results", 72, 72)
+	heading.IsHeading, heading.HeadingLevel = true, 1
+	heading.Font = FontSummary{Size: 18, Weight: 700}
+	doc := &Document{Pages: []Page{{
+		Number:              3,
+		StructureConfidence: 0.9,
+		Paragraphs:          []Paragraph{heading, placedParagraph("Sales rose", 72, 90)},
+	}}}
+
+	config := DefaultConfig()
+	var got struct {
+		Pages []struct {
+			Blocks []struct {
+				Review *struct {
+					Page       int
+					Box        struct{ X0, Y0, X1, Y1 float64 }
+					Confidence float64
+					Decisions  map[string]float64
+				}
+			}
+		}
+	}
+
+	data, err := doc.ToJSON(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Pages[0].Blocks[0].Review != nil {
+		t.Error("review written without IncludeReview")
+	}
+
+	config.IncludeReview = true
+	if data, err = doc.ToJSON(config); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	review := got.Pages[0].Blocks[0].Review
+	if review == nil {
+		t.Fatal("no review")
+	}
+	if review.Page != 3 || review.Box.X0 != 72 || review.Box.Y0 != 72 {
+		t.Errorf("review = %+v", review)
+	}
+	if review.Decisions[ConfidenceHeading] != 1 || review.Decisions[ConfidenceStructure] != 0.9 || review.Confidence != 0.9 {
+		t.Errorf("heading decisions = %v, confidence %v", review.Decisions, review.Confidence)
+	}
+	if body := got.Pages[0].Blocks[1].Review; body == nil || len(body.Decisions) != 1 {
+		t.Errorf("body review = %+v, want the structure score only", body)
+	}
+}
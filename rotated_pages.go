@@ -0,0 +1,246 @@
+package pdfmarkdown
+
+import "math"
+
+// quarterTurn maps between a page whose text mostly runs vertically and a
+// virtual page turned a quarter so that text reads left to right, with the
+// original page's width and height swapped. Angles follow pdfium, which
+// measures them clockwise: 90° text runs top to bottom and 270° text bottom
+// to top. A 180° turn rights upside-down pages, keeping their size.
+type quarterTurn struct {
+	angle         float64 // Text angle in degrees, 90, 180 or 270
+	width, height float64 // Size of the original page
+}
+
+// dominantQuarterTurn returns the quarter turn of the page when more than
+// half of its visible characters are set at 90° or at 270°, within 10°.
+func dominantQuarterTurn(chars []EnrichedChar, width, height float64) (quarterTurn, bool) {
+	const tolerance = 10.0 // degrees
+
+	var total, up, down int
+	for _, char := range chars {
+		if char.Text == ' ' || char.Text == '\t' || char.Text == '\n' || char.Text == '\r' {
+			continue
+		}
+		total++
+		degrees := normalizeAngle(float64(char.Angle) * 180 / math.Pi)
+		switch {
+		case math.Abs(degrees-90) <= tolerance:
+			up++
+		case math.Abs(degrees-270) <= tolerance:
+			down++
+		}
+	}
+
+	switch {
+	case up*2 > total:
+		return quarterTurn{angle: 90, width: width, height: height}, true
+	case down*2 > total:
+		return quarterTurn{angle: 270, width: width, height: height}, true
+	}
+	return quarterTurn{}, false
+}
+
+// upright maps a point on the original page to the turned page.
+func (q quarterTurn) upright(x, y float64) (float64, float64) {
+	switch q.angle {
+	case 270:
+		return q.height - y, x
+	case 180:
+		return q.width - x, q.height - y
+	}
+	return y, q.width - x
+}
+
+// original maps a point on the turned page back to the original page.
+func (q quarterTurn) original(x, y float64) (float64, float64) {
+	switch q.angle {
+	case 270:
+		return y, q.height - x
+	case 180:
+		return q.width - x, q.height - y
+	}
+	return q.width - y, x
+}
+
+// sideways reports whether the turn swaps the page's width and height, as
+// quarter turns do and the half turn of upside-down pages doesn't.
+func (q quarterTurn) sideways() bool {
+	return q.angle != 180
+}
+
+// rect maps both corners of a box with to and returns the box around them.
+func (q quarterTurn) rect(r Rect, to func(x, y float64) (float64, float64)) Rect {
+	x0, y0 := to(r.X0, r.Y0)
+	x1, y1 := to(r.X1, r.Y1)
+	return Rect{X0: math.Min(x0, x1), Y0: math.Min(y0, y1), X1: math.Max(x0, x1), Y1: math.Max(y0, y1)}
+}
+
+// cell maps a cell box with to.
+func (q quarterTurn) cell(b CellBBox, to func(x, y float64) (float64, float64)) CellBBox {
+	r := q.rect(Rect{X0: b.X0, Y0: b.Top, X1: b.X1, Y1: b.Bottom}, to)
+	return CellBBox{X0: r.X0, Top: r.Y0, X1: r.X1, Bottom: r.Y1}
+}
+
+// edge maps a ruling line with to; on a quarter turn horizontal lines
+// become vertical and the other way round.
+func (q quarterTurn) edge(e Edge, to func(x, y float64) (float64, float64)) Edge {
+	r := q.rect(Rect{X0: e.X0, Y0: e.Top, X1: e.X1, Y1: e.Bottom}, to)
+	e.X0, e.Top, e.X1, e.Bottom = r.X0, r.Y0, r.X1, r.Y1
+	e.Width, e.Height = r.Width(), r.Height()
+	if !q.sideways() {
+		return e
+	}
+	if e.Orientation == "h" {
+		e.Orientation = "v"
+	} else {
+		e.Orientation = "h"
+	}
+	return e
+}
+
+// displayedPage returns raw, read from a page in the orientation it is
+// stored in, as the page is displayed: turned clockwise by its /Rotate entry,
+// given in quarter turns. Turning a page a quarter clockwise is what turns
+// text set at 270° upright.
+func displayedPage(raw *rawPage, turns int) *rawPage {
+	angles := [4]float64{0, 270, 180, 90}
+	angle := angles[(turns%4+4)%4]
+	if angle == 0 {
+		return raw
+	}
+	return quarterTurn{angle: angle, width: raw.width, height: raw.height}.uprightRaw(raw)
+}
+
+// uprightRaw returns a copy of raw turned upright: its characters, figures,
+// rules and links are mapped to the turned page, and each character's angle
+// is measured from the turned text direction.
+func (q quarterTurn) uprightRaw(raw *rawPage) *rawPage {
+	upright := *raw
+	if q.sideways() {
+		upright.width, upright.height = raw.height, raw.width
+	}
+
+	offset := float32(q.angle * math.Pi / 180)
+	upright.chars = make([]EnrichedChar, len(raw.chars))
+	for i, char := range raw.chars {
+		char.Box = q.rect(char.Box, q.upright)
+		if char.HasOrigin {
+			char.Origin.X, char.Origin.Y = q.upright(char.Origin.X, char.Origin.Y)
+		}
+		char.Angle -= offset
+		if char.Angle < 0 {
+			char.Angle += 2 * math.Pi
+		}
+		upright.chars[i] = char
+	}
+
+	upright.figures = make([]Rect, len(raw.figures))
+	for i, fig := range raw.figures {
+		upright.figures[i] = q.rect(fig, q.upright)
+	}
+	upright.lines = make([]Edge, len(raw.lines))
+	for i, e := range raw.lines {
+		upright.lines[i] = q.edge(e, q.upright)
+	}
+	upright.links = make([]InternalLink, len(raw.links))
+	for i, link := range raw.links {
+		link.Box = q.rect(link.Box, q.upright)
+		upright.links[i] = link
+	}
+	return &upright
+}
+
+// restorePage maps a page structured upright back onto the original page
+// raw: every box returns to the original coordinates and every word to its
+// original angle, while reading order, rows and columns stay as found on the
+// turned page.
+func (q quarterTurn) restorePage(page *Page, raw *rawPage) {
+	page.Width, page.Height = raw.width, raw.height
+	page.Figures = raw.figures
+	page.Links = raw.links
+
+	for i := range page.Lines {
+		page.Lines[i] = q.edge(page.Lines[i], q.original)
+	}
+	page.Paragraphs = q.restoreParagraphs(page.Paragraphs)
+	page.textLines = q.restoreLines(page.textLines)
+	for i := range page.Columns {
+		page.Columns[i].Box = q.rect(page.Columns[i].Box, q.original)
+		page.Columns[i].Words = q.restoreWords(page.Columns[i].Words)
+		page.Columns[i].Paragraphs = q.restoreParagraphs(page.Columns[i].Paragraphs)
+	}
+	for i := range page.Tables {
+		table := &page.Tables[i]
+		table.BBox = q.cell(table.BBox, q.original)
+		for j := range table.Cells {
+			table.Cells[j] = q.cell(table.Cells[j], q.original)
+		}
+		for r := range table.Rows {
+			row := &table.Rows[r]
+			row.BBox = q.cell(row.BBox, q.original)
+			for c := range row.Cells {
+				row.Cells[c].BBox = q.cell(row.Cells[c].BBox, q.original)
+				row.Cells[c].Words = q.restoreWords(row.Cells[c].Words)
+			}
+		}
+		for j := range table.Provenance.Duplicates {
+			dup := &table.Provenance.Duplicates[j]
+			dup.BBox = q.cell(dup.BBox, q.original)
+		}
+	}
+	for i := range page.Diagnostics.RejectedTables {
+		reject := &page.Diagnostics.RejectedTables[i]
+		reject.Box = q.rect(reject.Box, q.original)
+	}
+}
+
+// restoreParagraphs returns paragraphs mapped back to the original page,
+// with the oriented boxes of their now rotated text. Paragraphs, lines and
+// words are shared between the page, its columns and its tables, so each is
+// copied rather than mapped in place.
+func (q quarterTurn) restoreParagraphs(paragraphs []Paragraph) []Paragraph {
+	if paragraphs == nil {
+		return nil
+	}
+	restored := make([]Paragraph, len(paragraphs))
+	for i, para := range paragraphs {
+		para.Box = q.rect(para.Box, q.original)
+		para.Lines = q.restoreLines(para.Lines)
+		para.OrientedBox = orientedParagraphBox(para)
+		restored[i] = para
+	}
+	return restored
+}
+
+// restoreLines returns lines mapped back to the original page. A vertical
+// line's baseline becomes its X position, as groupWordsIntoVerticalLines
+// records it.
+func (q quarterTurn) restoreLines(lines []Line) []Line {
+	if lines == nil {
+		return nil
+	}
+	restored := make([]Line, len(lines))
+	for i, line := range lines {
+		line.Box = q.rect(line.Box, q.original)
+		line.Baseline, _ = q.original(0, line.Baseline)
+		line.Words = q.restoreWords(line.Words)
+		restored[i] = line
+	}
+	return restored
+}
+
+// restoreWords returns words mapped back to the original page and angle.
+func (q quarterTurn) restoreWords(words []EnrichedWord) []EnrichedWord {
+	if words == nil {
+		return nil
+	}
+	restored := make([]EnrichedWord, len(words))
+	for i, word := range words {
+		word.Box = q.rect(word.Box, q.original)
+		word.Baseline, _ = q.original(0, word.Baseline)
+		word.Rotation = normalizeAngle(word.Rotation + q.angle)
+		restored[i] = word
+	}
+	return restored
+}
@@ -0,0 +1,127 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestQuarterTurn_RoundTrip(t *testing.T) {
+	for _, angle := range []float64{90, 180, 270} {
+		q := quarterTurn{angle: angle, width: 612, height: 792}
+		width, height := 792.0, 612.0
+		if !q.sideways() {
+			width, height = 612, 792
+		}
+		x, y := q.upright(100, 200)
+		if x < 0 || x > width || y < 0 || y > height {
+			t.Errorf("%v°: upright(100, 200) = (%v, %v), outside the turned page", angle, x, y)
+		}
+		if x, y = q.original(x, y); x != 100 || y != 200 {
+			t.Errorf("%v°: original(upright(100, 200)) = (%v, %v)", angle, x, y)
+		}
+	}
+}
+
+// TestDisplayedPage tests that a page stored sideways or upside down comes
+// out as displayed, with its text upright and in reading order
+func TestDisplayedPage(t *testing.T) {
+	for turns, angle := range []float64{0, 270, 180, 90} {
+		// Set a line of text upright on the displayed page, then store it
+		// turned back by the page's rotation
+		width, height := 612.0, 792.0
+		if turns%2 == 1 {
+			width, height = height, width
+		}
+		q := quarterTurn{angle: angle, width: width, height: height}
+		raw := &rawPage{width: width, height: height}
+		x := 72.0
+		for _, r := range "Upright" {
+			box := Rect{X0: x, Y0: 92, X1: x + 6, Y1: 102}
+			if angle != 0 {
+				box = q.rect(box, q.original)
+			}
+			raw.chars = append(raw.chars, EnrichedChar{Text: r, Box: box, FontSize: 11, Angle: float32(angle * math.Pi / 180)})
+			x += 6
+		}
+
+		displayed := displayedPage(raw, turns)
+		if displayed.width != 612 || displayed.height != 792 {
+			t.Errorf("%d turns: page is %vx%v, want 612x792", turns, displayed.width, displayed.height)
+		}
+		for i, char := range displayed.chars {
+			want := Rect{X0: 72 + float64(i)*6, Y0: 92, X1: 78 + float64(i)*6, Y1: 102}
+			if char.Box != want || char.Angle != 0 {
+				t.Errorf("%d turns: char %q at %+v angle %v, want %+v angle 0", turns, char.Text, char.Box, char.Angle, want)
+			}
+		}
+	}
+}
+
+// TestUprightRotatedPages tests that a ruled table printed sideways comes out
+// with its rows, columns and characters in order
+func TestUprightRotatedPages(t *testing.T) {
+	for _, angle := range []float64{90, 270} {
+		// Lay the table out on the turned page, then set it sideways
+		q := quarterTurn{angle: angle, width: 612, height: 792}
+		raw := &rawPage{width: 612, height: 792}
+		rows := [][]string{{"Region", "Sales", "Growth"}, {"North", "120", "4%"}, {"South", "95", "2%"}}
+		for i, row := range rows {
+			for j, cell := range row {
+				x, baseline := 80+float64(j)*150, 114+float64(i)*20
+				for _, r := range cell {
+					box := Rect{X0: x, Y0: baseline - 8, X1: x + 6, Y1: baseline + 2}
+					raw.chars = append(raw.chars, EnrichedChar{
+						Text:       r,
+						Box:        q.rect(box, q.original),
+						FontSize:   11,
+						FontWeight: 400,
+						FontName:   "Helvetica",
+						Angle:      float32(angle * math.Pi / 180),
+					})
+					x += 6
+				}
+				raw.chars = append(raw.chars, EnrichedChar{Text: ' ', Box: q.rect(Rect{X0: x, Y0: baseline - 8, X1: x + 3, Y1: baseline + 2}, q.original), FontSize: 11, Angle: float32(angle * math.Pi / 180)})
+			}
+		}
+		for i := 0; i <= len(rows); i++ {
+			y := 100 + float64(i)*20
+			raw.lines = append(raw.lines, q.edge(Edge{X0: 72, Top: y, X1: 522, Bottom: y, Width: 450, Orientation: "h"}, q.original))
+		}
+		for j := 0; j <= 3; j++ {
+			x := 72 + float64(j)*150
+			raw.lines = append(raw.lines, q.edge(Edge{X0: x, Top: 100, X1: x, Bottom: 160, Height: 60, Orientation: "v"}, q.original))
+		}
+
+		config := DefaultConfig()
+		config.UprightRotatedPages = true
+		page := structurePage(raw, 1, config)
+
+		if len(page.Tables) != 1 {
+			t.Fatalf("%v°: got %d tables, want 1", angle, len(page.Tables))
+		}
+		table := page.Tables[0]
+		var got [][]string
+		for _, row := range table.Rows {
+			var cells []string
+			for _, cell := range row.Cells {
+				cells = append(cells, cell.Content)
+			}
+			got = append(got, cells)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("%v°: cells = %q, want %q", angle, got, rows)
+		}
+
+		// Boxes are back on the sideways page, where the table is tall and narrow
+		if page.Width != 612 || page.Height != 792 {
+			t.Errorf("%v°: page is %vx%v, want 612x792", angle, page.Width, page.Height)
+		}
+		if box := table.BBox; box.Bottom-box.Top <= box.X1-box.X0 || box.X1 > 612 || box.Bottom > 792 {
+			t.Errorf("%v°: table box %+v is not upright on the original page", angle, box)
+		}
+		if word := table.Rows[0].Cells[0].Words[0]; math.Abs(word.Rotation-angle) > 1 {
+			t.Errorf("%v°: word rotation = %v", angle, word.Rotation)
+		}
+	}
+}
@@ -250,3 +250,81 @@ func groupWordsIntoHorizontalLines(words []EnrichedWord) []Line {
 func (r Rect) CenterX() float64 {
 	return (r.X0 + r.X1) / 2
 }
+
+// orientedParagraphBox returns the rotated box around a paragraph's words, or
+// nil when the paragraph runs horizontally. Word boxes are axis-aligned bounds
+// of rotated text, so each word's length along the text is recovered from its
+// box using the font size as its thickness, and the words' centers are
+// projected onto the text direction to find the paragraph's extent.
+func orientedParagraphBox(para Paragraph) *OrientedRect {
+	angle, ok := paragraphAngle(para)
+	if !ok {
+		return nil
+	}
+
+	rad := angle * math.Pi / 180
+	ux, uy := math.Cos(rad), -math.Sin(rad)
+	vx, vy := math.Sin(rad), math.Cos(rad)
+	absCos, absSin := math.Abs(math.Cos(rad)), math.Abs(math.Sin(rad))
+
+	uMin, vMin := math.Inf(1), math.Inf(1)
+	uMax, vMax := math.Inf(-1), math.Inf(-1)
+	for _, line := range para.Lines {
+		for _, word := range line.Words {
+			w, h := word.Box.Width(), word.Box.Height()
+			thickness := word.FontSize
+			if thickness <= 0 {
+				thickness = math.Min(w, h)
+			}
+
+			// An L×T rectangle at this angle has an axis-aligned box of
+			// L|cos|+T|sin| by L|sin|+T|cos|; solve whichever is better conditioned
+			var length float64
+			if absCos >= absSin {
+				length = (w - thickness*absSin) / absCos
+			} else {
+				length = (h - thickness*absCos) / absSin
+			}
+			length = math.Max(length, 0)
+
+			cx, cy := word.Box.CenterX(), word.Box.CenterY()
+			cu, cv := cx*ux+cy*uy, cx*vx+cy*vy
+			uMin, uMax = math.Min(uMin, cu-length/2), math.Max(uMax, cu+length/2)
+			vMin, vMax = math.Min(vMin, cv-thickness/2), math.Max(vMax, cv+thickness/2)
+		}
+	}
+
+	uc, vc := (uMin+uMax)/2, (vMin+vMax)/2
+	return &OrientedRect{
+		CenterX: uc*ux + vc*vx,
+		CenterY: uc*uy + vc*vy,
+		Width:   uMax - uMin,
+		Height:  vMax - vMin,
+		Angle:   angle,
+	}
+}
+
+// paragraphAngle returns the mean rotation of a paragraph's words in [0, 360),
+// weighted by text length, and whether it is far enough from 0 to be rotated.
+func paragraphAngle(para Paragraph) (float64, bool) {
+	const horizontalTolerance = 1.0 // degrees
+
+	var sumSin, sumCos float64
+	for _, line := range para.Lines {
+		for _, word := range line.Words {
+			rad := word.Rotation * math.Pi / 180
+			weight := float64(len(word.Text))
+			sumSin += math.Sin(rad) * weight
+			sumCos += math.Cos(rad) * weight
+		}
+	}
+	if sumSin == 0 && sumCos == 0 {
+		return 0, false
+	}
+
+	angle := normalizeAngle(math.Atan2(sumSin, sumCos) * 180 / math.Pi)
+	if angle < horizontalTolerance || angle > 360-horizontalTolerance {
+		return 0, false
+	}
+	return angle, true
+}
@@ -0,0 +1,98 @@
+package pdfmarkdown
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+// rotatedWord builds a word of the given length and font size centered at
+// (cx, cy) and rotated counterclockwise by angle degrees, with the axis-aligned
+// box pdfium would report for it.
+func rotatedWord(text string, cx, cy, length, fontSize, angle float64) EnrichedWord {
+	rad := angle * math.Pi / 180
+	w := length*math.Abs(math.Cos(rad)) + fontSize*math.Abs(math.Sin(rad))
+	h := length*math.Abs(math.Sin(rad)) + fontSize*math.Abs(math.Cos(rad))
+	return EnrichedWord{
+		Text:     text,
+		Box:      Rect{X0: cx - w/2, Y0: cy - h/2, X1: cx + w/2, Y1: cy + h/2},
+		FontSize: fontSize,
+		Rotation: angle,
+	}
+}
+
+func TestOrientedParagraphBox(t *testing.T) {
+	// Two 40pt words with a 10pt gap, centered on (200, 300)
+	along := func(angle, offset float64) (float64, float64) {
+		rad := angle * math.Pi / 180
+		return 200 + offset*math.Cos(rad), 300 - offset*math.Sin(rad)
+	}
+	paragraph := func(angle float64) Paragraph {
+		x1, y1 := along(angle, -25)
+		x2, y2 := along(angle, 25)
+		return Paragraph{Lines: []Line{{Words: []EnrichedWord{
+			rotatedWord("DRAFT", x1, y1, 40, 10, angle),
+			rotatedWord("COPY!", x2, y2, 40, 10, angle),
+		}}}}
+	}
+
+	tests := []struct {
+		name  string
+		angle float64
+	}{
+		{"diagonal watermark", 45},
+		{"vertical spine", 90},
+		{"slight tilt", 10},
+		{"upside down", 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := orientedParagraphBox(paragraph(tt.angle))
+			if box == nil {
+				t.Fatal("expected an oriented box for rotated text")
+			}
+
+			want := OrientedRect{CenterX: 200, CenterY: 300, Width: 90, Height: 10, Angle: tt.angle}
+			if !approxEqual(box.CenterX, want.CenterX) || !approxEqual(box.CenterY, want.CenterY) ||
+				!approxEqual(box.Width, want.Width) || !approxEqual(box.Height, want.Height) ||
+				!approxEqual(box.Angle, want.Angle) {
+				t.Errorf("got %+v, want %+v", *box, want)
+			}
+		})
+	}
+
+	if box := orientedParagraphBox(paragraph(0)); box != nil {
+		t.Errorf("horizontal text got oriented box %+v", *box)
+	}
+}
+
+func TestOrientedRectCorners(t *testing.T) {
+	box := OrientedRect{CenterX: 100, CenterY: 100, Width: 40, Height: 10, Angle: 90}
+
+	// Text rotated 90° reads bottom to top, so its top edge faces left
+	want := [4]Point{{95, 120}, {95, 80}, {105, 80}, {105, 120}}
+	got := box.Corners()
+	for i := range want {
+		if !approxEqual(got[i].X, want[i].X) || !approxEqual(got[i].Y, want[i].Y) {
+			t.Fatalf("Corners() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestOrientedBox_JSON(t *testing.T) {
+	para := Paragraph{OrientedBox: &OrientedRect{CenterX: 1, CenterY: 2, Width: 3, Height: 4, Angle: 45}}
+
+	data, err := json.Marshal(para)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"OrientedBox":{"CenterX":1,"CenterY":2,"Width":3,"Height":4,"Angle":45}`) {
+		t.Errorf("oriented box missing from JSON: %s", data)
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
@@ -0,0 +1,342 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ForLLM returns a copy of the config tuned for feeding output to language
+// models. Hidden text is dropped, Unicode is normalized, repeated page headers,
+// footers and page numbers are removed, whitespace is collapsed, headings stop
+// at H3, inline formatting is removed and page breaks become a one-line marker.
+func (c Config) ForLLM() Config {
+	c.SkipInvisibleText = true
+	c.NormalizeUnicode = true
+	c.RemovePageFurniture = true
+	c.CollapseWhitespace = true
+	c.MaxHeadingLevel = 3
+	c.StripInlineFormatting = true
+	c.IncludePageBreaks = true
+	c.PageBreakMarker = "<!-- page {page} -->"
+	return c
+}
+
+// removeInvisibleChars drops characters a reader can't see: fully transparent
+// text, text placed entirely off the page, and text under 1pt. Text drawn in
+// invisible render mode is dropped only when the page also has visible text,
+// since on scanned pages the invisible OCR layer is the only text there is.
+func removeInvisibleChars(chars []EnrichedChar, pageWidth, pageHeight float64) []EnrichedChar {
+	hasVisible := false
+	for _, char := range chars {
+		if !char.Invisible && !isHiddenChar(char, pageWidth, pageHeight) {
+			hasVisible = true
+			break
+		}
+	}
+
+	kept := chars[:0]
+	for _, char := range chars {
+		if isHiddenChar(char, pageWidth, pageHeight) || (char.Invisible && hasVisible) {
+			continue
+		}
+		kept = append(kept, char)
+	}
+	return kept
+}
+
+// isHiddenChar reports whether a character is transparent, off the page or too
+// small to read, regardless of its render mode.
+func isHiddenChar(char EnrichedChar, pageWidth, pageHeight float64) bool {
+	if char.FillColor.A == 0 {
+		return true
+	}
+	if char.FontSize > 0 && char.FontSize < 1 {
+		return true
+	}
+	box := char.Box
+	return box.X1 < 0 || box.Y1 < 0 || box.X0 > pageWidth || box.Y0 > pageHeight
+}
+
+// foldCompatibility applies NFKC to text except for superscripts, subscripts
+// and trademark signs, which NFKC flattens into ordinary characters and so
+// changes their meaning: "m²" would become "m2" and "Acme™" "AcmeTM".
+func foldCompatibility(text string) string {
+	var b strings.Builder
+	start := 0
+	for i, r := range text {
+		if !isSuperscriptMark(r) && (r < '\u2070' || r > '\u209f') {
+			continue
+		}
+		b.WriteString(norm.NFKC.String(text[start:i]))
+		b.WriteRune(r)
+		start = i + utf8.RuneLen(r)
+	}
+	b.WriteString(norm.NFKC.String(text[start:]))
+	return b.String()
+}
+
+// normalizeWords applies NFKC normalization to word text, which folds
+// compatibility forms such as fullwidth letters and ligatures, after spelling
+// out fractions and degree signs with normalizeSymbols. Superscripts,
+// subscripts and trademark signs keep their form (see foldCompatibility). It
+// also strips zero-width and other format characters, except the joiners and
+// tags that hold a grapheme cluster together, such as the zero-width joiners in
+// an emoji sequence. A soft hyphen ending a word is kept as a line break marker for
+// joinSoftHyphenatedLines. Words left empty are dropped.
+func normalizeWords(words []EnrichedWord) []EnrichedWord {
+	kept := words[:0]
+	for _, word := range words {
+		text := foldCompatibility(normalizeSymbols(word.Text))
+		trailingSoftHyphen := strings.HasSuffix(text, softHyphen)
+
+		runes := []rune(text)
+		starts := clusterStarts(runes)
+		stripped := runes[:0]
+		for i, r := range runes {
+			if unicode.IsControl(r) || (unicode.Is(unicode.Cf, r) && (i == 0 || starts[i] || !isClusterExtender(r))) {
+				continue
+			}
+			stripped = append(stripped, r)
+		}
+		text = string(stripped)
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if trailingSoftHyphen {
+			text += softHyphen
+		}
+
+		word.Text = text
+		kept = append(kept, word)
+	}
+	return kept
+}
+
+// joinSoftHyphenatedLines joins words split across lines at a soft hyphen, or
+// at a hyphen pdfium reports as a line-break hyphen when the next line goes on
+// in lowercase, and removes any remaining soft hyphens. Hard hyphens are left
+// alone. It runs after repairBrokenURLs, which has already copied each
+// paragraph's words.
+func joinSoftHyphenatedLines(paragraphs []Paragraph) {
+	for pi := range paragraphs {
+		para := &paragraphs[pi]
+
+		lines := para.Lines[:0]
+		for _, line := range para.Lines {
+			if n := len(lines); n > 0 && len(line.Words) > 0 && len(lines[n-1].Words) > 0 {
+				prev := &lines[n-1]
+				last := &prev.Words[len(prev.Words)-1]
+				if stem, ok := hyphenatedStem(*last, line.Words[0]); ok {
+					merged := []EnrichedWord{*last, line.Words[0]}
+					merged[0].Text = stem
+					*last = mergeWordGroup(merged)
+					prev.Box = mergeRects(prev.Box, last.Box)
+					line.Words = line.Words[1:]
+				}
+			}
+
+			if len(line.Words) > 0 {
+				lines = append(lines, line)
+			}
+		}
+		para.Lines = lines
+
+		// Soft hyphens left mid-line mark breaks that didn't happen
+		for li := range para.Lines {
+			for wi := range para.Lines[li].Words {
+				word := &para.Lines[li].Words[wi]
+				word.Text = strings.TrimSuffix(word.Text, softHyphen)
+			}
+		}
+	}
+}
+
+// hyphenatedStem returns the start of a word broken across lines, without its
+// hyphen, when word ends in a soft hyphen or a line-break hyphen followed by a
+// lowercase continuation.
+func hyphenatedStem(word, next EnrichedWord) (string, bool) {
+	if stem, ok := strings.CutSuffix(word.Text, softHyphen); ok {
+		return stem, true
+	}
+	if !word.TrailingHyphen {
+		return "", false
+	}
+	r, _ := utf8.DecodeRuneInString(next.Text)
+	if !unicode.IsLower(r) {
+		return "", false
+	}
+	for _, hyphen := range []string{"-", "\u2010"} {
+		if stem, ok := strings.CutSuffix(word.Text, hyphen); ok && stem != "" {
+			return stem, true
+		}
+	}
+	return "", false
+}
+
+// pageNumberPattern matches text that is only a page number: "12", "- 12 -",
+// "Page 12", "Page 12 of 40", "12/40" or a lowercase roman numeral.
+var pageNumberPattern = regexp.MustCompile(`^(?i:page\s+)?-?\s*(\d+|[ivx]{1,5})\s*-?(\s*(?i:of|/)\s*\d+)?$`)
+
+// furnitureMargin is the fraction of page height at the top and bottom where
+// running headers, footers and page numbers are looked for.
+const furnitureMargin = 0.1
+
+// withoutPageFurniture returns pages with running headers, footers and page
+// numbers removed. A paragraph in the top or bottom margin is furniture when it
+// is a bare page number or when the same text, ignoring digits, appears in the
+// margins of at least half the pages. The given pages are not modified.
+func withoutPageFurniture(pages []Page) []Page {
+	inMargin := func(page Page, para Paragraph) bool {
+		if page.Height <= 0 || len(para.Lines) == 0 {
+			return false
+		}
+		return para.Box.Y1 <= page.Height*furnitureMargin || para.Box.Y0 >= page.Height*(1-furnitureMargin)
+	}
+
+	// Count the pages each margin text appears on
+	counts := make(map[string]int)
+	for _, page := range pages {
+		seen := make(map[string]bool)
+		for _, para := range page.Paragraphs {
+			if key := furnitureKey(para.Text()); inMargin(page, para) && !seen[key] {
+				seen[key] = true
+				counts[key]++
+			}
+		}
+	}
+	minPages := max(2, (len(pages)+1)/2)
+
+	result := make([]Page, len(pages))
+	for i, page := range pages {
+		paragraphs := make([]Paragraph, 0, len(page.Paragraphs))
+		for _, para := range page.Paragraphs {
+			if inMargin(page, para) {
+				text := strings.TrimSpace(para.Text())
+				if pageNumberPattern.MatchString(text) || counts[furnitureKey(text)] >= minPages {
+					continue
+				}
+			}
+			paragraphs = append(paragraphs, para)
+		}
+		page.Paragraphs = paragraphs
+		result[i] = page
+	}
+	return result
+}
+
+// furnitureKey normalizes margin text for comparison across pages, so that
+// "Annual Report 2024 | 3" and "Annual Report 2024 | 4" match.
+func furnitureKey(text string) string {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '#'
+		}
+		return unicode.ToLower(r)
+	}, text)
+	return strings.Join(strings.Fields(key), " ")
+}
+
+// tableDelimiterRowPattern matches a markdown table's header delimiter row.
+var tableDelimiterRowPattern = regexp.MustCompile(`^\|(\s*:?-+:?\s*\|)+$`)
+
+// collapseMarkdownWhitespace removes whitespace that carries no meaning:
+// trailing spaces (including hard line breaks), runs of spaces inside lines,
+// table padding, and repeated blank lines. Indentation and fenced code blocks
+// are left as they are.
+func collapseMarkdownWhitespace(text string) string {
+	var b strings.Builder
+	c := newWhitespaceCollapser(&b)
+	c.Write([]byte(text))
+	c.Flush()
+	return b.String()
+}
+
+// whitespaceCollapser applies collapseMarkdownWhitespace to text streamed
+// through it, holding back only the current partial line and any blank line
+// that may turn out to be trailing.
+type whitespaceCollapser struct {
+	w            io.Writer
+	partial      []byte
+	inFence      bool
+	started      bool // A non-blank line has been written
+	pendingBlank bool // A blank line is due before the next non-blank one
+	err          error
+}
+
+func newWhitespaceCollapser(w io.Writer) *whitespaceCollapser {
+	return &whitespaceCollapser{w: w}
+}
+
+// Write collapses every complete line in p, buffering the remainder.
+func (c *whitespaceCollapser) Write(p []byte) (int, error) {
+	c.partial = append(c.partial, p...)
+	for {
+		i := bytes.IndexByte(c.partial, '\n')
+		if i < 0 {
+			break
+		}
+		c.writeLine(string(c.partial[:i]))
+		c.partial = c.partial[i+1:]
+	}
+	return len(p), c.err
+}
+
+// Flush writes any buffered partial line. Trailing blank lines are dropped.
+func (c *whitespaceCollapser) Flush() error {
+	if len(c.partial) > 0 {
+		c.writeLine(string(c.partial))
+		c.partial = nil
+	}
+	return c.err
+}
+
+func (c *whitespaceCollapser) writeLine(line string) {
+	if strings.HasPrefix(strings.TrimSpace(line), "```") {
+		c.inFence = !c.inFence
+		c.emit(strings.TrimRight(line, " \t"))
+		return
+	}
+	if c.inFence {
+		c.emit(line)
+		return
+	}
+
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(trimmed)]
+	trimmed = strings.Join(strings.Fields(trimmed), " ")
+
+	if tableDelimiterRowPattern.MatchString(trimmed) {
+		cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+		for i, cell := range cells {
+			cell = strings.TrimSpace(cell)
+			cells[i] = strings.TrimRight(cell[:1], "-") + "---" + strings.TrimLeft(cell[len(cell)-1:], "-")
+		}
+		trimmed = "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	if trimmed == "" {
+		c.pendingBlank = c.started
+		return
+	}
+	c.emit(indent + trimmed)
+}
+
+// emit writes a line, preceded by the pending blank line if there is one.
+func (c *whitespaceCollapser) emit(line string) {
+	if c.err != nil {
+		return
+	}
+	if c.pendingBlank {
+		line = "\n" + line
+		c.pendingBlank = false
+	}
+	c.started = true
+	_, c.err = io.WriteString(c.w, line+"\n")
+}
@@ -0,0 +1,228 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveInvisibleChars(t *testing.T) {
+	visible := EnrichedChar{Text: 'a', Box: Rect{X0: 10, Y0: 10, X1: 16, Y1: 22}, FontSize: 12, FillColor: RGBA{A: 255}}
+	transparent := visible
+	transparent.Text, transparent.FillColor.A = 'b', 0
+	offPage := visible
+	offPage.Text, offPage.Box = 'c', Rect{X0: -50, Y0: 10, X1: -44, Y1: 22}
+	tiny := visible
+	tiny.Text, tiny.FontSize = 'd', 0.5
+	ocr := visible
+	ocr.Text, ocr.Invisible = 'e', true
+
+	tests := []struct {
+		name  string
+		chars []EnrichedChar
+		want  string
+	}{
+		{"hidden text dropped", []EnrichedChar{visible, transparent, offPage, tiny, ocr}, "a"},
+		{"OCR layer kept on scanned page", []EnrichedChar{ocr, ocr}, "ee"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got strings.Builder
+			for _, char := range removeInvisibleChars(tt.chars, 100, 100) {
+				got.WriteRune(char.Text)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWords(t *testing.T) {
+	words := []EnrichedWord{
+		{Text: "Ｆｕｌｌｗｉｄｔｈ"},
+		{Text: "zero\u200bwidth"},
+		{Text: "\ufeff"},
+		{Text: "\ufb01nance"},
+		{Text: "hy\u00adphen\u00ad"},
+	}
+
+	var got []string
+	for _, word := range normalizeWords(words) {
+		got = append(got, word.Text)
+	}
+
+	want := []string{"Fullwidth", "zerowidth", "finance", "hyphen" + softHyphen}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("normalizeWords = %q, want %q", got, want)
+	}
+}
+
+func TestJoinSoftHyphenatedLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		first []EnrichedWord
+		next  []EnrichedWord
+		want  string
+	}{
+		{
+			name:  "soft hyphen",
+			first: []EnrichedWord{{Text: "an"}, {Text: "exam" + softHyphen}},
+			next:  []EnrichedWord{{Text: "ple"}, {Text: "here"}},
+			want:  "an example\nhere",
+		},
+		{
+			name:  "line-break hyphen",
+			first: []EnrichedWord{{Text: "an"}, {Text: "exam-", TrailingHyphen: true}},
+			next:  []EnrichedWord{{Text: "ple"}, {Text: "here"}},
+			want:  "an example\nhere",
+		},
+		{
+			name:  "hard hyphen",
+			first: []EnrichedWord{{Text: "a"}, {Text: "well-"}},
+			next:  []EnrichedWord{{Text: "known"}, {Text: "case"}},
+			want:  "a well-\nknown case",
+		},
+		{
+			name:  "line-break hyphen before a capital",
+			first: []EnrichedWord{{Text: "the"}, {Text: "Franco-", TrailingHyphen: true}},
+			next:  []EnrichedWord{{Text: "Prussian"}, {Text: "war"}},
+			want:  "the Franco-\nPrussian war",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{{Lines: []Line{{Words: tt.first}, {Words: tt.next}}}}
+
+			joinSoftHyphenatedLines(paragraphs)
+
+			if got := paragraphs[0].Text(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrailingHyphen(t *testing.T) {
+	chars := []EnrichedChar{{Text: 'e'}, {Text: 'x'}, {Text: '-', IsHyphen: true}}
+	word := aggregateWord(chars, Rect{})
+	if !word.TrailingHyphen {
+		t.Error("word ending in a pdfium hyphen should have TrailingHyphen set")
+	}
+
+	line := Line{Words: []EnrichedWord{{Text: "an"}, word}}
+	if !line.TrailingHyphen() {
+		t.Error("line ending in a hyphenated word should report TrailingHyphen")
+	}
+
+	merged := mergeWordGroup([]EnrichedWord{word, {Text: "ample"}})
+	if merged.TrailingHyphen {
+		t.Error("merged word should take TrailingHyphen from its last part")
+	}
+
+	chars[2].IsHyphen = false
+	if aggregateWord(chars, Rect{}).TrailingHyphen {
+		t.Error("hard hyphen should not set TrailingHyphen")
+	}
+}
+
+func TestWithoutPageFurniture(t *testing.T) {
+	para := func(text string, y float64) Paragraph {
+		var line Line
+		for _, word := range strings.Fields(text) {
+			line.Words = append(line.Words, EnrichedWord{Text: word})
+		}
+		return Paragraph{Lines: []Line{line}, Box: Rect{X0: 50, Y0: y, X1: 500, Y1: y + 12}}
+	}
+	page := func(number int, paragraphs ...Paragraph) Page {
+		return Page{Number: number, Height: 800, Paragraphs: paragraphs}
+	}
+
+	pages := []Page{
+		page(1, para("Annual Report 2024", 20), para("Introduction text", 300), para("1", 770)),
+		page(2, para("Annual Report 2024", 20), para("Body text", 300), para("Page 2 of 3", 770)),
+		page(3, para("Annual Report 2024", 20), para("Annual Report 2024", 300), para("- 3 -", 770)),
+	}
+
+	got := withoutPageFurniture(pages)
+
+	want := [][]string{{"Introduction text"}, {"Body text"}, {"Annual Report 2024"}}
+	for i, page := range got {
+		var texts []string
+		for _, p := range page.Paragraphs {
+			texts = append(texts, p.Text())
+		}
+		if strings.Join(texts, "|") != strings.Join(want[i], "|") {
+			t.Errorf("page %d = %q, want %q", i+1, texts, want[i])
+		}
+	}
+
+	if len(pages[0].Paragraphs) != 3 {
+		t.Error("withoutPageFurniture modified the original pages")
+	}
+}
+
+func TestCollapseMarkdownWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "hard breaks and blank lines",
+			in:   "# Title\n  \n\n\nFirst  line  \nSecond line  \n\n",
+			want: "# Title\n\nFirst line\nSecond line\n",
+		},
+		{
+			name: "table padding",
+			in:   "| Name       | Price |\n| ---------- | ----: |\n| Espresso   | $3.00 |\n",
+			want: "| Name | Price |\n| --- | ---: |\n| Espresso | $3.00 |\n",
+		},
+		{
+			name: "code blocks kept",
+			in:   "```\nfunc  main() {  \n\n\n}\n```\n",
+			want: "```\nfunc  main() {  \n\n\n}\n```\n",
+		},
+		{
+			name: "indentation kept",
+			in:   "- item\n    - nested  item\n",
+			want: "- item\n    - nested item\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collapseMarkdownWhitespace(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Streaming a byte at a time gives the same result
+			var b strings.Builder
+			c := newWhitespaceCollapser(&b)
+			for i := range len(tt.in) {
+				c.Write([]byte{tt.in[i]})
+			}
+			if err := c.Flush(); err != nil || b.String() != tt.want {
+				t.Errorf("streamed got %q (err %v), want %q", b.String(), err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentToMarkdown_ForLLM(t *testing.T) {
+	word := func(text string, bold bool) EnrichedWord {
+		return EnrichedWord{Text: text, IsBold: bold, FontSize: 12}
+	}
+	doc := &Document{Pages: []Page{
+		{Number: 1, Paragraphs: []Paragraph{{Lines: []Line{{Words: []EnrichedWord{word("Very", true), word("important", false)}}}}}},
+		{Number: 2, Paragraphs: []Paragraph{{Lines: []Line{{Words: []EnrichedWord{word("Next", false), word("page", false)}}}}}},
+	}}
+
+	got := doc.ToMarkdown(DefaultConfig().ForLLM())
+
+	want := "Very important\n\n<!-- page 2 -->\n\nNext page\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// scriptGapRatio is the gap, as a fraction of font size, that separates Latin
+// text from adjacent CJK text. CJK documents rarely contain space glyphs, so
+// this gap is often the only sign of a word break between the two scripts.
+const scriptGapRatio = 0.2
+
+// isUnspacedScript reports whether r belongs to a script written without
+// spaces between words: Chinese and Japanese characters, kana, and their
+// full-width punctuation. Korean spaces its words and is not included.
+func isUnspacedScript(r rune) bool {
+	return isCJK(r) ||
+		(r >= 0x3000 && r <= 0x303F) || // CJK Symbols and Punctuation
+		(r >= 0x3040 && r <= 0x309F) || // Hiragana
+		(r >= 0x30A0 && r <= 0x30FF) || // Katakana
+		(r >= 0x3100 && r <= 0x312F) || // Bopomofo
+		(r >= 0x31F0 && r <= 0x31FF) || // Katakana Phonetic Extensions
+		(r >= 0xFF00 && r <= 0xFFEF) // Halfwidth and Fullwidth Forms
+}
+
+// isScriptChange reports whether two adjacent characters switch between an
+// unspaced script and Latin letters or digits, where a word break belongs.
+func isScriptChange(prev, next rune) bool {
+	spacedWord := func(r rune) bool {
+		return r < 0x3000 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+	}
+	return (isUnspacedScript(prev) && spacedWord(next)) || (spacedWord(prev) && isUnspacedScript(next))
+}
+
+// isScriptGap reports whether a gap between two characters breaks Latin text
+// from adjacent CJK text.
+func isScriptGap(prev, next rune, gap, fontSize float64) bool {
+	return isScriptChange(prev, next) && gap >= fontSize*scriptGapRatio
+}
+
+// wordSeparator returns the text placed between two adjacent words on a line:
+// nothing where both sides are in an unspaced script, otherwise a space.
+func wordSeparator(prev, next string) string {
+	last, _ := utf8.DecodeLastRuneInString(prev)
+	first, _ := utf8.DecodeRuneInString(next)
+	if isUnspacedScript(last) && isUnspacedScript(first) {
+		return ""
+	}
+	return " "
+}
+
+// joinWords joins a line's words with script-aware separators.
+func joinWords(words []EnrichedWord) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i > 0 {
+			b.WriteString(wordSeparator(words[i-1].Text, word.Text))
+		}
+		b.WriteString(word.Text)
+	}
+	return b.String()
+}
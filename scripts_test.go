@@ -0,0 +1,49 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestLineText_MixedScripts(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  string
+	}{
+		{name: "latin", words: []string{"plain", "text"}, want: "plain text"},
+		{name: "chinese split into glyph runs", words: []string{"年度", "报告"}, want: "年度报告"},
+		{name: "japanese with punctuation", words: []string{"ありがとう", "ございます", "。"}, want: "ありがとうございます。"},
+		{name: "latin among chinese", words: []string{"使用", "PDF", "文件"}, want: "使用 PDF 文件"},
+		{name: "digits among japanese", words: []string{"第", "3", "章"}, want: "第 3 章"},
+		{name: "korean keeps spaces", words: []string{"연간", "보고서"}, want: "연간 보고서"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var line Line
+			for _, text := range tt.words {
+				line.Words = append(line.Words, EnrichedWord{Text: text})
+			}
+			if got := line.Text(); got != tt.want {
+				t.Errorf("Line.Text() = %q, want %q", got, tt.want)
+			}
+			para := Paragraph{Lines: []Line{line}}
+			if got := para.Text(); got != tt.want {
+				t.Errorf("Paragraph.Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectWordBoundaries_ScriptChange(t *testing.T) {
+	// CJK glyphs set without spaces, then "PDF" after a 3pt gap
+	chars := append(charRun("使用", 0), charRun("PDF", 15)...)
+
+	got := detectWordBoundaries(chars, spaceMetrics{})
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("boundaries = %v, want [2]", got)
+	}
+
+	// Touching glyphs are one run, whatever their scripts
+	if got := detectWordBoundaries(charRun("使用PDF", 0), spaceMetrics{}); len(got) != 0 {
+		t.Errorf("boundaries = %v for touching glyphs, want none", got)
+	}
+}
@@ -239,64 +239,88 @@ type TableArea struct {
 
 // buildTableAreas groups consecutive table/unknown lines into table areas
 func buildTableAreas(taggedLines []TaggedLine) []TableArea {
-	if len(taggedLines) == 0 {
-		return nil
-	}
+	areas, _ := gateTableAreas(taggedLines, true)
+	return areas
+}
 
+// gateTableAreas groups consecutive table/unknown lines into table areas and
+// keeps those that pass tableAreaRejection. Areas with at least two table
+// lines that fail are returned as rejected candidates; shorter runs of
+// unknown lines are ordinary text and not reported.
+func gateTableAreas(taggedLines []TaggedLine, strict bool) ([]TableArea, []RejectedTable) {
 	var areas []TableArea
+	var rejected []RejectedTable
 	var currentArea []TaggedLine
 
-	for i, tl := range taggedLines {
-		// Start or continue table area if line is table or unknown
-		if tl.Type == TableLine || tl.Type == UnknownLine {
-			if len(currentArea) == 0 {
-				currentArea = []TaggedLine{tl}
-			} else {
-				currentArea = append(currentArea, tl)
-			}
-		} else {
-			// Text line ends current table area
-			if len(currentArea) > 0 {
-				area := createTableArea(currentArea)
-				// Validate before adding
-				if isValidTableArea(area) {
-					areas = append(areas, area)
-				}
-				currentArea = nil
-			}
+	flush := func() {
+		if len(currentArea) == 0 {
+			return
+		}
+		area := createTableArea(currentArea)
+		currentArea = nil
+
+		reason := tableAreaRejection(area, strict)
+		switch {
+		case reason == "":
+			areas = append(areas, area)
+		case countTableLines(area.Lines) >= 2:
+			rejected = append(rejected, RejectedTable{Box: area.Box, Reason: reason})
 		}
+	}
 
-		// Handle end of lines
-		if i == len(taggedLines)-1 && len(currentArea) > 0 {
-			area := createTableArea(currentArea)
-			if isValidTableArea(area) {
-				areas = append(areas, area)
-			}
+	for _, tl := range taggedLines {
+		// Start or continue table area if line is table or unknown;
+		// a text line ends it
+		if tl.Type == TableLine || tl.Type == UnknownLine {
+			currentArea = append(currentArea, tl)
+		} else {
+			flush()
 		}
 	}
+	flush()
 
-	return areas
+	return areas, rejected
 }
 
 // isValidTableArea validates whether a table area is likely a real table
 // Implements validation rules to reduce false positives
 func isValidTableArea(area TableArea) bool {
+	return tableAreaRejection(area, true) == ""
+}
+
+// Reasons a table candidate is rejected, reported in RejectedTable.Reason.
+const (
+	rejectTooFewRows         = "too few rows"
+	rejectNoConsecutiveRows  = "too few consecutive table lines"
+	rejectUnstableColumns    = "unstable column count"
+	rejectSingleColumn       = "single column"
+	rejectUnalignedColumns   = "columns not aligned"
+	rejectSparseCells        = "too many empty cells"
+	rejectRaggedRows         = "inconsistent row lengths"
+	rejectHomogeneousContent = "homogeneous text content"
+)
+
+// minConsecutiveTableLines is the length of the longest run of table lines an
+// area needs. Address blocks and prose with wide gaps produce multi-segment
+// lines, but rarely three in a row.
+const minConsecutiveTableLines = 3
+
+// tableAreaRejection returns why a table area is unlikely to be a real table,
+// or "" when it passes every gate. strict adds the gates that keep prose and
+// address blocks out of segment-based detection; see gateTablesSegmentBased.
+func tableAreaRejection(area TableArea, strict bool) string {
 	// Must have at least 3 lines for a valid table (header + at least 2 data rows)
 	if len(area.Lines) < 3 {
-		return false
-	}
-
-	// Count how many lines are actually tagged as TableLine
-	tableLineCount := 0
-	for _, line := range area.Lines {
-		if line.Type == TableLine {
-			tableLineCount++
-		}
+		return rejectTooFewRows
 	}
 
-	// Must have at least 3 table lines (not just unknown lines)
+	// Must have at least 3 table lines (not just unknown lines), in a run
+	tableLineCount := countTableLines(area.Lines)
 	if tableLineCount < 3 {
-		return false
+		return rejectTooFewRows
+	}
+	if strict && longestTableLineRun(area.Lines) < minConsecutiveTableLines {
+		return rejectNoConsecutiveRows
 	}
 
 	// Check segment consistency across lines
@@ -323,21 +347,51 @@ func isValidTableArea(area TableArea) bool {
 
 	// At least 60% of table lines should have the same number of segments
 	if float64(maxCount)/float64(tableLineCount) < 0.6 {
-		return false
+		return rejectUnstableColumns
 	}
 
 	// Must have at least 2 segments (columns) for a table
 	if maxSegments < 2 {
-		return false
+		return rejectSingleColumn
 	}
 
 	// Check vertical alignment of segments
 	// Real tables have segments that align vertically across rows
 	if !hasVerticalAlignment(tableLines, maxSegments) {
-		return false
+		return rejectUnalignedColumns
 	}
 
-	return true
+	return ""
+}
+
+// countTableLines counts the lines tagged as table lines.
+func countTableLines(lines []TaggedLine) int {
+	count := 0
+	for _, line := range lines {
+		if line.Type == TableLine {
+			count++
+		}
+	}
+	return count
+}
+
+// longestTableLineRun returns the length of the longest run of table lines.
+// A single unknown line, such as a sparse row with one cell, doesn't break a
+// run; two in a row do.
+func longestTableLineRun(lines []TaggedLine) int {
+	longest, run, unknown := 0, 0, 0
+	for _, line := range lines {
+		if line.Type != TableLine {
+			if unknown++; unknown > 1 {
+				run = 0
+			}
+			continue
+		}
+		unknown = 0
+		run++
+		longest = max(longest, run)
+	}
+	return longest
 }
 
 // hasVerticalAlignment checks if segments align vertically across rows
@@ -588,12 +642,18 @@ type TableColumn struct {
 }
 
 // buildColumnsFromRows creates table columns from rows
-// Implements PDF-TREX column building with spanning header duplication
+// Implements PDF-TREX column building with spanning header duplication.
+// When the header row is complete it anchors the columns instead, see
+// buildColumnsFromHeader
 func buildColumnsFromRows(rows []SegmentTableRow, hT float64) []TableColumn {
 	if len(rows) == 0 {
 		return nil
 	}
 
+	if columns := buildColumnsFromHeader(rows); columns != nil {
+		return columns
+	}
+
 	// Collect all segments
 	var allSegments []Segment
 	for _, row := range rows {
@@ -649,6 +709,78 @@ func buildColumnsFromRows(rows []SegmentTableRow, hT float64) []TableColumn {
 	return merged
 }
 
+// buildColumnsFromHeader derives one column per segment of the header row
+// and bins the data segments into them by horizontal overlap, falling back to
+// the nearest column centre. Headers are usually complete where data rows are
+// sparse, and clustering sparse data segments alone merges or drops columns.
+// It returns nil unless the header has at least two non-overlapping segments
+// and no data row has more segments than the header.
+func buildColumnsFromHeader(rows []SegmentTableRow) []TableColumn {
+	header := rows[0]
+	if len(header.Segments) < 2 {
+		return nil
+	}
+	for _, row := range rows[1:] {
+		if len(row.Segments) > len(header.Segments) {
+			return nil
+		}
+	}
+
+	columns := make([]TableColumn, len(header.Segments))
+	for i, seg := range header.Segments {
+		columns[i] = TableColumn{Segments: []Segment{seg}, Box: seg.Box}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Box.X0 < columns[j].Box.X0
+	})
+	for i := 1; i < len(columns); i++ {
+		if columns[i].Box.X0 < columns[i-1].Box.X1 {
+			return nil
+		}
+	}
+
+	// Header extents, fixed before data segments widen the columns
+	anchors := make([]Rect, len(columns))
+	for i, col := range columns {
+		anchors[i] = col.Box
+	}
+
+	for _, row := range rows[1:] {
+		for _, seg := range row.Segments {
+			best, bestOverlap, overlapping := -1, 0.0, 0
+			for i, anchor := range anchors {
+				overlap := math.Min(seg.Box.X1, anchor.X1) - math.Max(seg.Box.X0, anchor.X0)
+				if overlap > 0 {
+					overlapping++
+					if overlap > bestOverlap {
+						best, bestOverlap = i, overlap
+					}
+				}
+			}
+			if best < 0 {
+				bestDist := math.MaxFloat64
+				for i, anchor := range anchors {
+					if dist := math.Abs(seg.Box.CenterX() - anchor.CenterX()); dist < bestDist {
+						best, bestDist = i, dist
+					}
+				}
+			}
+
+			columns[best].Segments = append(columns[best].Segments, seg)
+			if overlapping <= 1 {
+				columns[best].Box = mergeRects(columns[best].Box, seg.Box)
+			} else {
+				// A segment straddling several headers only extends the
+				// column vertically so it cannot swallow its neighbours
+				columns[best].Box.Y0 = math.Min(columns[best].Box.Y0, seg.Box.Y0)
+				columns[best].Box.Y1 = math.Max(columns[best].Box.Y1, seg.Box.Y1)
+			}
+		}
+	}
+
+	return makeColumnsContiguous(columns)
+}
+
 // mergeSingleSegmentColumns merges single-segment columns close to multi-segment ones
 func mergeSingleSegmentColumns(columns []TableColumn, hT float64) []TableColumn {
 	if len(columns) <= 1 {
@@ -731,14 +863,17 @@ func makeColumnsContiguous(columns []TableColumn) []TableColumn {
 // Used internally by segment-based table detection
 type SegmentTableCell struct {
 	Content string
+	Words   []EnrichedWord
 	Row     int
 	Column  int
 	Box     Rect
+	ColSpan int  // Columns a segment spanning several covers; 0 means 1
+	Covered bool // Covered by a spanning cell to the left, see TableCell.Covered
 }
 
 // buildCellsFromRowsAndColumns creates the final 2D cell grid
 // Implements PDF-TREX table building
-func buildCellsFromRowsAndColumns(rows []SegmentTableRow, columns []TableColumn) [][]SegmentTableCell {
+func buildCellsFromRowsAndColumns(rows []SegmentTableRow, columns []TableColumn, settings TableSettings) [][]SegmentTableCell {
 	if len(rows) == 0 || len(columns) == 0 {
 		return nil
 	}
@@ -751,97 +886,161 @@ func buildCellsFromRowsAndColumns(rows []SegmentTableRow, columns []TableColumn)
 
 	// Fill grid
 	for r, row := range rows {
+		cellBoxes := make([]CellBBox, len(columns))
 		for c, col := range columns {
 			// Find intersection of row and column
+			cellBoxes[c] = CellBBox{
+				X0:     col.Box.X0,
+				Top:    row.Box.Y0,
+				X1:     col.Box.X1,
+				Bottom: row.Box.Y1,
+			}
+		}
+
+		// Assign the row's words to its cells
+		var rowWords []EnrichedWord
+		for _, seg := range row.Segments {
+			rowWords = append(rowWords, seg.Words...)
+		}
+		assigned := assignWordsToCells(cellBoxes, rowWords, settings)
+
+		for c, col := range columns {
 			cellBox := Rect{
 				X0: col.Box.X0,
 				Y0: row.Box.Y0,
 				X1: col.Box.X1,
 				Y1: row.Box.Y1,
 			}
-
-			// Find all words in this cell
-			var cellWords []EnrichedWord
-			for _, seg := range row.Segments {
-				for _, word := range seg.Words {
-					// Check if word is in cell box
-					if wordInBox(word, cellBox) {
-						cellWords = append(cellWords, word)
-					}
-				}
-			}
-
-			// Sort words left-to-right, top-to-bottom
-			sort.Slice(cellWords, func(i, j int) bool {
-				if math.Abs(cellWords[i].Box.Y0-cellWords[j].Box.Y0) < 3 {
-					return cellWords[i].Box.X0 < cellWords[j].Box.X0
-				}
-				return cellWords[i].Box.Y0 < cellWords[j].Box.Y0
-			})
-
-			// Concatenate text
-			var content string
-			for i, word := range cellWords {
-				content += word.Text
-				if i < len(cellWords)-1 {
-					// Add space between words on same line
-					if math.Abs(cellWords[i].Box.Y0-cellWords[i+1].Box.Y0) < 3 {
-						content += " "
-					} else {
-						// Newline for multi-line cells
-						content += "\n"
-					}
-				}
-			}
+			cellWords, content := joinSegmentCellWords(assigned[c])
 
 			grid[r][c] = SegmentTableCell{
 				Content: content,
+				Words:   cellWords,
 				Row:     r,
 				Column:  c,
 				Box:     cellBox,
 			}
 		}
+
+		mergeSpanningSegments(grid[r], row.Segments, columns)
 	}
 
 	return grid
 }
 
-// wordInBox checks if a word's center is within the box
-func wordInBox(word EnrichedWord, box Rect) bool {
-	centerX := word.Box.CenterX()
-	centerY := word.Box.CenterY()
+// joinSegmentCellWords sorts a cell's words left to right, top to bottom, and
+// joins them, separating lines with newlines.
+func joinSegmentCellWords(cellWords []EnrichedWord) ([]EnrichedWord, string) {
+	sort.Slice(cellWords, func(i, j int) bool {
+		if math.Abs(cellWords[i].Box.Y0-cellWords[j].Box.Y0) < 3 {
+			return cellWords[i].Box.X0 < cellWords[j].Box.X0
+		}
+		return cellWords[i].Box.Y0 < cellWords[j].Box.Y0
+	})
 
-	return centerX >= box.X0 && centerX <= box.X1 &&
-		centerY >= box.Y0 && centerY <= box.Y1
+	var content string
+	for i, word := range cellWords {
+		content += word.Text
+		if i < len(cellWords)-1 {
+			// Add space between words on same line
+			if math.Abs(cellWords[i].Box.Y0-cellWords[i+1].Box.Y0) < 3 {
+				content += " "
+			} else {
+				// Newline for multi-line cells
+				content += "\n"
+			}
+		}
+	}
+	return cellWords, content
+}
+
+// mergeSpanningSegments merges a row's cells under a segment that reaches
+// across column edges, such as a header over several columns, into one cell
+// spanning them. The segment must cover at least a third of each column's
+// width and be the only text in them.
+func mergeSpanningSegments(cells []SegmentTableCell, segments []Segment, columns []TableColumn) {
+	for _, seg := range segments {
+		first, last := -1, -1
+		for c, col := range columns {
+			overlap := math.Min(seg.Box.X1, col.Box.X1) - math.Max(seg.Box.X0, col.Box.X0)
+			if overlap >= col.Box.Width()/3 {
+				if first < 0 {
+					first = c
+				}
+				last = c
+			}
+		}
+		if first < 0 || last == first {
+			continue
+		}
+
+		var words []EnrichedWord
+		alone := true
+		for c := first; c <= last; c++ {
+			if cells[c].Covered {
+				alone = false
+			}
+			for _, word := range cells[c].Words {
+				center := Point{X: word.Box.CenterX(), Y: (word.Box.Y0 + word.Box.Y1) / 2}
+				if center.X < seg.Box.X0 || center.X > seg.Box.X1 || center.Y < seg.Box.Y0 || center.Y > seg.Box.Y1 {
+					alone = false
+				}
+			}
+			words = append(words, cells[c].Words...)
+		}
+		if !alone {
+			continue
+		}
+
+		merged := cells[first]
+		merged.Box.X1 = cells[last].Box.X1
+		merged.Words, merged.Content = joinSegmentCellWords(words)
+		merged.ColSpan = last - first + 1
+		cells[first] = merged
+		for c := first + 1; c <= last; c++ {
+			covered := merged
+			covered.Column, covered.Words, covered.ColSpan, covered.Covered = c, nil, 0, true
+			cells[c] = covered
+		}
+	}
 }
 
 // DetectTablesSegmentBased detects tables using segment-based approach
 // This is an alternative to line-based detection for PDFs without ruling lines
+//
+// Deprecated: Use experimental.DetectTablesSegmentBased, or SegmentTableDetector
+// through Config.TableDetectors.
 func DetectTablesSegmentBased(page *Page, thresholds AdaptiveThresholds) []Table {
-	if len(page.Paragraphs) == 0 {
-		return nil
-	}
+	return detectTablesSegmentBased(page, thresholds, DefaultTableSettings())
+}
 
-	// Collect all words from paragraphs
-	var words []EnrichedWord
-	for _, para := range page.Paragraphs {
-		for _, line := range para.Lines {
-			words = append(words, line.Words...)
-		}
-	}
+// detectTablesSegmentBased runs segment-based detection, using settings for
+// cell content assignment.
+func detectTablesSegmentBased(page *Page, thresholds AdaptiveThresholds, settings TableSettings) []Table {
+	tables, _ := gateTablesSegmentBased(page, thresholds, settings, true)
+	return tables
+}
 
-	if len(words) == 0 {
-		return nil
+// gateTablesSegmentBased runs segment-based detection, returning the tables
+// found and the candidates rejected on the way. strict applies the gates
+// against prose and address blocks: a run of consecutive table lines and
+// heterogeneous cell content. Without it the results are only region
+// proposals, as for HybridTableDetector, which rebuilds and checks each grid.
+func gateTablesSegmentBased(page *Page, thresholds AdaptiveThresholds, settings TableSettings, strict bool) ([]Table, []RejectedTable) {
+	if len(page.Paragraphs) == 0 {
+		return nil, nil
 	}
 
-	// Build lines from words (use existing baseline-aware grouping)
-	lines := groupWordsIntoLinesBaseline(words)
+	lines := pageTextLines(page)
+	if len(lines) == 0 {
+		return nil, nil
+	}
 
 	// Build tagged lines with segments
 	taggedLines := buildTaggedLines(lines, thresholds.HorizontalThreshold, page.Width)
 
 	// Build table areas
-	tableAreas := buildTableAreas(taggedLines)
+	tableAreas, rejected := gateTableAreas(taggedLines, strict)
 
 	// Convert table areas to tables
 	var tables []Table
@@ -856,28 +1055,40 @@ func DetectTablesSegmentBased(page *Page, thresholds AdaptiveThresholds) []Table
 		columns := buildColumnsFromRows(rows, thresholds.HorizontalThreshold)
 
 		// Build cells
-		cellGrid := buildCellsFromRowsAndColumns(rows, columns)
+		cellGrid := buildCellsFromRowsAndColumns(rows, columns, settings)
 
 		// Convert to Table type
 		if len(cellGrid) > 0 && len(cellGrid[0]) > 0 {
 			table := convertCellGridToTable(cellGrid, area.Box)
 
 			// Final validation: ensure table meets minimum requirements
-			if isValidTable(table) {
-				tables = append(tables, table)
+			if reason := tableRejection(table, strict); reason != "" {
+				rejected = append(rejected, RejectedTable{Box: area.Box, Reason: reason})
+				continue
 			}
+			tables = append(tables, table)
 		}
 	}
 
-	return tables
+	return tables, rejected
 }
 
 // isValidTable performs final validation on extracted table
 func isValidTable(table Table) bool {
+	return tableRejection(table, true) == ""
+}
+
+// tableRejection returns why an extracted table is unlikely to be real, or ""
+// when it passes every gate. strict adds the content gate, see
+// gateTablesSegmentBased.
+func tableRejection(table Table, strict bool) string {
 	// Must have at least 4 rows and 2 columns (header + 3 data rows minimum)
 	// This reduces false positives from formatted text
-	if table.NumRows < 4 || table.NumCols < 2 {
-		return false
+	if table.NumRows < 4 {
+		return rejectTooFewRows
+	}
+	if table.NumCols < 2 {
+		return rejectSingleColumn
 	}
 
 	// Count non-empty cells
@@ -895,7 +1106,7 @@ func isValidTable(table Table) bool {
 
 	// At least 40% of cells must have content (stricter than 30%)
 	if totalCells > 0 && float64(nonEmptyCells)/float64(totalCells) < 0.4 {
-		return false
+		return rejectSparseCells
 	}
 
 	// Check for consistent column count across rows
@@ -904,12 +1115,96 @@ func isValidTable(table Table) bool {
 		for _, row := range table.Rows {
 			if len(row.Cells) != expectedCols {
 				// Inconsistent column count suggests malformed table
-				return false
+				return rejectRaggedRows
 			}
 		}
 	}
 
-	return true
+	if strict && !hasHeterogeneousContent(table) {
+		return rejectHomogeneousContent
+	}
+
+	return ""
+}
+
+// Kinds of cell content compared by hasHeterogeneousContent.
+const (
+	cellKindNumeric = iota
+	cellKindShort
+	cellKindLong
+)
+
+// maxShortCellWords is the most words a cell holds to count as a short label
+// rather than running text.
+const maxShortCellWords = 3
+
+// hasHeterogeneousContent reports whether a table's columns hold different
+// kinds of content, as real tables do: a column of numbers, labels beside
+// longer descriptions, or a bold header over plain data. Prose split at wide
+// gaps has running text in every column, and address blocks set side by side
+// have short text lines in every column.
+func hasHeterogeneousContent(table Table) bool {
+	if len(table.Rows) < 2 {
+		return false
+	}
+
+	kinds := make(map[int]bool)
+	for c := 0; c < table.NumCols; c++ {
+		var counts [3]int
+		for _, row := range table.Rows[1:] {
+			if c >= len(row.Cells) {
+				continue
+			}
+			content := strings.TrimSpace(row.Cells[c].Content)
+			switch {
+			case content == "":
+				continue
+			case isNumericCell(content):
+				counts[cellKindNumeric]++
+			case len(strings.Fields(content)) <= maxShortCellWords:
+				counts[cellKindShort]++
+			default:
+				counts[cellKindLong]++
+			}
+		}
+		if counts == [3]int{} {
+			continue
+		}
+
+		kind := cellKindNumeric
+		for k := range counts {
+			if counts[k] > counts[kind] {
+				kind = k
+			}
+		}
+		if kind == cellKindNumeric {
+			return true
+		}
+		kinds[kind] = true
+	}
+	return len(kinds) > 1 || hasBoldHeader(table)
+}
+
+// hasBoldHeader reports whether a table's first row is bold while most of
+// its other non-empty cells are not.
+func hasBoldHeader(table Table) bool {
+	var header, body cellStats
+	for r, row := range table.Rows {
+		counts := &body
+		if r == 0 {
+			counts = &header
+		}
+		for _, cell := range row.Cells {
+			if strings.TrimSpace(cell.Content) == "" {
+				continue
+			}
+			counts.cells++
+			if isBoldCell(cell) {
+				counts.bold++
+			}
+		}
+	}
+	return header.cells > 0 && body.cells > 0 && header.bold*2 > header.cells && body.bold*2 < body.cells
 }
 
 // convertCellGridToTable converts cell grid to Table structure
@@ -935,6 +1230,9 @@ func convertCellGridToTable(grid [][]SegmentTableCell, box Rect) Table {
 					Bottom: grid[r][c].Box.Y1,
 				},
 				Content: grid[r][c].Content,
+				Words:   grid[r][c].Words,
+				ColSpan: grid[r][c].ColSpan,
+				Covered: grid[r][c].Covered,
 			}
 		}
 		tableRows[r] = TableRow{
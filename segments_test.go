@@ -401,6 +401,97 @@ func TestMergeSingleSegmentColumns(t *testing.T) {
 	}
 }
 
+// segmentRow builds a one-line table row from segments given as text and
+// left edge pairs, 10pt tall and 6pt per character wide
+func segmentRow(y float64, cells ...any) SegmentTableRow {
+	row := SegmentTableRow{}
+	for i := 0; i < len(cells); i += 2 {
+		text, x := cells[i].(string), cells[i+1].(float64)
+		box := Rect{X0: x, Y0: y, X1: x + 6*float64(len(text)), Y1: y + 10}
+		row.Segments = append(row.Segments, Segment{
+			Words: []EnrichedWord{{Text: text, Box: box}},
+			Box:   box,
+		})
+		if i == 0 {
+			row.Box = box
+		}
+		row.Box = mergeRects(row.Box, box)
+	}
+	return row
+}
+
+// TestBuildColumnsFromRows_SparseData tests that a complete header row
+// anchors the columns of a table whose data rows are sparse
+func TestBuildColumnsFromRows_SparseData(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []SegmentTableRow
+		wantCols int
+		// wantCell maps "row,col" to the expected cell content
+		wantCell map[[2]int]string
+	}{
+		{
+			name: "empty column keeps its own header",
+			rows: []SegmentTableRow{
+				segmentRow(0, "Item", 10.0, "Q1", 110.0, "Q2", 128.0, "Q3", 190.0),
+				segmentRow(15, "Coffee", 10.0, "12", 190.0),
+				segmentRow(30, "Tea", 10.0, "7", 112.0),
+				segmentRow(45, "Cocoa", 10.0, "3", 110.0, "9", 190.0),
+			},
+			wantCols: 4,
+			wantCell: map[[2]int]string{
+				{0, 2}: "Q2",
+				{1, 3}: "12",
+				{2, 1}: "7",
+				{2, 2}: "",
+				{3, 1}: "3",
+			},
+		},
+		{
+			name: "data between headers goes to the nearest column",
+			rows: []SegmentTableRow{
+				segmentRow(0, "Item", 10.0, "Price", 200.0),
+				segmentRow(15, "Coffee", 10.0, "3.00", 180.0),
+				segmentRow(30, "Tea", 10.0, "2.50", 180.0),
+			},
+			wantCols: 2,
+			wantCell: map[[2]int]string{
+				{1, 1}: "3.00",
+				{2, 0}: "Tea",
+			},
+		},
+		{
+			name: "incomplete header falls back to clustering",
+			rows: []SegmentTableRow{
+				segmentRow(0, "Item", 10.0, "Price", 200.0),
+				segmentRow(15, "Coffee", 10.0, "hot", 100.0, "3.00", 200.0),
+				segmentRow(30, "Tea", 10.0, "hot", 100.0, "2.50", 200.0),
+			},
+			wantCols: 3,
+			wantCell: map[[2]int]string{
+				{1, 1}: "hot",
+				{2, 2}: "2.50",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := buildColumnsFromRows(tt.rows, 20.0)
+			if len(columns) != tt.wantCols {
+				t.Fatalf("got %d columns, want %d", len(columns), tt.wantCols)
+			}
+
+			grid := buildCellsFromRowsAndColumns(tt.rows, columns, DefaultTableSettings())
+			for pos, want := range tt.wantCell {
+				if got := grid[pos[0]][pos[1]].Content; got != want {
+					t.Errorf("cell %v = %q, want %q", pos, got, want)
+				}
+			}
+		})
+	}
+}
+
 // TestBuildCellsFromRowsAndColumns tests cell grid generation
 func TestBuildCellsFromRowsAndColumns(t *testing.T) {
 	// Create simple 2x2 table
@@ -442,7 +533,7 @@ func TestBuildCellsFromRowsAndColumns(t *testing.T) {
 		{Box: Rect{X0: 100, Y0: 0, X1: 200, Y1: 30}},
 	}
 
-	grid := buildCellsFromRowsAndColumns(rows, columns)
+	grid := buildCellsFromRowsAndColumns(rows, columns, DefaultTableSettings())
 
 	// Should create 2x2 grid
 	if len(grid) != 2 {
@@ -523,3 +614,178 @@ func TestDeduplicateTables(t *testing.T) {
 		t.Errorf("Expected 2 unique tables, got %d", len(unique))
 	}
 }
+
+// benchmarkSegmentPage builds a page of 60 ten-word lines, half prose and half
+// a four-column table, the way ExtractPage would.
+func benchmarkSegmentPage() *Page {
+	var words []EnrichedWord
+	for row := 0; row < 60; row++ {
+		y := 50 + float64(row)*12
+		for col := 0; col < 10; col++ {
+			x := 50 + float64(col)*50
+			if row >= 30 {
+				x = 50 + float64(col%4)*130 + float64(col/4)*35
+			}
+			words = append(words, EnrichedWord{
+				Text:     "word",
+				Box:      Rect{X0: x, Y0: y, X1: x + 30, Y1: y + 10},
+				FontSize: 10,
+				Baseline: y + 8,
+				XHeight:  5,
+			})
+		}
+	}
+
+	lines := buildTextLines(words, spaceMetrics{})
+	return &Page{
+		Width:      612,
+		Height:     792,
+		Paragraphs: buildParagraphs(lines, words, 612, nil, nil, DefaultConfig()),
+		textLines:  lines,
+	}
+}
+
+// BenchmarkDetectTablesSegmentBased compares reusing ExtractPage's lines with
+// regrouping the paragraph words into lines again.
+func BenchmarkDetectTablesSegmentBased(b *testing.B) {
+	page := benchmarkSegmentPage()
+	thresholds := calculateAdaptiveThresholds(pageWords(page))
+	settings := DefaultTableSettings()
+
+	b.Run("shared lines", func(b *testing.B) {
+		for b.Loop() {
+			detectTablesSegmentBased(page, thresholds, settings)
+		}
+	})
+
+	regrouped := *page
+	regrouped.textLines = nil
+	b.Run("regrouped lines", func(b *testing.B) {
+		for b.Loop() {
+			detectTablesSegmentBased(&regrouped, thresholds, settings)
+		}
+	})
+}
+
+// gateTable builds a table from rows of cell text, for the content gates
+func gateTable(rows ...[]string) Table {
+	table := Table{NumRows: len(rows), NumCols: len(rows[0])}
+	for _, texts := range rows {
+		row := TableRow{}
+		for _, text := range texts {
+			row.Cells = append(row.Cells, TableCell{Content: text})
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// TestTableRejection tests the gates that keep prose and address blocks out
+// of segment-based detection
+func TestTableRejection(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  Table
+		strict bool
+		want   string
+	}{
+		{
+			name: "numeric column passes",
+			table: gateTable(
+				[]string{"Item", "Price"},
+				[]string{"Coffee", "3.00"},
+				[]string{"Tea", "2.50"},
+				[]string{"Cocoa", "4.10"},
+			),
+			strict: true,
+		},
+		{
+			name: "labels beside descriptions pass",
+			table: gateTable(
+				[]string{"Term", "Meaning"},
+				[]string{"Lease", "an agreement to rent a property for a fixed period"},
+				[]string{"Bond", "money held against damage to the property"},
+				[]string{"Tenant", "the person who rents and lives in the property"},
+			),
+			strict: true,
+		},
+		{
+			name: "address blocks side by side are rejected",
+			table: gateTable(
+				[]string{"Jane Smith", "John Brown"},
+				[]string{"12 High Street", "4 Low Road"},
+				[]string{"Springfield", "Shelbyville"},
+				[]string{"VIC 3000", "NSW 2000"},
+			),
+			strict: true,
+			want:   rejectHomogeneousContent,
+		},
+		{
+			name: "prose split at wide gaps is rejected",
+			table: gateTable(
+				[]string{"The quick brown fox", "jumps over the lazy dog"},
+				[]string{"and then runs away", "into the dark green forest"},
+				[]string{"where nobody will ever", "find it again after today"},
+				[]string{"unless someone goes looking", "with a lantern and a map"},
+			),
+			strict: true,
+			want:   rejectHomogeneousContent,
+		},
+		{
+			name: "content gate only applies when strict",
+			table: gateTable(
+				[]string{"Jane Smith", "John Brown"},
+				[]string{"12 High Street", "4 Low Road"},
+				[]string{"Springfield", "Shelbyville"},
+				[]string{"VIC 3000", "NSW 2000"},
+			),
+		},
+		{
+			name: "too few rows",
+			table: gateTable(
+				[]string{"Item", "Price"},
+				[]string{"Coffee", "3.00"},
+			),
+			strict: true,
+			want:   rejectTooFewRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tableRejection(tt.table, tt.strict); got != tt.want {
+				t.Errorf("tableRejection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLongestTableLineRun tests that table lines must run consecutively,
+// allowing a single unknown line inside the run
+func TestLongestTableLineRun(t *testing.T) {
+	line := func(types ...LineType) []TaggedLine {
+		var lines []TaggedLine
+		for _, lt := range types {
+			lines = append(lines, TaggedLine{Type: lt})
+		}
+		return lines
+	}
+
+	tests := []struct {
+		name  string
+		lines []TaggedLine
+		want  int
+	}{
+		{"all table lines", line(TableLine, TableLine, TableLine), 3},
+		{"single unknown line keeps the run", line(TableLine, UnknownLine, TableLine, TableLine), 3},
+		{"two unknown lines break the run", line(TableLine, TableLine, UnknownLine, UnknownLine, TableLine, TableLine), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestTableLineRun(tt.lines); got != tt.want {
+				t.Errorf("longestTableLineRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
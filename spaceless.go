@@ -0,0 +1,171 @@
+package pdfmarkdown
+
+import (
+	"math"
+
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+)
+
+// Text is judged to lack spaces between its words when it has at least
+// minSpacelessSample letters and other visible characters and fewer than
+// maxSpacelessRatio spaces per visible character. Running text in languages
+// written with spaces has around one space per six characters.
+const (
+	minSpacelessSample = 200
+	maxSpacelessRatio  = 0.02
+)
+
+// calibrationPages is how many pages, spread over the range being converted,
+// Config.AggressiveWordSplitting samples to decide whether a document lacks
+// spaces.
+const calibrationPages = 5
+
+// spaceCount tallies visible characters and spaces.
+type spaceCount struct {
+	visible, spaces int
+}
+
+// add counts one character. Line breaks are neither, as text without spaces
+// between words still has them between lines.
+func (s *spaceCount) add(r rune) {
+	switch r {
+	case ' ', '\u00a0', '\t':
+		s.spaces++
+	case '\n', '\r':
+	default:
+		s.visible++
+	}
+}
+
+// lacksSpaces reports whether enough text was counted to judge and it has
+// almost no spaces.
+func (s spaceCount) lacksSpaces() bool {
+	return s.visible >= minSpacelessSample && float64(s.spaces) < float64(s.visible)*maxSpacelessRatio
+}
+
+// charsLackSpaces reports whether a page's characters lack spaces, for pages
+// extracted without the rest of their document.
+func charsLackSpaces(chars []EnrichedChar) bool {
+	var count spaceCount
+	for _, char := range chars {
+		count.add(char.Text)
+	}
+	return count.lacksSpaces()
+}
+
+// documentLacksSpaces samples the text of a few pages spread over startPage
+// through endPage (0-indexed, inclusive) and reports whether it lacks spaces.
+// Pages that can't be read are left out of the sample.
+func (c *Converter) documentLacksSpaces(docRef references.FPDF_DOCUMENT, startPage, endPage int) bool {
+	var count spaceCount
+	for _, index := range samplePages(startPage, endPage, calibrationPages) {
+		for _, r := range c.pageText(docRef, index) {
+			count.add(r)
+		}
+	}
+	return count.lacksSpaces()
+}
+
+// samplePages returns up to n page indexes evenly spread from start to end.
+func samplePages(start, end, n int) []int {
+	total := end - start + 1
+	if total <= 0 {
+		return nil
+	}
+	if total <= n {
+		n = total
+	}
+	pages := make([]int, 0, n)
+	for k := range n {
+		index := start
+		if n > 1 {
+			index += k * (total - 1) / (n - 1)
+		}
+		pages = append(pages, index)
+	}
+	return pages
+}
+
+// pageText returns a page's text from pdfium's bulk text API, or "" when the
+// page can't be read.
+func (c *Converter) pageText(docRef references.FPDF_DOCUMENT, pageIndex int) string {
+	pageResp, err := c.instance.FPDF_LoadPage(&requests.FPDF_LoadPage{Document: docRef, Index: pageIndex})
+	if err != nil {
+		return ""
+	}
+	defer c.instance.FPDF_ClosePage(&requests.FPDF_ClosePage{Page: pageResp.Page})
+
+	textPage, err := c.instance.FPDFText_LoadPage(&requests.FPDFText_LoadPage{
+		Page: requests.Page{ByReference: &pageResp.Page},
+	})
+	if err != nil {
+		return ""
+	}
+	defer c.instance.FPDFText_ClosePage(&requests.FPDFText_ClosePage{TextPage: textPage.TextPage})
+
+	count, err := c.instance.FPDFText_CountChars(&requests.FPDFText_CountChars{TextPage: textPage.TextPage})
+	if err != nil || count.Count == 0 {
+		return ""
+	}
+	text, err := c.instance.FPDFText_GetText(&requests.FPDFText_GetText{TextPage: textPage.TextPage, StartIndex: 0, Count: count.Count})
+	if err != nil {
+		return ""
+	}
+	return text.Text
+}
+
+// wordGapRatio returns the gap, as a fraction of the font size, that
+// separates words in a page's text set without spaces: well clear of the
+// typical gap between its letters, within sensible bounds.
+func wordGapRatio(chars []EnrichedChar) float64 {
+	const minGap, maxGap = 0.15, 0.5
+
+	var ratios []float64
+	for i := 1; i < len(chars); i++ {
+		prev, curr := chars[i-1], chars[i]
+		if isRotatedText(curr.Angle) || curr.FontSize <= 0 {
+			continue
+		}
+		overlap := math.Min(prev.Box.Y1, curr.Box.Y1) - math.Max(prev.Box.Y0, curr.Box.Y0)
+		if overlap <= math.Min(prev.Box.Height(), curr.Box.Height())*0.5 {
+			continue // Different lines
+		}
+		ratios = append(ratios, (curr.Box.X0-prev.Box.X1)/curr.FontSize)
+	}
+	if len(ratios) == 0 {
+		return minGap
+	}
+
+	// Most gaps are between letters, so the median and its spread describe them
+	median := calculateMedian(ratios)
+	deviations := make([]float64, len(ratios))
+	for i, r := range ratios {
+		deviations[i] = math.Abs(r - median)
+	}
+	spread := calculateMedian(deviations)
+
+	return math.Max(minGap, math.Min(maxGap, median+4*spread))
+}
+
+// spacelessBoundary reports whether a word starts at chars[i] in text set
+// without spaces: after a gap of at least splitGap times the font size, at a
+// change from lower to upper case or from digits to letters, before the last
+// capital of a run that starts a capitalised word ("PORate"), or before a
+// currency sign.
+func spacelessBoundary(chars []EnrichedChar, i int, gap, splitGap float64) bool {
+	prev, curr := chars[i-1].Text, chars[i].Text
+	switch {
+	case chars[i].FontSize > 0 && gap >= splitGap*chars[i].FontSize:
+		return true
+	case isLowerCase(prev) && isUpperCase(curr):
+		return true
+	case isUpperCase(prev) && isUpperCase(curr) && i+1 < len(chars) && isLowerCase(chars[i+1].Text):
+		return true
+	case isDigit(prev) && isAlpha(curr), isAlpha(prev) && isDigit(curr):
+		return true
+	case isCurrency(curr) && !isCurrency(prev):
+		return true
+	}
+	return false
+}
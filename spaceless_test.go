@@ -0,0 +1,91 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// spacelessChars lays out text on one line with no space characters: letters
+// touch within a word and words are set a gap apart.
+func spacelessChars(words []string, gap float64) []EnrichedChar {
+	var chars []EnrichedChar
+	x := 72.0
+	for _, word := range words {
+		for _, r := range word {
+			chars = append(chars, EnrichedChar{Text: r, FontSize: 10, Box: Rect{X0: x, Y0: 100, X1: x + 5, Y1: 110}})
+			x += 5
+		}
+		x += gap
+	}
+	return chars
+}
+
+func TestAggressiveWordSplitting(t *testing.T) {
+	// Words run together with no gap at all, and a wide gap before "Total"
+	chars := spacelessChars([]string{"numberPORateHandling0085648100305LILYSK$388.57"}, 0)
+	chars = append(chars, spacelessChars([]string{"Total"}, 0)...)
+	for i := len(chars) - 5; i < len(chars); i++ {
+		chars[i].Box.X0 += 240
+		chars[i].Box.X1 += 240
+	}
+
+	page := structurePage(&rawPage{width: 600, height: 800, chars: chars, spaceless: true}, 1, DefaultConfig())
+	var got []string
+	for _, para := range page.Paragraphs {
+		for _, line := range para.Lines {
+			for _, word := range line.Words {
+				got = append(got, word.Text)
+			}
+		}
+	}
+	want := []string{"number", "PO", "Rate", "Handling", "0085648100305", "LILYSK", "$388.57", "Total"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("words = %q, want %q", got, want)
+	}
+
+	// Pages of documents with spaces are left alone
+	page = structurePage(&rawPage{width: 600, height: 800, chars: chars[:len(chars)-5]}, 1, DefaultConfig())
+	if text := page.Paragraphs[0].Text(); text != "numberPORateHandling0085648100305LILYSK$388.57" {
+		t.Errorf("text without splitting = %q", text)
+	}
+}
+
+func TestSpaceCount(t *testing.T) {
+	spaced := strings.Repeat("The quick brown fox jumps over the lazy dog.\r\n", 10)
+	spaceless := strings.ReplaceAll(spaced, " ", "")
+
+	for _, tt := range []struct {
+		text string
+		want bool
+	}{
+		{spaced, false},
+		{spaceless, true},
+		{"Tooshort", false},
+	} {
+		var count spaceCount
+		for _, r := range tt.text {
+			count.add(r)
+		}
+		if got := count.lacksSpaces(); got != tt.want {
+			t.Errorf("lacksSpaces(%.20q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSamplePages(t *testing.T) {
+	tests := []struct {
+		start, end int
+		want       []int
+	}{
+		{0, 2, []int{0, 1, 2}},
+		{0, 99, []int{0, 24, 49, 74, 99}},
+		{10, 10, []int{10}},
+		{5, 4, nil},
+	}
+	for _, tt := range tests {
+		if got := samplePages(tt.start, tt.end, 5); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("samplePages(%d, %d) = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
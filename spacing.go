@@ -0,0 +1,57 @@
+package pdfmarkdown
+
+// minSpaceSamples is the number of measured whitespace glyphs needed before
+// gap heuristics trust the page's space width over fixed thresholds.
+const minSpaceSamples = 3
+
+// spaceMetrics records the whitespace glyphs on a page. Word grouping drops
+// whitespace characters, so their boxes are kept here to measure how wide a
+// real space is in this document.
+type spaceMetrics struct {
+	boxes      []Rect  // Boxes of whitespace glyphs with a measurable width
+	widthRatio float64 // Median space width as a fraction of font size
+
+	// splitGap is the gap, as a fraction of font size, that separates words
+	// in text set without spaces; 0 unless Config.AggressiveWordSplitting
+	// found the document lacks them
+	splitGap float64
+}
+
+// measureSpaces collects whitespace glyph boxes and the median space width
+// relative to font size. pdfium's generated spaces have empty boxes and are ignored.
+func measureSpaces(chars []EnrichedChar) spaceMetrics {
+	var metrics spaceMetrics
+	var ratios []float64
+
+	for _, char := range chars {
+		if char.Text != ' ' && char.Text != '\u00a0' {
+			continue
+		}
+		if isRotatedText(char.Angle) || char.Box.Width() <= 0 || char.FontSize <= 0 {
+			continue
+		}
+		metrics.boxes = append(metrics.boxes, char.Box)
+		ratios = append(ratios, char.Box.Width()/char.FontSize)
+	}
+
+	metrics.widthRatio = calculateMedian(ratios)
+	return metrics
+}
+
+// spaceWidth returns the measured space width at the given font size, and
+// whether enough whitespace was measured to rely on it.
+func (m spaceMetrics) spaceWidth(fontSize float64) (float64, bool) {
+	if len(m.boxes) < minSpaceSamples || m.widthRatio <= 0 || fontSize <= 0 {
+		return 0, false
+	}
+	return m.widthRatio * fontSize, true
+}
+
+// mergeGapThreshold returns the gap below which two adjacent words are merged:
+// half a measured space, or 2pt when the page has no usable whitespace.
+func (m spaceMetrics) mergeGapThreshold(fontSize float64) float64 {
+	if width, ok := m.spaceWidth(fontSize); ok {
+		return width * 0.5
+	}
+	return 2.0
+}
@@ -0,0 +1,100 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"testing"
+)
+
+// charRun lays out text as 6pt-wide glyphs at 12pt, starting at x. Spaces get
+// a 3pt box like a real space glyph.
+func charRun(text string, x float64) []EnrichedChar {
+	var chars []EnrichedChar
+	for _, r := range text {
+		width := 6.0
+		if r == ' ' {
+			width = 3.0
+		}
+		chars = append(chars, EnrichedChar{
+			Text:     r,
+			Box:      Rect{X0: x, Y0: 100, X1: x + width, Y1: 112},
+			FontSize: 12,
+		})
+		x += width
+	}
+	return chars
+}
+
+func TestMeasureSpaces(t *testing.T) {
+	chars := charRun("a b c d", 0)
+	// Generated spaces have an empty box and must not skew the measurement
+	chars = append(chars, EnrichedChar{Text: ' ', FontSize: 12})
+
+	spaces := measureSpaces(chars)
+	if len(spaces.boxes) != 3 {
+		t.Errorf("measured %d spaces, want 3", len(spaces.boxes))
+	}
+
+	width, ok := spaces.spaceWidth(24)
+	if !ok || math.Abs(width-6) > 0.01 {
+		t.Errorf("spaceWidth(24) = %v, %v, want 6, true", width, ok)
+	}
+
+	if _, ok := measureSpaces(charRun("a b", 0)).spaceWidth(12); ok {
+		t.Error("expected too few samples to be unusable")
+	}
+}
+
+func TestDetectWordBoundaries_MeasuredSpaces(t *testing.T) {
+	// "Bill amount" where the space glyph is missing but the gap is space-sized
+	chars := append(charRun("Bill", 0), charRun("amount", 27)...)
+
+	tests := []struct {
+		name   string
+		spaces spaceMetrics
+		want   []int
+	}{
+		{
+			name:   "no measured spaces keeps whitespace-only behaviour",
+			spaces: spaceMetrics{},
+			want:   nil,
+		},
+		{
+			name:   "space-sized gap splits words",
+			spaces: measureSpaces(charRun("a b c d", 0)),
+			want:   []int{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectWordBoundaries(chars, tt.spaces)
+			if len(got) != len(tt.want) {
+				t.Fatalf("boundaries = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("boundaries = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeCloseWords_MeasuredSpaces(t *testing.T) {
+	words := []EnrichedWord{
+		{Text: "exam", Box: Rect{X0: 0, Y0: 100, X1: 24, Y1: 112}, FontSize: 12},
+		{Text: "ple", Box: Rect{X0: 25, Y0: 100, X1: 43, Y1: 112}, FontSize: 12},
+		{Text: "text", Box: Rect{X0: 46, Y0: 100, X1: 70, Y1: 112}, FontSize: 12},
+	}
+
+	// Measured space is 3pt at 12pt, so gaps under 1.5pt merge and 3pt gaps don't
+	merged := mergeCloseWords(words, measureSpaces(charRun("a b c d", 0)))
+
+	var got []string
+	for _, w := range merged {
+		got = append(got, w.Text)
+	}
+	if len(got) != 2 || got[0] != "example" || got[1] != "text" {
+		t.Errorf("mergeCloseWords() = %v, want [example text]", got)
+	}
+}
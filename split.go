@@ -0,0 +1,105 @@
+package pdfmarkdown
+
+// SubDocument is one part of a document split by SplitByHeading: a heading
+// and everything up to the next heading at its level or above.
+type SubDocument struct {
+	Heading string // Text of the heading the part starts with; "" for text before the first
+	Level   int    // Level of that heading; 0 for text before the first
+	Slug    string // Heading as a GitHub-style anchor, such as "getting-started"
+
+	// Document holds the part's pages. A page shared with the parts before or
+	// after it appears in each, with only its own paragraphs, tables and figures
+	Document *Document
+}
+
+// SplitByHeading splits the document at every heading of level or above
+// (1 splits at H1 only), such as into one part per chapter of a manual.
+// Heading levels are normalized with config first, so the parts follow the
+// outline ToMarkdown would render. Text before the first such heading is a
+// part of its own, without a heading, when there is any. Each part keeps the
+// document's metadata.
+func (d *Document) SplitByHeading(level int, config Config) []SubDocument {
+	normalizeDocumentHeadings(d, config)
+
+	var parts []SubDocument
+	current := SubDocument{Document: &Document{Metadata: d.Metadata}}
+	flush := func() {
+		if !current.Document.isEmpty() {
+			parts = append(parts, current)
+		}
+	}
+
+	for _, page := range d.Pages {
+		// Split points on this page, as paragraph indexes
+		var cuts []int
+		for i, para := range page.Paragraphs {
+			if para.IsHeading && para.HeadingLevel >= 1 && para.HeadingLevel <= level {
+				cuts = append(cuts, i)
+			}
+		}
+
+		start := 0
+		for _, cut := range cuts {
+			// A heading at the top of the page leaves only what is above it
+			if slice := pageSlice(page, start, cut); cut > 0 || len(slice.Tables) > 0 || len(slice.Figures) > 0 {
+				current.Document.Pages = append(current.Document.Pages, slice)
+			}
+			flush()
+
+			heading := headingText(page.Paragraphs[cut])
+			current = SubDocument{
+				Heading:  heading,
+				Level:    page.Paragraphs[cut].HeadingLevel,
+				Slug:     headingAnchor(heading),
+				Document: &Document{Metadata: d.Metadata},
+			}
+			start = cut
+		}
+		current.Document.Pages = append(current.Document.Pages, pageSlice(page, start, len(page.Paragraphs)))
+	}
+	flush()
+	return parts
+}
+
+// pageSlice returns a copy of page with paragraphs from start up to end, and
+// the tables and figures lying between the first of them and the paragraph at
+// end. start 0 takes everything above the first paragraph too, and end at
+// the last paragraph everything below.
+func pageSlice(page Page, start, end int) Page {
+	top, bottom := -1e9, 1e9
+	if start > 0 {
+		top = page.Paragraphs[start].Box.Y0
+	}
+	if end < len(page.Paragraphs) {
+		bottom = page.Paragraphs[end].Box.Y0
+	}
+	within := func(y float64) bool { return y >= top && y < bottom }
+
+	slice := page
+	slice.Paragraphs = page.Paragraphs[start:end:end]
+	slice.Tables, slice.Figures, slice.FigureAltText = nil, nil, nil
+	for _, table := range page.Tables {
+		if within(table.BBox.Top) {
+			slice.Tables = append(slice.Tables, table)
+		}
+	}
+	for i, fig := range page.Figures {
+		if within(fig.Y0) {
+			slice.Figures = append(slice.Figures, fig)
+			if i < len(page.FigureAltText) {
+				slice.FigureAltText = append(slice.FigureAltText, page.FigureAltText[i])
+			}
+		}
+	}
+	return slice
+}
+
+// isEmpty reports whether the document has no paragraphs, tables or figures.
+func (d *Document) isEmpty() bool {
+	for _, page := range d.Pages {
+		if len(page.Paragraphs) > 0 || len(page.Tables) > 0 || len(page.Figures) > 0 {
+			return false
+		}
+	}
+	return true
+}
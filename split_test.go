@@ -0,0 +1,78 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+// splitPage builds a page of paragraphs, stacked 20pt apart; texts starting
+// with "# " or "## " are headings at 18pt or 14pt
+func splitPage(number int, texts ...string) Page {
+	page := Page{Number: number}
+	for i, text := range texts {
+		para := Paragraph{Box: Rect{X0: 50, Y0: float64(i) * 20, X1: 300, Y1: float64(i)*20 + 10}}
+		size := 10.0
+		switch {
+		case len(text) > 3 && text[:3] == "## ":
+			text, size, para.IsHeading, para.HeadingLevel = text[3:], 14, true, 2
+		case len(text) > 2 && text[:2] == "# ":
+			text, size, para.IsHeading, para.HeadingLevel = text[2:], 18, true, 1
+		}
+		para.Lines = []Line{{Words: []EnrichedWord{{Text: text, FontSize: size}}}}
+		page.Paragraphs = append(page.Paragraphs, para)
+	}
+	return page
+}
+
+func TestSplitByHeading(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Title: "Manual"},
+		Pages: []Page{
+			splitPage(1, "Preface text", "# Getting Started", "Install it"),
+			splitPage(2, "## Requirements", "Go 1.25", "# Usage", "Run it"),
+		},
+	}
+	// A table below the second chapter's heading goes with it
+	doc.Pages[1].Tables = []Table{{BBox: CellBBox{Top: 70, Bottom: 90}}}
+
+	type part struct {
+		heading string
+		level   int
+		slug    string
+		pages   []int
+		texts   []string
+		tables  int
+	}
+	summarize := func(parts []SubDocument) []part {
+		var got []part
+		for _, p := range parts {
+			s := part{heading: p.Heading, level: p.Level, slug: p.Slug}
+			for _, page := range p.Document.Pages {
+				s.pages = append(s.pages, page.Number)
+				s.tables += len(page.Tables)
+				for _, para := range page.Paragraphs {
+					s.texts = append(s.texts, para.Text())
+				}
+			}
+			if p.Document.Metadata.Title != "Manual" {
+				t.Errorf("part %q lost the document metadata", p.Heading)
+			}
+			got = append(got, s)
+		}
+		return got
+	}
+
+	got := summarize(doc.SplitByHeading(1, DefaultConfig()))
+	want := []part{
+		{texts: []string{"Preface text"}, pages: []int{1}},
+		{heading: "Getting Started", level: 1, slug: "getting-started", pages: []int{1, 2}, texts: []string{"Getting Started", "Install it", "Requirements", "Go 1.25"}},
+		{heading: "Usage", level: 1, slug: "usage", pages: []int{2}, texts: []string{"Usage", "Run it"}, tables: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitByHeading(1) =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if parts := doc.SplitByHeading(2, DefaultConfig()); len(parts) != 4 || parts[2].Heading != "Requirements" {
+		t.Errorf("SplitByHeading(2) gave %d parts, want 4 with Requirements third", len(parts))
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"sort"
 )
 
-// buildParagraphs groups words into lines and paragraphs with rotation and column awareness.
-func buildParagraphs(words []EnrichedWord, pageWidth float64, config Config) []Paragraph {
+// buildTextLines groups words into lines with rotation awareness and merges
+// words split by tight kerning. The lines are built once per page and shared
+// by paragraph building and table detection.
+func buildTextLines(words []EnrichedWord, spaces spaceMetrics) []Line {
 	if len(words) == 0 {
 		return nil
 	}
@@ -55,10 +57,12 @@ func buildParagraphs(words []EnrichedWord, pageWidth float64, config Config) []P
 		}
 	}
 
-	// Merge words that are too close together within each line
+	// Merge words that are too close together within each line, then put
+	// right-to-left words in reading order
 	for bi := range textBlocks {
 		for li := range textBlocks[bi].Lines {
-			textBlocks[bi].Lines[li].Words = mergeCloseWords(textBlocks[bi].Lines[li].Words)
+			words := mergeCloseWords(textBlocks[bi].Lines[li].Words, spaces)
+			textBlocks[bi].Lines[li].Words = logicalWords(words)
 		}
 	}
 
@@ -68,23 +72,68 @@ func buildParagraphs(words []EnrichedWord, pageWidth float64, config Config) []P
 		allLines = append(allLines, block.Lines...)
 	}
 
-	// Group lines into paragraphs with adaptive spacing
-	paragraphs := groupLinesIntoParagraphsAdaptive(allLines, pageWidth)
+	return allLines
+}
+
+// buildParagraphs groups lines into paragraphs with column awareness.
+// columnRules are X positions of drawn column separators used to guide reading order,
+// and figures are image regions that text flow must not cross.
+func buildParagraphs(lines []Line, words []EnrichedWord, pageWidth float64, columnRules []float64, figures []Rect, config Config) []Paragraph {
+	if len(lines) == 0 {
+		return nil
+	}
 
-	// Detect columns for reading order
-	columns := detectColumns(words, pageWidth)
+	// Detect columns for grouping and reading order
+	columns := detectColumnsWithSeparators(words, pageWidth, columnRules)
 
-	// Determine reading order with column awareness
-	paragraphs = determineReadingOrder(paragraphs, columns)
+	// Group lines into paragraphs with adaptive spacing, column by column
+	lines = splitLinesAtColumns(lines, columns)
+	paragraphs := groupLinesByColumn(lines, columns, pageWidth, figures)
 
-	// Detect heading levels
-	detectHeadings(paragraphs, config)
+	// Determine reading order with column awareness, reading around figures
+	paragraphs = determineReadingOrderWithFigures(paragraphs, columns, figures)
+	paragraphs = joinColumnContinuations(paragraphs, columns)
 
-	// Detect lists
-	detectLists(paragraphs)
+	// Rejoin URLs and email addresses broken across lines
+	repairBrokenURLs(paragraphs, config.LinkURLs)
+	if config.NormalizeUnicode {
+		joinSoftHyphenatedLines(paragraphs)
+	}
+
+	// Break up overly long paragraphs that tight leading ran together
+	paragraphs = splitLongParagraphs(paragraphs, pageWidth, config)
+
+	// Give each marked line of a list its own item
+	paragraphs = splitListItems(paragraphs, pageWidth, config)
 
-	// Detect code blocks
+	// Summarize each paragraph's dominant font, orientation and direction
+	for i := range paragraphs {
+		paragraphs[i].Font = summarizeFont(paragraphs[i].Lines)
+		paragraphs[i].OrientedBox = orientedParagraphBox(paragraphs[i])
+		paragraphs[i].ReadingDirection = textDirection(paragraphs[i].Text())
+	}
+	measureIndents(paragraphs)
+
+	// Apply configured style rules, then detect heading levels
+	paragraphs = applyStyleRules(paragraphs, config)
+	markHeadings(paragraphs, config)
+
+	// Detect lists, rejoining items split from their hanging-indented text,
+	// and nest them by their indents and markers
+	detectLists(paragraphs, config)
+	paragraphs = joinListContinuations(paragraphs)
+	inferListLevels(paragraphs, config)
+
+	// Detect code blocks and display equations
 	detectCodeBlocks(paragraphs)
+	if config.DetectEquations {
+		detectEquations(paragraphs)
+	}
+
+	// Detect price list style rows joined by leader dots
+	if config.LeaderRows != LeaderRowsKeep {
+		detectLeaderRows(paragraphs)
+	}
 
 	return paragraphs
 }
@@ -227,7 +276,7 @@ func groupWordsIntoLinesBaseline(words []EnrichedWord) []Line {
 }
 
 // groupLinesIntoParagraphsAdaptive groups lines into paragraphs using adaptive spacing
-func groupLinesIntoParagraphsAdaptive(lines []Line, pageWidth float64) []Paragraph {
+func groupLinesIntoParagraphsAdaptive(lines []Line, pageWidth float64, figures []Rect) []Paragraph {
 	if len(lines) == 0 {
 		return nil
 	}
@@ -259,7 +308,10 @@ func groupLinesIntoParagraphsAdaptive(lines []Line, pageWidth float64) []Paragra
 			// Use adaptive threshold
 			normalizedGap := lineGap / avgFontSize
 
-			if normalizedGap > threshold || significantFontChange {
+			// A figure between the lines ends the paragraph regardless of spacing
+			separatedByFigure := figureBetween(currentPara[len(currentPara)-1], line, figures)
+
+			if normalizedGap > threshold || significantFontChange || separatedByFigure {
 				// End current paragraph, start new one
 				paragraphs = append(paragraphs, Paragraph{
 					Lines:     currentPara,
@@ -468,16 +520,14 @@ func detectHeadings(paragraphs []Paragraph, config Config) {
 		if len(para.Lines) == 0 || len(para.Lines[0].Words) == 0 {
 			continue
 		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
 
 		line := para.Lines[0]
 
 		// Get the maximum font size in the first line
-		var maxFontSize float64
-		for _, word := range line.Words {
-			if word.FontSize > maxFontSize {
-				maxFontSize = word.FontSize
-			}
-		}
+		maxFontSize := lineMaxFontSize(line)
 
 		// For multi-line paragraphs, check if first line is a potential subsection heading
 		// (larger than the rest of the paragraph content)
@@ -536,17 +586,15 @@ func detectHeadings(paragraphs []Paragraph, config Config) {
 		if len(para.Lines) == 0 || len(para.Lines[0].Words) == 0 {
 			continue
 		}
+		if _, pinned := config.styleRule(para.Font); pinned {
+			continue
+		}
 
 		// For multi-line paragraphs, check if the first line is a subsection heading
 		// (larger font than the rest of the paragraph)
 		if len(para.Lines) > 1 {
 			// Get font size of first line
-			var firstLineMaxSize float64
-			for _, word := range para.Lines[0].Words {
-				if word.FontSize > firstLineMaxSize {
-					firstLineMaxSize = word.FontSize
-				}
-			}
+			firstLineMaxSize := lineMaxFontSize(para.Lines[0])
 
 			// Get average font size of remaining lines
 			var totalSize float64
@@ -580,12 +628,7 @@ func detectHeadings(paragraphs []Paragraph, config Config) {
 		line := para.Lines[0]
 
 		// Get maximum font size in line
-		var maxFontSize float64
-		for _, word := range line.Words {
-			if word.FontSize > maxFontSize {
-				maxFontSize = word.FontSize
-			}
-		}
+		maxFontSize := lineMaxFontSize(line)
 
 		// Check if this line is a heading based on font size
 		if level, isHeading := sizeToLevel[maxFontSize]; isHeading {
@@ -608,10 +651,18 @@ func detectHeadings(paragraphs []Paragraph, config Config) {
 			}
 		}
 	}
+
+	// Centered and colored titles at body size rank below every size-based heading
+	detectCenteredHeadings(paragraphs, min(len(headingSizes)+1, 6), config)
+	detectColoredHeadings(paragraphs, bodyFontSize, min(len(headingSizes)+1, 6), config)
 }
 
-// detectLists identifies paragraphs that are list items.
-func detectLists(paragraphs []Paragraph) {
+// detectLists identifies paragraphs that are list items, by the built-in
+// bullets and numbers, Config.ListMarkers, or sequences of roman numerals or
+// letters. A number following a paragraph that ends in an abbreviation ("see
+// Schedule No." / "3. ...") continues that sentence rather than starting a
+// numbered item.
+func detectLists(paragraphs []Paragraph, config Config) {
 	for i := range paragraphs {
 		para := &paragraphs[i]
 
@@ -620,11 +671,22 @@ func detectLists(paragraphs []Paragraph) {
 			continue
 		}
 
-		firstWord := para.Lines[0].Words[0]
-		if firstWord.IsBulletOrNumber() {
-			para.IsList = true
+		marker, level, ok := config.listMarker(para.Lines[0])
+		if !ok {
+			continue
+		}
+		if i > 0 && isDigit([]rune(marker)[0]) && len(paragraphs[i-1].Lines) > 0 {
+			prevLines := paragraphs[i-1].Lines
+			if config.endsWithAbbreviation(prevLines[len(prevLines)-1]) {
+				continue
+			}
 		}
+		para.IsList = true
+		para.ListMarker = marker
+		para.ListLevel = level
 	}
+
+	detectOrdinalLists(paragraphs)
 }
 
 // detectCodeBlocks identifies paragraphs that are code blocks.
@@ -708,14 +770,14 @@ func stdDev(values []float64) float64 {
 
 // mergeCloseWords merges words that are very close together horizontally.
 // This handles PDFs with inconsistent spacing where words are split incorrectly.
-// Words with gaps < 2.0 pixels are merged together (except punctuation).
-func mergeCloseWords(words []EnrichedWord) []EnrichedWord {
-	if len(words) <= 1 {
+// Words with gaps under half a measured space (2.0 pixels when the page has no
+// measurable spaces) are merged together (except punctuation).
+func mergeCloseWords(words []EnrichedWord, spaces spaceMetrics) []EnrichedWord {
+	if len(words) <= 1 || spaces.splitGap > 0 {
+		// Text without spaces was split into words deliberately
 		return words
 	}
 
-	const gapThreshold = 2.0 // pixels
-
 	var merged []EnrichedWord
 	var currentMerge []EnrichedWord
 
@@ -739,6 +801,7 @@ func mergeCloseWords(words []EnrichedWord) []EnrichedWord {
 		}
 
 		// Merge if gap is small and not punctuation
+		gapThreshold := spaces.mergeGapThreshold(word.FontSize)
 		if gap < gapThreshold && !isPunctuation {
 			currentMerge = append(currentMerge, word)
 		} else {
@@ -773,9 +836,9 @@ func mergeWordGroup(words []EnrichedWord) EnrichedWord {
 		return words[0]
 	}
 
-	// Concatenate text
+	// Concatenate text in reading order
 	var text string
-	for _, word := range words {
+	for _, word := range logicalWords(words) {
 		text += word.Text
 	}
 
@@ -795,10 +858,17 @@ func mergeWordGroup(words []EnrichedWord) EnrichedWord {
 		FontSize:    words[0].FontSize,
 		FontWeight:  words[0].FontWeight,
 		FontName:    words[0].FontName,
+		Font:        words[0].Font,
 		FontFlags:   words[0].FontFlags,
 		FillColor:   words[0].FillColor,
 		IsBold:      words[0].IsBold,
 		IsItalic:    words[0].IsItalic,
 		IsMonospace: words[0].IsMonospace,
+		Baseline:    words[0].Baseline,
+
+		IsStrikethrough: words[0].IsStrikethrough,
+		IsUnderline:     words[0].IsUnderline,
+
+		TrailingHyphen: words[len(words)-1].TrailingHyphen,
 	}
 }
@@ -0,0 +1,95 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// StyleRule pins how text in a matching style is classified, so a recurring
+// document template can be handled without relying on heading heuristics.
+// Rules are matched against a paragraph's dominant font (see
+// Document.StyleCatalog for the styles a document uses). Empty conditions
+// match anything.
+type StyleRule struct {
+	// FontContains matches font names containing this text, ignoring case
+	FontContains string
+
+	// Size matches font sizes: "18" (within 0.5pt), ">=18", ">18", "<=9" or "<9"
+	Size string
+
+	// HeadingLevel makes matching paragraphs headings of this level (1-6).
+	// Zero keeps them as body text, never headings
+	HeadingLevel int
+
+	// Ignore drops matching paragraphs from the output
+	Ignore bool
+}
+
+// matches reports whether a font satisfies the rule's conditions. A Size that
+// cannot be parsed matches nothing.
+func (r StyleRule) matches(font FontSummary) bool {
+	if r.FontContains != "" && !strings.Contains(strings.ToLower(font.Name), strings.ToLower(r.FontContains)) {
+		return false
+	}
+	if r.Size == "" {
+		return true
+	}
+
+	const sizeTolerance = 0.5
+
+	condition := strings.TrimSpace(r.Size)
+	op := strings.TrimRight(condition[:min(2, len(condition))], "0123456789. ")
+	size, err := strconv.ParseFloat(strings.TrimSpace(condition[len(op):]), 64)
+	if err != nil {
+		return false
+	}
+	switch op {
+	case "", "=":
+		return math.Abs(font.Size-size) < sizeTolerance
+	case ">=":
+		return font.Size >= size
+	case ">":
+		return font.Size > size
+	case "<=":
+		return font.Size <= size
+	case "<":
+		return font.Size < size
+	}
+	return false
+}
+
+// styleRule returns the first of the configured rules that matches font.
+func (c Config) styleRule(font FontSummary) (StyleRule, bool) {
+	for _, rule := range c.StyleRules {
+		if rule.matches(font) {
+			return rule, true
+		}
+	}
+	return StyleRule{}, false
+}
+
+// applyStyleRules drops paragraphs matched by Ignore rules and marks those
+// matched by heading rules, ahead of heuristic heading detection, which
+// leaves every matched paragraph alone.
+func applyStyleRules(paragraphs []Paragraph, config Config) []Paragraph {
+	if len(config.StyleRules) == 0 {
+		return paragraphs
+	}
+
+	kept := paragraphs[:0]
+	for _, para := range paragraphs {
+		rule, ok := config.styleRule(para.Font)
+		if ok && rule.Ignore {
+			continue
+		}
+		if ok {
+			para.IsHeading, para.HeadingLevel = false, 0
+			if rule.HeadingLevel > 0 {
+				para.IsHeading, para.HeadingLevel = true, config.clampHeadingLevel(rule.HeadingLevel)
+			}
+		}
+		kept = append(kept, para)
+	}
+	return kept
+}
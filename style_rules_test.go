@@ -0,0 +1,78 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStyleRuleMatches(t *testing.T) {
+	font := FontSummary{Name: "ABCDEF+Museo-700", Size: 18}
+
+	tests := []struct {
+		name string
+		rule StyleRule
+		want bool
+	}{
+		{"empty rule", StyleRule{}, true},
+		{"font substring ignores case", StyleRule{FontContains: "museo"}, true},
+		{"other font", StyleRule{FontContains: "Helvetica"}, false},
+		{"exact size", StyleRule{Size: "18"}, true},
+		{"size within tolerance", StyleRule{Size: "=18.3"}, true},
+		{"size outside tolerance", StyleRule{Size: "17"}, false},
+		{"at least", StyleRule{Size: ">=18"}, true},
+		{"greater than", StyleRule{Size: "> 18"}, false},
+		{"at most", StyleRule{Size: "<=18"}, true},
+		{"less than", StyleRule{Size: "<20"}, true},
+		{"font and size", StyleRule{FontContains: "Museo", Size: ">=18"}, true},
+		{"font matches, size does not", StyleRule{FontContains: "Museo", Size: "<12"}, false},
+		{"unparseable size", StyleRule{Size: "big"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.matches(font); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyleRules(t *testing.T) {
+	para := func(text, font string, size float64) Paragraph {
+		p := Paragraph{Lines: []Line{styledLine(text, font, size, 400)}}
+		p.Font = summarizeFont(p.Lines)
+		return p
+	}
+	page := func() []Paragraph {
+		return []Paragraph{
+			para("ACME Template Co", "Museo", 20),
+			para("Introduction", "Helvetica", 16),
+			para("Body text that runs on.", "Times", 10),
+			para("Body text continues here.", "Times", 10),
+			para("Confidential", "Courier", 8),
+		}
+	}
+
+	config := DefaultConfig()
+	config.IncludePageBreaks = false
+	config.CollapseWhitespace = true
+
+	heuristic := &Document{Pages: []Page{{Paragraphs: page()}}}
+	detectHeadings(heuristic.Pages[0].Paragraphs, config)
+	if got := heuristic.ToMarkdown(config); !strings.HasPrefix(got, "# ACME Template Co\n\n## Introduction\n") {
+		t.Fatalf("heuristic headings not as expected:\n%s", got)
+	}
+
+	config.StyleRules = []StyleRule{
+		{FontContains: "museo", Size: ">=18", HeadingLevel: 3},
+		{FontContains: "Courier", Ignore: true},
+		{FontContains: "Helvetica"}, // Body text, never a heading
+	}
+	pinned := &Document{Pages: []Page{{Paragraphs: applyStyleRules(page(), config)}}}
+	detectHeadings(pinned.Pages[0].Paragraphs, config)
+
+	want := "### ACME Template Co\n\nIntroduction\n\nBody text that runs on.\n\nBody text continues here.\n"
+	if got := pinned.ToMarkdown(config); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
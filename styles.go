@@ -0,0 +1,150 @@
+package pdfmarkdown
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// StyleEntry describes one distinct text style found in a document: a font,
+// size, weight and color combination, how much text uses it, and the role
+// classification gave that text. Reading the catalog shows why text was or
+// wasn't taken for a heading, and which sizes to list in Config.HeadingLevels.
+type StyleEntry struct {
+	Family     string   // Font family with subset prefix and style suffix removed
+	Name       string   // Font name as reported by the PDF, without its subset tag
+	Size       float64  // Font size in points, rounded to 0.1pt
+	Weight     int      // Font weight (400 normal, 700 bold)
+	Color      RGBA     // Fill color
+	Characters int      // Characters set in this style
+	Words      int      // Words set in this style
+	Role       string   // Role of most text in this style: "body", "h1"-"h6", "list", "code", "quote", "equation" or "leader"
+	Examples   []string // Up to maxStyleExamples snippets of text in this style
+}
+
+const (
+	maxStyleExamples      = 3
+	maxStyleExampleLength = 60 // runes
+)
+
+// styleKey identifies a style in the catalog.
+type styleKey struct {
+	name   string
+	size   float64
+	weight int
+	color  RGBA
+}
+
+// StyleCatalog lists every distinct style in the document, most used first.
+// Heading levels are normalized with config first, so roles match the levels
+// ToMarkdown would render.
+func (d *Document) StyleCatalog(config Config) []StyleEntry {
+	normalizeDocumentHeadings(d, config)
+
+	entries := make(map[styleKey]*StyleEntry)
+	roles := make(map[styleKey]map[string]int)
+
+	for _, page := range d.Pages {
+		for _, para := range page.Paragraphs {
+			role := paragraphRole(para)
+
+			// Consecutive words in one style form a run, used as an example
+			var run []string
+			var runKey styleKey
+			flush := func() {
+				if len(run) == 0 {
+					return
+				}
+				entry := entries[runKey]
+				if len(entry.Examples) < maxStyleExamples {
+					example := truncateText(strings.Join(run, " "), maxStyleExampleLength)
+					if !slices.Contains(entry.Examples, example) {
+						entry.Examples = append(entry.Examples, example)
+					}
+				}
+				run = nil
+			}
+
+			for _, line := range para.Lines {
+				for _, word := range line.Words {
+					key := styleKey{
+						name:   parseFontName(word.FontName).Name,
+						size:   math.Round(word.FontSize*10) / 10,
+						weight: word.FontWeight,
+						color:  word.FillColor,
+					}
+					entry, ok := entries[key]
+					if !ok {
+						entry = &StyleEntry{
+							Family: fontFamily(key.name),
+							Name:   key.name,
+							Size:   key.size,
+							Weight: key.weight,
+							Color:  key.color,
+						}
+						entries[key] = entry
+						roles[key] = make(map[string]int)
+					}
+
+					n := utf8.RuneCountInString(word.Text)
+					entry.Characters += n
+					entry.Words++
+					roles[key][role] += n
+
+					if len(run) > 0 && key != runKey {
+						flush()
+					}
+					run = append(run, word.Text)
+					runKey = key
+				}
+			}
+			flush()
+		}
+	}
+
+	catalog := make([]StyleEntry, 0, len(entries))
+	for key, entry := range entries {
+		entry.Role = dominant(roles[key])
+		catalog = append(catalog, *entry)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		if catalog[i].Characters != catalog[j].Characters {
+			return catalog[i].Characters > catalog[j].Characters
+		}
+		return fmt.Sprint(catalog[i]) < fmt.Sprint(catalog[j])
+	})
+	return catalog
+}
+
+// paragraphRole names the role classification gave a paragraph.
+func paragraphRole(para Paragraph) string {
+	switch {
+	case para.IsHeading:
+		return fmt.Sprintf("h%d", para.HeadingLevel)
+	case len(para.Leaders) > 0:
+		return "leader"
+	case para.IsCode:
+		return "code"
+	case para.IsList:
+		return "list"
+	case para.IsQuote:
+		return "quote"
+	case para.IsEquation:
+		return "equation"
+	default:
+		return "body"
+	}
+}
+
+// truncateText shortens s to at most n grapheme clusters, marking the cut
+// with an ellipsis.
+func truncateText(s string, n int) string {
+	clusters := graphemeClusters(s)
+	if len(clusters) <= n {
+		return s
+	}
+	return strings.TrimSpace(strings.Join(clusters[:n-1], "")) + "…"
+}
@@ -0,0 +1,88 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// styledLine builds a line of words sharing one font, size and weight.
+func styledLine(text, font string, size float64, weight int) Line {
+	var line Line
+	for _, word := range strings.Fields(text) {
+		line.Words = append(line.Words, EnrichedWord{Text: word, FontName: font, FontSize: size, FontWeight: weight})
+	}
+	return line
+}
+
+func TestStyleCatalog(t *testing.T) {
+	doc := &Document{Pages: []Page{{Paragraphs: []Paragraph{
+		{IsHeading: true, HeadingLevel: 2, Lines: []Line{styledLine("Annual Report", "ABCDEF+Helvetica-Bold", 18, 700)}},
+		{Lines: []Line{
+			styledLine("Revenue grew strongly", "Times-Roman", 10, 400),
+			styledLine("over the year.", "Times-Roman", 10.02, 400),
+		}},
+		{IsList: true, Lines: []Line{styledLine("• Widgets", "Times-Roman", 10, 400)}},
+		{Lines: []Line{styledLine("Figure 1", "Times-Italic", 8, 400)}},
+	}}}}
+
+	got := doc.StyleCatalog(DefaultConfig())
+
+	want := []StyleEntry{
+		{
+			Family: "Times", Name: "Times-Roman", Size: 10, Weight: 400,
+			Characters: 39, Words: 8, Role: "body",
+			Examples: []string{"Revenue grew strongly over the year.", "• Widgets"},
+		},
+		{
+			Family: "Helvetica", Name: "Helvetica-Bold", Size: 18, Weight: 700,
+			Characters: 12, Words: 2, Role: "h1",
+			Examples: []string{"Annual Report"},
+		},
+		{
+			Family: "Times", Name: "Times-Italic", Size: 8, Weight: 400,
+			Characters: 7, Words: 2, Role: "body",
+			Examples: []string{"Figure 1"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StyleCatalog() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestStyleCatalog_Examples(t *testing.T) {
+	long := strings.Repeat("word ", 20)
+	var paragraphs []Paragraph
+	for _, text := range []string{"first", "first", long, "third", "fourth"} {
+		paragraphs = append(paragraphs, Paragraph{Lines: []Line{styledLine(text, "Body", 10, 400)}})
+	}
+	doc := &Document{Pages: []Page{{Paragraphs: paragraphs}}}
+
+	got := doc.StyleCatalog(DefaultConfig())[0].Examples
+	want := []string{"first", strings.Repeat("word ", 11) + "word…", "third"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Examples = %q, want %q", got, want)
+	}
+}
+
+func TestParagraphRole(t *testing.T) {
+	tests := []struct {
+		name string
+		para Paragraph
+		want string
+	}{
+		{"body", Paragraph{}, "body"},
+		{"heading", Paragraph{IsHeading: true, HeadingLevel: 3}, "h3"},
+		{"list", Paragraph{IsList: true}, "list"},
+		{"code", Paragraph{IsCode: true}, "code"},
+		{"leader", Paragraph{Leaders: []LeaderRow{{}}}, "leader"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paragraphRole(tt.para); got != tt.want {
+				t.Errorf("paragraphRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
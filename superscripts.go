@@ -0,0 +1,81 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// superscriptMarks are the symbols set small and raised after a word:
+// trademark and copyright signs, footnote daggers and superscript digits.
+const superscriptMarks = "®™©℠†‡⁰¹²³⁴⁵⁶⁷⁸⁹⁺⁻ⁿ"
+
+// superscriptMaxRunes is the longest raised run, such as a footnote number,
+// attached to the word before it.
+const superscriptMaxRunes = 3
+
+// isSuperscriptMark reports whether r is one of superscriptMarks.
+func isSuperscriptMark(r rune) bool {
+	return strings.ContainsRune(superscriptMarks, r)
+}
+
+// isSuperscriptOf reports whether mark is a superscript set directly after
+// base: either a word of superscriptMarks, or a short run set at most three
+// quarters of base's size with its bottom raised above base's. Either way it
+// must start within a third of an em of base's end and not reach below it.
+func isSuperscriptOf(mark, base EnrichedWord) bool {
+	size := base.FontSize
+	if size <= 0 || mark.Text == "" || utf8.RuneCountInString(mark.Text) > superscriptMaxRunes {
+		return false
+	}
+	if mark.Rotation != base.Rotation {
+		return false
+	}
+	if gap := mark.Box.X0 - base.Box.X1; gap < -0.1*size || gap > 0.3*size {
+		return false
+	}
+	if mark.Box.Y1 > base.Box.Y1+0.1*size || mark.Box.Y0 < base.Box.Y0-0.5*size {
+		return false
+	}
+
+	if !strings.ContainsFunc(mark.Text, func(r rune) bool { return !isSuperscriptMark(r) }) {
+		return true
+	}
+	return mark.FontSize <= size*0.75 && mark.Box.Y1 < base.Box.Y1-0.2*size
+}
+
+// attachSuperscripts joins superscripts such as "®" or a footnote number,
+// which extraction reads as separate words in a smaller size, onto the end of
+// the word before them: "Acme" "®" becomes "Acme®". The joined word keeps the
+// base word's size and baseline, so a title with a trademark sign keeps one
+// font size and stays on one line.
+func attachSuperscripts(words []EnrichedWord) []EnrichedWord {
+	result := make([]EnrichedWord, 0, len(words))
+	for _, word := range words {
+		if n := len(result); n > 0 && isSuperscriptOf(word, result[n-1]) {
+			base := &result[n-1]
+			base.Text += word.Text
+			base.Box.X1 = max(base.Box.X1, word.Box.X1)
+			continue
+		}
+		result = append(result, word)
+	}
+	return result
+}
+
+// lineMaxFontSize returns the largest font size among a line's words,
+// ignoring words made only of superscriptMarks unless nothing else is left.
+// Headings are ranked by this size.
+func lineMaxFontSize(line Line) float64 {
+	var size, marks float64
+	for _, word := range line.Words {
+		if word.Text != "" && !strings.ContainsFunc(word.Text, func(r rune) bool { return !isSuperscriptMark(r) }) {
+			marks = max(marks, word.FontSize)
+			continue
+		}
+		size = max(size, word.FontSize)
+	}
+	if size == 0 {
+		return marks
+	}
+	return size
+}
@@ -0,0 +1,141 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAttachSuperscripts(t *testing.T) {
+	// placed returns a word of the given size with its bottom at y
+	placed := func(text string, x0, x1, y, size float64) EnrichedWord {
+		return EnrichedWord{Text: text, FontSize: size, Box: Rect{X0: x0, Y0: y - size, X1: x1, Y1: y}}
+	}
+
+	tests := []struct {
+		name  string
+		words []EnrichedWord
+		want  []string
+	}{
+		{
+			name:  "registered sign after title word",
+			words: []EnrichedWord{placed("Acme", 72, 116, 100, 24), placed("®", 117, 124, 92, 12), placed("Widgets", 130, 210, 100, 24)},
+			want:  []string{"Acme®", "Widgets"},
+		},
+		{
+			name:  "trademark sign at full size",
+			words: []EnrichedWord{placed("Acme", 72, 116, 100, 24), placed("™", 116, 130, 100, 24)},
+			want:  []string{"Acme™"},
+		},
+		{
+			name:  "raised footnote number",
+			words: []EnrichedWord{placed("revenue", 72, 110, 100, 10), placed("12", 110.5, 118, 96, 6)},
+			want:  []string{"revenue12"},
+		},
+		{
+			name:  "small number on the baseline stays separate",
+			words: []EnrichedWord{placed("Table", 72, 100, 100, 10), placed("3", 101, 105, 100, 6)},
+			want:  []string{"Table", "3"},
+		},
+		{
+			name:  "sign after a word space stays separate",
+			words: []EnrichedWord{placed("Acme", 72, 116, 100, 24), placed("®", 126, 133, 92, 12)},
+			want:  []string{"Acme", "®"},
+		},
+		{
+			name:  "sign on the next line stays separate",
+			words: []EnrichedWord{placed("Acme", 72, 116, 100, 24), placed("®", 116, 123, 130, 12)},
+			want:  []string{"Acme", "®"},
+		},
+		{
+			name:  "long raised text is not a superscript",
+			words: []EnrichedWord{placed("see", 72, 90, 100, 10), placed("note", 90.5, 104, 96, 6)},
+			want:  []string{"see", "note"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, word := range attachSuperscripts(tt.words) {
+				got = append(got, word.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attachSuperscripts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The joined word keeps the base word's size and vertical extent
+	joined := attachSuperscripts([]EnrichedWord{placed("Acme", 72, 116, 100, 24), placed("®", 117, 124, 92, 12)})[0]
+	if joined.FontSize != 24 || joined.Box != (Rect{X0: 72, Y0: 76, X1: 124, Y1: 100}) {
+		t.Errorf("joined word = size %v box %+v", joined.FontSize, joined.Box)
+	}
+}
+
+func TestLineMaxFontSize(t *testing.T) {
+	sized := func(text string, size float64) EnrichedWord { return EnrichedWord{Text: text, FontSize: size} }
+
+	tests := []struct {
+		name  string
+		words []EnrichedWord
+		want  float64
+	}{
+		{name: "largest word", words: []EnrichedWord{sized("Annual", 18), sized("Report", 24)}, want: 24},
+		{name: "oversized sign ignored", words: []EnrichedWord{sized("Acme", 16), sized("®", 20)}, want: 16},
+		{name: "only signs", words: []EnrichedWord{sized("©", 8), sized("™", 9)}, want: 9},
+		{name: "empty line", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineMaxFontSize(Line{Words: tt.words}); got != tt.want {
+				t.Errorf("lineMaxFontSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSuperscriptInHeading checks a raised "®" after a heading word is kept
+// with the heading rather than lowering its level or splitting it up
+func TestSuperscriptInHeading(t *testing.T) {
+	// line places words left to right from x at the given baseline
+	var words []EnrichedWord
+	line := func(x, baseline, size float64, bold bool, texts ...string) {
+		for _, text := range texts {
+			width := float64(len(text)) * size * 0.55
+			words = append(words, EnrichedWord{
+				Text:     text,
+				Box:      Rect{X0: x, Y0: baseline - size*0.75, X1: x + width, Y1: baseline + size*0.2},
+				FontSize: size,
+				IsBold:   bold,
+				Baseline: baseline,
+				XHeight:  size * 0.5,
+			})
+			x += width + size*0.28
+		}
+	}
+	line(72, 90, 24, true, "Annual", "Report")
+	line(72, 130, 16, true, "Acme")
+	line(72+4*16*0.55, 124, 8, true, "®")
+	line(72, 160, 11, false, "Our", "widgets", "are", "trusted", "by", "teams", "everywhere.")
+	line(72, 200, 16, true, "Overview")
+	line(72, 230, 11, false, "Sales", "rose", "in", "every", "region", "this", "year.")
+
+	words = attachSuperscripts(words)
+	lines := buildTextLines(words, spaceMetrics{})
+	config := DefaultConfig()
+	doc := &Document{Pages: []Page{{
+		Number:     1,
+		Width:      612,
+		Height:     792,
+		Paragraphs: buildParagraphs(lines, words, 612, nil, nil, config),
+	}}}
+
+	got := doc.ToMarkdown(config)
+	for _, want := range []string{"# Annual Report\n", "## Acme®\n", "## Overview\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// vulgarFractions spells out the precomposed fraction characters. NFKC would
+// turn "3½" into "31⁄2", so these are replaced before it runs.
+var vulgarFractions = map[rune]string{
+	'½': "1/2", '⅓': "1/3", '⅔': "2/3", '¼': "1/4", '¾': "3/4",
+	'⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5", '⅙': "1/6",
+	'⅚': "5/6", '⅐': "1/7", '⅛': "1/8", '⅜': "3/8", '⅝': "5/8",
+	'⅞': "7/8", '⅑': "1/9", '⅒': "1/10", '↉': "0/3",
+}
+
+// normalizeSymbols rewrites fraction and unit glyphs that NFKC garbles or
+// leaves awkward: vulgar fractions become "n/d", set off from a preceding whole
+// number by a space, the fraction slash becomes "/", and a masculine ordinal
+// or ring above after a digit, both used as degree signs, becomes "°". The
+// micro, ohm and degree Celsius signs are left to NFKC, which folds them to
+// μ, Ω and °C.
+func normalizeSymbols(text string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range text {
+		switch {
+		case vulgarFractions[r] != "":
+			if unicode.IsDigit(prev) {
+				b.WriteByte(' ')
+			}
+			b.WriteString(vulgarFractions[r])
+		case r == '⁄': // Fraction slash
+			b.WriteByte('/')
+		case (r == 'º' || r == '˚') && unicode.IsDigit(prev):
+			b.WriteRune('°')
+		default:
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// stackedFractionMaxDigits is the longest numerator or denominator joined
+// into a stacked fraction.
+const stackedFractionMaxDigits = 3
+
+// joinStackedFractions joins fractions typeset as a small numerator stacked
+// over a small denominator, which extraction otherwise splits across two
+// lines, into single "n/d" words. Both parts must be short runs of digits set
+// smaller than the page's body text, overlap horizontally and sit one
+// directly above the other.
+func joinStackedFractions(words []EnrichedWord) []EnrichedWord {
+	sizes := make([]float64, 0, len(words))
+	for _, word := range words {
+		if word.FontSize > 0 {
+			sizes = append(sizes, word.FontSize)
+		}
+	}
+	if len(sizes) == 0 {
+		return words
+	}
+	sort.Float64s(sizes)
+	bodySize := sizes[len(sizes)/2]
+
+	isPart := func(word EnrichedWord) bool {
+		if word.FontSize <= 0 || word.FontSize > bodySize*0.85 || len(word.Text) > stackedFractionMaxDigits {
+			return false
+		}
+		for _, r := range word.Text {
+			if !unicode.IsDigit(r) {
+				return false
+			}
+		}
+		return word.Text != ""
+	}
+
+	used := make([]bool, len(words))
+	result := make([]EnrichedWord, 0, len(words))
+	for i, num := range words {
+		if used[i] {
+			continue
+		}
+		if isPart(num) {
+			for j, den := range words {
+				if j == i || used[j] || !isPart(den) || !isStackedOver(num.Box, den.Box, math.Max(num.FontSize, den.FontSize)) {
+					continue
+				}
+				fraction := den
+				fraction.Text = num.Text + "/" + den.Text
+				fraction.Box = mergeRects(num.Box, den.Box)
+				num, used[j] = fraction, true
+				break
+			}
+		}
+		used[i] = true
+		result = append(result, num)
+	}
+	return result
+}
+
+// isStackedOver reports whether box top sits directly above box bottom,
+// overlapping it by at least half the narrower width.
+func isStackedOver(top, bottom Rect, fontSize float64) bool {
+	overlap := math.Min(top.X1, bottom.X1) - math.Max(top.X0, bottom.X0)
+	if overlap < math.Min(top.Width(), bottom.Width())/2 {
+		return false
+	}
+	gap := bottom.Y0 - top.Y1
+	return gap >= -fontSize*0.2 && gap <= fontSize*0.5
+}
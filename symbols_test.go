@@ -0,0 +1,89 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestNormalizeWords_Symbols(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"½", "1/2"},
+		{"3½", "3 1/2"},
+		{"⅞in", "7/8in"},
+		{"1⁄4", "1/4"},
+		{"10µF", "10μF"},
+		{"4.7kΩ", "4.7kΩ"},
+		{"37℃", "37°C"},
+		{"25º", "25°"},
+		{"90˚", "90°"},
+		{"Nº", "No"}, // Ordinal indicator after a letter is left to NFKC
+		{"10m²", "10m²"},
+		{"H₂O", "H₂O"},
+		{"x¹⁰", "x¹⁰"},
+		{"Acme™", "Acme™"},
+		{"ﬁle²", "file²"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := normalizeWords([]EnrichedWord{{Text: tt.text}})
+			if len(got) != 1 || got[0].Text != tt.want {
+				t.Errorf("normalizeWords(%q) = %v, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinStackedFractions(t *testing.T) {
+	body := func(text string, x0 float64) EnrichedWord {
+		return EnrichedWord{Text: text, FontSize: 10, Box: Rect{X0: x0, Y0: 100, X1: x0 + float64(len(text))*5, Y1: 110}}
+	}
+	small := func(text string, x0, y0 float64) EnrichedWord {
+		return EnrichedWord{Text: text, FontSize: 6, Box: Rect{X0: x0, Y0: y0, X1: x0 + float64(len(text))*3, Y1: y0 + 6}}
+	}
+
+	tests := []struct {
+		name  string
+		words []EnrichedWord
+		want  []string
+	}{
+		{
+			name:  "stacked fraction after whole number",
+			words: []EnrichedWord{body("Add", 0), body("3", 20), small("1", 27, 98), small("2", 27, 105), body("cups", 40), body("of", 65), body("flour", 80)},
+			want:  []string{"Add", "3", "1/2", "cups", "of", "flour"},
+		},
+		{
+			name:  "body size digits on separate lines",
+			words: []EnrichedWord{body("12", 0), {Text: "34", FontSize: 10, Box: Rect{X0: 0, Y0: 111, X1: 10, Y1: 121}}, body("text", 20)},
+			want:  []string{"12", "34", "text"},
+		},
+		{
+			name:  "small digits side by side",
+			words: []EnrichedWord{body("Note", 0), small("1", 30, 98), small("2", 40, 98), body("text", 50), body("here", 80)},
+			want:  []string{"Note", "1", "2", "text", "here"},
+		},
+		{
+			name:  "small letters stacked",
+			words: []EnrichedWord{body("See", 0), small("a", 27, 98), small("b", 27, 105), body("more", 40), body("text", 70)},
+			want:  []string{"See", "a", "b", "more", "text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinStackedFractions(tt.words)
+			var texts []string
+			for _, word := range got {
+				texts = append(texts, word.Text)
+			}
+			if len(texts) != len(tt.want) {
+				t.Fatalf("words = %q, want %q", texts, tt.want)
+			}
+			for i := range tt.want {
+				if texts[i] != tt.want[i] {
+					t.Fatalf("words = %q, want %q", texts, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package pdfmarkdown_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticPDF builds PDFs with pdfium's editing API, so tests can check the
+// converter against documents whose structure is known exactly. Coordinates
+// are in points from the top-left of the page, matching extracted text.
+type syntheticPDF struct {
+	t        testing.TB
+	instance pdfium.Pdfium
+	doc      references.FPDF_DOCUMENT
+	pages    []*syntheticPage
+}
+
+// syntheticPage is a page being drawn in a syntheticPDF.
+type syntheticPage struct {
+	pdf    *syntheticPDF
+	page   references.FPDF_PAGE
+	height float64
+}
+
+func newSyntheticPDF(t testing.TB, instance pdfium.Pdfium) *syntheticPDF {
+	t.Helper()
+
+	doc, err := instance.FPDF_CreateNewDocument(&requests.FPDF_CreateNewDocument{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{Document: doc.Document})
+	})
+
+	return &syntheticPDF{t: t, instance: instance, doc: doc.Document}
+}
+
+// addPage appends a US Letter page.
+func (s *syntheticPDF) addPage() *syntheticPage {
+	s.t.Helper()
+
+	const width, height = 612, 792
+	page, err := s.instance.FPDFPage_New(&requests.FPDFPage_New{
+		Document:  s.doc,
+		PageIndex: len(s.pages),
+		Width:     width,
+		Height:    height,
+	})
+	require.NoError(s.t, err)
+
+	p := &syntheticPage{pdf: s, page: page.Page, height: height}
+	s.pages = append(s.pages, p)
+	return p
+}
+
+// bytes writes out the document.
+func (s *syntheticPDF) bytes() []byte {
+	s.t.Helper()
+
+	for _, p := range s.pages {
+		_, err := s.instance.FPDFPage_GenerateContent(&requests.FPDFPage_GenerateContent{
+			Page: requests.Page{ByReference: &p.page},
+		})
+		require.NoError(s.t, err)
+	}
+
+	saved, err := s.instance.FPDF_SaveAsCopy(&requests.FPDF_SaveAsCopy{Document: s.doc})
+	require.NoError(s.t, err)
+	return *saved.FileBytes
+}
+
+// text draws a run of text in one of the standard 14 fonts, with its baseline
+// starting at (x, baseline).
+func (p *syntheticPage) text(x, baseline float64, font string, size float64, text string) {
+	p.rotatedText(x, baseline, font, size, 0, text)
+}
+
+// rotatedText draws text turned counterclockwise by angle degrees about the
+// start of its baseline.
+func (p *syntheticPage) rotatedText(x, baseline float64, font string, size, angle float64, text string) {
+	t, instance := p.pdf.t, p.pdf.instance
+	t.Helper()
+
+	obj, err := instance.FPDFPageObj_NewTextObj(&requests.FPDFPageObj_NewTextObj{
+		Document: p.pdf.doc,
+		Font:     font,
+		FontSize: float32(size),
+	})
+	require.NoError(t, err)
+
+	_, err = instance.FPDFText_SetText(&requests.FPDFText_SetText{PageObject: obj.PageObject, Text: text})
+	require.NoError(t, err)
+
+	rad := angle * math.Pi / 180
+	cos, sin := float32(math.Cos(rad)), float32(math.Sin(rad))
+	_, err = instance.FPDFPageObj_Transform(&requests.FPDFPageObj_Transform{
+		PageObject: obj.PageObject,
+		Transform:  structs.FPDF_FS_MATRIX{A: cos, B: sin, C: -sin, D: cos, E: float32(x), F: float32(p.height - baseline)},
+	})
+	require.NoError(t, err)
+
+	p.insert(obj.PageObject)
+}
+
+// rule draws a hairline from (x0, y0) to (x1, y1).
+func (p *syntheticPage) rule(x0, y0, x1, y1 float64) {
+	t, instance := p.pdf.t, p.pdf.instance
+	t.Helper()
+
+	path, err := instance.FPDFPageObj_CreateNewPath(&requests.FPDFPageObj_CreateNewPath{
+		X: float32(x0),
+		Y: float32(p.height - y0),
+	})
+	require.NoError(t, err)
+
+	_, err = instance.FPDFPath_LineTo(&requests.FPDFPath_LineTo{
+		PageObject: path.PageObject,
+		X:          float32(x1),
+		Y:          float32(p.height - y1),
+	})
+	require.NoError(t, err)
+
+	_, err = instance.FPDFPageObj_SetStrokeColor(&requests.FPDFPageObj_SetStrokeColor{
+		PageObject:  path.PageObject,
+		StrokeColor: structs.FPDF_COLOR{A: 255},
+	})
+	require.NoError(t, err)
+
+	_, err = instance.FPDFPageObj_SetStrokeWidth(&requests.FPDFPageObj_SetStrokeWidth{
+		PageObject:  path.PageObject,
+		StrokeWidth: 0.5,
+	})
+	require.NoError(t, err)
+
+	_, err = instance.FPDFPath_SetDrawMode(&requests.FPDFPath_SetDrawMode{
+		PageObject: path.PageObject,
+		FillMode:   enums.FPDF_FILLMODE_NONE,
+		Stroke:     true,
+	})
+	require.NoError(t, err)
+
+	p.insert(path.PageObject)
+}
+
+func (p *syntheticPage) insert(obj references.FPDF_PAGEOBJECT) {
+	_, err := p.pdf.instance.FPDFPage_InsertObject(&requests.FPDFPage_InsertObject{
+		Page:       requests.Page{ByReference: &p.page},
+		PageObject: obj,
+	})
+	require.NoError(p.pdf.t, err)
+}
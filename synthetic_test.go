@@ -0,0 +1,180 @@
+package pdfmarkdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
+)
+
+// TestSyntheticDocuments draws documents with a known structure and checks the
+// converter reproduces it.
+func TestSyntheticDocuments(t *testing.T) {
+	instance := setupPDFium(t)
+
+	tests := []struct {
+		name    string
+		draw    func(pdf *syntheticPDF)
+		want    string
+		table   bool // Enable table detection
+		upright bool // Enable Config.UprightRotatedPages
+	}{
+		{
+			name: "headings and paragraphs",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				page.text(72, 90, "Helvetica-Bold", 24, "Quarterly Report")
+				page.text(72, 130, "Helvetica-Bold", 16, "Overview")
+				page.text(72, 160, "Helvetica", 11, "Revenue grew in every region this quarter, led by strong")
+				page.text(72, 174, "Helvetica", 11, "demand for our new product line.")
+				page.text(72, 210, "Helvetica-Bold", 16, "Outlook")
+				page.text(72, 240, "Helvetica", 11, "We expect growth to continue next quarter.")
+			},
+			want: "# Quarterly Report\n\n" +
+				"## Overview\n\n" +
+				"Revenue grew in every region this quarter, led by strong\n" +
+				"demand for our new product line.\n\n" +
+				"## Outlook\n\n" +
+				"We expect growth to continue next quarter.",
+		},
+		{
+			name: "numbered list",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				page.text(72, 90, "Times-Bold", 16, "Steps")
+				page.text(72, 130, "Times-Roman", 11, "1. Unpack the box")
+				page.text(72, 154, "Times-Roman", 11, "2. Plug in the cable")
+				page.text(72, 178, "Times-Roman", 11, "3. Press the power button")
+			},
+			want: "# Steps\n\n" +
+				"1. Unpack the box\n" +
+				"2. Plug in the cable\n" +
+				"3. Press the power button",
+		},
+		{
+			name: "ruled table",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				rows := [][]string{{"Region", "Sales", "Growth"}, {"North", "120", "4%"}, {"South", "95", "2%"}}
+				for i, row := range rows {
+					for j, cell := range row {
+						page.text(80+float64(j)*150, 114+float64(i)*20, "Helvetica", 11, cell)
+					}
+				}
+				for i := 0; i <= len(rows); i++ {
+					y := 100 + float64(i)*20
+					page.rule(72, y, 522, y)
+				}
+				for j := 0; j <= 3; j++ {
+					x := 72 + float64(j)*150
+					page.rule(x, 100, x, 160)
+				}
+			},
+			table: true,
+			want: "| Region | Sales | Growth |\n" +
+				"| ------ | ----- | ------ |\n" +
+				"| North  | 120   | 4%     |\n" +
+				"| South  | 95    | 2%     |",
+		},
+		{
+			name: "page breaks",
+			draw: func(pdf *syntheticPDF) {
+				pdf.addPage().text(72, 90, "Helvetica", 11, "The first page ends here.")
+				pdf.addPage().text(72, 90, "Helvetica", 11, "The second page starts here.")
+			},
+			want: "The first page ends here.\n\n" +
+				"---\n\n" +
+				"The second page starts here.",
+		},
+		{
+			name: "bullet list",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				page.text(72, 90, "Helvetica-Bold", 16, "Highlights")
+				page.text(72, 130, "Helvetica", 11, "• Sales rose sharply")
+				page.text(72, 154, "Helvetica", 11, "• Costs fell slightly")
+				page.text(72, 178, "Helvetica", 11, "• Margins widened")
+			},
+			want: "# Highlights\n\n" +
+				"- Sales rose sharply\n" +
+				"- Costs fell slightly\n" +
+				"- Margins widened",
+		},
+		{
+			name: "two columns",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				left := []string{"The left column opens the story and", "continues over a few lines until", "it reaches the end of its text."}
+				right := []string{"The right column follows after it", "and carries on in the same way", "until the end of the page."}
+				for i := range left {
+					page.text(72, 100+float64(i)*14, "Helvetica", 11, left[i])
+					page.text(324, 100+float64(i)*14, "Helvetica", 11, right[i])
+				}
+			},
+			want: "The left column opens the story and\n" +
+				"continues over a few lines until\n" +
+				"it reaches the end of its text.\n\n" +
+				"The right column follows after it\n" +
+				"and carries on in the same way\n" +
+				"until the end of the page.",
+		},
+		{
+			name: "rotated page",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				lines := []string{"This page is printed sideways so", "that a wide table fits across it."}
+				for i, line := range lines {
+					page.rotatedText(400-float64(i)*16, 100, "Helvetica", 12, -90, line)
+				}
+			},
+			upright: true,
+			want: "This page is printed sideways so\n" +
+				"that a wide table fits across it.",
+		},
+		{
+			name: "rotated page read upwards",
+			draw: func(pdf *syntheticPDF) {
+				page := pdf.addPage()
+				lines := []string{"This page is turned the other way", "and its text runs up the page."}
+				for i, line := range lines {
+					page.rotatedText(200+float64(i)*16, 700, "Helvetica", 12, 90, line)
+				}
+			},
+			upright: true,
+			want: "This page is turned the other way\n" +
+				"and its text runs up the page.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdf := newSyntheticPDF(t, instance)
+			tt.draw(pdf)
+
+			config := pdfmarkdown.DefaultConfig()
+			config.DetectTables = tt.table
+			config.UprightRotatedPages = tt.upright
+			markdown, err := pdfmarkdown.NewConverterWithConfig(instance, config).ConvertBytes(pdf.bytes())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, normalizeSyntheticMarkdown(markdown))
+		})
+	}
+}
+
+// normalizeSyntheticMarkdown drops hard line break markers and blank-line
+// padding so expectations read as plain markdown.
+func normalizeSyntheticMarkdown(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	text := strings.Join(lines, "\n")
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(text)
+}
@@ -255,6 +255,9 @@ func wordsToEdgesVertical(words []EnrichedWord, minWords int) []Edge {
 
 // DetectTables finds tables in a page using word alignment or explicit lines.
 // Based on pdfplumber's TableFinder supporting multiple strategies.
+//
+// Deprecated: Use experimental.DetectTables, or LineTableDetector through
+// Config.TableDetectors.
 func DetectTables(page *Page, settings TableSettings) []Table {
 	// Get all words from paragraphs
 	var words []EnrichedWord
@@ -272,7 +275,7 @@ func DetectTables(page *Page, settings TableSettings) []Table {
 	if settings.VerticalStrategy == "lines" || settings.VerticalStrategy == "lines_text" {
 		// Use explicit line objects from PDF
 		for _, line := range page.Lines {
-			if line.Orientation == "v" {
+			if line.Orientation == "v" && !line.IsColumnSeparator {
 				edges = append(edges, line)
 				vLineEdges++
 			}
@@ -294,7 +297,7 @@ func DetectTables(page *Page, settings TableSettings) []Table {
 	if settings.HorizontalStrategy == "lines" || settings.HorizontalStrategy == "lines_text" {
 		// Use explicit line objects from PDF
 		for _, line := range page.Lines {
-			if line.Orientation == "h" {
+			if line.Orientation == "h" && !line.IsColumnSeparator {
 				edges = append(edges, line)
 				hLineEdges++
 			}
@@ -333,7 +336,7 @@ func DetectTables(page *Page, settings TableSettings) []Table {
 	// Create table structures
 	tables := make([]Table, 0, len(tableGroups))
 	for _, cellGroup := range tableGroups {
-		table := createTable(page, cellGroup, words)
+		table := createTable(page, cellGroup, words, settings)
 		tables = append(tables, table)
 	}
 
@@ -0,0 +1,204 @@
+package pdfmarkdown
+
+import (
+	"math"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// cellAlignTolerance is how far apart, in points, two text edges can be and
+// still count as lined up when inferring alignment.
+const cellAlignTolerance = 2.0
+
+// VerticalAlignment is where text sits within a table cell's height.
+type VerticalAlignment int
+
+const (
+	VerticalAlignTop VerticalAlignment = iota
+	VerticalAlignMiddle
+	VerticalAlignBottom
+)
+
+// String returns the alignment's CSS vertical-align value.
+func (a VerticalAlignment) String() string {
+	switch a {
+	case VerticalAlignMiddle:
+		return "middle"
+	case VerticalAlignBottom:
+		return "bottom"
+	default:
+		return "top"
+	}
+}
+
+// String returns the alignment's CSS text-align value.
+func (a Alignment) String() string {
+	switch a {
+	case AlignmentCenter:
+		return "center"
+	case AlignmentRight:
+		return "right"
+	case AlignmentJustified:
+		return "justify"
+	default:
+		return "left"
+	}
+}
+
+// ColumnAlignments infers each column's horizontal alignment. Cells with room
+// around their text vote with their own alignment; when every cell's box hugs
+// its text, the column is right aligned if the text shares a right edge,
+// centered if it shares a center, and left aligned otherwise. Header cells
+// are ignored when there are at least two body cells to go on, since headers
+// are often centered over columns of numbers.
+func (t Table) ColumnAlignments() []Alignment {
+	alignments := make([]Alignment, t.NumCols)
+	for c := range alignments {
+		var body, all []TableCell
+		for r, row := range t.Rows {
+			if c >= len(row.Cells) || len(row.Cells[c].Words) == 0 {
+				continue
+			}
+			all = append(all, row.Cells[c])
+			if r > 0 {
+				body = append(body, row.Cells[c])
+			}
+		}
+		if len(body) < 2 {
+			body = all
+		}
+		alignments[c] = columnAlignment(body)
+	}
+	return alignments
+}
+
+// columnAlignment picks the alignment of one column's non-empty cells.
+func columnAlignment(cells []TableCell) Alignment {
+	votes := make(map[Alignment]int)
+	var extents []Rect
+	for _, cell := range cells {
+		extent, _ := cellTextExtent(cell)
+		extents = append(extents, extent)
+		if !hugsText(cell.BBox, extent) {
+			votes[horizontalCellAlignment(cell.BBox, extent)]++
+		}
+	}
+
+	if len(votes) > 0 {
+		best := AlignmentLeft
+		for _, a := range []Alignment{AlignmentRight, AlignmentCenter} {
+			if votes[a] > votes[best] {
+				best = a
+			}
+		}
+		return best
+	}
+	if len(extents) < 2 {
+		return AlignmentLeft
+	}
+
+	spread := func(edge func(Rect) float64) float64 {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, extent := range extents {
+			lo, hi = math.Min(lo, edge(extent)), math.Max(hi, edge(extent))
+		}
+		return hi - lo
+	}
+	switch {
+	case spread(func(r Rect) float64 { return r.X0 }) <= cellAlignTolerance:
+		return AlignmentLeft
+	case spread(func(r Rect) float64 { return r.X1 }) <= cellAlignTolerance:
+		return AlignmentRight
+	case spread(Rect.CenterX) <= cellAlignTolerance:
+		return AlignmentCenter
+	default:
+		return AlignmentLeft
+	}
+}
+
+// alignTableCells records where each cell's text sits within its box. Cells
+// whose box hugs the text, as segment-based detection produces, take their
+// column's alignment instead.
+func alignTableCells(table Table) Table {
+	columns := table.ColumnAlignments()
+	for r := range table.Rows {
+		for c := range table.Rows[r].Cells {
+			cell := &table.Rows[r].Cells[c]
+			extent, ok := cellTextExtent(*cell)
+			if !ok {
+				continue
+			}
+			cell.Alignment = horizontalCellAlignment(cell.BBox, extent)
+			cell.VerticalAlignment = verticalCellAlignment(cell.BBox, extent)
+			if hugsText(cell.BBox, extent) && c < len(columns) {
+				cell.Alignment = columns[c]
+			}
+		}
+	}
+	return table
+}
+
+// hugsText reports whether a cell box leaves no room beside its text to tell
+// how the text is aligned.
+func hugsText(box CellBBox, extent Rect) bool {
+	return extent.X0-box.X0 <= cellAlignTolerance && box.X1-extent.X1 <= cellAlignTolerance
+}
+
+// cellTextExtent returns the box around a cell's words.
+func cellTextExtent(cell TableCell) (Rect, bool) {
+	if len(cell.Words) == 0 {
+		return Rect{}, false
+	}
+	extent := cell.Words[0].Box
+	for _, word := range cell.Words[1:] {
+		extent = mergeRects(extent, word.Box)
+	}
+	return extent, true
+}
+
+// horizontalCellAlignment compares the space left and right of the text.
+func horizontalCellAlignment(box CellBBox, extent Rect) Alignment {
+	left, right := extent.X0-box.X0, box.X1-extent.X1
+	switch {
+	case hugsText(box, extent):
+		return AlignmentLeft
+	case math.Abs(left-right) <= cellAlignTolerance:
+		return AlignmentCenter
+	case right < left:
+		return AlignmentRight
+	default:
+		return AlignmentLeft
+	}
+}
+
+// verticalCellAlignment compares the space above and below the text.
+func verticalCellAlignment(box CellBBox, extent Rect) VerticalAlignment {
+	above, below := extent.Y0-box.Top, box.Bottom-extent.Y1
+	switch {
+	case above <= cellAlignTolerance && below <= cellAlignTolerance:
+		return VerticalAlignTop // The box hugs the text
+	case math.Abs(above-below) <= cellAlignTolerance:
+		return VerticalAlignMiddle
+	case below < above:
+		return VerticalAlignBottom
+	default:
+		return VerticalAlignTop
+	}
+}
+
+// markdownAlignments converts column alignments to GFM alignment markers.
+// Left alignment is GFM's default and gets no marker.
+func markdownAlignments(alignments []Alignment) []markdown.TableAlignment {
+	result := make([]markdown.TableAlignment, len(alignments))
+	for i, a := range alignments {
+		switch a {
+		case AlignmentCenter:
+			result[i] = markdown.AlignCenter
+		case AlignmentRight:
+			result[i] = markdown.AlignRight
+		default:
+			result[i] = markdown.AlignDefault
+		}
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// placedCell is a cell box with its text drawn from x0 to x1 and top to bottom.
+type placedCell struct {
+	box                 CellBBox
+	x0, x1, top, bottom float64
+	text                string
+}
+
+// placedTable builds a table from rows of placed cells.
+func placedTable(rows ...[]placedCell) Table {
+	table := Table{NumRows: len(rows), NumCols: len(rows[0])}
+	for _, cells := range rows {
+		var row TableRow
+		for _, c := range cells {
+			cell := TableCell{BBox: c.box, Content: c.text}
+			if c.text != "" {
+				cell.Words = []EnrichedWord{{Text: c.text, Box: Rect{X0: c.x0, Y0: c.top, X1: c.x1, Y1: c.bottom}}}
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// ruledRow lays out a row of three 100pt cells, 20pt tall at top: the first
+// with its text at the top left, the second at the bottom right and the third
+// centered both ways.
+func ruledRow(top float64, texts ...string) []placedCell {
+	return []placedCell{
+		{box: CellBBox{X0: 0, Top: top, X1: 100, Bottom: top + 20}, x0: 4, x1: 40, top: top + 2, bottom: top + 10, text: texts[0]},
+		{box: CellBBox{X0: 100, Top: top, X1: 200, Bottom: top + 20}, x0: 170, x1: 196, top: top + 10, bottom: top + 18, text: texts[1]},
+		{box: CellBBox{X0: 200, Top: top, X1: 300, Bottom: top + 20}, x0: 230, x1: 270, top: top + 6, bottom: top + 14, text: texts[2]},
+	}
+}
+
+func TestAlignTableCells(t *testing.T) {
+	table := alignTableCells(placedTable(
+		ruledRow(0, "Item", "Price", "Stock"),
+		ruledRow(20, "Coffee", "3.50", "Yes"),
+	))
+
+	wantH := []Alignment{AlignmentLeft, AlignmentRight, AlignmentCenter}
+	wantV := []VerticalAlignment{VerticalAlignTop, VerticalAlignBottom, VerticalAlignMiddle}
+	for r, row := range table.Rows {
+		for c, cell := range row.Cells {
+			if cell.Alignment != wantH[c] || cell.VerticalAlignment != wantV[c] {
+				t.Errorf("cell %d,%d aligned %v/%v, want %v/%v", r, c, cell.Alignment, cell.VerticalAlignment, wantH[c], wantV[c])
+			}
+		}
+	}
+
+	got := table.ColumnAlignments()
+	for c := range wantH {
+		if got[c] != wantH[c] {
+			t.Errorf("column %d aligned %v, want %v", c, got[c], wantH[c])
+		}
+	}
+}
+
+func TestColumnAlignments(t *testing.T) {
+	// tightCell is a segment-style cell whose box is exactly its text
+	tightCell := func(x0, x1, top float64, text string) placedCell {
+		box := CellBBox{X0: x0, Top: top, X1: x1, Bottom: top + 10}
+		return placedCell{box: box, x0: x0, x1: x1, top: top, bottom: top + 10, text: text}
+	}
+
+	tests := []struct {
+		name  string
+		table Table
+		want  []Alignment
+	}{
+		{
+			name: "tight boxes sharing edges",
+			table: placedTable(
+				[]placedCell{tightCell(0, 30, 0, "Name"), tightCell(80, 100, 0, "Qty"), tightCell(150, 190, 0, "Code")},
+				[]placedCell{tightCell(0, 50, 12, "Widget"), tightCell(90, 100, 12, "4"), tightCell(160, 180, 12, "AB")},
+				[]placedCell{tightCell(0, 20, 24, "Nut"), tightCell(70, 100, 24, "1200"), tightCell(155, 185, 24, "XYZ")},
+			),
+			want: []Alignment{AlignmentLeft, AlignmentRight, AlignmentCenter},
+		},
+		{
+			name: "centered header over right aligned numbers",
+			table: placedTable(
+				[]placedCell{{box: CellBBox{X0: 0, X1: 100, Bottom: 20}, x0: 30, x1: 70, top: 6, bottom: 14, text: "Total"}},
+				[]placedCell{{box: CellBBox{X0: 0, Top: 20, X1: 100, Bottom: 40}, x0: 70, x1: 96, top: 26, bottom: 34, text: "3.50"}},
+				[]placedCell{{box: CellBBox{X0: 0, Top: 40, X1: 100, Bottom: 60}, x0: 60, x1: 96, top: 46, bottom: 54, text: "12.00"}},
+			),
+			want: []Alignment{AlignmentRight},
+		},
+		{
+			name: "empty column",
+			table: placedTable(
+				[]placedCell{{box: CellBBox{X0: 0, X1: 100, Bottom: 20}}},
+			),
+			want: []Alignment{AlignmentLeft},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.table.ColumnAlignments()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d alignments, want %d", len(got), len(tt.want))
+			}
+			for c := range tt.want {
+				if got[c] != tt.want[c] {
+					t.Errorf("column %d aligned %v, want %v", c, got[c], tt.want[c])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertTableToMarkdown_AlignTableColumns(t *testing.T) {
+	table := alignTableCells(placedTable(
+		ruledRow(0, "Item", "Price", "Stock"),
+		ruledRow(20, "Coffee", "3.50", "Yes"),
+	))
+
+	render := func(config Config) string {
+		var buf bytes.Buffer
+		md := markdown.NewMarkdown(&buf)
+		convertTableToMarkdown(md, table, config)
+		if err := md.Build(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := render(DefaultConfig()); strings.Contains(got, ":") {
+		t.Errorf("alignment markers without AlignTableColumns:\n%s", got)
+	}
+
+	config := DefaultConfig()
+	config.AlignTableColumns = true
+	got := render(config)
+	if !strings.Contains(got, "| ------ | ----: | :---: |") {
+		t.Errorf("missing alignment markers:\n%s", got)
+	}
+}
@@ -0,0 +1,124 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// boundaryTolerance is how far apart two cell edges can be and still be read
+// as the same boundary, matching the 1pt createTable groups rows by.
+const boundaryTolerance = 1.0
+
+// RowBoundaries returns the Y positions separating the table's rows, top to
+// bottom, including the table's top and bottom edges. They are inferred from
+// the cell boxes, so a table with N rows usually has N+1 boundaries.
+func (t Table) RowBoundaries() []float64 {
+	var values []float64
+	for _, row := range t.Rows {
+		for _, cell := range row.Cells {
+			values = append(values, cell.BBox.Top, cell.BBox.Bottom)
+		}
+	}
+	return distinctBoundaries(values)
+}
+
+// ColumnBoundaries returns the X positions separating the table's columns,
+// left to right, including the table's left and right edges. They are
+// inferred from the cell boxes, so cells spanning columns add no boundary.
+func (t Table) ColumnBoundaries() []float64 {
+	var values []float64
+	for _, row := range t.Rows {
+		for _, cell := range row.Cells {
+			values = append(values, cell.BBox.X0, cell.BBox.X1)
+		}
+	}
+	return distinctBoundaries(values)
+}
+
+// distinctBoundaries sorts positions and merges those within
+// boundaryTolerance of the previous one.
+func distinctBoundaries(values []float64) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Float64s(values)
+	bounds := []float64{values[0]}
+	for _, v := range values[1:] {
+		if v-bounds[len(bounds)-1] > boundaryTolerance {
+			bounds = append(bounds, v)
+		}
+	}
+	return bounds
+}
+
+// WithBoundaries rebuilds the table on a grid of corrected row and column
+// boundaries, such as ones adjusted by hand after inspecting RowBoundaries and
+// ColumnBoundaries in the JSON output, and reassigns the table's words to the
+// new cells with settings. Detection is not run again, so only words already in
+// the table are placed; words falling outside the corrected grid are dropped.
+// Each boundary list needs at least two positions in increasing order.
+func (t Table) WithBoundaries(rowBounds, colBounds []float64, settings TableSettings) (Table, error) {
+	if err := checkBoundaries(rowBounds); err != nil {
+		return Table{}, errors.Wrap(err, "invalid row boundaries")
+	}
+	if err := checkBoundaries(colBounds); err != nil {
+		return Table{}, errors.Wrap(err, "invalid column boundaries")
+	}
+
+	var words []EnrichedWord
+	for _, row := range t.Rows {
+		for _, cell := range row.Cells {
+			words = append(words, cell.Words...)
+		}
+	}
+
+	table := Table{
+		BBox: CellBBox{
+			X0:     colBounds[0],
+			Top:    rowBounds[0],
+			X1:     colBounds[len(colBounds)-1],
+			Bottom: rowBounds[len(rowBounds)-1],
+		},
+		NumRows: len(rowBounds) - 1,
+		NumCols: len(colBounds) - 1,
+	}
+	for r := 0; r+1 < len(rowBounds); r++ {
+		for c := 0; c+1 < len(colBounds); c++ {
+			table.Cells = append(table.Cells, CellBBox{X0: colBounds[c], Top: rowBounds[r], X1: colBounds[c+1], Bottom: rowBounds[r+1]})
+		}
+	}
+
+	assigned := assignWordsToCells(table.Cells, words, settings)
+	for r := 0; r < table.NumRows; r++ {
+		row := TableRow{BBox: CellBBox{X0: table.BBox.X0, Top: rowBounds[r], X1: table.BBox.X1, Bottom: rowBounds[r+1]}}
+		for c := 0; c < table.NumCols; c++ {
+			i := r*table.NumCols + c
+			cellWords, content := joinCellWords(assigned[i])
+			row.Cells = append(row.Cells, TableCell{BBox: table.Cells[i], Content: content, Words: cellWords})
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	table = alignTableCells(table)
+	table.Orientation = detectTableOrientation(table)
+	return table, nil
+}
+
+// checkBoundaries reports an error unless bounds has at least two finite
+// positions in increasing order.
+func checkBoundaries(bounds []float64) error {
+	if len(bounds) < 2 {
+		return errors.Errorf("need at least 2 boundaries, got %d", len(bounds))
+	}
+	for i, b := range bounds {
+		if math.IsNaN(b) || math.IsInf(b, 0) {
+			return errors.Errorf("boundary %d is not a number", i)
+		}
+		if i > 0 && b <= bounds[i-1] {
+			return errors.Errorf("boundary %d (%g) is not after boundary %d (%g)", i, b, i-1, bounds[i-1])
+		}
+	}
+	return nil
+}
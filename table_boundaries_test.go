@@ -0,0 +1,56 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableBoundaries(t *testing.T) {
+	table := placedTable(
+		ruledRow(0, "Item", "Price", "Stock"),
+		ruledRow(20, "Coffee", "3.50", "Yes"),
+	)
+	// A cell edge a fraction of a point off is the same boundary
+	table.Rows[1].Cells[2].BBox.X1 = 300.4
+
+	if got, want := table.RowBoundaries(), []float64{0, 20, 40}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RowBoundaries() = %v, want %v", got, want)
+	}
+	if got, want := table.ColumnBoundaries(), []float64{0, 100, 200, 300}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ColumnBoundaries() = %v, want %v", got, want)
+	}
+}
+
+// TestTableWithBoundaries tests that words are reassigned to a corrected grid
+func TestTableWithBoundaries(t *testing.T) {
+	table := placedTable(
+		ruledRow(0, "Item", "Price", "Stock"),
+		ruledRow(20, "Coffee", "3.50", "Yes"),
+	)
+
+	// Merge the first two columns
+	merged, err := table.WithBoundaries([]float64{0, 20, 40}, []float64{0, 200, 300}, DefaultTableSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.NumRows != 2 || merged.NumCols != 2 {
+		t.Fatalf("got %dx%d table, want 2x2", merged.NumRows, merged.NumCols)
+	}
+	var got [][]string
+	for _, row := range merged.Rows {
+		var cells []string
+		for _, cell := range row.Cells {
+			cells = append(cells, cell.Content)
+		}
+		got = append(got, cells)
+	}
+	if want := [][]string{{"Item Price", "Stock"}, {"Coffee 3.50", "Yes"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cells = %q, want %q", got, want)
+	}
+
+	for _, bounds := range [][]float64{{0}, {0, 20, 20}, {40, 20, 0}} {
+		if _, err := table.WithBoundaries(bounds, []float64{0, 300}, DefaultTableSettings()); err == nil {
+			t.Errorf("WithBoundaries(%v) succeeded, want an error", bounds)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"strings"
+)
+
+// assignWordsToCells distributes words among table cells, returning the words
+// for each cell in the same order as cells.
+//
+// With the "overlap" mode each word goes to the single cell it overlaps most, so
+// a word straddling a column boundary lands in the cell holding most of it
+// rather than being dropped or binned by its center point. Words lying mostly
+// outside the table belong to the text around it and are left out. When
+// SplitWordsAtCellBoundaries is set, words spanning several cells of the same
+// row are cut at the boundaries instead. The "center" mode keeps the original
+// center-point test.
+func assignWordsToCells(cells []CellBBox, words []EnrichedWord, settings TableSettings) [][]EnrichedWord {
+	assigned := make([][]EnrichedWord, len(cells))
+	if len(cells) == 0 {
+		return assigned
+	}
+
+	if settings.CellAssignment == "center" {
+		for i, cell := range cells {
+			for _, word := range words {
+				if wordCenterInCell(word, cell) {
+					assigned[i] = append(assigned[i], word)
+				}
+			}
+		}
+		return assigned
+	}
+
+	for _, word := range words {
+		best, bestArea, inside := -1, 0.0, 0.0
+		for i, cell := range cells {
+			area := cellOverlapArea(word.Box, cell)
+			if area > bestArea {
+				best, bestArea = i, area
+			}
+			inside += area
+		}
+		box := overlapBox(word.Box)
+		if best < 0 || inside*2 < box.Width()*box.Height() {
+			continue
+		}
+
+		if settings.SplitWordsAtCellBoundaries {
+			if parts, indices := splitWordAtCells(word, cells, cells[best]); len(parts) > 1 {
+				for j, part := range parts {
+					assigned[indices[j]] = append(assigned[indices[j]], part)
+				}
+				continue
+			}
+		}
+
+		assigned[best] = append(assigned[best], word)
+	}
+
+	return assigned
+}
+
+// wordCenterInCell checks if a word's center is inside the cell, allowing a
+// small tolerance for words sitting on the boundary.
+func wordCenterInCell(word EnrichedWord, cell CellBBox) bool {
+	const tolerance = 1.0
+	wordCenterX := (word.Box.X0 + word.Box.X1) / 2
+	wordCenterY := (word.Box.Y0 + word.Box.Y1) / 2
+
+	return wordCenterX >= cell.X0-tolerance &&
+		wordCenterX <= cell.X1+tolerance &&
+		wordCenterY >= cell.Top-tolerance &&
+		wordCenterY <= cell.Bottom+tolerance
+}
+
+// cellOverlapArea returns the area of the word box inside the cell.
+func cellOverlapArea(box Rect, cell CellBBox) float64 {
+	box = overlapBox(box)
+	w := math.Min(box.X1, cell.X1) - math.Max(box.X0, cell.X0)
+	h := math.Min(box.Y1, cell.Bottom) - math.Max(box.Y0, cell.Top)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// overlapBox widens degenerate word boxes to 1pt around their center so they
+// still register when measuring overlap.
+func overlapBox(box Rect) Rect {
+	if box.X1-box.X0 < 1 {
+		cx := (box.X0 + box.X1) / 2
+		box.X0, box.X1 = cx-0.5, cx+0.5
+	}
+	if box.Y1-box.Y0 < 1 {
+		cy := (box.Y0 + box.Y1) / 2
+		box.Y0, box.Y1 = cy-0.5, cy+0.5
+	}
+	return box
+}
+
+// splitWordAtCells cuts a word at the vertical boundaries of the cells it spans
+// in the row of best. Characters are assumed to have equal width, which holds
+// well enough for the numeric runs that typically collide in packed tables.
+// Cuts fall between grapheme clusters, so accents stay on their letters.
+// It returns the parts with the index of the cell each belongs to.
+func splitWordAtCells(word EnrichedWord, cells []CellBBox, best CellBBox) ([]EnrichedWord, []int) {
+	clusters := graphemeClusters(word.Text)
+	width := word.Box.Width()
+	if len(clusters) < 2 || width <= 0 {
+		return nil, nil
+	}
+	charWidth := width / float64(len(clusters))
+
+	var parts []EnrichedWord
+	var indices []int
+	for i, cell := range cells {
+		// Only split horizontally, across cells of the same row
+		if math.Abs(cell.Top-best.Top) >= 1.0 || cellOverlapArea(word.Box, cell) == 0 {
+			continue
+		}
+
+		start := int(math.Round((math.Max(cell.X0, word.Box.X0) - word.Box.X0) / charWidth))
+		end := int(math.Round((math.Min(cell.X1, word.Box.X1) - word.Box.X0) / charWidth))
+		start = max(start, 0)
+		end = min(end, len(clusters))
+		if start >= end {
+			continue
+		}
+
+		part := word
+		part.Text = strings.Join(clusters[start:end], "")
+		part.Box.X0 = word.Box.X0 + float64(start)*charWidth
+		part.Box.X1 = word.Box.X0 + float64(end)*charWidth
+		parts = append(parts, part)
+		indices = append(indices, i)
+	}
+
+	return parts, indices
+}
@@ -0,0 +1,101 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignWordsToCells(t *testing.T) {
+	cells := []CellBBox{
+		{X0: 0, Top: 0, X1: 50, Bottom: 20},
+		{X0: 50, Top: 0, X1: 100, Bottom: 20},
+	}
+
+	tests := []struct {
+		name     string
+		words    []EnrichedWord
+		settings TableSettings
+		want     [][]string
+	}{
+		{
+			name: "overlap assigns boundary word once",
+			words: []EnrichedWord{
+				{Text: "1,234.50", Box: Rect{X0: 38, Y0: 5, X1: 60, Y1: 15}},
+			},
+			settings: TableSettings{CellAssignment: "overlap"},
+			want:     [][]string{{"1,234.50"}, nil},
+		},
+		{
+			name: "center duplicates boundary word",
+			words: []EnrichedWord{
+				{Text: "1,234.50", Box: Rect{X0: 38, Y0: 5, X1: 60, Y1: 15}},
+			},
+			settings: TableSettings{CellAssignment: "center"},
+			want:     [][]string{{"1,234.50"}, {"1,234.50"}},
+		},
+		{
+			name: "overlap keeps word hanging below the cell",
+			words: []EnrichedWord{
+				{Text: "12.5", Box: Rect{X0: 60, Y0: 8, X1: 80, Y1: 28}},
+			},
+			settings: TableSettings{CellAssignment: "overlap"},
+			want:     [][]string{nil, {"12.5"}},
+		},
+		{
+			name: "overlap drops word lying mostly outside the table",
+			words: []EnrichedWord{
+				{Text: "12.5", Box: Rect{X0: 60, Y0: 14, X1: 80, Y1: 34}},
+			},
+			settings: TableSettings{CellAssignment: "overlap"},
+			want:     [][]string{nil, nil},
+		},
+		{
+			name: "overlap drops long word running through the table",
+			words: []EnrichedWord{
+				{Text: "heading", Box: Rect{X0: 20, Y0: -60, X1: 30, Y1: 18}},
+			},
+			settings: TableSettings{CellAssignment: "overlap"},
+			want:     [][]string{nil, nil},
+		},
+		{
+			name: "center drops word whose center is outside the cell",
+			words: []EnrichedWord{
+				{Text: "12.5", Box: Rect{X0: 60, Y0: 14, X1: 80, Y1: 34}},
+			},
+			settings: TableSettings{CellAssignment: "center"},
+			want:     [][]string{nil, nil},
+		},
+		{
+			name: "split at cell boundary",
+			words: []EnrichedWord{
+				{Text: "10.0020.00", Box: Rect{X0: 30, Y0: 5, X1: 70, Y1: 15}},
+			},
+			settings: TableSettings{CellAssignment: "overlap", SplitWordsAtCellBoundaries: true},
+			want:     [][]string{{"10.00"}, {"20.00"}},
+		},
+		{
+			name: "word outside all cells is dropped",
+			words: []EnrichedWord{
+				{Text: "footnote", Box: Rect{X0: 0, Y0: 40, X1: 40, Y1: 50}},
+			},
+			settings: TableSettings{},
+			want:     [][]string{nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigned := assignWordsToCells(cells, tt.words, tt.settings)
+
+			got := make([][]string, len(assigned))
+			for i, words := range assigned {
+				for _, w := range words {
+					got[i] = append(got[i], w.Text)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assignWordsToCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
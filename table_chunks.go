@@ -0,0 +1,43 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+)
+
+// OversizedTableStyle selects how tables longer than Config.TableMaxRows are
+// rendered.
+type OversizedTableStyle string
+
+const (
+	// OversizedTablesSplit renders consecutive markdown tables of at most
+	// TableMaxRows rows, each repeating the header row.
+	OversizedTablesSplit OversizedTableStyle = ""
+
+	// OversizedTablesCSV renders the whole table as a CSV code block.
+	OversizedTablesCSV OversizedTableStyle = "csv"
+)
+
+// chunkTableRows splits body rows into chunks of at most maxRows. A maxRows of
+// zero or less keeps every row in one chunk.
+func chunkTableRows(rows [][]string, maxRows int) [][][]string {
+	if maxRows <= 0 || len(rows) <= maxRows {
+		return [][][]string{rows}
+	}
+	var chunks [][][]string
+	for start := 0; start < len(rows); start += maxRows {
+		end := min(start+maxRows, len(rows))
+		chunks = append(chunks, rows[start:end])
+	}
+	return chunks
+}
+
+// tableCSV encodes a header and body rows as CSV, without a trailing newline.
+func tableCSV(header []string, rows [][]string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header) // Writes to a bytes.Buffer cannot fail
+	_ = w.WriteAll(rows)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
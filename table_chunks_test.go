@@ -0,0 +1,86 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// numberedTable builds a two-column table with a header and n body rows.
+func numberedTable(n int) Table {
+	table := Table{NumRows: n + 1, NumCols: 2}
+	table.Rows = append(table.Rows, TableRow{Cells: []TableCell{{Content: "Item"}, {Content: "Note"}}})
+	for i := 1; i <= n; i++ {
+		table.Rows = append(table.Rows, TableRow{Cells: []TableCell{
+			{Content: "Row " + strconv.Itoa(i)},
+			{Content: "a, b"},
+		}})
+	}
+	return table
+}
+
+func TestChunkTableRows(t *testing.T) {
+	rows := [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}}
+
+	tests := []struct {
+		name    string
+		maxRows int
+		want    []int
+	}{
+		{name: "no limit", maxRows: 0, want: []int{5}},
+		{name: "under limit", maxRows: 10, want: []int{5}},
+		{name: "exact multiple", maxRows: 5, want: []int{5}},
+		{name: "remainder", maxRows: 2, want: []int{2, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := chunkTableRows(rows, tt.maxRows)
+			if len(chunks) != len(tt.want) {
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.want))
+			}
+			for i, chunk := range chunks {
+				if len(chunk) != tt.want[i] {
+					t.Errorf("chunk %d has %d rows, want %d", i, len(chunk), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertTableToMarkdown_TableMaxRows(t *testing.T) {
+	render := func(config Config) string {
+		var buf bytes.Buffer
+		md := markdown.NewMarkdown(&buf)
+		convertTableToMarkdown(md, numberedTable(5), config)
+		if err := md.Build(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := strings.Count(render(DefaultConfig()), "| Item"); got != 1 {
+		t.Errorf("unlimited table rendered %d headers, want 1", got)
+	}
+
+	config := DefaultConfig()
+	config.TableMaxRows = 2
+	split := render(config)
+	if got := strings.Count(split, "| Item"); got != 3 {
+		t.Errorf("split table rendered %d headers, want 3:\n%s", got, split)
+	}
+	for i := 1; i <= 5; i++ {
+		if !strings.Contains(split, "| Row "+strconv.Itoa(i)+" ") {
+			t.Errorf("split table lost row %d:\n%s", i, split)
+		}
+	}
+
+	config.OversizedTables = OversizedTablesCSV
+	csv := render(config)
+	if !strings.HasPrefix(csv, "```csv\nItem,Note\nRow 1,\"a, b\"\n") || !strings.Contains(csv, "Row 5,\"a, b\"\n```") {
+		t.Errorf("unexpected CSV rendering:\n%s", csv)
+	}
+}
@@ -0,0 +1,116 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"html"
+	"strings"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+// TwoColumnTableStyle selects how tables of label/value pairs, two columns
+// without a header row such as "Invoice No. | 12345", are rendered.
+type TwoColumnTableStyle string
+
+const (
+	// TwoColumnTablesKeep renders label/value tables as pipe tables, the
+	// first pair taking the header row.
+	TwoColumnTablesKeep TwoColumnTableStyle = ""
+
+	// TwoColumnTablesBold renders each pair as a "**Label:** value" line.
+	TwoColumnTablesBold TwoColumnTableStyle = "bold"
+
+	// TwoColumnTablesDefinitions renders the pairs as a definition list,
+	// each label followed by a ": value" line, as Markdown Extra and Pandoc
+	// read them.
+	TwoColumnTablesDefinitions TwoColumnTableStyle = "definitions"
+)
+
+// maxLabelWords is the most words a label/value table's labels have.
+const maxLabelWords = 8
+
+// labelValuePairs returns the rows of a two-column table whose first column
+// labels the second, with no header row: the labels are short and all filled
+// in, no cells are merged, and the first row doesn't stand apart from the
+// rest the way a header does. Tables with headers down the first column are
+// label/value tables by definition.
+func labelValuePairs(table Table) ([]LeaderRow, bool) {
+	if table.NumCols != 2 || len(table.Rows) == 0 {
+		return nil, false
+	}
+	if table.Orientation != TableHeaderLeft && len(table.Rows) > 1 && headerContrast(table, func(r, c int) bool { return r == 0 }) >= 0.5 {
+		return nil, false
+	}
+
+	pairs := make([]LeaderRow, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		if len(row.Cells) != 2 {
+			return nil, false
+		}
+		for _, cell := range row.Cells {
+			if cell.Covered || cell.RowSpan > 1 || cell.ColSpan > 1 {
+				return nil, false
+			}
+		}
+		label := strings.Join(strings.Fields(row.Cells[0].Content), " ")
+		value := strings.Join(strings.Fields(row.Cells[1].Content), " ")
+		if label == "" || len(strings.Fields(label)) > maxLabelWords {
+			return nil, false
+		}
+		pairs = append(pairs, LeaderRow{Label: label, Value: value})
+	}
+	return pairs, true
+}
+
+// convertLabelValuesToMarkdown renders label/value pairs in style, returning
+// false for TwoColumnTablesKeep.
+func convertLabelValuesToMarkdown(md *markdown.Markdown, pairs []LeaderRow, style TwoColumnTableStyle) bool {
+	switch style {
+	case TwoColumnTablesBold:
+		lines := make([]string, len(pairs))
+		for i, pair := range pairs {
+			lines[i] = strings.TrimSpace("**" + labelWithColon(pair.Label) + "** " + pair.Value)
+		}
+		md.PlainText(strings.Join(lines, "  \n"))
+	case TwoColumnTablesDefinitions:
+		items := make([]string, len(pairs))
+		for i, pair := range pairs {
+			items[i] = strings.TrimSpace(pair.Label + "\n: " + pair.Value)
+		}
+		md.PlainText(strings.Join(items, "\n\n"))
+	default:
+		return false
+	}
+	return true
+}
+
+// writeLabelValuesHTML renders label/value pairs in style as a paragraph of
+// lines or a definition list, returning false for TwoColumnTablesKeep.
+func writeLabelValuesHTML(b *bytes.Buffer, pairs []LeaderRow, style TwoColumnTableStyle) bool {
+	switch style {
+	case TwoColumnTablesBold:
+		lines := make([]string, len(pairs))
+		for i, pair := range pairs {
+			lines[i] = strings.TrimSpace("<strong>" + html.EscapeString(labelWithColon(pair.Label)) + "</strong> " + html.EscapeString(pair.Value))
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+	case TwoColumnTablesDefinitions:
+		b.WriteString("<dl>\n")
+		for _, pair := range pairs {
+			b.WriteString("<dt>" + html.EscapeString(pair.Label) + "</dt>\n<dd>" + html.EscapeString(pair.Value) + "</dd>\n")
+		}
+		b.WriteString("</dl>\n")
+	default:
+		return false
+	}
+	return true
+}
+
+// labelWithColon ends a label with a colon, unless it already ends in
+// punctuation.
+func labelWithColon(label string) string {
+	if strings.HasSuffix(label, ":") || strings.HasSuffix(label, "?") {
+		return label
+	}
+	return label + ":"
+}
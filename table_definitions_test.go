@@ -0,0 +1,66 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/markdown"
+)
+
+func TestLabelValuePairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		want  bool
+	}{
+		{"label/value", makeTable([]string{"Invoice No.", "12345"}, []string{"Date", "1 March 2024"}), true},
+		{"bold labels", makeTable([]string{"*Invoice No.", "12345"}, []string{"*Date", "1 March 2024"}), true},
+		{"header row", makeTable([]string{"*Item", "*Price"}, []string{"Coffee", "3.00"}, []string{"Tea", "2.50"}), false},
+		{"three columns", makeTable([]string{"Item", "Price", "Qty"}, []string{"Coffee", "3.00", "1"}), false},
+		{"missing label", makeTable([]string{"Invoice No.", "12345"}, []string{"", "continued"}), false},
+		{"long label", makeTable([]string{"This label runs on for far too many words to be one", "x"}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := labelValuePairs(tt.table); got != tt.want {
+				t.Errorf("labelValuePairs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTableToMarkdown_TwoColumnStyle(t *testing.T) {
+	table := makeTable([]string{"Invoice No.", "12345"}, []string{"Due:", "1 March 2024"}, []string{"Notes", ""})
+
+	tests := []struct {
+		style TwoColumnTableStyle
+		want  string
+	}{
+		{TwoColumnTablesBold, "**Invoice No.:** 12345  \n**Due:** 1 March 2024  \n**Notes:**"},
+		{TwoColumnTablesDefinitions, "Invoice No.\n: 12345\n\nDue:\n: 1 March 2024\n\nNotes\n:"},
+		{TwoColumnTablesKeep, "| Invoice No. | 12345        |"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.style), func(t *testing.T) {
+			var b strings.Builder
+			md := markdown.NewMarkdown(&b)
+			config := DefaultConfig()
+			config.TwoColumnTableStyle = tt.style
+			convertTableToMarkdown(md, table, config)
+			if err := md.Build(); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	config := DefaultConfig()
+	config.TwoColumnTableStyle = TwoColumnTablesDefinitions
+	doc := &Document{Pages: []Page{{Tables: []Table{table}}}}
+	if got := doc.ToHTML(config); !strings.Contains(got, "<dl>\n<dt>Invoice No.</dt>\n<dd>12345</dd>\n") {
+		t.Errorf("HTML definition list not as expected:\n%s", got)
+	}
+}
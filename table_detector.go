@@ -0,0 +1,402 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// TableDetector finds tables on an extracted page.
+// Implement it to plug in custom detectors (for example an ML table transformer)
+// while reusing the package's cell content assignment and markdown rendering.
+// Detectors may return cells with only a bounding box; tables without any cell
+// content are filled from the page's words before rendering.
+type TableDetector interface {
+	Detect(page *Page, cfg TableSettings) []Table
+}
+
+// TableDetectorFunc adapts an ordinary function to the TableDetector interface.
+type TableDetectorFunc func(page *Page, cfg TableSettings) []Table
+
+// Detect calls f(page, cfg).
+func (f TableDetectorFunc) Detect(page *Page, cfg TableSettings) []Table {
+	return f(page, cfg)
+}
+
+// LineTableDetector detects tables from explicit ruling lines using the
+// pdfplumber-style edge intersection approach.
+type LineTableDetector struct{}
+
+// Detect runs line-based detection on pages that contain line objects.
+func (LineTableDetector) Detect(page *Page, cfg TableSettings) []Table {
+	if len(page.Lines) == 0 {
+		return nil
+	}
+	return DetectTables(page, cfg)
+}
+
+// SegmentTableDetector detects tables without ruling lines using PDF-TREX
+// segment analysis.
+type SegmentTableDetector struct {
+	// Adaptive derives spacing thresholds from the page's word gaps instead of
+	// using fixed defaults
+	Adaptive bool
+
+	// proposeOnly skips the gates against prose and address blocks, for
+	// detectors that only use the results as candidate regions
+	proposeOnly bool
+}
+
+// Detect runs segment-based detection on the page.
+func (d SegmentTableDetector) Detect(page *Page, cfg TableSettings) []Table {
+	tables, _ := d.detectWithRejects(page, cfg)
+	return tables
+}
+
+// detectWithRejects runs segment-based detection, also returning the
+// candidates that failed its gates.
+func (d SegmentTableDetector) detectWithRejects(page *Page, cfg TableSettings) ([]Table, []RejectedTable) {
+	thresholds := d.thresholds(page)
+	tables, rejected := gateTablesSegmentBased(page, thresholds, cfg, !d.proposeOnly)
+	for i := range tables {
+		tables[i].Provenance.Thresholds = &thresholds
+		tables[i].Provenance.Adaptive = d.Adaptive
+	}
+	return tables, rejected
+}
+
+// thresholds returns the spacing thresholds to cluster the page's words with.
+func (d SegmentTableDetector) thresholds(page *Page) AdaptiveThresholds {
+	if d.Adaptive {
+		return calculateAdaptiveThresholds(pageWords(page))
+	}
+	return AdaptiveThresholds{
+		HorizontalThreshold: 20.0,
+		VerticalThreshold:   5.0,
+	}
+}
+
+// rejectingDetector is implemented by built-in detectors that can report the
+// candidates they turned down, which are recorded in Page.Diagnostics.
+type rejectingDetector interface {
+	detectWithRejects(page *Page, cfg TableSettings) ([]Table, []RejectedTable)
+}
+
+// DefaultTableDetectors returns the built-in detectors enabled by config.
+// Append to the result to run a custom detector alongside them:
+//
+//	config.TableDetectors = append(pdfmarkdown.DefaultTableDetectors(config), myDetector)
+func DefaultTableDetectors(config Config) []TableDetector {
+	if config.UseHybridTables {
+		return []TableDetector{HybridTableDetector{Adaptive: config.UseAdaptiveThresholds}}
+	}
+
+	var detectors []TableDetector
+	if config.UseSegmentBasedTables {
+		detectors = append(detectors, SegmentTableDetector{Adaptive: config.UseAdaptiveThresholds})
+	}
+	detectors = append(detectors, LineTableDetector{})
+	return detectors
+}
+
+// tableDetectorName returns the name TableProvenance records for a detector.
+func tableDetectorName(detector TableDetector) string {
+	switch detector.(type) {
+	case LineTableDetector, *LineTableDetector:
+		return DetectorLines
+	case SegmentTableDetector, *SegmentTableDetector:
+		return DetectorSegments
+	case HybridTableDetector, *HybridTableDetector:
+		return DetectorHybrid
+	}
+	return DetectorCustom
+}
+
+// tableDetectors returns the configured detectors, falling back to the defaults.
+func (c Config) tableDetectors() []TableDetector {
+	if c.TableDetectors != nil {
+		return c.TableDetectors
+	}
+	return DefaultTableDetectors(c)
+}
+
+// detectPageTables runs every configured detector on the page, fills in any
+// missing cell content, records each table's provenance and removes tables
+// found by more than one detector.
+// On multi-column pages each column is searched separately so a table
+// confined to one column never picks up edges or words from its neighbour.
+// Candidates the detectors reject are added to the page's diagnostics.
+func detectPageTables(page *Page, config Config) []Table {
+	var tables []Table
+	for _, region := range tableRegions(page) {
+		words := pageWords(region)
+		for _, detector := range config.tableDetectors() {
+			var found []Table
+			if gated, ok := detector.(rejectingDetector); ok {
+				var rejected []RejectedTable
+				found, rejected = gated.detectWithRejects(region, config.TableSettings)
+				for _, reject := range rejected {
+					reject.Page = page.Number
+					page.Diagnostics.RejectedTables = append(page.Diagnostics.RejectedTables, reject)
+				}
+			} else {
+				found = detector.Detect(region, config.TableSettings)
+			}
+			for _, table := range found {
+				table = fillTableContent(table, words, config.TableSettings)
+				table = alignTableCells(table)
+				table.Orientation = detectTableOrientation(table)
+				if table.Provenance.Detector == "" {
+					table.Provenance.Detector = tableDetectorName(detector)
+				}
+				table.Provenance.Settings = config.TableSettings
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	return deduplicateTables(tables)
+}
+
+// tableRegions returns one sub-page per detected column on multi-column
+// pages, or the page itself. The gutters of a page-wide table also show up as
+// columns, so columns only count when each holds running text.
+func tableRegions(page *Page) []*Page {
+	if len(page.Columns) <= 1 {
+		return []*Page{page}
+	}
+
+	regions := make([]*Page, 0, len(page.Columns))
+	for _, col := range page.Columns {
+		region := columnPage(page, col)
+		if !isTextColumn(region) {
+			return []*Page{page}
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// isTextColumn reports whether a column region reads like running text rather
+// than a single table column, judged by the average number of words per line.
+func isTextColumn(region *Page) bool {
+	const minWordsPerLine = 4.0 // Table columns average one or two words per row
+
+	var lines, words int
+	for _, para := range region.Paragraphs {
+		for _, line := range para.Lines {
+			lines++
+			words += len(line.Words)
+		}
+	}
+	return lines > 0 && float64(words)/float64(lines) >= minWordsPerLine
+}
+
+// columnPage builds a sub-page holding only the words and edges within a
+// column's horizontal extent. Lines that cross the gutter are cut down to the
+// words inside the column, and horizontal edges are clipped to its width.
+func columnPage(page *Page, col Column) *Page {
+	x0, x1 := col.Box.X0, col.Box.X1
+	inColumn := func(x float64) bool { return x >= x0 && x < x1 }
+
+	region := &Page{
+		Number:  page.Number,
+		Width:   x1 - x0,
+		Height:  page.Height,
+		Columns: []Column{col},
+		Figures: page.Figures,
+	}
+
+	for _, para := range page.Paragraphs {
+		lines := clipLines(para.Lines, inColumn)
+		if len(lines) == 0 {
+			continue
+		}
+
+		clipped := para
+		clipped.Lines = lines
+		clipped.Box = lines[0].Box
+		for _, line := range lines[1:] {
+			clipped.Box = mergeRects(clipped.Box, line.Box)
+		}
+		region.Paragraphs = append(region.Paragraphs, clipped)
+	}
+	region.textLines = clipLines(page.textLines, inColumn)
+
+	for _, edge := range page.Lines {
+		switch edge.Orientation {
+		case "v":
+			if inColumn(edge.X0) {
+				region.Lines = append(region.Lines, edge)
+			}
+		case "h":
+			edge.X0 = math.Max(edge.X0, x0)
+			edge.X1 = math.Min(edge.X1, x1)
+			if edge.X1 > edge.X0 {
+				edge.Width = edge.X1 - edge.X0
+				region.Lines = append(region.Lines, edge)
+			}
+		}
+	}
+
+	return region
+}
+
+// fillTableContent assigns page words to the cells of a table a detector
+// returned with geometry only. Tables with any cell content are left as is.
+// Covered cells repeat the content of the merged cell with the same box.
+func fillTableContent(table Table, words []EnrichedWord, settings TableSettings) Table {
+	var boxes []CellBBox
+	for _, row := range table.Rows {
+		for _, cell := range row.Cells {
+			if cell.Content != "" || len(cell.Words) > 0 {
+				return table
+			}
+			if !cell.Covered {
+				boxes = append(boxes, cell.BBox)
+			}
+		}
+	}
+
+	assigned := assignWordsToCells(boxes, words, settings)
+	merged := make(map[CellBBox]string)
+	i := 0
+	for r := range table.Rows {
+		for c := range table.Rows[r].Cells {
+			cell := &table.Rows[r].Cells[c]
+			if cell.Covered {
+				continue
+			}
+			cell.Words, cell.Content = joinCellWords(assigned[i])
+			merged[cell.BBox] = cell.Content
+			i++
+		}
+	}
+	for r := range table.Rows {
+		for c := range table.Rows[r].Cells {
+			if cell := &table.Rows[r].Cells[c]; cell.Covered {
+				cell.Content = merged[cell.BBox]
+			}
+		}
+	}
+	return table
+}
+
+// withoutTableText removes the words placed in table cells from the
+// paragraphs, so table text is rendered once, as the table, rather than
+// repeated as running text. Lines and paragraphs left empty are dropped.
+func withoutTableText(paragraphs []Paragraph, tables []Table) []Paragraph {
+	var cellWords []EnrichedWord
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			for _, cell := range row.Cells {
+				cellWords = append(cellWords, cell.Words...)
+			}
+		}
+	}
+	if len(cellWords) == 0 {
+		return paragraphs
+	}
+
+	// Cell words match paragraph words, or are parts of them when words were
+	// split at cell boundaries
+	const tolerance = 0.5
+	inTable := func(word EnrichedWord) bool {
+		for _, cellWord := range cellWords {
+			if rectContains(expandRect(word.Box, tolerance), cellWord.Box) && strings.Contains(word.Text, cellWord.Text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	kept := make([]Paragraph, 0, len(paragraphs))
+	for _, para := range paragraphs {
+		var lines []Line
+		clipped := false
+		for _, line := range para.Lines {
+			var words []EnrichedWord
+			for _, word := range line.Words {
+				if !inTable(word) {
+					words = append(words, word)
+				}
+			}
+			if len(words) < len(line.Words) {
+				clipped = true
+			}
+			if len(words) == 0 {
+				continue
+			}
+			if len(words) < len(line.Words) {
+				line = lineFromWords(words, line.Baseline)
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		if clipped {
+			para.Lines = lines
+			para.Box = lines[0].Box
+			for _, line := range lines[1:] {
+				para.Box = mergeRects(para.Box, line.Box)
+			}
+		}
+		kept = append(kept, para)
+	}
+	return kept
+}
+
+// clipLines keeps the words of each line whose center passes inColumn,
+// dropping lines left empty.
+func clipLines(lines []Line, inColumn func(x float64) bool) []Line {
+	var clipped []Line
+	for _, line := range lines {
+		var words []EnrichedWord
+		for _, word := range line.Words {
+			if inColumn(word.Box.CenterX()) {
+				words = append(words, word)
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		kept := line
+		kept.Words = words
+		kept.Box = words[0].Box
+		for _, word := range words[1:] {
+			kept.Box = mergeRects(kept.Box, word.Box)
+		}
+		clipped = append(clipped, kept)
+	}
+	return clipped
+}
+
+// pageTextLines returns the page's lines in top-to-bottom order. Pages from
+// ExtractPage reuse the lines paragraphs were built from; pages assembled
+// elsewhere have their paragraph words regrouped.
+func pageTextLines(page *Page) []Line {
+	if page.textLines == nil {
+		return groupWordsIntoLinesBaseline(pageWords(page))
+	}
+
+	lines := make([]Line, len(page.textLines))
+	copy(lines, page.textLines)
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Box.Y0 < lines[j].Box.Y0
+	})
+	return lines
+}
+
+// pageWords collects all words from the page's paragraphs.
+func pageWords(page *Page) []EnrichedWord {
+	var words []EnrichedWord
+	for _, para := range page.Paragraphs {
+		for _, line := range para.Lines {
+			words = append(words, line.Words...)
+		}
+	}
+	return words
+}
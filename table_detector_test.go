@@ -0,0 +1,268 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectPageTables_CustomDetector(t *testing.T) {
+	page := &Page{
+		Number: 1,
+		Width:  612,
+		Height: 792,
+		Paragraphs: []Paragraph{
+			{Lines: []Line{{Words: []EnrichedWord{
+				{Text: "Name", Box: Rect{X0: 100, Y0: 100, X1: 140, Y1: 110}},
+				{Text: "Age", Box: Rect{X0: 200, Y0: 100, X1: 225, Y1: 110}},
+			}}}},
+			{Lines: []Line{{Words: []EnrichedWord{
+				{Text: "Jane", Box: Rect{X0: 100, Y0: 120, X1: 135, Y1: 130}},
+				{Text: "30", Box: Rect{X0: 200, Y0: 120, X1: 215, Y1: 130}},
+			}}}},
+		},
+	}
+
+	// A detector that only knows cell geometry, like an ML model would
+	calls := 0
+	detector := TableDetectorFunc(func(page *Page, cfg TableSettings) []Table {
+		calls++
+		cell := func(x0, top, x1, bottom float64) TableCell {
+			return TableCell{BBox: CellBBox{X0: x0, Top: top, X1: x1, Bottom: bottom}}
+		}
+		return []Table{{
+			BBox: CellBBox{X0: 90, Top: 95, X1: 250, Bottom: 135},
+			Rows: []TableRow{
+				{Cells: []TableCell{cell(90, 95, 190, 115), cell(190, 95, 250, 115)}},
+				{Cells: []TableCell{cell(90, 115, 190, 135), cell(190, 115, 250, 135)}},
+			},
+			NumRows: 2,
+			NumCols: 2,
+		}}
+	})
+
+	config := DefaultConfig()
+	config.TableDetectors = []TableDetector{detector}
+
+	tables := detectPageTables(page, config)
+	if calls != 1 {
+		t.Fatalf("custom detector called %d times, want 1", calls)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+
+	want := [][]string{{"Name", "Age"}, {"Jane", "30"}}
+	for r, row := range tables[0].Rows {
+		for c, cell := range row.Cells {
+			if cell.Content != want[r][c] {
+				t.Errorf("cell[%d][%d] = %q, want %q", r, c, cell.Content, want[r][c])
+			}
+		}
+	}
+}
+
+func TestDetectPageTables_Provenance(t *testing.T) {
+	page := &Page{Number: 1, Width: 612, Height: 792}
+	grid := func(x0 float64) Table {
+		cell := func(x0, top, x1, bottom float64) TableCell {
+			return TableCell{BBox: CellBBox{X0: x0, Top: top, X1: x1, Bottom: bottom}, Content: "x"}
+		}
+		return Table{
+			BBox: CellBBox{X0: x0, Top: 100, X1: x0 + 100, Bottom: 140},
+			Rows: []TableRow{
+				{Cells: []TableCell{cell(x0, 100, x0+50, 120), cell(x0+50, 100, x0+100, 120)}},
+				{Cells: []TableCell{cell(x0, 120, x0+50, 140), cell(x0+50, 120, x0+100, 140)}},
+			},
+			NumRows: 2,
+			NumCols: 2,
+		}
+	}
+
+	// Two detectors find nearly the same table; the second names itself
+	unnamed := TableDetectorFunc(func(page *Page, cfg TableSettings) []Table {
+		return []Table{grid(100)}
+	})
+	named := TableDetectorFunc(func(page *Page, cfg TableSettings) []Table {
+		table := grid(102)
+		table.Provenance.Detector = "model"
+		return []Table{table}
+	})
+
+	config := DefaultConfig()
+	config.TableSettings.SnapTolerance = 5
+	config.TableDetectors = []TableDetector{unnamed, named}
+
+	tables := detectPageTables(page, config)
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	got := tables[0].Provenance
+	if got.Detector != "model" || got.Settings.SnapTolerance != 5 {
+		t.Errorf("Provenance = %+v, want detector %q with the configured settings", got, "model")
+	}
+	if len(got.Duplicates) != 1 || got.Duplicates[0].Detector != DetectorCustom || got.Duplicates[0].BBox.X0 != 100 || got.Duplicates[0].Overlap < 0.9 {
+		t.Errorf("Duplicates = %+v, want the custom detector's table at x 100", got.Duplicates)
+	}
+
+	out := tableJSON(tables[0]).Provenance
+	if out.Detector != "model" || len(out.Duplicates) != 1 || out.Thresholds != nil {
+		t.Errorf("JSON provenance = %+v", out)
+	}
+}
+
+func TestTableDetectorName(t *testing.T) {
+	tests := map[string]TableDetector{
+		DetectorLines:    LineTableDetector{},
+		DetectorSegments: SegmentTableDetector{Adaptive: true},
+		DetectorHybrid:   &HybridTableDetector{},
+		DetectorCustom:   TableDetectorFunc(func(*Page, TableSettings) []Table { return nil }),
+	}
+	for want, detector := range tests {
+		if got := tableDetectorName(detector); got != want {
+			t.Errorf("tableDetectorName(%T) = %q, want %q", detector, got, want)
+		}
+	}
+}
+
+func TestDefaultTableDetectors(t *testing.T) {
+	tests := []struct {
+		name         string
+		segmentBased bool
+		hybrid       bool
+		want         int
+	}{
+		{name: "line-based only", segmentBased: false, want: 1},
+		{name: "segment and line-based", segmentBased: true, want: 2},
+		{name: "hybrid replaces both", segmentBased: true, hybrid: true, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.UseSegmentBasedTables = tt.segmentBased
+			config.UseHybridTables = tt.hybrid
+
+			if got := len(DefaultTableDetectors(config)); got != tt.want {
+				t.Errorf("len(DefaultTableDetectors) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// proseLine lays out text as 30pt words starting at x, one line at y.
+func proseLine(text string, x, y float64) Line {
+	var line Line
+	for _, word := range strings.Fields(text) {
+		w := EnrichedWord{Text: word, Box: Rect{X0: x, Y0: y, X1: x + 30, Y1: y + 10}}
+		line.Words = append(line.Words, w)
+		x += 35
+	}
+	line.Box = mergeRects(line.Words[0].Box, line.Words[len(line.Words)-1].Box)
+	return line
+}
+
+func TestDetectPageTables_PerColumn(t *testing.T) {
+	columns := []Column{
+		{Box: Rect{X0: 0, X1: 306}},
+		{Box: Rect{X0: 306, X1: 612}},
+	}
+	edges := []Edge{
+		{X0: 50, X1: 560, Top: 95, Bottom: 95, Width: 510, Orientation: "h"},
+		{X0: 100, X1: 100, Top: 95, Bottom: 150, Height: 55, Orientation: "v"},
+	}
+
+	// Each line runs across both columns at the same height
+	prose := &Page{
+		Width:  612,
+		Height: 792,
+		Paragraphs: []Paragraph{{Lines: []Line{
+			joinLines(proseLine("revenue grew in the year", 50, 100), proseLine("as discussed in the notes", 330, 100)),
+			joinLines(proseLine("driven by higher volumes and", 50, 115), proseLine("below the operating result", 330, 115)),
+		}}},
+		Lines:   edges,
+		Columns: columns,
+	}
+
+	// A page-wide table whose gutters look like columns
+	table := &Page{
+		Width:  612,
+		Height: 792,
+		Paragraphs: []Paragraph{{Lines: []Line{
+			joinLines(proseLine("Revenue", 50, 100), proseLine("1,200", 330, 100)),
+			joinLines(proseLine("Expenses", 50, 115), proseLine("900", 330, 115)),
+		}}},
+		Lines:   edges,
+		Columns: columns,
+	}
+
+	var seen []*Page
+	detector := TableDetectorFunc(func(region *Page, cfg TableSettings) []Table {
+		seen = append(seen, region)
+		return nil
+	})
+	config := DefaultConfig()
+	config.TableDetectors = []TableDetector{detector}
+
+	detectPageTables(prose, config)
+	if len(seen) != 2 {
+		t.Fatalf("detector called %d times on two-column prose, want once per column", len(seen))
+	}
+
+	left, right := pageWords(seen[0]), pageWords(seen[1])
+	if len(left) != 10 || left[0].Text != "revenue" {
+		t.Errorf("left column has %d words starting %q, want 10 starting \"revenue\"", len(left), left[0].Text)
+	}
+	if len(right) != 9 || right[0].Text != "as" {
+		t.Errorf("right column has %d words starting %q, want 9 starting \"as\"", len(right), right[0].Text)
+	}
+	if len(seen[0].Lines) != 2 || seen[0].Lines[0].X1 != 306 {
+		t.Errorf("left column edges = %+v, want clipped rule and vertical edge", seen[0].Lines)
+	}
+	if len(seen[1].Lines) != 1 || seen[1].Lines[0].X0 != 306 {
+		t.Errorf("right column edges = %+v, want only the clipped rule", seen[1].Lines)
+	}
+	if seen[0].Width != 306 {
+		t.Errorf("left column width = %v, want 306", seen[0].Width)
+	}
+
+	seen = nil
+	detectPageTables(table, config)
+	if len(seen) != 1 || seen[0] != table {
+		t.Errorf("detector called %d times on a page-wide table, want once on the full page", len(seen))
+	}
+}
+
+// joinLines combines two lines at the same height into one.
+func joinLines(a, b Line) Line {
+	return Line{Words: append(a.Words, b.Words...), Box: mergeRects(a.Box, b.Box)}
+}
+
+func TestWithoutTableText(t *testing.T) {
+	word := func(text string, x0, y0 float64) EnrichedWord {
+		return EnrichedWord{Text: text, Box: Rect{X0: x0, Y0: y0, X1: x0 + 40, Y1: y0 + 10}}
+	}
+	intro := word("Sales", 50, 50)
+	region, north := word("Region", 50, 100), word("North", 50, 120)
+	paragraphs := []Paragraph{
+		{Lines: []Line{{Words: []EnrichedWord{intro}, Box: intro.Box}}},
+		{Lines: []Line{
+			{Words: []EnrichedWord{region, word("note", 200, 100)}},
+			{Words: []EnrichedWord{north}, Box: north.Box},
+		}},
+	}
+	tables := []Table{{Rows: []TableRow{
+		{Cells: []TableCell{{Words: []EnrichedWord{region}}}},
+		{Cells: []TableCell{{Words: []EnrichedWord{north}}}},
+	}}}
+
+	got := withoutTableText(paragraphs, tables)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 paragraphs, got %d", len(got))
+	}
+	if got[0].Text() != "Sales" {
+		t.Errorf("Paragraph outside the table changed: %q", got[0].Text())
+	}
+	if len(got[1].Lines) != 1 || got[1].Text() != "note" || got[1].Box.X0 != 200 {
+		t.Errorf("Expected only the word beside the table to remain, got %q at %+v", got[1].Text(), got[1].Box)
+	}
+}
@@ -403,7 +403,7 @@ func cellsToTables(cells []CellBBox) [][]CellBBox {
 }
 
 // createTable creates a Table structure from cells and extracts content.
-func createTable(page *Page, cells []CellBBox, words []EnrichedWord) Table {
+func createTable(page *Page, cells []CellBBox, words []EnrichedWord, settings TableSettings) Table {
 	if len(cells) == 0 {
 		return Table{}
 	}
@@ -464,52 +464,21 @@ func createTable(page *Page, cells []CellBBox, words []EnrichedWord) Table {
 		})
 	}
 
+	// Assign words to cells
+	assigned := assignWordsToCells(cells, words, settings)
+	wordsByCell := make(map[CellBBox][]EnrichedWord, len(cells))
+	for i, cell := range cells {
+		wordsByCell[cell] = append(wordsByCell[cell], assigned[i]...)
+	}
+
 	// Extract content for each cell
 	tableRows := make([]TableRow, 0, len(rows))
-	maxCols := 0
 
 	for _, row := range rows {
 		tableCells := make([]TableCell, 0, len(row.cells))
 
 		for _, cellBBox := range row.cells {
-			// Find words within this cell (with small tolerance for boundary)
-			const tolerance = 1.0
-			cellWords := []EnrichedWord{}
-			for _, word := range words {
-				// Check if word center is inside cell
-				wordCenterX := (word.Box.X0 + word.Box.X1) / 2
-				wordCenterY := (word.Box.Y0 + word.Box.Y1) / 2
-
-				if wordCenterX >= cellBBox.X0-tolerance &&
-					wordCenterX <= cellBBox.X1+tolerance &&
-					wordCenterY >= cellBBox.Top-tolerance &&
-					wordCenterY <= cellBBox.Bottom+tolerance {
-					cellWords = append(cellWords, word)
-				}
-			}
-
-			// Sort words by position (top to bottom, left to right)
-			sort.Slice(cellWords, func(i, j int) bool {
-				if math.Abs(cellWords[i].Box.Y0-cellWords[j].Box.Y0) < 2.0 {
-					return cellWords[i].Box.X0 < cellWords[j].Box.X0
-				}
-				return cellWords[i].Box.Y0 < cellWords[j].Box.Y0
-			})
-
-			// Build cell content
-			content := ""
-			for i, word := range cellWords {
-				if i > 0 {
-					prevWord := cellWords[i-1]
-					// Check if this is a new line (vertical gap)
-					if word.Box.Y0-prevWord.Box.Y1 > 2.0 {
-						content += "\n"
-					} else {
-						content += " "
-					}
-				}
-				content += word.Text
-			}
+			cellWords, content := joinCellWords(wordsByCell[cellBBox])
 
 			tableCells = append(tableCells, TableCell{
 				BBox:    cellBBox,
@@ -518,17 +487,17 @@ func createTable(page *Page, cells []CellBBox, words []EnrichedWord) Table {
 			})
 		}
 
-		if len(tableCells) > maxCols {
-			maxCols = len(tableCells)
-		}
-
-		// Calculate row bounding box
+		// Calculate row bounding box, ending where the shortest cell does so
+		// cells merged with the rows below don't stretch it
 		rowBBox := CellBBox{
 			X0:     row.cells[0].X0,
 			Top:    row.top,
 			X1:     row.cells[len(row.cells)-1].X1,
 			Bottom: row.cells[0].Bottom,
 		}
+		for _, cell := range row.cells[1:] {
+			rowBBox.Bottom = math.Min(rowBBox.Bottom, cell.Bottom)
+		}
 
 		tableRows = append(tableRows, TableRow{
 			Cells: tableCells,
@@ -536,6 +505,9 @@ func createTable(page *Page, cells []CellBBox, words []EnrichedWord) Table {
 		})
 	}
 
+	// Merged cells span the columns and rows their boxes cross
+	tableRows, numCols := gridTableRows(tableRows)
+
 	// Filter out empty rows (rows where all cells are empty)
 	nonEmptyRows := make([]TableRow, 0, len(tableRows))
 	for _, row := range tableRows {
@@ -551,11 +523,42 @@ func createTable(page *Page, cells []CellBBox, words []EnrichedWord) Table {
 		}
 	}
 
+	recountRowSpans(nonEmptyRows)
+
 	return Table{
 		BBox:    bbox,
 		Rows:    nonEmptyRows,
 		Cells:   cells,
 		NumRows: len(nonEmptyRows),
-		NumCols: maxCols,
+		NumCols: numCols,
 	}
 }
+
+// joinCellWords sorts a cell's words into reading order and joins them,
+// separating visual lines with newlines.
+func joinCellWords(cellWords []EnrichedWord) ([]EnrichedWord, string) {
+	// Sort words by position (top to bottom, left to right)
+	sort.Slice(cellWords, func(i, j int) bool {
+		if math.Abs(cellWords[i].Box.Y0-cellWords[j].Box.Y0) < 2.0 {
+			return cellWords[i].Box.X0 < cellWords[j].Box.X0
+		}
+		return cellWords[i].Box.Y0 < cellWords[j].Box.Y0
+	})
+
+	// Build cell content
+	content := ""
+	for i, word := range cellWords {
+		if i > 0 {
+			prevWord := cellWords[i-1]
+			// Check if this is a new line (vertical gap)
+			if word.Box.Y0-prevWord.Box.Y1 > 2.0 {
+				content += "\n"
+			} else {
+				content += " "
+			}
+		}
+		content += word.Text
+	}
+
+	return cellWords, content
+}
@@ -0,0 +1,287 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"sort"
+)
+
+// HybridTableDetector combines drawn-line and text-alignment evidence within
+// each table. Candidate regions come from both line-based and segment-based
+// detection; inside a region, drawn rules set cell boundaries where they exist
+// and gaps in the text set them everywhere else. This handles partially ruled
+// tables, such as those with only the header underlined, that neither
+// approach reads correctly alone.
+type HybridTableDetector struct {
+	// Adaptive derives spacing thresholds for segment-based region finding
+	// from the page's word gaps instead of using fixed defaults
+	Adaptive bool
+}
+
+// Detect finds table regions and rebuilds each region's grid from rules and text.
+func (d HybridTableDetector) Detect(page *Page, cfg TableSettings) []Table {
+	tables, _ := d.detectWithRejects(page, cfg)
+	return tables
+}
+
+// detectWithRejects runs hybrid detection, also returning the segment-based
+// candidates that failed their gates.
+func (d HybridTableDetector) detectWithRejects(page *Page, cfg TableSettings) ([]Table, []RejectedTable) {
+	var candidates []Table
+	if len(page.Lines) > 0 {
+		candidates = append(candidates, DetectTables(page, cfg)...)
+	}
+	segments := SegmentTableDetector{Adaptive: d.Adaptive, proposeOnly: true}
+	thresholds := segments.thresholds(page)
+	segmentTables, rejected := segments.detectWithRejects(page, cfg)
+	candidates = append(candidates, segmentTables...)
+	if len(candidates) == 0 {
+		return nil, rejected
+	}
+
+	regions := make([]Rect, 0, len(candidates))
+	for _, table := range candidates {
+		regions = append(regions, Rect{X0: table.BBox.X0, Y0: table.BBox.Top, X1: table.BBox.X1, Y1: table.BBox.Bottom})
+	}
+	regions = mergeOverlappingRects(regions)
+
+	lines := pageTextLines(page)
+	var tables []Table
+	for _, region := range regions {
+		region = extendToRules(region, page.Lines)
+		if table, ok := buildHybridTable(region, clipLinesToRect(lines, region), page.Lines); ok {
+			table.Provenance.Thresholds = &thresholds
+			table.Provenance.Adaptive = d.Adaptive
+			tables = append(tables, table)
+		}
+	}
+	return tables, rejected
+}
+
+// hybridRuleCoverage is the fraction of a region's width (or height) a drawn
+// rule must span to count as a row (or column) boundary.
+const hybridRuleCoverage = 0.5
+
+// buildHybridTable builds the cell grid for a table region. It reports false
+// when the region does not hold at least two rows and two columns.
+func buildHybridTable(region Rect, lines []Line, rules []Edge) (Table, bool) {
+	if len(lines) < 2 {
+		return Table{}, false
+	}
+
+	var fontSizes []float64
+	for _, line := range lines {
+		fontSizes = append(fontSizes, getLineFontSize(line))
+	}
+	fontSize := calculateMedian(fontSizes)
+	tolerance := fontSize / 2
+
+	hRules, vRules := regionRules(region, rules, tolerance)
+	colBounds := columnBoundaries(region, lines, vRules, fontSize)
+	rowBounds := rowBoundaries(region, lines, hRules, colBounds)
+	if len(colBounds) < 3 || len(rowBounds) < 3 {
+		return Table{}, false
+	}
+
+	table := Table{
+		BBox:    CellBBox{X0: region.X0, Top: region.Y0, X1: region.X1, Bottom: region.Y1},
+		NumRows: len(rowBounds) - 1,
+		NumCols: len(colBounds) - 1,
+	}
+	for r := 0; r+1 < len(rowBounds); r++ {
+		row := TableRow{BBox: CellBBox{X0: region.X0, Top: rowBounds[r], X1: region.X1, Bottom: rowBounds[r+1]}}
+		for c := 0; c+1 < len(colBounds); c++ {
+			cell := CellBBox{X0: colBounds[c], Top: rowBounds[r], X1: colBounds[c+1], Bottom: rowBounds[r+1]}
+			row.Cells = append(row.Cells, TableCell{BBox: cell})
+			table.Cells = append(table.Cells, cell)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, true
+}
+
+// regionRules returns the positions of drawn rules that cross most of the
+// region: the Y of horizontal rules strictly inside it and the X of vertical ones.
+func regionRules(region Rect, rules []Edge, tolerance float64) (hRules, vRules []float64) {
+	for _, rule := range rules {
+		if rule.IsColumnSeparator {
+			continue
+		}
+		switch rule.Orientation {
+		case "h":
+			overlap := math.Min(rule.X1, region.X1) - math.Max(rule.X0, region.X0)
+			if overlap >= region.Width()*hybridRuleCoverage && rule.Top > region.Y0+tolerance && rule.Top < region.Y1-tolerance {
+				hRules = append(hRules, rule.Top)
+			}
+		case "v":
+			overlap := math.Min(rule.Bottom, region.Y1) - math.Max(rule.Top, region.Y0)
+			if overlap >= region.Height()*hybridRuleCoverage && rule.X0 > region.X0+tolerance && rule.X0 < region.X1-tolerance {
+				vRules = append(vRules, rule.X0)
+			}
+		}
+	}
+	sort.Float64s(hRules)
+	sort.Float64s(vRules)
+	return hRules, vRules
+}
+
+// columnBoundaries returns the X positions separating columns, region edges
+// included. Drawn vertical rules are used where present; elsewhere a column
+// boundary falls in any gap at least a font size wide that no word in the
+// region crosses.
+func columnBoundaries(region Rect, lines []Line, vRules []float64, fontSize float64) []float64 {
+	type span struct{ x0, x1 float64 }
+	var spans []span
+	for _, line := range lines {
+		for _, word := range line.Words {
+			spans = append(spans, span{word.Box.X0, word.Box.X1})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].x0 < spans[j].x0 })
+
+	bounds := append([]float64{region.X0}, vRules...)
+	if len(spans) > 0 {
+		coveredTo := spans[0].x1
+		for _, s := range spans[1:] {
+			if gap := s.x0 - coveredTo; gap >= fontSize && !containsAny(vRules, coveredTo, s.x0) {
+				bounds = append(bounds, (coveredTo+s.x0)/2)
+			}
+			coveredTo = math.Max(coveredTo, s.x1)
+		}
+	}
+	bounds = append(bounds, region.X1)
+	sort.Float64s(bounds)
+	return bounds
+}
+
+// rowBoundaries returns the Y positions separating rows, region edges
+// included. Drawn horizontal rules always separate rows. Between rules, each
+// text line starts a new row unless it continues the row above, which it does
+// when its first column is empty. Up to three lines above the first rule are
+// the header and form one row, as long as more lines follow the rule; a rule
+// near the bottom instead sets off a totals row.
+func rowBoundaries(region Rect, lines []Line, hRules []float64, colBounds []float64) []float64 {
+	firstColumnEnd := region.X1
+	if len(colBounds) > 1 {
+		firstColumnEnd = colBounds[1]
+	}
+	startsInFirstColumn := func(line Line) bool {
+		return len(line.Words) > 0 && line.Words[0].Box.CenterX() < firstColumnEnd
+	}
+
+	const maxHeaderLines = 3
+	headerLines := 0
+	if len(hRules) > 0 {
+		for _, line := range lines {
+			if line.Box.Y1 < hRules[0] {
+				headerLines++
+			}
+		}
+		if headerLines > maxHeaderLines || headerLines >= len(lines)-headerLines {
+			headerLines = 0
+		}
+	}
+
+	bounds := append([]float64{region.Y0}, hRules...)
+	for i := 1; i < len(lines); i++ {
+		prev, line := lines[i-1], lines[i]
+		gapTop, gapBottom := prev.Box.Y1, line.Box.Y0
+		if gapBottom < gapTop {
+			gapTop, gapBottom = gapBottom, gapTop
+		}
+
+		switch {
+		case containsAny(hRules, gapTop, gapBottom):
+			// A drawn rule already separates these lines
+		case i < headerLines:
+			// Header lines above the first rule form one row
+		case !startsInFirstColumn(line):
+			// Continuation of a wrapped cell
+		default:
+			bounds = append(bounds, (prev.Box.Y1+line.Box.Y0)/2)
+		}
+	}
+	bounds = append(bounds, region.Y1)
+	sort.Float64s(bounds)
+	return bounds
+}
+
+// extendToRules grows a region vertically to take in horizontal rules just
+// above or below it, such as the top rule over a header, so they count as
+// table borders rather than being left outside.
+func extendToRules(region Rect, rules []Edge) Rect {
+	const reach = 6.0 // points beyond the text a border rule may sit
+
+	for _, rule := range rules {
+		if rule.Orientation != "h" || rule.IsColumnSeparator {
+			continue
+		}
+		overlap := math.Min(rule.X1, region.X1) - math.Max(rule.X0, region.X0)
+		if overlap < region.Width()*hybridRuleCoverage {
+			continue
+		}
+		if rule.Top < region.Y0 && rule.Top >= region.Y0-reach {
+			region.Y0 = rule.Top
+		}
+		if rule.Top > region.Y1 && rule.Top <= region.Y1+reach {
+			region.Y1 = rule.Top
+		}
+	}
+	return region
+}
+
+// clipLinesToRect keeps the words of each line whose center lies in rect,
+// dropping lines left empty.
+func clipLinesToRect(lines []Line, rect Rect) []Line {
+	var clipped []Line
+	for _, line := range lines {
+		var words []EnrichedWord
+		for _, word := range line.Words {
+			x, y := word.Box.CenterX(), word.Box.CenterY()
+			if x >= rect.X0 && x <= rect.X1 && y >= rect.Y0 && y <= rect.Y1 {
+				words = append(words, word)
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		kept := line
+		kept.Words = words
+		kept.Box = words[0].Box
+		for _, word := range words[1:] {
+			kept.Box = mergeRects(kept.Box, word.Box)
+		}
+		clipped = append(clipped, kept)
+	}
+	return clipped
+}
+
+// mergeOverlappingRects unions rectangles that overlap until none do.
+func mergeOverlappingRects(rects []Rect) []Rect {
+	merged := append([]Rect(nil), rects...)
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(merged) && !changed; i++ {
+			for j := i + 1; j < len(merged); j++ {
+				a, b := merged[i], merged[j]
+				if a.X0 < b.X1 && b.X0 < a.X1 && a.Y0 < b.Y1 && b.Y0 < a.Y1 {
+					merged[i] = mergeRects(a, b)
+					merged = append(merged[:j], merged[j+1:]...)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// containsAny reports whether any value lies strictly between lo and hi.
+func containsAny(values []float64, lo, hi float64) bool {
+	for _, v := range values {
+		if v > lo && v < hi {
+			return true
+		}
+	}
+	return false
+}
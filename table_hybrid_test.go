@@ -0,0 +1,117 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// tableLine lays out one table row, placing each non-empty cell's words from
+// the cell's column X position.
+func tableLine(y float64, columnX []float64, cells ...string) Line {
+	var line Line
+	for i, text := range cells {
+		x := columnX[i]
+		for _, word := range strings.Fields(text) {
+			width := float64(len(word)) * 5
+			line.Words = append(line.Words, EnrichedWord{Text: word, FontSize: 10, Box: Rect{X0: x, Y0: y, X1: x + width, Y1: y + 10}})
+			x += width + 3
+		}
+	}
+	line.Box = line.Words[0].Box
+	for _, word := range line.Words[1:] {
+		line.Box = mergeRects(line.Box, word.Box)
+	}
+	return line
+}
+
+func TestHybridTableDetector_HeaderRuleOnly(t *testing.T) {
+	columnX := []float64{100, 250, 350}
+	lines := []Line{
+		tableLine(100, columnX, "Item", "Qty", "Price"),
+		tableLine(125, columnX, "Flat white", "2", "$9.00"),
+		tableLine(140, columnX, "Banana bread with", "1", "$6.50"),
+		tableLine(152, columnX, "", "butter", ""),
+		tableLine(167, columnX, "Sparkling water", "3", "$12.00"),
+		tableLine(182, columnX, "Scone", "2", "$8.00"),
+	}
+
+	// Only the header is underlined: no vertical rules and no row rules
+	headerRule := Edge{X0: 95, X1: 400, Top: 115, Bottom: 115, Width: 305, Orientation: "h"}
+
+	page := &Page{Number: 1, Width: 612, Height: 792, Lines: []Edge{headerRule}, textLines: lines}
+	for _, line := range lines {
+		page.Paragraphs = append(page.Paragraphs, Paragraph{Lines: []Line{line}, Box: line.Box})
+	}
+
+	config := DefaultConfig()
+	config.UseHybridTables = true
+	tables := detectPageTables(page, config)
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+
+	want := [][]string{
+		{"Item", "Qty", "Price"},
+		{"Flat white", "2", "$9.00"},
+		{"Banana bread with", "1 butter", "$6.50"},
+		{"Sparkling water", "3", "$12.00"},
+		{"Scone", "2", "$8.00"},
+	}
+	table := tables[0]
+	if table.NumRows != len(want) || table.NumCols != 3 {
+		t.Fatalf("got %dx%d table, want %dx3", table.NumRows, table.NumCols, len(want))
+	}
+	for r, row := range table.Rows {
+		for c, cell := range row.Cells {
+			if got := strings.ReplaceAll(cell.Content, "\n", " "); got != want[r][c] {
+				t.Errorf("cell[%d][%d] = %q, want %q", r, c, got, want[r][c])
+			}
+		}
+	}
+}
+
+func TestRowBoundaries_Header(t *testing.T) {
+	columnX := []float64{100, 250}
+	region := Rect{X0: 95, Y0: 95, X1: 300, Y1: 200}
+	colBounds := []float64{95, 200, 300}
+
+	tests := []struct {
+		name   string
+		lines  []Line
+		hRules []float64
+		want   int
+	}{
+		{
+			name: "two line header above rule",
+			lines: []Line{
+				tableLine(100, columnX, "Unit", "Total"),
+				tableLine(112, columnX, "Price", "Amount"),
+				tableLine(135, columnX, "A", "1"),
+				tableLine(150, columnX, "B", "2"),
+				tableLine(165, columnX, "C", "3"),
+			},
+			hRules: []float64{127},
+			want:   4,
+		},
+		{
+			name: "rule above totals row",
+			lines: []Line{
+				tableLine(100, columnX, "A", "1"),
+				tableLine(115, columnX, "B", "2"),
+				tableLine(130, columnX, "C", "3"),
+				tableLine(145, columnX, "D", "4"),
+				tableLine(165, columnX, "Total", "10"),
+			},
+			hRules: []float64{158},
+			want:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(rowBoundaries(region, tt.lines, tt.hRules, colBounds)) - 1; got != tt.want {
+				t.Errorf("got %d rows, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package pdfmarkdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TableOrientation records where a table's headers are.
+type TableOrientation string
+
+const (
+	// TableHeaderTop is a conventional table with headers across the first row.
+	TableHeaderTop TableOrientation = ""
+
+	// TableHeaderLeft is a transposed table with headers down the first column.
+	TableHeaderLeft TableOrientation = "left"
+)
+
+// detectTableOrientation decides whether a table's headers run across its
+// first row or down its first column. Headers stand apart from the data they
+// label: they are bold where the data is not, and words where the data is
+// numbers. Both candidates are compared against the cells outside the first
+// row and column, and the first column is taken as the header only when it
+// stands apart clearly more than the first row does.
+func detectTableOrientation(table Table) TableOrientation {
+	const (
+		minContrast    = 0.5  // First column must differ from the body by this much
+		minImprovement = 0.25 // and by this much more than the first row does
+	)
+
+	if table.NumRows < 2 || table.NumCols < 2 {
+		return TableHeaderTop
+	}
+
+	rowContrast := headerContrast(table, func(r, c int) bool { return r == 0 })
+	colContrast := headerContrast(table, func(r, c int) bool { return c == 0 })
+	if colContrast >= minContrast && colContrast >= rowContrast+minImprovement {
+		return TableHeaderLeft
+	}
+	return TableHeaderTop
+}
+
+// headerContrast scores how much the non-empty cells selected by isHeader
+// differ from the data cells, those outside the first row and column, in
+// boldness and numeric content. Years count as words, since they are common
+// column headers over numeric data.
+func headerContrast(table Table, isHeader func(r, c int) bool) float64 {
+	var header, body cellStats
+	for r, row := range table.Rows {
+		for c, cell := range row.Cells {
+			content := strings.TrimSpace(cell.Content)
+			if content == "" {
+				continue
+			}
+
+			var counts *cellStats
+			switch {
+			case isHeader(r, c):
+				counts = &header
+			case r > 0 && c > 0:
+				counts = &body
+			default:
+				continue
+			}
+			counts.cells++
+			if isBoldCell(cell) {
+				counts.bold++
+			}
+			if isNumericCell(content) && !(counts == &header && isYearLabel(content)) {
+				counts.numeric++
+			}
+		}
+	}
+	if header.cells == 0 || body.cells == 0 {
+		return 0
+	}
+
+	frac := func(n, total int) float64 { return float64(n) / float64(total) }
+	boldContrast := frac(header.bold, header.cells) - frac(body.bold, body.cells)
+	numericContrast := frac(body.numeric, body.cells) - frac(header.numeric, header.cells)
+	return boldContrast + numericContrast
+}
+
+// cellStats counts the non-empty, bold and numeric cells in a group.
+type cellStats struct {
+	cells, bold, numeric int
+}
+
+// isBoldCell reports whether most of a cell's words are bold.
+func isBoldCell(cell TableCell) bool {
+	if len(cell.Words) == 0 {
+		return false
+	}
+	bold := 0
+	for _, word := range cell.Words {
+		if word.IsBold {
+			bold++
+		}
+	}
+	return bold*2 > len(cell.Words)
+}
+
+// isNumericCell reports whether cell text is a number, allowing currency
+// symbols, thousands separators, percentages and accounting negatives.
+func isNumericCell(content string) bool {
+	s := strings.NewReplacer("$", "", "€", "", "£", "", "¥", "", ",", "", "%", "", " ", "").Replace(content)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// isYearLabel reports whether text is a four-digit year such as "2024".
+func isYearLabel(text string) bool {
+	year, err := strconv.Atoi(text)
+	return err == nil && len(text) == 4 && year >= 1900 && year < 2100
+}
+
+// transposeTable swaps a table's rows and columns, so a table with headers
+// down its first column renders with them across the header row.
+func transposeTable(table Table) Table {
+	transposed := Table{
+		BBox:        table.BBox,
+		Cells:       table.Cells,
+		NumRows:     table.NumCols,
+		NumCols:     len(table.Rows),
+		Orientation: TableHeaderTop,
+	}
+
+	transposed.Rows = make([]TableRow, table.NumCols)
+	for c := range transposed.Rows {
+		row := TableRow{Cells: make([]TableCell, len(table.Rows))}
+		for r := range table.Rows {
+			if c < len(table.Rows[r].Cells) {
+				cell := table.Rows[r].Cells[c]
+				cell.RowSpan, cell.ColSpan = cell.ColSpan, cell.RowSpan
+				row.Cells[r] = cell
+			}
+		}
+
+		// The new row spans the cells of the old column
+		found := false
+		for _, cell := range row.Cells {
+			switch {
+			case cell.BBox == (CellBBox{}):
+				continue
+			case !found:
+				row.BBox, found = cell.BBox, true
+			default:
+				row.BBox.X0 = min(row.BBox.X0, cell.BBox.X0)
+				row.BBox.Top = min(row.BBox.Top, cell.BBox.Top)
+				row.BBox.X1 = max(row.BBox.X1, cell.BBox.X1)
+				row.BBox.Bottom = max(row.BBox.Bottom, cell.BBox.Bottom)
+			}
+		}
+		transposed.Rows[c] = row
+	}
+	return transposed
+}
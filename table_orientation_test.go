@@ -0,0 +1,108 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// makeTable builds a table from rows of cell text. Cells prefixed with "*" are bold.
+func makeTable(rows ...[]string) Table {
+	table := Table{NumRows: len(rows), NumCols: len(rows[0])}
+	for r, cells := range rows {
+		var row TableRow
+		for c, text := range cells {
+			bold := strings.HasPrefix(text, "*")
+			text = strings.TrimPrefix(text, "*")
+			box := CellBBox{X0: float64(c * 100), Top: float64(r * 20), X1: float64(c*100 + 100), Bottom: float64(r*20 + 20)}
+
+			cell := TableCell{BBox: box, Content: text}
+			for _, word := range strings.Fields(text) {
+				cell.Words = append(cell.Words, EnrichedWord{Text: word, IsBold: bold})
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+func TestDetectTableOrientation(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		want  TableOrientation
+	}{
+		{
+			name: "header row",
+			table: makeTable(
+				[]string{"*Product", "*Q1", "*Q2"},
+				[]string{"Widgets", "1,200", "1,350"},
+				[]string{"Gadgets", "800", "(40)"},
+			),
+			want: TableHeaderTop,
+		},
+		{
+			name: "header column",
+			table: makeTable(
+				[]string{"*Revenue", "$1,200", "$1,350", "$1,500"},
+				[]string{"*Expenses", "$900", "$950", "$1,000"},
+				[]string{"*Margin", "25%", "30%", "33%"},
+			),
+			want: TableHeaderLeft,
+		},
+		{
+			name: "plain labels down the first column",
+			table: makeTable(
+				[]string{"Revenue", "1200", "1350"},
+				[]string{"Expenses", "900", "950"},
+			),
+			want: TableHeaderLeft,
+		},
+		{
+			name: "headers on both axes",
+			table: makeTable(
+				[]string{"*Region", "*2023", "*2024"},
+				[]string{"*North", "10", "12"},
+				[]string{"*South", "8", "9"},
+			),
+			want: TableHeaderTop,
+		},
+		{
+			name: "all text",
+			table: makeTable(
+				[]string{"Name", "Role"},
+				[]string{"Jane", "Adviser"},
+			),
+			want: TableHeaderTop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectTableOrientation(tt.table); got != tt.want {
+				t.Errorf("detectTableOrientation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTableToMarkdown_Transpose(t *testing.T) {
+	table := makeTable(
+		[]string{"*Revenue", "$1,200", "$1,350"},
+		[]string{"*Expenses", "$900", "$950"},
+	)
+	table.Orientation = detectTableOrientation(table)
+
+	doc := &Document{Pages: []Page{{Tables: []Table{table}}}}
+
+	config := Config{DetectTables: true, CollapseWhitespace: true}
+	if got := doc.ToMarkdown(config); !strings.Contains(got, "| Revenue | $1,200 | $1,350 |") {
+		t.Errorf("table should render as extracted without TransposeTables:\n%s", got)
+	}
+
+	config.TransposeTables = true
+	want := "| Revenue | Expenses |\n| --- | --- |\n| $1,200 | $900 |\n| $1,350 | $950 |\n"
+	if got := doc.ToMarkdown(config); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package pdfmarkdown
+
+import "math"
+
+// gridTableRows lays out rows of cells, each sorted left to right, on the
+// grid formed by every cell edge: a cell whose box crosses column edges or
+// reaches below the next rows' tops becomes a merged cell with ColSpan and
+// RowSpan, and the positions it covers hold Covered copies of it. Grid
+// positions no cell covers are left empty. It returns the rows and the
+// number of grid columns.
+func gridTableRows(rows []TableRow) ([]TableRow, int) {
+	var edges []float64
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			edges = append(edges, cell.BBox.X0, cell.BBox.X1)
+		}
+	}
+	bounds := distinctBoundaries(edges)
+	numCols := len(bounds) - 1
+	if numCols < 1 {
+		return rows, 0
+	}
+
+	grid := make([]TableRow, len(rows))
+	for r, row := range rows {
+		grid[r] = TableRow{BBox: row.BBox, Cells: make([]TableCell, numCols)}
+		for c := range grid[r].Cells {
+			grid[r].Cells[c].BBox = CellBBox{X0: bounds[c], Top: row.BBox.Top, X1: bounds[c+1], Bottom: row.BBox.Bottom}
+		}
+	}
+
+	filled := make([][]bool, len(rows))
+	for r := range filled {
+		filled[r] = make([]bool, numCols)
+	}
+	for r, row := range rows {
+		for _, cell := range row.Cells {
+			first, last := nearestBoundary(bounds, cell.BBox.X0), nearestBoundary(bounds, cell.BBox.X1)-1
+			if last < first || filled[r][first] {
+				continue // Degenerate, or overlapping a cell placed already
+			}
+			end := r + 1
+			for end < len(rows) && rows[end].BBox.Top < cell.BBox.Bottom-boundaryTolerance {
+				end++
+			}
+
+			for rr := r; rr < end; rr++ {
+				for c := first; c <= last; c++ {
+					if filled[rr][c] {
+						continue
+					}
+					filled[rr][c] = true
+					covered := cell
+					covered.Words, covered.Covered = nil, true
+					grid[rr].Cells[c] = covered
+				}
+			}
+			cell.RowSpan, cell.ColSpan = end-r, last-first+1
+			grid[r].Cells[first] = cell
+		}
+	}
+	return grid, numCols
+}
+
+// nearestBoundary returns the index of the boundary closest to x.
+func nearestBoundary(bounds []float64, x float64) int {
+	best := 0
+	for i, b := range bounds {
+		if math.Abs(b-x) < math.Abs(bounds[best]-x) {
+			best = i
+		}
+	}
+	return best
+}
+
+// recountRowSpans corrects the RowSpan of merged cells after rows are
+// removed from a grid, counting the Covered copies left below each one.
+func recountRowSpans(rows []TableRow) {
+	for r := range rows {
+		for c := range rows[r].Cells {
+			cell := &rows[r].Cells[c]
+			if cell.Covered || cell.RowSpan <= 1 {
+				continue
+			}
+			span := 1
+			for r+span < len(rows) && c < len(rows[r+span].Cells) && rows[r+span].Cells[c].Covered && rows[r+span].Cells[c].BBox == cell.BBox {
+				span++
+			}
+			cell.RowSpan = span
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+// spannedHeaderTable is a ruled table whose "Item" header spans two rows and
+// "Price" header spans two columns
+func spannedHeaderTable() ([]CellBBox, []EnrichedWord) {
+	cells := []CellBBox{
+		{X0: 0, Top: 0, X1: 100, Bottom: 40},
+		{X0: 100, Top: 0, X1: 300, Bottom: 20},
+		{X0: 100, Top: 20, X1: 200, Bottom: 40},
+		{X0: 200, Top: 20, X1: 300, Bottom: 40},
+		{X0: 0, Top: 40, X1: 100, Bottom: 60},
+		{X0: 100, Top: 40, X1: 200, Bottom: 60},
+		{X0: 200, Top: 40, X1: 300, Bottom: 60},
+	}
+	word := func(text string, x0, top float64) EnrichedWord {
+		return EnrichedWord{Text: text, Box: Rect{X0: x0, Y0: top, X1: x0 + 30, Y1: top + 10}}
+	}
+	words := []EnrichedWord{
+		word("Item", 4, 15), word("Price", 185, 5),
+		word("Retail", 104, 25), word("Trade", 204, 25),
+		word("Coffee", 4, 45), word("3.50", 104, 45), word("3.00", 204, 45),
+	}
+	return cells, words
+}
+
+func TestCreateTableSpans(t *testing.T) {
+	cells, words := spannedHeaderTable()
+	table := createTable(&Page{}, cells, words, DefaultTableSettings())
+
+	if table.NumRows != 3 || table.NumCols != 3 {
+		t.Fatalf("got %dx%d table, want 3x3", table.NumRows, table.NumCols)
+	}
+
+	type span struct {
+		content          string
+		rowSpan, colSpan int
+		covered          bool
+	}
+	var got [][]span
+	for _, row := range table.Rows {
+		var spans []span
+		for _, cell := range row.Cells {
+			spans = append(spans, span{cell.Content, cell.RowSpan, cell.ColSpan, cell.Covered})
+		}
+		got = append(got, spans)
+	}
+	want := [][]span{
+		{{"Item", 2, 1, false}, {"Price", 1, 2, false}, {"Price", 0, 0, true}},
+		{{"Item", 0, 0, true}, {"Retail", 1, 1, false}, {"Trade", 1, 1, false}},
+		{{"Coffee", 1, 1, false}, {"3.50", 1, 1, false}, {"3.00", 1, 1, false}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cells = %v, want %v", got, want)
+	}
+}
+
+// TestTableSpansHTML tests that merged cells render once with their spans,
+// and header cells spanning rows take those rows into the header
+func TestTableSpansHTML(t *testing.T) {
+	cells, words := spannedHeaderTable()
+	table := createTable(&Page{}, cells, words, DefaultTableSettings())
+
+	doc := &Document{Pages: []Page{{Number: 1, Tables: []Table{table}}}}
+
+	want := "<table>\n<thead>\n" +
+		"<tr><th rowspan=\"2\">Item</th><th colspan=\"2\">Price</th></tr>\n" +
+		"<tr><th>Retail</th><th>Trade</th></tr>\n" +
+		"</thead>\n<tbody>\n" +
+		"<tr><td>Coffee</td><td>3.50</td><td>3.00</td></tr>\n" +
+		"</tbody>\n</table>\n"
+	if got := doc.ToHTML(DefaultConfig()); got != want {
+		t.Errorf("ToHTML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergeSpanningSegments(t *testing.T) {
+	columns := []TableColumn{
+		{Box: Rect{X0: 0, X1: 100}},
+		{Box: Rect{X0: 100, X1: 200}},
+		{Box: Rect{X0: 200, X1: 300}},
+	}
+	quarter := EnrichedWord{Text: "Quarter", Box: Rect{X0: 120, Y0: 0, X1: 160, Y1: 10}}
+	results := EnrichedWord{Text: "results", Box: Rect{X0: 165, Y0: 0, X1: 270, Y1: 10}}
+	cells := []SegmentTableCell{
+		{Content: "Item", Words: []EnrichedWord{{Text: "Item", Box: Rect{X0: 4, Y0: 0, X1: 30, Y1: 10}}}},
+		{Content: "Quarter", Words: []EnrichedWord{quarter}, Box: Rect{X0: 100, X1: 200}},
+		{Content: "results", Words: []EnrichedWord{results}, Box: Rect{X0: 200, X1: 300}},
+	}
+	segments := []Segment{
+		{Words: cells[0].Words, Box: cells[0].Words[0].Box},
+		{Words: []EnrichedWord{quarter, results}, Box: Rect{X0: 120, Y0: 0, X1: 270, Y1: 10}},
+	}
+
+	mergeSpanningSegments(cells, segments, columns)
+	if cells[0].ColSpan != 0 || cells[0].Content != "Item" {
+		t.Errorf("cell 0 = %q span %d, want unchanged", cells[0].Content, cells[0].ColSpan)
+	}
+	if cells[1].Content != "Quarter results" || cells[1].ColSpan != 2 || cells[1].Box.X1 != 300 {
+		t.Errorf("cell 1 = %q span %d to %g, want \"Quarter results\" spanning 2 to 300", cells[1].Content, cells[1].ColSpan, cells[1].Box.X1)
+	}
+	if !cells[2].Covered || cells[2].Content != "Quarter results" || cells[2].Words != nil {
+		t.Errorf("cell 2 = %+v, want a covered copy", cells[2])
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	pdfmarkdown "github.com/ivanvanderbyl/pdfmarkdown"
+	"github.com/ivanvanderbyl/pdfmarkdown/experimental"
 )
 
 func TestTableDetection_SOA(t *testing.T) {
@@ -149,7 +150,7 @@ func TestTableDetection_SimpleGrid(t *testing.T) {
 	}
 
 	settings := pdfmarkdown.DefaultTableSettings()
-	tables := pdfmarkdown.DetectTables(page, settings)
+	tables := experimental.DetectTables(page, settings)
 
 	require.Greater(t, len(tables), 0, "Expected to detect at least one table")
 
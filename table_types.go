@@ -10,6 +10,9 @@ type Edge struct {
 	Width       float64 // Width (for horizontal edges)
 	Height      float64 // Height (for vertical edges)
 	Orientation string  // "h" for horizontal, "v" for vertical
+
+	// IsColumnSeparator marks a vertical rule dividing text columns rather than table cells
+	IsColumnSeparator bool
 }
 
 // Point represents an (x, y) coordinate where edges intersect.
@@ -31,6 +34,18 @@ type TableCell struct {
 	BBox    CellBBox
 	Content string
 	Words   []EnrichedWord
+
+	// Alignment and VerticalAlignment record where the text sits within BBox
+	Alignment         Alignment
+	VerticalAlignment VerticalAlignment
+
+	// RowSpan and ColSpan count the rows and columns a merged cell covers,
+	// such as a header over two columns; 0 means 1. Rows stays a full grid:
+	// the other positions a merged cell covers hold copies of it, without
+	// Words, marked Covered
+	RowSpan int
+	ColSpan int
+	Covered bool
 }
 
 // TableRow represents a row of cells in a table.
@@ -46,6 +61,50 @@ type Table struct {
 	Cells   []CellBBox // Raw cell bounding boxes
 	NumRows int
 	NumCols int
+
+	// Orientation records whether headers run across the first row or down
+	// the first column
+	Orientation TableOrientation
+
+	// Provenance records which detector found the table and how
+	Provenance TableProvenance
+}
+
+// Names of the built-in table detectors in TableProvenance.Detector.
+const (
+	DetectorLines    = "lines"    // LineTableDetector
+	DetectorSegments = "segments" // SegmentTableDetector
+	DetectorHybrid   = "hybrid"   // HybridTableDetector
+	DetectorCustom   = "custom"   // Detectors outside the package that don't name themselves
+)
+
+// TableProvenance records how a table was detected, so a table that looks
+// wrong can be traced to the detector and settings that produced it.
+type TableProvenance struct {
+	// Detector names the detector that found the table, one of the Detector
+	// constants. Custom detectors may set their own name; tables they leave
+	// unnamed are recorded as DetectorCustom
+	Detector string
+
+	// Settings are the table settings detection ran with
+	Settings TableSettings
+
+	// Thresholds are the gaps segment-based region finding clustered words
+	// with, derived from the page when Adaptive is set; nil for detectors
+	// that don't use them
+	Thresholds *AdaptiveThresholds
+	Adaptive   bool
+
+	// Duplicates lists the tables found over the same area, by other
+	// detectors or the same one, that were dropped in favour of this one
+	Duplicates []TableDuplicate
+}
+
+// TableDuplicate is a table dropped because it overlapped a table that was kept.
+type TableDuplicate struct {
+	Detector string
+	BBox     CellBBox
+	Overlap  float64 // Overlapping area as a share of the smaller table's
 }
 
 // TableSettings configures table detection behavior.
@@ -76,6 +135,14 @@ type TableSettings struct {
 	IntersectionTolerance  float64
 	IntersectionXTolerance float64
 	IntersectionYTolerance float64
+
+	// How words are assigned to cells: "overlap" assigns each word to the cell
+	// it overlaps most, "center" uses the word's center point
+	CellAssignment string
+
+	// Split words that span several cells at the cell boundaries instead of
+	// assigning them whole (only with "overlap" assignment)
+	SplitWordsAtCellBoundaries bool
 }
 
 // DefaultTableSettings returns default settings for table detection.
@@ -96,5 +163,6 @@ func DefaultTableSettings() TableSettings {
 		IntersectionTolerance:  3.0,
 		IntersectionXTolerance: 3.0,
 		IntersectionYTolerance: 3.0,
+		CellAssignment:         "overlap",
 	}
 }
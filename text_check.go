@@ -0,0 +1,177 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+)
+
+// textCheckLookahead is how far ahead in pdfium's bulk text a character read
+// individually is looked for before it is taken to be absent from the bulk
+// text rather than preceded by missing ones.
+const textCheckLookahead = 64
+
+// TextMismatch is a run of text pdfium's bulk text API returns for a page but
+// per-character extraction did not, found with Config.VerifyText.
+type TextMismatch struct {
+	Page     int    // 1-indexed page number
+	Missing  string // The text missing from per-character extraction
+	After    string // Up to 20 extracted characters before it, to locate it
+	Repaired bool   // Inserted into the page's text (Config.RepairMissingText)
+}
+
+// verifyChars compares characters read one at a time with the page's bulk
+// text from FPDFText_GetText, returning the runs missing from chars. With
+// repair, the missing runs are inserted into chars after the character before
+// them, in its style. Whitespace is ignored when aligning the two, since
+// pdfium generates spaces and line breaks in the bulk text. Page is left
+// unset.
+func verifyChars(instance pdfium.Pdfium, textPage references.FPDF_TEXTPAGE, count int, chars []EnrichedChar, repair bool) ([]EnrichedChar, []TextMismatch) {
+	resp, err := instance.FPDFText_GetText(&requests.FPDFText_GetText{
+		TextPage:   textPage,
+		StartIndex: 0,
+		Count:      count,
+	})
+	if err != nil {
+		return chars, nil
+	}
+
+	runs := missingRuns(chars, []rune(resp.Text))
+	if len(runs) == 0 {
+		return chars, nil
+	}
+
+	mismatches := make([]TextMismatch, len(runs))
+	for i, run := range runs {
+		mismatches[i] = TextMismatch{
+			Missing:  string(run.text),
+			After:    textBefore(chars, run.after, 20),
+			Repaired: repair,
+		}
+	}
+	if repair {
+		chars = insertMissingRuns(chars, runs)
+	}
+	return chars, mismatches
+}
+
+// missingRun is text absent from per-character extraction; after is the
+// index of the extracted character it follows, or -1 at the start.
+type missingRun struct {
+	text  []rune
+	after int
+}
+
+// missingRuns aligns chars with the bulk text and returns the runs of bulk
+// text with no extracted character. Extracted characters not found in the
+// bulk text nearby are skipped.
+func missingRuns(chars []EnrichedChar, bulk []rune) []missingRun {
+	var runs []missingRun
+	b, after := 0, -1
+	for i, char := range chars {
+		if isIgnoredInCheck(char.Text) {
+			continue
+		}
+		for b < len(bulk) && isIgnoredInCheck(bulk[b]) {
+			b++
+		}
+
+		match := -1
+		for k := b; k < len(bulk) && k < b+textCheckLookahead; k++ {
+			if bulk[k] == char.Text {
+				match = k
+				break
+			}
+		}
+		if match < 0 {
+			continue // Not in the bulk text, such as an expanded glyph
+		}
+
+		if missing := trimIgnored(bulk[b:match]); len(missing) > 0 {
+			runs = append(runs, missingRun{text: missing, after: after})
+		}
+		b, after = match+1, i
+	}
+	if missing := trimIgnored(bulk[min(b, len(bulk)):]); len(missing) > 0 {
+		runs = append(runs, missingRun{text: missing, after: after})
+	}
+	return runs
+}
+
+// isIgnoredInCheck reports whether r is whitespace or a control character,
+// which the bulk text adds between text runs.
+func isIgnoredInCheck(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r) || r == 0xFFFE
+}
+
+// trimIgnored trims the runes isIgnoredInCheck skips from both ends of
+// runes, and collapses those between other runes to a single space.
+func trimIgnored(runes []rune) []rune {
+	var kept []rune
+	gap := false
+	for _, r := range runes {
+		if isIgnoredInCheck(r) {
+			gap = len(kept) > 0
+			continue
+		}
+		if gap {
+			kept = append(kept, ' ')
+			gap = false
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// textBefore returns up to n characters of chars ending at index end.
+func textBefore(chars []EnrichedChar, end, n int) string {
+	if end < 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, char := range chars[max(0, end-n+1) : end+1] {
+		b.WriteRune(char.Text)
+	}
+	return b.String()
+}
+
+// insertMissingRuns inserts each run after the character it follows, copying
+// that character's style and continuing along its line at its width per
+// character. A run at the start goes before the first character.
+func insertMissingRuns(chars []EnrichedChar, runs []missingRun) []EnrichedChar {
+	if len(chars) == 0 {
+		return chars
+	}
+	result := make([]EnrichedChar, 0, len(chars))
+	next := 0
+	place := func(run missingRun, anchor EnrichedChar, before bool) {
+		width := anchor.Box.Width()
+		x := anchor.Box.X1
+		if before {
+			x = anchor.Box.X0 - width*float64(len(run.text))
+		}
+		for _, r := range run.text {
+			char := anchor
+			char.Text, char.IsHyphen, char.HasOrigin = r, false, false
+			char.Box.X0, char.Box.X1 = x, x+width
+			result = append(result, char)
+			x += width
+		}
+	}
+
+	for next < len(runs) && runs[next].after < 0 {
+		place(runs[next], chars[0], true)
+		next++
+	}
+	for i, char := range chars {
+		result = append(result, char)
+		for next < len(runs) && runs[next].after == i {
+			place(runs[next], char, false)
+			next++
+		}
+	}
+	return result
+}
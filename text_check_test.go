@@ -0,0 +1,65 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+// charsOf lays out text as 5pt-wide characters on one line
+func charsOf(text string) []EnrichedChar {
+	var chars []EnrichedChar
+	x := 0.0
+	for _, r := range text {
+		chars = append(chars, EnrichedChar{Text: r, FontSize: 10, Box: Rect{X0: x, Y0: 0, X1: x + 5, Y1: 10}})
+		x += 5
+	}
+	return chars
+}
+
+func textOf(chars []EnrichedChar) string {
+	runes := make([]rune, len(chars))
+	for i, char := range chars {
+		runes[i] = char.Text
+	}
+	return string(runes)
+}
+
+func TestMissingRuns(t *testing.T) {
+	tests := []struct {
+		name  string
+		chars string
+		bulk  string
+		want  []missingRun
+	}{
+		{"identical", "Hello world", "Hello world\r\n", nil},
+		{"generated spaces", "Helloworld", "Hello world", nil},
+		{"middle", "Helo", "Hello", []missingRun{{text: []rune("l"), after: 2}}},
+		{"start and end", "ell", "Hello", []missingRun{{text: []rune("H"), after: -1}, {text: []rune("o"), after: 2}}},
+		{"run across a space", "Totl", "Total 12 units", []missingRun{{text: []rune("a"), after: 2}, {text: []rune("12 units"), after: 3}}},
+		{"extra extracted char", "Hexllo", "Hello", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingRuns(charsOf(tt.chars), []rune(tt.bulk)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingRuns(%q, %q) = %v, want %v", tt.chars, tt.bulk, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertMissingRuns(t *testing.T) {
+	chars := charsOf("ell")
+	runs := missingRuns(chars, []rune("Hello"))
+	repaired := insertMissingRuns(chars, runs)
+
+	if got := textOf(repaired); got != "Hello" {
+		t.Fatalf("repaired text = %q, want %q", got, "Hello")
+	}
+	// Inserted characters continue along the line at their neighbour's width
+	if h, o := repaired[0].Box, repaired[4].Box; h.X0 != -5 || h.X1 != 0 || o.X0 != 15 || o.X1 != 20 {
+		t.Errorf("inserted boxes = %+v, %+v", h, o)
+	}
+	if repaired[4].FontSize != 10 {
+		t.Errorf("inserted char font size = %g, want its neighbour's 10", repaired[4].FontSize)
+	}
+}
@@ -0,0 +1,33 @@
+package pdfmarkdown
+
+import "regexp"
+
+// applyTextFilters runs each filter over every word in order. Words a filter
+// leaves without text are dropped.
+func applyTextFilters(words []EnrichedWord, filters []func(word *EnrichedWord)) []EnrichedWord {
+	if len(filters) == 0 {
+		return words
+	}
+
+	result := words[:0]
+	for _, word := range words {
+		for _, filter := range filters {
+			filter(&word)
+		}
+		if word.Text != "" {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+// RedactPattern returns a text filter that replaces each match of pattern in
+// a word's text with replacement, which may refer to submatches as in
+// regexp.Regexp.ReplaceAllString. Patterns only see one word at a time, so
+// values printed with spaces, such as "555 123 4567", need a filter that
+// masks each part.
+func RedactPattern(pattern *regexp.Regexp, replacement string) func(word *EnrichedWord) {
+	return func(word *EnrichedWord) {
+		word.Text = pattern.ReplaceAllString(word.Text, replacement)
+	}
+}
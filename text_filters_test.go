@@ -0,0 +1,56 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestApplyTextFilters(t *testing.T) {
+	words := []EnrichedWord{
+		{Text: "Call", Box: Rect{X0: 0, X1: 20}},
+		{Text: "0412-555-019", Box: Rect{X0: 25, X1: 80}},
+		{Text: "or", Box: Rect{X0: 85, X1: 95}},
+		{Text: "ann@example.com", Box: Rect{X0: 100, X1: 180}},
+	}
+
+	var audited []Rect
+	phone := regexp.MustCompile(`^\d{4}-\d{3}-\d{3}$`)
+	filters := []func(word *EnrichedWord){
+		func(word *EnrichedWord) {
+			if phone.MatchString(word.Text) {
+				audited = append(audited, word.Box)
+			}
+		},
+		RedactPattern(phone, "[PHONE]"),
+		RedactPattern(regexp.MustCompile(`[^@\s]+@[^@\s]+`), "[EMAIL]"),
+		func(word *EnrichedWord) {
+			if word.Text == "or" {
+				word.Text = "" // Dropped
+			}
+		},
+	}
+
+	got := applyTextFilters(words, filters)
+	want := []string{"Call", "[PHONE]", "[EMAIL]"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d words, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Text != want[i] {
+			t.Errorf("word %d = %q, want %q", i, got[i].Text, want[i])
+		}
+	}
+	if len(audited) != 1 || audited[0] != (Rect{X0: 25, X1: 80}) {
+		t.Errorf("audited boxes = %v, want the phone number's box", audited)
+	}
+	if got[1].Box != (Rect{X0: 25, X1: 80}) {
+		t.Errorf("redacted word moved to %v", got[1].Box)
+	}
+}
+
+func TestApplyTextFilters_None(t *testing.T) {
+	words := []EnrichedWord{{Text: "kept"}}
+	if got := applyTextFilters(words, nil); len(got) != 1 || got[0].Text != "kept" {
+		t.Errorf("applyTextFilters without filters = %v", got)
+	}
+}
@@ -0,0 +1,294 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TOCHandling selects what happens to the document's own table of contents
+// pages, whose entries run into their page numbers through rows of leader
+// dots ("Introduction ........ 1").
+type TOCHandling string
+
+const (
+	// TOCKeep renders table of contents pages as ordinary text, dots and
+	// page numbers included.
+	TOCKeep TOCHandling = ""
+
+	// TOCClean renders the entries as a nested list linking each one to the
+	// heading it names, dropping the dots and page numbers.
+	TOCClean TOCHandling = "clean"
+
+	// TOCSkip leaves table of contents pages out.
+	TOCSkip TOCHandling = "skip"
+)
+
+// TOCEntry is an entry read from a table of contents page.
+type TOCEntry struct {
+	Title  string
+	Page   string // The page number as printed, "" for entries without one
+	Level  int    // Nesting depth by indent, 0 for the outermost entries
+	Anchor string // Link target of the heading the entry names, "" if none was found
+}
+
+// minTOCEntries is the fewest leader rows ending in a page number that make a
+// page a table of contents.
+const minTOCEntries = 3
+
+// tocPageNumberPattern matches the page number a table of contents entry
+// ends with: arabic or roman, or with a chapter prefix such as "A-3".
+var tocPageNumberPattern = regexp.MustCompile(`^(?:\d+|(?i)[ivxlcdm]+|[A-Z]-?\d+)$`)
+
+// tocEntryLine splits a line of a table of contents page into its title and
+// page number.
+func tocEntryLine(line Line) (title, page string, ok bool) {
+	row, ok := parseLeaderRow(line.Text())
+	if !ok || !tocPageNumberPattern.MatchString(row.Value) {
+		return "", "", false
+	}
+	return row.Label, row.Value, true
+}
+
+// isTOCPage reports whether a page is a table of contents: at least
+// minTOCEntries lines, and half its lines outside headings, are entries
+// running through leader dots to a page number.
+func isTOCPage(page Page) bool {
+	var lines, entries int
+	for _, para := range page.Paragraphs {
+		if para.IsHeading || para.IsCode {
+			continue
+		}
+		for _, line := range para.Lines {
+			lines++
+			if _, _, ok := tocEntryLine(line); ok {
+				entries++
+			}
+		}
+	}
+	return entries >= minTOCEntries && entries*2 >= lines
+}
+
+// withoutTOCPages returns the pages that are not tables of contents.
+func withoutTOCPages(pages []Page) []Page {
+	kept := make([]Page, 0, len(pages))
+	for _, page := range pages {
+		if !isTOCPage(page) {
+			kept = append(kept, page)
+		}
+	}
+	return kept
+}
+
+// cleanTOCPages reads the entries of table of contents pages into their
+// paragraphs' TOCEntries, for rendering as a list of links. Each entry links
+// to the heading whose text it matches, or to the target of an internal link
+// over it (Config.LinkInternalDestinations). Paragraphs without entries, such
+// as the "Contents" heading, are left as they are. The pages are copied
+// rather than changed in place.
+func cleanTOCPages(pages []Page) []Page {
+	targets := headingTargets(pages)
+	pages = append([]Page(nil), pages...)
+	for pi, page := range pages {
+		if !isTOCPage(page) {
+			continue
+		}
+		paragraphs := append([]Paragraph(nil), page.Paragraphs...)
+		var indents []float64
+		for i := range paragraphs {
+			para := &paragraphs[i]
+			if para.IsHeading || para.IsCode {
+				continue
+			}
+			entries, starts := readTOCEntries(*para, targets)
+			para.TOCEntries = entries
+			indents = append(indents, starts...)
+		}
+		levels := indentLevels(indents, paragraphs)
+		k := 0
+		for i := range paragraphs {
+			for j := range paragraphs[i].TOCEntries {
+				paragraphs[i].TOCEntries[j].Level = levels[k]
+				k++
+			}
+		}
+		pages[pi].Paragraphs = paragraphs
+	}
+	return pages
+}
+
+// readTOCEntries reads the entries of a paragraph of a table of contents
+// page, along with the left edge of each. Lines running most of the way
+// across before an entry's line are the start of its title, wrapped; shorter
+// ones are entries of their own without a page number, as part titles often
+// are. A paragraph without entries that have page numbers yields none.
+func readTOCEntries(para Paragraph, targets map[string]string) ([]TOCEntry, []float64) {
+	var entries []TOCEntry
+	var starts []float64
+	var pending []Line
+	var parts []string
+	numbered := false
+	add := func(page string) {
+		title := strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+		entries = append(entries, TOCEntry{Title: title, Page: page, Anchor: tocAnchor(pending, title, targets)})
+		starts = append(starts, pending[0].Box.X0)
+		pending, parts = nil, nil
+	}
+
+	wrapAt := para.Box.X0 + para.Box.Width()*0.7
+	for _, line := range para.Lines {
+		pending = append(pending, line)
+		if title, page, ok := tocEntryLine(line); ok {
+			parts = append(parts, title)
+			add(page)
+			numbered = true
+			continue
+		}
+		parts = append(parts, line.Text())
+		if line.Box.X1 < wrapAt {
+			add("")
+		}
+	}
+	if len(pending) > 0 {
+		add("")
+	}
+	if !numbered {
+		return nil, nil
+	}
+	return entries, starts
+}
+
+// headingTargets maps the normalized text of each heading on the pages to
+// its link target, the first heading taking a text. Headings are also found
+// without a leading section number, as contents pages sometimes leave it out
+// or add it.
+func headingTargets(pages []Page) map[string]string {
+	anchors := headingAnchors(&Document{Pages: pages})
+	targets := make(map[string]string)
+	for pi, page := range pages {
+		for i, para := range page.Paragraphs {
+			anchor, ok := anchors[headingRef{page: pi, para: i}]
+			if !ok {
+				continue
+			}
+			title := normalizeOutlineTitle(headingText(para))
+			for _, key := range []string{title, withoutSectionNumber(title)} {
+				if _, taken := targets[key]; !taken && key != "" {
+					targets[key] = "#" + anchor
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// tocAnchor returns the link target of a table of contents entry: that of an
+// internal link over its text, or else of the heading its title names.
+func tocAnchor(lines []Line, title string, targets map[string]string) string {
+	for _, line := range lines {
+		for _, word := range line.Words {
+			if strings.HasPrefix(word.Link, "#") {
+				return word.Link
+			}
+		}
+	}
+	title = normalizeOutlineTitle(title)
+	if target, ok := targets[title]; ok {
+		return target
+	}
+	return targets[withoutSectionNumber(title)]
+}
+
+// withoutSectionNumber drops a leading section number such as "2 1" (from
+// "2.1") or "chapter 3" from normalized title text.
+func withoutSectionNumber(title string) string {
+	fields := strings.Fields(title)
+	if len(fields) > 1 && (fields[0] == "chapter" || fields[0] == "part" || fields[0] == "section" || fields[0] == "appendix") {
+		fields = fields[1:]
+	}
+	for len(fields) > 1 && strings.ContainsAny(fields[0], "0123456789") {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// indentLevels numbers the distinct left edges of a page's entries from the
+// left, counting edges within half an em of each other as one, and returns
+// the level of each.
+func indentLevels(indents []float64, paragraphs []Paragraph) []int {
+	var sizes []float64
+	for _, para := range paragraphs {
+		if len(para.TOCEntries) > 0 {
+			sizes = append(sizes, paragraphEm(para))
+		}
+	}
+	tolerance := calculateMedian(sizes) / 2
+
+	sorted := append([]float64(nil), indents...)
+	sort.Float64s(sorted)
+	var edges []float64
+	for _, x := range sorted {
+		if len(edges) == 0 || x-edges[len(edges)-1] > tolerance {
+			edges = append(edges, x)
+		}
+	}
+
+	levels := make([]int, len(indents))
+	for i, x := range indents {
+		best := math.MaxFloat64
+		for level, edge := range edges {
+			if d := math.Abs(x - edge); d < best {
+				best, levels[i] = d, level
+			}
+		}
+	}
+	return levels
+}
+
+// tocMarkdown renders entries as a nested markdown list of links, two spaces
+// of indent per level.
+func tocMarkdown(entries []TOCEntry) string {
+	lines := make([]string, len(entries))
+	depth := -1
+	for i, entry := range entries {
+		// A level may only go one deeper than the entry before it
+		depth = min(entry.Level, depth+1)
+		text := entry.Title
+		if entry.Anchor != "" {
+			text = "[" + text + "](" + entry.Anchor + ")"
+		}
+		lines[i] = strings.Repeat("  ", depth) + "- " + text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeTOCHTML renders entries as nested ul elements of links.
+func writeTOCHTML(b *bytes.Buffer, entries []TOCEntry) {
+	depth := -1
+	for _, entry := range entries {
+		level := min(entry.Level, depth+1)
+		switch {
+		case level > depth && depth < 0:
+			b.WriteString("<ul>\n")
+		case level > depth:
+			b.WriteString("\n<ul>\n")
+		case level < depth:
+			b.WriteString(strings.Repeat("</li>\n</ul>\n", depth-level) + "</li>\n")
+		default:
+			b.WriteString("</li>\n")
+		}
+		depth = level
+
+		text := html.EscapeString(entry.Title)
+		if entry.Anchor != "" {
+			text = `<a href="` + html.EscapeString(entry.Anchor) + `">` + text + "</a>"
+		}
+		b.WriteString("<li>" + text)
+	}
+	if depth >= 0 {
+		b.WriteString(strings.Repeat("</li>\n</ul>\n", depth+1))
+	}
+}
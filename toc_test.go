@@ -0,0 +1,123 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// tocLine is a line of text and its left edge.
+type tocLine struct {
+	text string
+	x0   float64
+}
+
+// tocParagraph lays out lines from y down, each at its own left edge.
+func tocParagraph(y float64, lines ...tocLine) Paragraph {
+	var para Paragraph
+	for i, l := range lines {
+		line := placedParagraph(l.text, l.x0, y+float64(i)*12).Lines[0]
+		para.Lines = append(para.Lines, line)
+		if i == 0 {
+			para.Box = line.Box
+		} else {
+			para.Box = mergeRects(para.Box, line.Box)
+		}
+	}
+	para.Alignment = AlignmentLeft
+	return para
+}
+
+func tocDocument() *Document {
+	contents := placedParagraph("Contents", 72, 60)
+	contents.IsHeading, contents.HeadingLevel = true, 1
+	intro := placedParagraph("1 Introduction", 72, 60)
+	intro.IsHeading, intro.HeadingLevel = true, 1
+	background := placedParagraph("Background", 72, 100)
+	background.IsHeading, background.HeadingLevel = true, 2
+
+	return &Document{Pages: []Page{
+		{Number: 1, Height: 792, Paragraphs: []Paragraph{
+			contents,
+			tocParagraph(100,
+				tocLine{"1 Introduction .......... 2", 72},
+				tocLine{"1.1 Background .......... 2", 90},
+				tocLine{"1.2 A section whose title runs on across the whole", 90},
+				tocLine{"width of the page .......... 3", 90},
+				tocLine{"Appendices", 72},
+				tocLine{"A Glossary .......... A-1", 90},
+			),
+		}},
+		{Number: 2, Height: 792, Paragraphs: []Paragraph{intro, background, placedParagraph("Some text.", 72, 120)}},
+	}}
+}
+
+func TestIsTOCPage(t *testing.T) {
+	doc := tocDocument()
+	if !isTOCPage(doc.Pages[0]) {
+		t.Error("contents page not detected")
+	}
+	if isTOCPage(doc.Pages[1]) {
+		t.Error("body page detected as contents")
+	}
+
+	prices := Page{Paragraphs: []Paragraph{tocParagraph(100,
+		tocLine{"Espresso .......... $3.00", 72},
+		tocLine{"Flat White .......... $4.50", 72},
+		tocLine{"Latte .......... $4.50", 72},
+	)}}
+	if isTOCPage(prices) {
+		t.Error("price list detected as contents")
+	}
+}
+
+func TestCleanTOCPages(t *testing.T) {
+	doc := tocDocument()
+	pages := cleanTOCPages(doc.Pages)
+
+	want := []TOCEntry{
+		{Title: "1 Introduction", Page: "2", Level: 0, Anchor: "#1-introduction"},
+		{Title: "1.1 Background", Page: "2", Level: 1, Anchor: "#background"},
+		{Title: "1.2 A section whose title runs on across the whole width of the page", Page: "3", Level: 1},
+		{Title: "Appendices", Level: 0},
+		{Title: "A Glossary", Page: "A-1", Level: 1},
+	}
+	got := pages[0].Paragraphs[1].TOCEntries
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if doc.Pages[0].Paragraphs[1].TOCEntries != nil {
+		t.Error("document pages were changed in place")
+	}
+}
+
+func TestTOCHandlingMarkdown(t *testing.T) {
+	config := DefaultConfig()
+	config.CollapseWhitespace = true
+
+	config.TOCHandling = TOCClean
+	got := tocDocument().ToMarkdown(config)
+	wantList := strings.Join([]string{
+		"- [1 Introduction](#1-introduction)",
+		"  - [1.1 Background](#background)",
+		"  - 1.2 A section whose title runs on across the whole width of the page",
+		"- Appendices",
+		"  - A Glossary",
+	}, "\n")
+	if !strings.Contains(got, wantList) {
+		t.Errorf("clean TOC missing list:\n%s", got)
+	}
+	if strings.Contains(got, "....") {
+		t.Errorf("clean TOC kept leader dots:\n%s", got)
+	}
+
+	config.TOCHandling = TOCSkip
+	got = tocDocument().ToMarkdown(config)
+	if strings.Contains(got, "Contents") || !strings.Contains(got, "Introduction") {
+		t.Errorf("skip TOC output:\n%s", got)
+	}
+}
@@ -0,0 +1,52 @@
+package pdfmarkdown
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenEstimator estimates how many tokens a language model's tokenizer
+// splits text into, for planning batches without running the tokenizer.
+type TokenEstimator func(text string) int
+
+// EstimateTokensByChars estimates one token per four characters, the usual
+// rule of thumb for English text with BPE tokenizers.
+func EstimateTokensByChars(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// EstimateTokensByWords estimates four tokens per three words, which holds up
+// better than character counts for text with long words or many numbers.
+func EstimateTokensByWords(text string) int {
+	return (len(strings.Fields(text))*4 + 2) / 3
+}
+
+// tokenEstimator returns Config.TokenEstimator, or EstimateTokensByChars when
+// none is set.
+func (c Config) tokenEstimator() TokenEstimator {
+	if c.TokenEstimator != nil {
+		return c.TokenEstimator
+	}
+	return EstimateTokensByChars
+}
+
+// EstimatedTokens estimates the tokens in the page's markdown, rendered with
+// config, using config's TokenEstimator.
+func (p *Page) EstimatedTokens(config Config) int {
+	var buf bytes.Buffer
+	if err := newPageWriter(&buf, config).writePage(*p); err != nil {
+		return 0
+	}
+	return config.tokenEstimator()(buf.String())
+}
+
+// EstimatedTokens estimates the tokens in the document's markdown as the sum
+// over its pages; see Page.EstimatedTokens. Page breaks are not counted.
+func (d *Document) EstimatedTokens(config Config) int {
+	total := 0
+	for i := range d.Pages {
+		total += d.Pages[i].EstimatedTokens(config)
+	}
+	return total
+}
@@ -0,0 +1,48 @@
+package pdfmarkdown
+
+import "testing"
+
+func TestTokenEstimators(t *testing.T) {
+	tests := []struct {
+		text         string
+		chars, words int
+	}{
+		{"", 0, 0},
+		{"Hello", 2, 2},
+		{"The quick brown fox", 5, 6},
+		{"Prüfung", 2, 2}, // Characters, not bytes
+	}
+
+	for _, tt := range tests {
+		if got := EstimateTokensByChars(tt.text); got != tt.chars {
+			t.Errorf("EstimateTokensByChars(%q) = %d, want %d", tt.text, got, tt.chars)
+		}
+		if got := EstimateTokensByWords(tt.text); got != tt.words {
+			t.Errorf("EstimateTokensByWords(%q) = %d, want %d", tt.text, got, tt.words)
+		}
+	}
+}
+
+// TestEstimatedTokens tests that estimates cover the rendered markdown and
+// follow the configured estimator
+func TestEstimatedTokens(t *testing.T) {
+	doc := &Document{Pages: []Page{
+		headingPage(1, "Intro", 18),
+		headingPage(2, "Details", 14),
+	}}
+
+	config := DefaultConfig()
+	// "# Intro\n  \nBody\n  " is 18 characters
+	if got := doc.Pages[0].EstimatedTokens(config); got != 5 {
+		t.Errorf("page 1 estimate = %d, want 5", got)
+	}
+
+	config.TokenEstimator = EstimateTokensByWords
+	// "#", "Intro" and "Body" are 3 words
+	if got := doc.Pages[0].EstimatedTokens(config); got != 4 {
+		t.Errorf("page 1 word estimate = %d, want 4", got)
+	}
+	if got, want := doc.EstimatedTokens(config), 8; got != want {
+		t.Errorf("document estimate = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package pdfmarkdown
+
+import (
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minTrackedLetters is the fewest single letters in a row read as letter-spaced
+// text, so that a stray initial or variable name isn't taken for it.
+const minTrackedLetters = 4
+
+// trackedWordGapRatio is how much wider than the gaps between its letters the
+// gap between two letter-spaced words is.
+const trackedWordGapRatio = 1.6
+
+// mergeTrackedLetters joins letter-spaced ("tracked") text, extracted as a
+// word per letter, back into words: "S P A C E D" becomes "SPACED", marked
+// Tracked. A run of single letters or digits in the same font along a line
+// counts when its letters are spaced evenly; gaps clearly wider than the rest
+// separate its words. With bold set, the words are also set in bold.
+func mergeTrackedLetters(words []EnrichedWord, bold bool) []EnrichedWord {
+	result := make([]EnrichedWord, 0, len(words))
+	for start := 0; start < len(words); {
+		end := start + 1
+		for end < len(words) && isTrackedLetter(words[start]) && continuesTrackedRun(words[end-1], words[end]) {
+			end++
+		}
+		if end-start < minTrackedLetters {
+			result = append(result, words[start])
+			start++
+			continue
+		}
+		result = append(result, trackedWords(words[start:end], bold)...)
+		start = end
+	}
+	return result
+}
+
+// isTrackedLetter reports whether a word is a single horizontal letter or
+// digit, as each letter of tracked text is extracted.
+func isTrackedLetter(word EnrichedWord) bool {
+	r, size := utf8.DecodeRuneInString(word.Text)
+	return size == len(word.Text) && size > 0 && word.Rotation == 0 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// continuesTrackedRun reports whether word follows prev in a run of tracked
+// letters: a letter in the same font on the same line, less than an em and
+// a half to its right.
+func continuesTrackedRun(prev, word EnrichedWord) bool {
+	if !isTrackedLetter(word) || prev.FontName != word.FontName || math.Abs(prev.FontSize-word.FontSize) > 0.5 {
+		return false
+	}
+	overlap := math.Min(prev.Box.Y1, word.Box.Y1) - math.Max(prev.Box.Y0, word.Box.Y0)
+	gap := word.Box.X0 - prev.Box.X1
+	return overlap > math.Min(prev.Box.Height(), word.Box.Height())*0.5 && gap >= 0 && gap < word.FontSize*1.5
+}
+
+// trackedWords joins a run of tracked letters into words, splitting it at gaps
+// wider than trackedWordGapRatio times the median gap. The run is left as it
+// is when its letters are barely apart or the gaps within a word are uneven.
+func trackedWords(letters []EnrichedWord, bold bool) []EnrichedWord {
+	gaps := make([]float64, len(letters)-1)
+	for i := range gaps {
+		gaps[i] = letters[i+1].Box.X0 - letters[i].Box.X1
+	}
+	letterGap := calculateMedian(gaps)
+	if letterGap < letters[0].FontSize*0.05 {
+		return letters // Too tight for tracking
+	}
+
+	var words []EnrichedWord
+	word := letters[0]
+	for i, gap := range gaps {
+		next := letters[i+1]
+		if gap > letterGap*trackedWordGapRatio {
+			words = append(words, word)
+			word = next
+			continue
+		}
+		if gap < letterGap*0.5 {
+			return letters // Unevenly spaced, not tracking
+		}
+		word.Text += next.Text
+		word.Box = mergeRects(word.Box, next.Box)
+	}
+	words = append(words, word)
+
+	for i := range words {
+		words[i].Tracked = true
+		words[i].IsBold = words[i].IsBold || bold
+	}
+	return words
+}
@@ -0,0 +1,65 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// spacedWords lays out text with each character a 6pt wide word, letters
+// letterGap apart and spaces adding wordGap.
+func spacedWords(text string, letterGap, wordGap float64) []EnrichedWord {
+	var words []EnrichedWord
+	x := 72.0
+	for _, r := range text {
+		if r == ' ' {
+			x += wordGap
+			continue
+		}
+		words = append(words, EnrichedWord{Text: string(r), FontName: "Helvetica", FontSize: 10, Box: Rect{X0: x, Y0: 100, X1: x + 6, Y1: 110}})
+		x += 6 + letterGap
+	}
+	return words
+}
+
+func TestMergeTrackedLetters(t *testing.T) {
+	// Two letters almost touching among evenly spaced ones
+	unevenWords := spacedWords("ABCDEF", 4, 0)
+	for i := 3; i < len(unevenWords); i++ {
+		unevenWords[i].Box.X0 -= 3.5
+		unevenWords[i].Box.X1 -= 3.5
+	}
+
+	tests := []struct {
+		name  string
+		words []EnrichedWord
+		want  string
+	}{
+		{"one word", spacedWords("SPACED", 4, 0), "SPACED"},
+		{"two words", spacedWords("SPACED OUT", 4, 10), "SPACED OUT"},
+		{"digits", spacedWords("2024", 3, 0), "2024"},
+		{"too short", spacedWords("ABC", 4, 0), "A B C"},
+		{"uneven", unevenWords, "A B C D E F"},
+		{"ordinary words", []EnrichedWord{{Text: "x", FontSize: 10}, {Text: "and", FontSize: 10}}, "x and"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeTrackedLetters(tt.words, false)
+			texts := make([]string, len(merged))
+			for i, word := range merged {
+				texts[i] = word.Text
+				if word.Tracked != (len(merged) < len(tt.words)) {
+					t.Errorf("%q: Tracked = %v", word.Text, word.Tracked)
+				}
+			}
+			if got := strings.Join(texts, " "); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	merged := mergeTrackedLetters(spacedWords("SPACED", 4, 0), true)
+	if len(merged) != 1 || !merged[0].IsBold || merged[0].Box.X1 != 72+6*6+5*4 {
+		t.Errorf("bold merge = %+v", merged)
+	}
+}
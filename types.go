@@ -1,15 +1,18 @@
 package pdfmarkdown
 
+import "math"
+
 import "slices"
 
 import "github.com/klippa-app/go-pdfium/references"
 
-// Rect represents a bounding box in PDF coordinates.
+// Rect represents a bounding box in page space, with the origin at the
+// top-left of the page. Boxes in PDF space are PDFRect; see coords.go.
 type Rect struct {
 	X0 float64 // Left
-	Y0 float64 // Top (after conversion from PDF coordinates)
+	Y0 float64 // Top
 	X1 float64 // Right
-	Y1 float64 // Bottom (after conversion from PDF coordinates)
+	Y1 float64 // Bottom
 }
 
 // Width returns the width of the rectangle.
@@ -27,6 +30,31 @@ func (r Rect) CenterY() float64 {
 	return (r.Y0 + r.Y1) / 2
 }
 
+// OrientedRect is a rectangle rotated about its center, for text that does not
+// run horizontally. Coordinates are top-left page coordinates like Rect.
+type OrientedRect struct {
+	CenterX float64
+	CenterY float64
+	Width   float64 // Extent along the text direction
+	Height  float64 // Extent across the text direction
+	Angle   float64 // Counterclockwise text rotation in degrees, as EnrichedWord.Rotation
+}
+
+// Corners returns the rectangle's corners relative to the text: top-left,
+// top-right, bottom-right and bottom-left as the text is read.
+func (r OrientedRect) Corners() [4]Point {
+	rad := r.Angle * math.Pi / 180
+	// u runs along the text and v down across it; y grows downwards
+	ux, uy := math.Cos(rad), -math.Sin(rad)
+	vx, vy := math.Sin(rad), math.Cos(rad)
+
+	corner := func(du, dv float64) Point {
+		return Point{X: r.CenterX + du*ux + dv*vx, Y: r.CenterY + du*uy + dv*vy}
+	}
+	hw, hh := r.Width/2, r.Height/2
+	return [4]Point{corner(-hw, -hh), corner(hw, -hh), corner(hw, hh), corner(-hw, hh)}
+}
+
 // RGBA represents a color.
 type RGBA struct {
 	R, G, B, A uint
@@ -39,27 +67,45 @@ type EnrichedChar struct {
 	FontSize   float64
 	FontWeight int
 	FontName   string
-	FontFlags  int
+	FontFlags  FontFlags
 	FillColor  RGBA
 	Angle      float32
 	IsHyphen   bool
+	Invisible  bool  // Invisible render mode, as used by OCR layers (read only with Config.SkipInvisibleText)
+	Origin     Point // Glyph origin on the text baseline (top-left coordinates)
+	HasOrigin  bool  // Whether Origin was reported by pdfium
 }
 
 // EnrichedWord represents a word with aggregated style information.
 type EnrichedWord struct {
 	Text        string
 	Box         Rect
-	FontSize    float64 // Average font size
-	FontWeight  int     // Dominant font weight
-	FontName    string  // Dominant font name
-	FontFlags   int     // Dominant font flags
-	FillColor   RGBA    // Dominant fill color
+	FontSize    float64   // Average font size
+	FontWeight  int       // Dominant font weight
+	FontName    string    // Dominant font name, without its subset tag
+	Font        FontInfo  // FontName parsed into family, weight and style
+	FontFlags   FontFlags // Dominant font flags
+	FillColor   RGBA      // Dominant fill color
 	IsBold      bool
 	IsItalic    bool
 	IsMonospace bool
 	Baseline    float64 // Y-coordinate of the text baseline
 	XHeight     float64 // Height of lowercase letters
 	Rotation    float64 // Rotation angle in degrees (0, 90, 180, 270, etc.)
+	Link        string  // Link target when the word is a URL or email address
+
+	// TrailingHyphen is set when the word ends in a hyphen pdfium reports as
+	// breaking a word across lines, rather than a hard hyphen in the text
+	TrailingHyphen bool
+
+	// IsStrikethrough and IsUnderline are set when a rule is drawn through
+	// the word or just beneath it
+	IsStrikethrough bool
+	IsUnderline     bool
+
+	// Tracked is set when the word was letter-spaced for emphasis and joined
+	// back together from a word per letter
+	Tracked bool
 }
 
 // IsBulletOrNumber checks if the word looks like a list marker.
@@ -98,16 +144,83 @@ type Line struct {
 	Baseline float64 // Y-coordinate of the baseline
 }
 
+// TrailingHyphen reports whether the line ends in a hyphen that breaks a word
+// across lines (see EnrichedWord.TrailingHyphen).
+func (l Line) TrailingHyphen() bool {
+	return len(l.Words) > 0 && l.Words[len(l.Words)-1].TrailingHyphen
+}
+
+// Text returns the words of the line joined by spaces.
+func (l Line) Text() string {
+	return joinWords(l.Words)
+}
+
 // Paragraph represents a block of text.
 type Paragraph struct {
 	Lines        []Line
 	Box          Rect
 	Alignment    Alignment
 	IsHeading    bool
-	HeadingLevel int // 1-6 for markdown headings
+	HeadingLevel int  // 1-6 for markdown headings
+	FromOutline  bool // Heading level taken from the document outline (Config.UseOutlineHeadings)
 	IsList       bool
+	ListMarker   string // The list item's marker as printed, such as "•", "3." or "(a)"
+	ListLevel    int    // Nesting depth of the list item, 0 for top-level items
 	IsCode       bool
-	Indent       float64 // Left indentation
+	IsQuote      bool        // Set apart as a quotation (Config.DetectBlockquotes)
+	IsEquation   bool        // A display equation (Config.DetectEquations)
+	Indent       float64     // Left indentation
+	Font         FontSummary // Dominant font across the paragraph's text
+	Leaders      []LeaderRow // Label/value rows when every line is joined by leader dots
+	TOCEntries   []TOCEntry  // Entries of a table of contents page, read when rendering with TOCClean
+
+	// FirstLineIndent is how far the first line starts right of the lines
+	// below it, and HangingIndent how far it starts left of them, in points.
+	// At most one is set, and neither for single-line, centred, right-aligned
+	// or rotated paragraphs
+	FirstLineIndent float64
+	HangingIndent   float64
+
+	// HeadingLevelFixed is set when a HeadingDetector chose HeadingLevel from
+	// the heading's text, as NumberedHeadings does from section numbers, so
+	// headings ranked by font size leave it as it is
+	HeadingLevelFixed bool
+
+	// ReadingDirection is DirectionRTL for paragraphs mostly in Hebrew,
+	// Arabic or another right-to-left script, and DirectionLTR otherwise.
+	// Their lines' words are in reading order, right to left on the page
+	ReadingDirection string
+
+	// OrientedBox is the tight box around rotated text, whose axis-aligned Box
+	// overlaps neighbouring content. Nil for horizontal text.
+	OrientedBox *OrientedRect
+
+	// Breadcrumb holds the text of the headings enclosing the paragraph,
+	// outermost first; a heading's own text is not included. Filled in by
+	// Document.BuildBreadcrumbs
+	Breadcrumb []string
+}
+
+// FontSummary describes the dominant font of a block of text. Each property
+// is the most common value weighted by character count.
+type FontSummary struct {
+	Family string  // Font family with subset prefix and style suffix removed
+	Name   string  // Font name as reported by the PDF, without its subset tag
+	Size   float64 // Font size in points
+	Weight int     // Font weight (400 normal, 700 bold)
+	Color  RGBA    // Fill color
+}
+
+// FontInfo is a font name parsed into its parts. Embedded fonts are usually
+// subsets named with a random tag ("ABCDEF+Helvetica-Bold") that differs
+// between documents and sometimes between pages; Name leaves the tag out so
+// one font is recognised wherever it is used.
+type FontInfo struct {
+	Name   string // Font name without the subset tag, e.g. "Helvetica-Bold"
+	Family string // Family with the style suffix removed, e.g. "Helvetica"
+	Weight int    // Weight the name gives (400 when it names none, 700 bold)
+	Italic bool   // Whether the name marks an italic or oblique face
+	Subset string // Subset tag, e.g. "ABCDEF", or "" for whole fonts
 }
 
 // Text returns the full text of the paragraph.
@@ -117,7 +230,7 @@ func (p Paragraph) Text() string {
 		for j, word := range line.Words {
 			result += word.Text
 			if j < len(line.Words)-1 {
-				result += " "
+				result += wordSeparator(word.Text, line.Words[j+1].Text)
 			}
 		}
 		if i < len(p.Lines)-1 {
@@ -156,17 +269,53 @@ type TextBlock struct {
 // Page represents all extracted content from a PDF page.
 type Page struct {
 	Number     int
+	Label      string // Logical page label, such as "iii" or "A-7"; "" when the PDF gives none
 	Width      float64
 	Height     float64
 	Paragraphs []Paragraph
 	Tables     []Table
 	Lines      []Edge   // Explicit line objects extracted from PDF
 	Columns    []Column // Detected column layout
+	Figures    []Rect   // Image regions, located without extracting the images
+
+	// Links are the page's links to other places in the document, read when
+	// Config.LinkInternalDestinations is set
+	Links []InternalLink
+
+	// FigureAltText describes each of Figures, in the same order: the alt
+	// text tagged in the PDF, an adjacent caption or the nearest heading above.
+	// Entries are "" where none was found
+	FigureAltText []string
+
+	// Diagnostics records text corrections applied during extraction
+	Diagnostics Diagnostics
+
+	// StructureConfidence estimates from 0 to 1 how far structure detection
+	// can be trusted on this page; see Config.PlainTextBelow
+	StructureConfidence float64
+
+	// Breadcrumb holds the headings in effect at the top of the page,
+	// outermost first. Filled in by Document.BuildBreadcrumbs
+	Breadcrumb []string
+
+	// ContentHash is a stable hash over the page's normalized text, used to
+	// detect duplicate pages within and across documents. Empty for pages without text.
+	ContentHash string
+
+	// textLines are the lines paragraphs were built from, shared with table
+	// detection so words are grouped into lines only once
+	textLines []Line
+
+	// inlineBreak is set when the page's break is written inside the
+	// paragraph that ran on from the page before (Config.PageBreakFlow)
+	inlineBreak bool
 }
 
 // Document represents the complete extracted document structure.
 type Document struct {
-	Pages []Page
+	Pages      []Page
+	Duplicates []DuplicatePage // Pages whose content matched an earlier page
+	Metadata   Metadata        // Title, author and other document information
 }
 
 // PageExtractor provides context for extracting text from a page.
@@ -0,0 +1,152 @@
+package pdfmarkdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// softHyphen is U+00AD, an invisible hyphenation point that becomes visible
+// only when a line breaks there.
+const softHyphen = "\u00ad"
+
+var (
+	// urlStartPattern matches words that begin a URL or email address
+	urlStartPattern = regexp.MustCompile(`^(?i)(https?://|ftp://|www\.|mailto:|[\w.+-]+@)`)
+
+	// urlBodyPattern matches text made only of characters that can appear in a URL
+	urlBodyPattern = regexp.MustCompile(`^[\w\-./?#&=%~+:@!$'()*,;\[\]]+$`)
+
+	// emailPattern matches a complete email address
+	emailPattern = regexp.MustCompile(`^[\w.+-]+@[\w-]+(\.[\w-]+)+$`)
+)
+
+// urlBreakChars are characters a URL is conventionally broken after or before.
+const urlBreakChars = "/.-_?=&#%~@"
+
+// trailingURLPunctuation is sentence punctuation that follows a URL in running
+// text but is not part of it.
+const trailingURLPunctuation = ".,;:!?)]'\""
+
+// repairBrokenURLs rejoins URLs and email addresses that were broken across
+// lines within a paragraph. Characters at the break are kept as written, since
+// URLs are broken without adding hyphens, except soft hyphens which only mark
+// the break. When linkURLs is set, URL and email words are given link targets.
+func repairBrokenURLs(paragraphs []Paragraph, linkURLs bool) {
+	for pi := range paragraphs {
+		para := &paragraphs[pi]
+
+		// Copy the lines so words shared with the page's text lines aren't modified
+		lines := make([]Line, 0, len(para.Lines))
+		for _, line := range para.Lines {
+			line.Words = append([]EnrichedWord(nil), line.Words...)
+
+			if n := len(lines); n > 0 && len(line.Words) > 0 && len(lines[n-1].Words) > 0 {
+				prev := &lines[n-1]
+				last := &prev.Words[len(prev.Words)-1]
+				if urlContinues(last.Text, line.Words[0].Text) {
+					merged := []EnrichedWord{*last, line.Words[0]}
+					merged[0].Text = strings.TrimSuffix(last.Text, softHyphen)
+					*last = mergeWordGroup(merged)
+					prev.Box = mergeRects(prev.Box, last.Box)
+					line.Words = line.Words[1:]
+				}
+			}
+
+			if len(line.Words) > 0 {
+				lines = append(lines, line)
+			}
+		}
+		para.Lines = lines
+
+		if linkURLs {
+			for li := range para.Lines {
+				for wi := range para.Lines[li].Words {
+					word := &para.Lines[li].Words[wi]
+					word.Link = urlLinkTarget(word.Text)
+				}
+			}
+		}
+	}
+}
+
+// urlContinues reports whether next, at the start of a line, continues the
+// URL or email address that last ends the previous line with.
+func urlContinues(last, next string) bool {
+	if !urlStartPattern.MatchString(last) || next == "" {
+		return false
+	}
+
+	// The break must fall at a soft hyphen or a conventional URL break point
+	trimmed := strings.TrimSuffix(last, softHyphen)
+	brokenAtSoftHyphen := trimmed != last
+	lastRune := []rune(trimmed)[len([]rune(trimmed))-1]
+	firstRune := []rune(next)[0]
+	if !brokenAtSoftHyphen && !strings.ContainsRune(urlBreakChars, lastRune) && !strings.ContainsRune(urlBreakChars, firstRune) {
+		return false
+	}
+
+	// A continuation starts with URL text, not an aside like "(see page 4)"
+	if !unicode.IsLetter(firstRune) && !unicode.IsDigit(firstRune) && !strings.ContainsRune(urlBreakChars, firstRune) {
+		return false
+	}
+
+	body := strings.TrimRight(next, trailingURLPunctuation)
+	if body == "" || !urlBodyPattern.MatchString(body) {
+		return false
+	}
+
+	// A URL ending a sentence ("... example.com/." or "... www.site.org.") is
+	// followed by an ordinary capitalised word, not more of the URL
+	if (lastRune == '.' || lastRune == '/') && isCapitalisedWord(body) {
+		return false
+	}
+
+	return true
+}
+
+// isCapitalisedWord reports whether text is a plain word starting with an
+// uppercase letter followed only by lowercase letters.
+func isCapitalisedWord(text string) bool {
+	for i, r := range []rune(text) {
+		if i == 0 && !unicode.IsUpper(r) {
+			return false
+		}
+		if i > 0 && !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTrailingPunctuation separates sentence punctuation from the end of a
+// URL, keeping a closing parenthesis that balances one inside it.
+func splitTrailingPunctuation(text string) (string, string) {
+	core := strings.TrimRight(text, trailingURLPunctuation)
+	if strings.Count(core, "(") > strings.Count(core, ")") && strings.HasPrefix(text[len(core):], ")") {
+		core += ")"
+	}
+	return core, text[len(core):]
+}
+
+// urlLinkTarget returns the link target for a word that is a URL or email
+// address, or "" if it is neither. Bare domains are not linked since they are
+// indistinguishable from file names.
+func urlLinkTarget(text string) string {
+	core, _ := splitTrailingPunctuation(text)
+
+	lower := strings.ToLower(core)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "ftp://"):
+		if !strings.HasSuffix(core, "://") {
+			return core
+		}
+	case strings.HasPrefix(lower, "www.") && len(core) > len("www."):
+		return "https://" + core
+	case strings.HasPrefix(lower, "mailto:") && emailPattern.MatchString(core[len("mailto:"):]):
+		return core
+	case emailPattern.MatchString(core):
+		return "mailto:" + core
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURLContinues(t *testing.T) {
+	tests := []struct {
+		last string
+		next string
+		want bool
+	}{
+		{"https://example-", "com/path", true},
+		{"https://example.com/", "docs/index.html", true},
+		{"www.example.", "org/about", true},
+		{"jane.doe@example.", "com.au", true},
+		{"https://exam" + softHyphen, "ple.com", true},
+		{"https://example.com/path", "continues", false},
+		{"https://example.com/.", "Next", false},
+		{"Visit", "example.com", false},
+		{"https://example.com/", "(see", false},
+	}
+
+	for _, tt := range tests {
+		if got := urlContinues(tt.last, tt.next); got != tt.want {
+			t.Errorf("urlContinues(%q, %q) = %v, want %v", tt.last, tt.next, got, tt.want)
+		}
+	}
+}
+
+func TestURLLinkTarget(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"https://example.com/path", "https://example.com/path"},
+		{"https://example.com/path.", "https://example.com/path"},
+		{"https://en.wikipedia.org/wiki/Go_(language))", "https://en.wikipedia.org/wiki/Go_(language)"},
+		{"www.example.com,", "https://www.example.com"},
+		{"jane.doe@example.com", "mailto:jane.doe@example.com"},
+		{"mailto:jane@example.com", "mailto:jane@example.com"},
+		{"https://", ""},
+		{"report.pdf", ""},
+		{"hello", ""},
+	}
+
+	for _, tt := range tests {
+		if got := urlLinkTarget(tt.text); got != tt.want {
+			t.Errorf("urlLinkTarget(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRepairBrokenURLs(t *testing.T) {
+	line := func(words ...string) Line {
+		var l Line
+		for i, text := range words {
+			l.Words = append(l.Words, EnrichedWord{Text: text, Box: Rect{X0: float64(i * 50), X1: float64(i*50 + 40), Y1: 10}})
+		}
+		return l
+	}
+
+	tests := []struct {
+		name     string
+		lines    []Line
+		linkURLs bool
+		want     string
+	}{
+		{
+			name:     "hyphen in domain kept",
+			lines:    []Line{line("See", "https://example-"), line("com/path", "for", "details.")},
+			linkURLs: true,
+			want:     "See [https://example-com/path](https://example-com/path) for details.",
+		},
+		{
+			name:     "soft hyphen dropped",
+			lines:    []Line{line("Email", "jane.doe@exam"+softHyphen), line("ple.com.")},
+			linkURLs: true,
+			want:     "Email [jane.doe@example.com](mailto:jane.doe@example.com).",
+		},
+		{
+			name:     "sentence after URL left alone",
+			lines:    []Line{line("Visit", "www.example.com/."), line("Then", "continue.")},
+			linkURLs: true,
+			want:     "Visit [www.example.com/](https://www.example.com/). Then continue.",
+		},
+		{
+			name:     "joined without linking",
+			lines:    []Line{line("At", "https://example.com/"), line("docs.")},
+			linkURLs: false,
+			want:     "At https://example.com/docs.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paragraphs := []Paragraph{{Lines: tt.lines}}
+			repairBrokenURLs(paragraphs, tt.linkURLs)
+
+			var words []string
+			for _, l := range paragraphs[0].Lines {
+				for _, w := range l.Words {
+					words = append(words, applyInlineFormatting(w))
+				}
+			}
+			if got := strings.Join(words, " "); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// The caller's words must be left untouched
+			if tt.lines[0].Words[len(tt.lines[0].Words)-1].Link != "" {
+				t.Error("repairBrokenURLs modified the original words")
+			}
+		})
+	}
+}
@@ -45,6 +45,29 @@ func calculateBaseline(word EnrichedWord) float64 {
 	return word.Box.Y1 - (word.FontSize * 0.15)
 }
 
+// wordBaseline returns the median origin Y of the word's characters, which
+// pdfium reports on the true baseline regardless of descenders. Rotated text,
+// characters without origin data and implausible origins fall back to the
+// calculateBaseline estimate.
+func wordBaseline(chars []EnrichedChar, word EnrichedWord) float64 {
+	var origins []float64
+	for _, char := range chars {
+		if !char.HasOrigin || isRotatedText(char.Angle) {
+			continue
+		}
+		// Some producers report zeroed origins; reject anything far outside the glyph
+		if char.Origin.Y < char.Box.Y0-char.FontSize || char.Origin.Y > char.Box.Y1+char.FontSize {
+			continue
+		}
+		origins = append(origins, char.Origin.Y)
+	}
+
+	if len(origins) == 0 {
+		return calculateBaseline(word)
+	}
+	return calculateMedian(origins)
+}
+
 // calculateXHeight estimates the x-height (height of lowercase letters) for a word
 // X-height is typically about 0.5-0.7 times the font size
 func calculateXHeight(word EnrichedWord) float64 {
@@ -66,6 +89,33 @@ func calculateXHeight(word EnrichedWord) float64 {
 	return word.FontSize * 0.5
 }
 
+// xHeightGlyphs are lowercase letters with neither ascenders nor descenders,
+// so their tight glyph boxes measure the font's x-height directly.
+var xHeightGlyphs = map[rune]bool{
+	'a': true, 'c': true, 'e': true, 'm': true, 'n': true, 'o': true,
+	'r': true, 's': true, 'u': true, 'v': true, 'w': true, 'x': true, 'z': true,
+}
+
+// wordXHeight returns the median measured height of the word's x-height glyphs.
+// Words without such glyphs, or with rotated text, fall back to the
+// calculateXHeight estimate.
+func wordXHeight(chars []EnrichedChar, word EnrichedWord) float64 {
+	var heights []float64
+	for _, char := range chars {
+		if !xHeightGlyphs[char.Text] || isRotatedText(char.Angle) {
+			continue
+		}
+		if h := char.Box.Height(); h > 0 {
+			heights = append(heights, h)
+		}
+	}
+
+	if len(heights) == 0 {
+		return calculateXHeight(word)
+	}
+	return calculateMedian(heights)
+}
+
 // quantizeAngle rounds an angle to the nearest multiple of step degrees
 func quantizeAngle(angle, step float64) float64 {
 	return math.Round(angle/step) * step
@@ -0,0 +1,180 @@
+package pdfmarkdown
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The text layer is compared in chunks of verifyChunk letters and digits; a
+// chunk is retained when the markdown contains it anywhere. Runs of missing
+// chunks covering at least minMissingRegion characters are reported as
+// missing regions.
+const (
+	verifyChunk      = 8
+	minMissingRegion = 200
+)
+
+// DefaultMinRetention is the share of a PDF's text a conversion must retain
+// to pass verification when no other threshold is given.
+const DefaultMinRetention = 0.95
+
+// VerificationReport compares converted markdown with the text layer of the
+// PDF it came from. Text is compared as letters and digits only, after NFKC
+// normalization and case folding, so markup, spacing, punctuation,
+// hyphenation and reading order don't count against the conversion.
+type VerificationReport struct {
+	Characters int     // Letters and digits in the PDF's text layer
+	Retained   int     // Of those, how many the markdown retains
+	Retention  float64 // Retained / Characters, or 1 for a PDF without text
+
+	// Pages lists the retention of every page with text
+	Pages []PageRetention
+
+	// MissingRegions lists runs of at least minMissingRegion consecutive
+	// characters, in the text layer's order, that the markdown lacks
+	MissingRegions []MissingRegion
+
+	// Passed is set when Retention reaches the minimum verification was
+	// asked for and no region is missing
+	Passed bool
+}
+
+// PageRetention is how much of one page's text a conversion retains.
+type PageRetention struct {
+	Page       int // 1-indexed page number
+	Characters int
+	Retained   int
+	Retention  float64
+}
+
+// MissingRegion is a run of a page's text missing from the markdown.
+type MissingRegion struct {
+	Page       int    // 1-indexed page number
+	Characters int    // Letters and digits missing
+	Text       string // The missing text, normalized, truncated to 80 characters
+}
+
+// Verify checks that markdown converted from the PDF at filePath retains at
+// least minRetention (0 to 1) of the text in the PDF's text layer, read with
+// pdfium's bulk text API, and reports pages and regions whose text is
+// missing. Values <= 0 use DefaultMinRetention.
+func (c *Converter) Verify(filePath, markdown string, minRetention float64) (*VerificationReport, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	doc, err := c.instance.OpenDocument(&requests.OpenDocument{
+		FilePath: &filePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PDF document")
+	}
+	defer c.instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{
+		Document: doc.Document,
+	})
+
+	pageCount, err := c.instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{
+		Document: doc.Document,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get page count")
+	}
+
+	pages := make([]string, pageCount.PageCount)
+	for i := range pages {
+		pages[i] = c.pageText(doc.Document, i)
+	}
+	return verifyText(pages, markdown, minRetention), nil
+}
+
+// verifyText compares the text of each page, by index, with markdown.
+func verifyText(pages []string, markdown string, minRetention float64) *VerificationReport {
+	if minRetention <= 0 {
+		minRetention = DefaultMinRetention
+	}
+
+	// Every chunk-long window of the markdown, so chunks are found wherever
+	// the conversion put them
+	converted := verifiableRunes(markdown)
+	convertedText := string(converted)
+	windows := make(map[uint64]struct{}, len(converted))
+	for i := 0; i+verifyChunk <= len(converted); i++ {
+		windows[hashRunes(converted[i:i+verifyChunk])] = struct{}{}
+	}
+
+	report := &VerificationReport{}
+	for i, text := range pages {
+		runes := verifiableRunes(text)
+		if len(runes) == 0 {
+			continue
+		}
+		page := PageRetention{Page: i + 1, Characters: len(runes)}
+
+		missingFrom := -1
+		flush := func(end int) {
+			if missingFrom >= 0 && end-missingFrom >= minMissingRegion {
+				report.MissingRegions = append(report.MissingRegions, MissingRegion{
+					Page:       i + 1,
+					Characters: end - missingFrom,
+					Text:       truncateText(string(runes[missingFrom:end]), 80),
+				})
+			}
+			missingFrom = -1
+		}
+		for start := 0; start < len(runes); start += verifyChunk {
+			chunk := runes[start:min(start+verifyChunk, len(runes))]
+			found := len(chunk) < verifyChunk && strings.Contains(convertedText, string(chunk))
+			if len(chunk) == verifyChunk {
+				_, found = windows[hashRunes(chunk)]
+			}
+			if found {
+				page.Retained += len(chunk)
+				flush(start)
+			} else if missingFrom < 0 {
+				missingFrom = start
+			}
+		}
+		flush(len(runes))
+
+		page.Retention = float64(page.Retained) / float64(page.Characters)
+		report.Pages = append(report.Pages, page)
+		report.Characters += page.Characters
+		report.Retained += page.Retained
+	}
+
+	report.Retention = 1
+	if report.Characters > 0 {
+		report.Retention = float64(report.Retained) / float64(report.Characters)
+	}
+	report.Passed = report.Retention >= minRetention && len(report.MissingRegions) == 0
+	return report
+}
+
+// verifiableRunes returns the letters and digits of text, NFKC normalized and
+// case folded.
+func verifiableRunes(text string) []rune {
+	var runes []rune
+	for _, r := range norm.NFKC.String(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			runes = append(runes, unicode.ToLower(r))
+		}
+	}
+	return runes
+}
+
+// hashRunes returns a hash of runes for looking up markdown windows.
+func hashRunes(runes []rune) uint64 {
+	h := fnv.New64a()
+	var buf [4]byte
+	for _, r := range runes {
+		buf[0], buf[1], buf[2], buf[3] = byte(r), byte(r>>8), byte(r>>16), byte(r>>24)
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,57 @@
+package pdfmarkdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyText(t *testing.T) {
+	intro := "Quarterly results improved across every region, led by the northern\r\nstores."
+	table := "Region Sales Growth\r\nNorth 120 4%\r\nSouth 95 2%"
+	pages := []string{intro, table, ""}
+
+	t.Run("complete", func(t *testing.T) {
+		// Reordered, marked up and differently spaced, with a hyphen
+		// breaking a word, but all there
+		markdown := "| Region | Sales | Growth |\n|---|---|---|\n| North | 120 | 4% |\n| South | 95 | 2% |\n\n" +
+			"## Quarterly results\n\nimproved across every re-\ngion, **led** by the NORTHERN stores.\n"
+		report := verifyText(pages, markdown, 0)
+		if !report.Passed || report.Retained != report.Characters || len(report.Pages) != 2 {
+			t.Errorf("report = %+v, want everything retained on 2 pages", report)
+		}
+	})
+
+	t.Run("missing page", func(t *testing.T) {
+		report := verifyText(pages, intro, 0)
+		if report.Passed {
+			t.Errorf("report passed without page 2: %+v", report)
+		}
+		if got := report.Pages[1]; got.Page != 2 || got.Retention > 0.5 {
+			t.Errorf("page 2 = %+v, want little retained", got)
+		}
+		if report.Pages[0].Retention != 1 {
+			t.Errorf("page 1 = %+v, want all retained", report.Pages[0])
+		}
+	})
+
+	t.Run("missing region", func(t *testing.T) {
+		long := strings.Repeat("The fund invests in listed shares and bonds. ", 20)
+		missing := "Past performance is not a reliable indicator of future returns and the value of investments can fall as well as rise, and you may get back less than you invested. Fees apply to every transaction and are described in full in the product disclosure statement that accompanies this report."
+		report := verifyText([]string{long + missing}, long, 0.5)
+		if report.Retention < 0.5 {
+			t.Fatalf("retention = %v", report.Retention)
+		}
+		if report.Passed || len(report.MissingRegions) != 1 {
+			t.Fatalf("report = %+v, want one missing region", report)
+		}
+		if region := report.MissingRegions[0]; region.Page != 1 || region.Characters < minMissingRegion || !strings.Contains(region.Text, "performance") {
+			t.Errorf("region = %+v", region)
+		}
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		if report := verifyText([]string{"", " \r\n"}, "", 0); !report.Passed || report.Retention != 1 {
+			t.Errorf("report = %+v, want a pass", report)
+		}
+	})
+}
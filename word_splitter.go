@@ -0,0 +1,183 @@
+package pdfmarkdown
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// minSplitTokenLength is the shortest alphabetic token offered to a WordSplitter.
+// Shorter tokens are rarely concatenations and splitting them risks damage.
+const minSplitTokenLength = 8
+
+// WordSplitter repairs words that lost the space between them during
+// extraction ("Billamount" → "Bill amount"). It is only invoked on long,
+// purely alphabetic tokens.
+type WordSplitter interface {
+	// Split returns the parts of word, or nil if it should be left intact.
+	// The parts must concatenate back to word exactly.
+	Split(word string) []string
+}
+
+//go:embed wordlists/english.txt
+var englishWordList string
+
+// FrequencyWordSplitter splits concatenated words using a frequency-ranked
+// word list. Each candidate split is scored by Zipf's law, so common words are
+// preferred, and a split is only accepted when every part is a listed word and
+// the parts together are more likely than the token as one unknown word.
+type FrequencyWordSplitter struct {
+	costs   map[string]float64
+	maxWord int
+
+	// unknownCost is the cost of a word ranked just past the end of the list;
+	// an unknown token costs this plus one per rune
+	unknownCost float64
+}
+
+// NewFrequencyWordSplitter creates a splitter from words ordered from most to
+// least frequent. Words are matched case-insensitively.
+func NewFrequencyWordSplitter(words []string) *FrequencyWordSplitter {
+	s := &FrequencyWordSplitter{costs: make(map[string]float64, len(words))}
+	logN := math.Log(float64(len(words) + 1))
+
+	for rank, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if _, ok := s.costs[word]; ok {
+			continue
+		}
+		s.costs[word] = math.Log(float64(rank+1) * logN)
+		s.maxWord = max(s.maxWord, len([]rune(word)))
+	}
+	s.unknownCost = math.Log(float64(len(words)+1) * logN)
+
+	return s
+}
+
+// NewEnglishWordSplitter creates a splitter using the built-in English
+// frequency list: the ranked US television and film subtitle list from zxcvbn
+// (MIT licensed), with financial document vocabulary ranked among the common
+// words and everyday words zxcvbn lists only as passwords ranked last.
+func NewEnglishWordSplitter() *FrequencyWordSplitter {
+	return NewFrequencyWordSplitter(strings.Fields(englishWordList))
+}
+
+// Split finds the cheapest segmentation of word into listed words. Words that
+// are themselves listed, that cannot be fully covered, or whose cheapest split
+// costs more than keeping them as one unknown word are left intact. The unknown
+// word cost grows with length, so long tokens made of common words split while
+// short rare words stay whole rather than becoming fragments like "author is ed".
+func (s *FrequencyWordSplitter) Split(word string) []string {
+	runes := []rune(word)
+	lower := []rune(strings.ToLower(word))
+	if len(lower) != len(runes) {
+		return nil // Case mapping changed the length; parts could not be mapped back
+	}
+	if _, known := s.costs[string(lower)]; known {
+		return nil
+	}
+
+	// best[i] is the cost of the cheapest segmentation of lower[:i]
+	n := len(lower)
+	best := make([]float64, n+1)
+	from := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		best[i] = math.Inf(1)
+		for j := max(0, i-s.maxWord); j < i; j++ {
+			cost, ok := s.costs[string(lower[j:i])]
+			if !ok || !validSplitPart(i-j, string(lower[j:i])) {
+				continue
+			}
+			if c := best[j] + cost; c < best[i] {
+				best[i], from[i] = c, j
+			}
+		}
+	}
+	if math.IsInf(best[n], 1) || best[n] >= s.unknownCost+float64(n) {
+		return nil
+	}
+
+	// Walk back through the cheapest path, slicing the original to keep case
+	var parts []string
+	for i := n; i > 0; i = from[i] {
+		parts = append([]string{string(runes[from[i]:i])}, parts...)
+	}
+	if len(parts) < 2 {
+		return nil
+	}
+	return parts
+}
+
+// validSplitPart rejects single letters other than "a" and "i", which would
+// otherwise let almost any token be shredded into fragments.
+func validSplitPart(length int, part string) bool {
+	return length > 1 || part == "a" || part == "i"
+}
+
+// splitMergedWords offers long alphabetic tokens to the splitter and replaces
+// them with the returned parts, dividing the box in proportion to rune count.
+func splitMergedWords(words []EnrichedWord, splitter WordSplitter) []EnrichedWord {
+	if splitter == nil {
+		return words
+	}
+
+	result := make([]EnrichedWord, 0, len(words))
+	for _, word := range words {
+		if !isSplitCandidate(word) {
+			result = append(result, word)
+			continue
+		}
+
+		parts := splitter.Split(word.Text)
+		if len(parts) < 2 || strings.Join(parts, "") != word.Text {
+			result = append(result, word)
+			continue
+		}
+
+		result = append(result, splitWordByParts(word, parts)...)
+	}
+
+	return result
+}
+
+// isSplitCandidate reports whether a word is a long, horizontal, purely
+// alphabetic token.
+func isSplitCandidate(word EnrichedWord) bool {
+	if word.Rotation != 0 {
+		return false
+	}
+
+	count := 0
+	for _, r := range word.Text {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+		count++
+	}
+	return count >= minSplitTokenLength
+}
+
+// splitWordByParts divides a word into the given parts, assigning each a slice
+// of the box proportional to its grapheme cluster count.
+func splitWordByParts(word EnrichedWord, parts []string) []EnrichedWord {
+	total := graphemeCount(word.Text)
+	charWidth := word.Box.Width() / float64(total)
+
+	result := make([]EnrichedWord, 0, len(parts))
+	offset := 0
+	for _, text := range parts {
+		n := graphemeCount(text)
+		part := word
+		part.Text = text
+		part.Box.X0 = word.Box.X0 + float64(offset)*charWidth
+		part.Box.X1 = word.Box.X0 + float64(offset+n)*charWidth
+		result = append(result, part)
+		offset += n
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package pdfmarkdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnglishWordSplitter(t *testing.T) {
+	splitter := NewEnglishWordSplitter()
+
+	tests := []struct {
+		name string
+		word string
+		want []string
+	}{
+		{name: "concatenated words", word: "Billamount", want: []string{"Bill", "amount"}},
+		{name: "three words", word: "closingbalancedate", want: []string{"closing", "balance", "date"}},
+		{name: "domain vocabulary", word: "superannuationfund", want: []string{"superannuation", "fund"}},
+		{name: "listed word left intact", word: "statement", want: nil},
+		{name: "unknown word left intact", word: "Vanderbyl", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitter.Split(tt.word); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEnglishWordSplitter_KeepsRealWords tests words that could be covered by
+// shorter listed words but must not be split
+func TestEnglishWordSplitter_KeepsRealWords(t *testing.T) {
+	splitter := NewEnglishWordSplitter()
+
+	words := []string{
+		"together",     // to get her
+		"whatever",     // what ever
+		"understand",   // under stand
+		"nowhere",      // no where
+		"therefore",    // there for e
+		"Together",     // case is ignored
+		"authorised",   // author is ed, British spelling missing from the list
+		"standardised", // standard is ed
+		"minimised",    // mini mis ed
+		"Henderson",    // hen der son
+		"Parramatta",   // par ram atta
+		"Zhangwei",     // zhang we i
+	}
+
+	for _, word := range words {
+		if got := splitter.Split(word); got != nil {
+			t.Errorf("Split(%q) = %v, want nil", word, got)
+		}
+	}
+}
+
+func TestSplitMergedWords(t *testing.T) {
+	words := []EnrichedWord{
+		{Text: "Billamount", Box: Rect{X0: 0, Y0: 0, X1: 100, Y1: 12}},
+		{Text: "$1,234.00", Box: Rect{X0: 110, Y0: 0, X1: 160, Y1: 12}},
+		{Text: "Billamt", Box: Rect{X0: 0, Y0: 20, X1: 70, Y1: 32}},
+	}
+
+	got := splitMergedWords(words, NewEnglishWordSplitter())
+
+	var texts []string
+	for _, w := range got {
+		texts = append(texts, w.Text)
+	}
+	want := []string{"Bill", "amount", "$1,234.00", "Billamt"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("splitMergedWords() = %v, want %v", texts, want)
+	}
+
+	// Boxes are divided by rune count
+	if got[0].Box.X1 != 40 || got[1].Box.X0 != 40 || got[1].Box.X1 != 100 {
+		t.Errorf("split boxes = %+v, %+v", got[0].Box, got[1].Box)
+	}
+
+	if same := splitMergedWords(words, nil); !reflect.DeepEqual(same, words) {
+		t.Error("nil splitter should leave words unchanged")
+	}
+}